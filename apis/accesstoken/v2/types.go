@@ -22,6 +22,7 @@ import (
 )
 
 
+// +kubebuilder:validation:XValidation:rule="!has(self.writePullSecretToRef) || self.scopes.exists(s, s in ['read:package', 'write:package', 'all'])",message="writePullSecretToRef requires the read:package scope"
 type AccessTokenParameters struct {
 	// Username is the user that owns this access token
 	// +kubebuilder:validation:Required
@@ -41,6 +42,27 @@ type AccessTokenParameters struct {
 	// +kubebuilder:validation:UniqueItems=true
 	Scopes []string `json:"scopes"`
 
+	// Repository optionally scopes the published credentials to a single
+	// repository (owner/name format). When unset, credentials are published
+	// for every repository on the Gitea instance.
+	// +kubebuilder:validation:Pattern="^[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?/[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?$"
+	// +optional
+	Repository *string `json:"repository,omitempty"`
+
+	// ConnectionSecretFormat selects the layout of the connection secret
+	// written to writeConnectionSecretToRef.
+	// +kubebuilder:validation:Enum=Default;ArgoCD;Flux;GitCredentials
+	// +kubebuilder:default="Default"
+	ConnectionSecretFormat *string `json:"connectionSecretFormat,omitempty"`
+
+	// WritePullSecretToRef publishes the token as a
+	// kubernetes.io/dockerconfigjson Secret for the Gitea container registry,
+	// whose host is taken from the ProviderConfig base URL. The token must
+	// carry the read:package scope. The Secret is owned by the AccessToken
+	// and rewritten whenever a new token is issued.
+	// +optional
+	WritePullSecretToRef *xpv1.LocalSecretReference `json:"writePullSecretToRef,omitempty"`
+
 	// V2 Enhancement: Connection reference for multi-tenant support
 	// ConnectionRef specifies the Gitea connection to use
 	ConnectionRef *xpv1.Reference `json:"connectionRef,omitempty"`
@@ -142,6 +142,21 @@ func (in *AccessTokenParameters) DeepCopyInto(out *AccessTokenParameters) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Repository != nil {
+		in, out := &in.Repository, &out.Repository
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConnectionSecretFormat != nil {
+		in, out := &in.ConnectionSecretFormat, &out.ConnectionSecretFormat
+		*out = new(string)
+		**out = **in
+	}
+	if in.WritePullSecretToRef != nil {
+		in, out := &in.WritePullSecretToRef, &out.WritePullSecretToRef
+		*out = new(corev2.LocalSecretReference)
+		**out = **in
+	}
 	if in.ConnectionRef != nil {
 		in, out := &in.ConnectionRef, &out.ConnectionRef
 		*out = new(corev2.Reference)
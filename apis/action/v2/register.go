@@ -22,10 +22,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
-// ActionLastRun type metadata.
+// Action type metadata.
 var (
-	ActionLastRunKind             = reflect.TypeOf(ActionLastRun{}).Name()
-	ActionLastRunGroupKind        = schema.GroupKind{Group: Group, Kind: ActionLastRunKind}
-	ActionLastRunKindAPIVersion   = ActionLastRunKind + "." + SchemeGroupVersion.String()
-	ActionLastRunGroupVersionKind = SchemeGroupVersion.WithKind(ActionLastRunKind)
+	ActionKind             = reflect.TypeOf(Action{}).Name()
+	ActionGroupKind        = schema.GroupKind{Group: Group, Kind: ActionKind}
+	ActionKindAPIVersion   = ActionKind + "." + SchemeGroupVersion.String()
+	ActionGroupVersionKind = SchemeGroupVersion.WithKind(ActionKind)
 )
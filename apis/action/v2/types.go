@@ -44,6 +44,9 @@ type ActionLastRun struct {
 
 	// Event is the event that triggered the run
 	Event *string `json:"event,omitempty"`
+
+	// HTMLURL is the web URL for the run
+	HTMLURL *string `json:"htmlUrl,omitempty"`
 }
 
 type ActionParameters struct {
@@ -52,7 +55,8 @@ type ActionParameters struct {
 	// +kubebuilder:validation:Pattern="^[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?/[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?$"
 	Repository string `json:"repository"`
 
-	// WorkflowName is the name of the workflow file
+	// WorkflowName is the name of the workflow file, committed as
+	// .gitea/workflows/<workflowName>
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:Pattern="^[a-zA-Z0-9._-]+.ya?ml$"
 	// +kubebuilder:validation:MinLength=1
@@ -89,7 +93,13 @@ type ActionObservation struct {
 	WorkflowName *string `json:"workflowName,omitempty"`
 
 	// ID is the workflow identifier
-	ID *int64 `json:"id,omitempty"`
+	ID *string `json:"id,omitempty"`
+
+	// Path is the repository path of the workflow file
+	Path *string `json:"path,omitempty"`
+
+	// SHA is the blob SHA of the workflow file
+	SHA *string `json:"sha,omitempty"`
 
 	// State indicates the workflow state (active, disabled_manually, disabled_fork)
 	State *string `json:"state,omitempty"`
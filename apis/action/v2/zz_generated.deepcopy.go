@@ -90,6 +90,11 @@ func (in *ActionLastRun) DeepCopyInto(out *ActionLastRun) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.HTMLURL != nil {
+		in, out := &in.HTMLURL, &out.HTMLURL
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionLastRun.
@@ -144,7 +149,17 @@ func (in *ActionObservation) DeepCopyInto(out *ActionObservation) {
 	}
 	if in.ID != nil {
 		in, out := &in.ID, &out.ID
-		*out = new(int64)
+		*out = new(string)
+		**out = **in
+	}
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	if in.SHA != nil {
+		in, out := &in.SHA, &out.SHA
+		*out = new(string)
 		**out = **in
 	}
 	if in.State != nil {
@@ -23,11 +23,14 @@ import (
 	accesstokenv2 "github.com/rossigee/provider-gitea/apis/accesstoken/v2"
 	actionv2 "github.com/rossigee/provider-gitea/apis/action/v2"
 	adminuserv2 "github.com/rossigee/provider-gitea/apis/adminuser/v2"
+	branchv2 "github.com/rossigee/provider-gitea/apis/branch/v2"
 	branchprotectionv2 "github.com/rossigee/provider-gitea/apis/branchprotection/v2"
 	deploykeyv2 "github.com/rossigee/provider-gitea/apis/deploykey/v2"
 	githookv2 "github.com/rossigee/provider-gitea/apis/githook/v2"
 	issuev2 "github.com/rossigee/provider-gitea/apis/issue/v2"
 	labelv2 "github.com/rossigee/provider-gitea/apis/label/v2"
+	labelsetv2 "github.com/rossigee/provider-gitea/apis/labelset/v2"
+	oauth2applicationv2 "github.com/rossigee/provider-gitea/apis/oauth2application/v2"
 	orgv2 "github.com/rossigee/provider-gitea/apis/organization/v2"
 	organizationmemberv2 "github.com/rossigee/provider-gitea/apis/organizationmember/v2"
 	orgsecretv2 "github.com/rossigee/provider-gitea/apis/organizationsecret/v2"
@@ -36,13 +39,22 @@ import (
 	releasev2 "github.com/rossigee/provider-gitea/apis/release/v2"
 	giteav2 "github.com/rossigee/provider-gitea/apis/repository/v2"
 	repositorycollaboratorv2 "github.com/rossigee/provider-gitea/apis/repositorycollaborator/v2"
+	repositoryfilev2 "github.com/rossigee/provider-gitea/apis/repositoryfile/v2"
 	repositorykeyv2 "github.com/rossigee/provider-gitea/apis/repositorykey/v2"
 	repositorysecretv2 "github.com/rossigee/provider-gitea/apis/repositorysecret/v2"
 	runnerv2 "github.com/rossigee/provider-gitea/apis/runner/v2"
+	runnerregistrationtokenv2 "github.com/rossigee/provider-gitea/apis/runnerregistrationtoken/v2"
+	tagv2 "github.com/rossigee/provider-gitea/apis/tag/v2"
+	tagprotectionv2 "github.com/rossigee/provider-gitea/apis/tagprotection/v2"
 	teamv2 "github.com/rossigee/provider-gitea/apis/team/v2"
+	teammembershipv2 "github.com/rossigee/provider-gitea/apis/teammembership/v2"
+	teamrepositoryv2 "github.com/rossigee/provider-gitea/apis/teamrepository/v2"
 	userv2 "github.com/rossigee/provider-gitea/apis/user/v2"
+	useremailv2 "github.com/rossigee/provider-gitea/apis/useremail/v2"
+	usergpgkeyv2 "github.com/rossigee/provider-gitea/apis/usergpgkey/v2"
 	userkeyv2 "github.com/rossigee/provider-gitea/apis/userkey/v2"
 	webhookv2 "github.com/rossigee/provider-gitea/apis/webhook/v2"
+	workflowdispatchv2 "github.com/rossigee/provider-gitea/apis/workflowdispatch/v2"
 
 	// Provider configuration APIs
 	v1alpha1 "github.com/rossigee/provider-gitea/apis/v1alpha1"
@@ -80,6 +92,18 @@ func init() {
 		actionv2.SchemeBuilder.AddToScheme,
 		adminuserv2.SchemeBuilder.AddToScheme,
 		runnerv2.SchemeBuilder.AddToScheme,
+		repositoryfilev2.SchemeBuilder.AddToScheme,
+		runnerregistrationtokenv2.SchemeBuilder.AddToScheme,
+		workflowdispatchv2.SchemeBuilder.AddToScheme,
+		tagprotectionv2.SchemeBuilder.AddToScheme,
+		branchv2.SchemeBuilder.AddToScheme,
+		tagv2.SchemeBuilder.AddToScheme,
+		teammembershipv2.SchemeBuilder.AddToScheme,
+		teamrepositoryv2.SchemeBuilder.AddToScheme,
+		useremailv2.SchemeBuilder.AddToScheme,
+		usergpgkeyv2.SchemeBuilder.AddToScheme,
+		oauth2applicationv2.SchemeBuilder.AddToScheme,
+		labelsetv2.SchemeBuilder.AddToScheme,
 	)
 }
 
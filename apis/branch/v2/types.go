@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type BranchParameters struct {
+	// Repository is the repository the branch belongs to (owner/name format)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern="^[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?/[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?$"
+	Repository string `json:"repository"`
+
+	// Name is the branch name
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="name is immutable"
+	Name string `json:"name"`
+
+	// From is the branch, tag or commit SHA the branch is created from.
+	// Defaults to the repository default branch. It is only used when the
+	// branch is created.
+	// +optional
+	From *string `json:"from,omitempty"`
+
+	// DeleteOnRemoval deletes the branch from Gitea when the resource is
+	// deleted. By default the branch is left in place.
+	// +kubebuilder:default=false
+	// +optional
+	DeleteOnRemoval *bool `json:"deleteOnRemoval,omitempty"`
+
+	// V2 Enhancement: Connection reference for multi-tenant support
+	// ConnectionRef specifies the Gitea connection to use
+	ConnectionRef *xpv1.Reference `json:"connectionRef,omitempty"`
+
+	// V2 Enhancement: Namespace-scoped provider config
+	// ProviderConfigRef references a ProviderConfig resource in the same namespace
+	ProviderConfigRef *xpv1.Reference `json:"providerConfigRef,omitempty"`
+}
+
+type BranchObservation struct {
+	// Name is the branch name
+	Name *string `json:"name,omitempty"`
+
+	// CommitSHA is the SHA of the branch head commit
+	CommitSHA *string `json:"commitSha,omitempty"`
+
+	// CommitMessage is the message of the branch head commit
+	CommitMessage *string `json:"commitMessage,omitempty"`
+
+	// Protected indicates if a branch protection rule applies to the branch
+	Protected *bool `json:"protected,omitempty"`
+}
+
+// BranchSpec defines the desired state of Branch
+type BranchSpec struct {
+	xpv1.ManagedResourceSpec `json:",inline"`
+	ForProvider              BranchParameters `json:"forProvider"`
+}
+
+// BranchStatus defines the observed state of Branch
+type BranchStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+	AtProvider                 BranchObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,gitea}
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="BRANCH",type="string",JSONPath=".spec.forProvider.name"
+// +kubebuilder:printcolumn:name="COMMIT",type="string",JSONPath=".status.atProvider.commitSha"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Branch is the Schema for the branches API v2 (namespaced)
+type Branch struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BranchSpec   `json:"spec,omitempty"`
+	Status BranchStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BranchList contains a list of Branch
+type BranchList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Branch `json:"items"`
+}
+
+// GetCondition returns the condition for the given ConditionType if it exists, otherwise returns nil.
+func (r *Branch) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return r.Status.GetCondition(ct)
+}
+
+// SetConditions sets the supplied conditions, replacing any existing conditions of the same type.
+func (r *Branch) SetConditions(c ...xpv1.Condition) {
+	r.Status.SetConditions(c...)
+}
+
+// GetManagementPolicies returns the management policies for this resource.
+func (r *Branch) GetManagementPolicies() xpv1.ManagementPolicies {
+	return r.Spec.ManagementPolicies
+}
+
+// SetManagementPolicies sets the management policies for this resource.
+func (r *Branch) SetManagementPolicies(p xpv1.ManagementPolicies) {
+	r.Spec.ManagementPolicies = p
+}
+
+// GetProviderConfigReference of this Branch.
+func (r *Branch) GetProviderConfigReference() *xpv1.ProviderConfigReference {
+	return r.Spec.ProviderConfigReference
+}
+
+// SetProviderConfigReference of this Branch.
+func (r *Branch) SetProviderConfigReference(p *xpv1.ProviderConfigReference) {
+	r.Spec.ProviderConfigReference = p
+}
+
+// GetWriteConnectionSecretToReference of this Branch.
+func (r *Branch) GetWriteConnectionSecretToReference() *xpv1.LocalSecretReference {
+	return r.Spec.WriteConnectionSecretToReference
+}
+
+// SetWriteConnectionSecretToReference of this Branch.
+func (r *Branch) SetWriteConnectionSecretToReference(p *xpv1.LocalSecretReference) {
+	r.Spec.WriteConnectionSecretToReference = p
+}
@@ -22,10 +22,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
-// BranchProtectionAppliedSettings type metadata.
+// BranchProtection type metadata.
 var (
-	BranchProtectionAppliedSettingsKind             = reflect.TypeOf(BranchProtectionAppliedSettings{}).Name()
-	BranchProtectionAppliedSettingsGroupKind        = schema.GroupKind{Group: Group, Kind: BranchProtectionAppliedSettingsKind}
-	BranchProtectionAppliedSettingsKindAPIVersion   = BranchProtectionAppliedSettingsKind + "." + SchemeGroupVersion.String()
-	BranchProtectionAppliedSettingsGroupVersionKind = SchemeGroupVersion.WithKind(BranchProtectionAppliedSettingsKind)
+	BranchProtectionKind             = reflect.TypeOf(BranchProtection{}).Name()
+	BranchProtectionGroupKind        = schema.GroupKind{Group: Group, Kind: BranchProtectionKind}
+	BranchProtectionKindAPIVersion   = BranchProtectionKind + "." + SchemeGroupVersion.String()
+	BranchProtectionGroupVersionKind = SchemeGroupVersion.WithKind(BranchProtectionKind)
 )
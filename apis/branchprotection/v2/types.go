@@ -27,11 +27,86 @@ type BranchProtectionAppliedSettings struct {
 	// EnablePush indicates if push protection is enabled
 	EnablePush *bool `json:"enablePush,omitempty"`
 
+	// EnablePushWhitelist indicates if the push whitelist is enabled
+	EnablePushWhitelist *bool `json:"enablePushWhitelist,omitempty"`
+
+	// PushWhitelistUsernames lists the users allowed to push
+	PushWhitelistUsernames []string `json:"pushWhitelistUsernames,omitempty"`
+
+	// PushWhitelistTeams lists the teams allowed to push
+	PushWhitelistTeams []string `json:"pushWhitelistTeams,omitempty"`
+
+	// PushWhitelistDeployKeys indicates if deploy keys may push
+	PushWhitelistDeployKeys *bool `json:"pushWhitelistDeployKeys,omitempty"`
+
+	// EnableForcePush indicates if force pushes are allowed
+	EnableForcePush *bool `json:"enableForcePush,omitempty"`
+
+	// EnableForcePushAllowlist indicates if force pushes are limited to the allowlist
+	EnableForcePushAllowlist *bool `json:"enableForcePushAllowlist,omitempty"`
+
+	// ForcePushAllowlistUsernames lists the users allowed to force push
+	ForcePushAllowlistUsernames []string `json:"forcePushAllowlistUsernames,omitempty"`
+
+	// ForcePushAllowlistTeams lists the teams allowed to force push
+	ForcePushAllowlistTeams []string `json:"forcePushAllowlistTeams,omitempty"`
+
+	// ForcePushAllowlistDeployKeys indicates if deploy keys may force push
+	ForcePushAllowlistDeployKeys *bool `json:"forcePushAllowlistDeployKeys,omitempty"`
+
+	// EnableMergeWhitelist indicates if the merge whitelist is enabled
+	EnableMergeWhitelist *bool `json:"enableMergeWhitelist,omitempty"`
+
+	// MergeWhitelistUsernames lists the users allowed to merge
+	MergeWhitelistUsernames []string `json:"mergeWhitelistUsernames,omitempty"`
+
+	// MergeWhitelistTeams lists the teams allowed to merge
+	MergeWhitelistTeams []string `json:"mergeWhitelistTeams,omitempty"`
+
 	// EnableStatusCheck indicates if status checks are required
 	EnableStatusCheck *bool `json:"enableStatusCheck,omitempty"`
 
 	// RequiredStatusChecks lists the required status checks
 	RequiredStatusChecks []string `json:"requiredStatusChecks,omitempty"`
+
+	// RequiredApprovals is the number of required approvals
+	RequiredApprovals *int `json:"requiredApprovals,omitempty"`
+
+	// EnableApprovalsWhitelist indicates if the approvals whitelist is enabled
+	EnableApprovalsWhitelist *bool `json:"enableApprovalsWhitelist,omitempty"`
+
+	// ApprovalsWhitelistUsernames lists the users whose approvals count
+	ApprovalsWhitelistUsernames []string `json:"approvalsWhitelistUsernames,omitempty"`
+
+	// ApprovalsWhitelistTeams lists the teams whose approvals count
+	ApprovalsWhitelistTeams []string `json:"approvalsWhitelistTeams,omitempty"`
+
+	// BlockOnRejectedReviews indicates if rejected reviews block merging
+	BlockOnRejectedReviews *bool `json:"blockOnRejectedReviews,omitempty"`
+
+	// BlockOnOfficialReviewRequests indicates if official review requests block merging
+	BlockOnOfficialReviewRequests *bool `json:"blockOnOfficialReviewRequests,omitempty"`
+
+	// BlockOnOutdatedBranch indicates if outdated branches block merging
+	BlockOnOutdatedBranch *bool `json:"blockOnOutdatedBranch,omitempty"`
+
+	// BlockAdminMergeOverride indicates if administrators must follow the rule
+	BlockAdminMergeOverride *bool `json:"blockAdminMergeOverride,omitempty"`
+
+	// DismissStaleApprovals indicates if new commits dismiss approvals
+	DismissStaleApprovals *bool `json:"dismissStaleApprovals,omitempty"`
+
+	// IgnoreStaleApprovals indicates if approvals of older commits are not counted
+	IgnoreStaleApprovals *bool `json:"ignoreStaleApprovals,omitempty"`
+
+	// RequireSignedCommits indicates if commits must be signed
+	RequireSignedCommits *bool `json:"requireSignedCommits,omitempty"`
+
+	// ProtectedFilePatterns lists the protected file patterns
+	ProtectedFilePatterns *string `json:"protectedFilePatterns,omitempty"`
+
+	// UnprotectedFilePatterns lists the unprotected file patterns
+	UnprotectedFilePatterns *string `json:"unprotectedFilePatterns,omitempty"`
 }
 
 type BranchProtectionParameters struct {
@@ -40,14 +115,19 @@ type BranchProtectionParameters struct {
 	// +kubebuilder:validation:Pattern="^[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?/[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?$"
 	Repository string `json:"repository"`
 
-	// Branch is the branch name to protect
-	// +kubebuilder:validation:Required
-	Branch string `json:"branch"`
-
-	// RuleName is the name of the protection rule
+	// RuleName is the branch name or glob pattern the rule applies to,
+	// e.g. main or release/*. Gitea identifies rules by this name.
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="ruleName is immutable"
 	RuleName string `json:"ruleName"`
 
+	// Priority orders rules whose patterns match the same branch. Rules
+	// with a lower priority are matched first.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Priority *int64 `json:"priority,omitempty"`
+
 	// EnablePush controls whether pushes are allowed
 	// +kubebuilder:default=true
 	EnablePush *bool `json:"enablePush,omitempty"`
@@ -66,6 +146,24 @@ type BranchProtectionParameters struct {
 	// +kubebuilder:default=false
 	PushWhitelistDeployKeys *bool `json:"pushWhitelistDeployKeys,omitempty"`
 
+	// EnableForcePush controls whether force pushes are allowed
+	// +kubebuilder:default=false
+	EnableForcePush *bool `json:"enableForcePush,omitempty"`
+
+	// EnableForcePushAllowlist limits force pushes to the allowlist
+	// +kubebuilder:default=false
+	EnableForcePushAllowlist *bool `json:"enableForcePushAllowlist,omitempty"`
+
+	// ForcePushAllowlistUsernames is the list of usernames allowed to force push
+	ForcePushAllowlistUsernames []string `json:"forcePushAllowlistUsernames,omitempty"`
+
+	// ForcePushAllowlistTeams is the list of teams allowed to force push
+	ForcePushAllowlistTeams []string `json:"forcePushAllowlistTeams,omitempty"`
+
+	// ForcePushAllowlistDeployKeys allows deploy keys to force push
+	// +kubebuilder:default=false
+	ForcePushAllowlistDeployKeys *bool `json:"forcePushAllowlistDeployKeys,omitempty"`
+
 	// EnableMergeWhitelist enables merge whitelist
 	// +kubebuilder:default=false
 	EnableMergeWhitelist *bool `json:"enableMergeWhitelist,omitempty"`
@@ -111,18 +209,29 @@ type BranchProtectionParameters struct {
 	// +kubebuilder:default=false
 	BlockOnOutdatedBranch *bool `json:"blockOnOutdatedBranch,omitempty"`
 
+	// BlockAdminMergeOverride prevents administrators from merging
+	// pull requests that do not satisfy the rule
+	// +kubebuilder:default=false
+	BlockAdminMergeOverride *bool `json:"blockAdminMergeOverride,omitempty"`
+
 	// DismissStaleApprovals dismisses stale approvals when new commits are pushed
 	// +kubebuilder:default=false
 	DismissStaleApprovals *bool `json:"dismissStaleApprovals,omitempty"`
 
+	// IgnoreStaleApprovals does not count approvals made on older commits
+	// +kubebuilder:default=false
+	IgnoreStaleApprovals *bool `json:"ignoreStaleApprovals,omitempty"`
+
 	// RequireSignedCommits requires all commits to be signed
 	// +kubebuilder:default=false
 	RequireSignedCommits *bool `json:"requireSignedCommits,omitempty"`
 
-	// ProtectedFilePatterns defines patterns for files that require special protection
+	// ProtectedFilePatterns is a semicolon-separated list of glob patterns
+	// for files that may not be changed by pushes
 	ProtectedFilePatterns *string `json:"protectedFilePatterns,omitempty"`
 
-	// UnprotectedFilePatterns defines patterns for files that bypass protection
+	// UnprotectedFilePatterns is a semicolon-separated list of glob patterns
+	// for files that may be pushed directly when pushes are otherwise blocked
 	UnprotectedFilePatterns *string `json:"unprotectedFilePatterns,omitempty"`
 
 	// V2 Enhancement: Connection reference for multi-tenant support
@@ -138,6 +247,9 @@ type BranchProtectionObservation struct {
 	// RuleName is the name of the protection rule
 	RuleName *string `json:"ruleName,omitempty"`
 
+	// Priority is the rule's priority
+	Priority *int64 `json:"priority,omitempty"`
+
 	// CreatedAt is the timestamp when the rule was created
 	CreatedAt *string `json:"createdAt,omitempty"`
 
@@ -170,6 +282,7 @@ type BranchProtectionStatus struct {
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane.io/external-name"
+// +kubebuilder:printcolumn:name="RULE",type="string",JSONPath=".spec.forProvider.ruleName"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // BranchProtection is the Schema for the branchprotections API v2 (namespaced)
@@ -60,6 +60,66 @@ func (in *BranchProtectionAppliedSettings) DeepCopyInto(out *BranchProtectionApp
 		*out = new(bool)
 		**out = **in
 	}
+	if in.EnablePushWhitelist != nil {
+		in, out := &in.EnablePushWhitelist, &out.EnablePushWhitelist
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PushWhitelistUsernames != nil {
+		in, out := &in.PushWhitelistUsernames, &out.PushWhitelistUsernames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PushWhitelistTeams != nil {
+		in, out := &in.PushWhitelistTeams, &out.PushWhitelistTeams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PushWhitelistDeployKeys != nil {
+		in, out := &in.PushWhitelistDeployKeys, &out.PushWhitelistDeployKeys
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableForcePush != nil {
+		in, out := &in.EnableForcePush, &out.EnableForcePush
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableForcePushAllowlist != nil {
+		in, out := &in.EnableForcePushAllowlist, &out.EnableForcePushAllowlist
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ForcePushAllowlistUsernames != nil {
+		in, out := &in.ForcePushAllowlistUsernames, &out.ForcePushAllowlistUsernames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ForcePushAllowlistTeams != nil {
+		in, out := &in.ForcePushAllowlistTeams, &out.ForcePushAllowlistTeams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ForcePushAllowlistDeployKeys != nil {
+		in, out := &in.ForcePushAllowlistDeployKeys, &out.ForcePushAllowlistDeployKeys
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableMergeWhitelist != nil {
+		in, out := &in.EnableMergeWhitelist, &out.EnableMergeWhitelist
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MergeWhitelistUsernames != nil {
+		in, out := &in.MergeWhitelistUsernames, &out.MergeWhitelistUsernames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MergeWhitelistTeams != nil {
+		in, out := &in.MergeWhitelistTeams, &out.MergeWhitelistTeams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.EnableStatusCheck != nil {
 		in, out := &in.EnableStatusCheck, &out.EnableStatusCheck
 		*out = new(bool)
@@ -70,6 +130,71 @@ func (in *BranchProtectionAppliedSettings) DeepCopyInto(out *BranchProtectionApp
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.RequiredApprovals != nil {
+		in, out := &in.RequiredApprovals, &out.RequiredApprovals
+		*out = new(int)
+		**out = **in
+	}
+	if in.EnableApprovalsWhitelist != nil {
+		in, out := &in.EnableApprovalsWhitelist, &out.EnableApprovalsWhitelist
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ApprovalsWhitelistUsernames != nil {
+		in, out := &in.ApprovalsWhitelistUsernames, &out.ApprovalsWhitelistUsernames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ApprovalsWhitelistTeams != nil {
+		in, out := &in.ApprovalsWhitelistTeams, &out.ApprovalsWhitelistTeams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BlockOnRejectedReviews != nil {
+		in, out := &in.BlockOnRejectedReviews, &out.BlockOnRejectedReviews
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BlockOnOfficialReviewRequests != nil {
+		in, out := &in.BlockOnOfficialReviewRequests, &out.BlockOnOfficialReviewRequests
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BlockOnOutdatedBranch != nil {
+		in, out := &in.BlockOnOutdatedBranch, &out.BlockOnOutdatedBranch
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BlockAdminMergeOverride != nil {
+		in, out := &in.BlockAdminMergeOverride, &out.BlockAdminMergeOverride
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DismissStaleApprovals != nil {
+		in, out := &in.DismissStaleApprovals, &out.DismissStaleApprovals
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IgnoreStaleApprovals != nil {
+		in, out := &in.IgnoreStaleApprovals, &out.IgnoreStaleApprovals
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RequireSignedCommits != nil {
+		in, out := &in.RequireSignedCommits, &out.RequireSignedCommits
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ProtectedFilePatterns != nil {
+		in, out := &in.ProtectedFilePatterns, &out.ProtectedFilePatterns
+		*out = new(string)
+		**out = **in
+	}
+	if in.UnprotectedFilePatterns != nil {
+		in, out := &in.UnprotectedFilePatterns, &out.UnprotectedFilePatterns
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BranchProtectionAppliedSettings.
@@ -122,6 +247,11 @@ func (in *BranchProtectionObservation) DeepCopyInto(out *BranchProtectionObserva
 		*out = new(string)
 		**out = **in
 	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int64)
+		**out = **in
+	}
 	if in.CreatedAt != nil {
 		in, out := &in.CreatedAt, &out.CreatedAt
 		*out = new(string)
@@ -152,6 +282,11 @@ func (in *BranchProtectionObservation) DeepCopy() *BranchProtectionObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BranchProtectionParameters) DeepCopyInto(out *BranchProtectionParameters) {
 	*out = *in
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int64)
+		**out = **in
+	}
 	if in.EnablePush != nil {
 		in, out := &in.EnablePush, &out.EnablePush
 		*out = new(bool)
@@ -177,6 +312,31 @@ func (in *BranchProtectionParameters) DeepCopyInto(out *BranchProtectionParamete
 		*out = new(bool)
 		**out = **in
 	}
+	if in.EnableForcePush != nil {
+		in, out := &in.EnableForcePush, &out.EnableForcePush
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableForcePushAllowlist != nil {
+		in, out := &in.EnableForcePushAllowlist, &out.EnableForcePushAllowlist
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ForcePushAllowlistUsernames != nil {
+		in, out := &in.ForcePushAllowlistUsernames, &out.ForcePushAllowlistUsernames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ForcePushAllowlistTeams != nil {
+		in, out := &in.ForcePushAllowlistTeams, &out.ForcePushAllowlistTeams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ForcePushAllowlistDeployKeys != nil {
+		in, out := &in.ForcePushAllowlistDeployKeys, &out.ForcePushAllowlistDeployKeys
+		*out = new(bool)
+		**out = **in
+	}
 	if in.EnableMergeWhitelist != nil {
 		in, out := &in.EnableMergeWhitelist, &out.EnableMergeWhitelist
 		*out = new(bool)
@@ -237,11 +397,21 @@ func (in *BranchProtectionParameters) DeepCopyInto(out *BranchProtectionParamete
 		*out = new(bool)
 		**out = **in
 	}
+	if in.BlockAdminMergeOverride != nil {
+		in, out := &in.BlockAdminMergeOverride, &out.BlockAdminMergeOverride
+		*out = new(bool)
+		**out = **in
+	}
 	if in.DismissStaleApprovals != nil {
 		in, out := &in.DismissStaleApprovals, &out.DismissStaleApprovals
 		*out = new(bool)
 		**out = **in
 	}
+	if in.IgnoreStaleApprovals != nil {
+		in, out := &in.IgnoreStaleApprovals, &out.IgnoreStaleApprovals
+		*out = new(bool)
+		**out = **in
+	}
 	if in.RequireSignedCommits != nil {
 		in, out := &in.RequireSignedCommits, &out.RequireSignedCommits
 		*out = new(bool)
@@ -22,6 +22,7 @@ import (
 )
 
 
+// +kubebuilder:validation:XValidation:rule="has(self.key) != has(self.generateKey)",message="exactly one of key or generateKey must be set"
 type DeployKeyParameters struct {
 	// Repository is the repository name
 	// +kubebuilder:validation:Required
@@ -35,14 +36,33 @@ type DeployKeyParameters struct {
 	// +kubebuilder:validation:Required
 	Title string `json:"title"`
 
-	// Key is the SSH public key
-	// +kubebuilder:validation:Required
-	Key string `json:"key"`
+	// Key is the SSH public key. Leave unset when GenerateKey is used.
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// GenerateKey has the provider generate the keypair rather than
+	// registering Key. The private key, public key and known_hosts entry are
+	// written to the connection secret. Changing the
+	// gitea.m.crossplane.io/regenerate-key annotation replaces the key.
+	// +optional
+	GenerateKey *KeyGeneration `json:"generateKey,omitempty"`
 
 	// ReadOnly determines if the key has read-only access
 	// +kubebuilder:default=true
 	ReadOnly *bool `json:"readOnly,omitempty"`
 
+	// PrivateKeySecretRef references the private half of Key. When set, the
+	// private key is included in the connection secret so that it can be
+	// consumed by GitOps tooling.
+	// +optional
+	PrivateKeySecretRef *xpv1.SecretKeySelector `json:"privateKeySecretRef,omitempty"`
+
+	// ConnectionSecretFormat selects the layout of the connection secret
+	// written to writeConnectionSecretToRef.
+	// +kubebuilder:validation:Enum=Default;ArgoCD;Flux;GitCredentials
+	// +kubebuilder:default="Default"
+	ConnectionSecretFormat *string `json:"connectionSecretFormat,omitempty"`
+
 	// V2 Enhancement: Connection reference for multi-tenant support
 	// ConnectionRef specifies the Gitea connection to use
 	ConnectionRef *xpv1.Reference `json:"connectionRef,omitempty"`
@@ -52,6 +72,20 @@ type DeployKeyParameters struct {
 	ProviderConfigRef *xpv1.Reference `json:"providerConfigRef,omitempty"`
 }
 
+// KeyGeneration configures an SSH keypair generated by the provider.
+type KeyGeneration struct {
+	// Algorithm of the generated key.
+	// +kubebuilder:validation:Enum=ed25519;rsa
+	// +kubebuilder:default=ed25519
+	// +optional
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// Bits is the size of a generated RSA key. Defaults to 4096.
+	// +kubebuilder:validation:Enum=2048;3072;4096
+	// +optional
+	Bits *int `json:"bits,omitempty"`
+}
+
 type DeployKeyObservation struct {
 	// ID is the deploy key ID
 	ID *int64 `json:"id,omitempty"`
@@ -62,6 +96,9 @@ type DeployKeyObservation struct {
 	// Fingerprint is the key fingerprint
 	Fingerprint *string `json:"fingerprint,omitempty"`
 
+	// PublicKey is the SSH public key registered with Gitea
+	PublicKey *string `json:"publicKey,omitempty"`
+
 	// CreatedAt is the creation timestamp
 	CreatedAt *string `json:"createdAt,omitempty"`
 
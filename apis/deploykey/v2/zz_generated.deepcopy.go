@@ -102,6 +102,11 @@ func (in *DeployKeyObservation) DeepCopyInto(out *DeployKeyObservation) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.PublicKey != nil {
+		in, out := &in.PublicKey, &out.PublicKey
+		*out = new(string)
+		**out = **in
+	}
 	if in.CreatedAt != nil {
 		in, out := &in.CreatedAt, &out.CreatedAt
 		*out = new(string)
@@ -122,11 +127,26 @@ func (in *DeployKeyObservation) DeepCopy() *DeployKeyObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeployKeyParameters) DeepCopyInto(out *DeployKeyParameters) {
 	*out = *in
+	if in.GenerateKey != nil {
+		in, out := &in.GenerateKey, &out.GenerateKey
+		*out = new(KeyGeneration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ReadOnly != nil {
 		in, out := &in.ReadOnly, &out.ReadOnly
 		*out = new(bool)
 		**out = **in
 	}
+	if in.PrivateKeySecretRef != nil {
+		in, out := &in.PrivateKeySecretRef, &out.PrivateKeySecretRef
+		*out = new(corev2.SecretKeySelector)
+		**out = **in
+	}
+	if in.ConnectionSecretFormat != nil {
+		in, out := &in.ConnectionSecretFormat, &out.ConnectionSecretFormat
+		*out = new(string)
+		**out = **in
+	}
 	if in.ConnectionRef != nil {
 		in, out := &in.ConnectionRef, &out.ConnectionRef
 		*out = new(corev2.Reference)
@@ -182,3 +202,23 @@ func (in *DeployKeyStatus) DeepCopy() *DeployKeyStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyGeneration) DeepCopyInto(out *KeyGeneration) {
+	*out = *in
+	if in.Bits != nil {
+		in, out := &in.Bits, &out.Bits
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyGeneration.
+func (in *KeyGeneration) DeepCopy() *KeyGeneration {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyGeneration)
+	in.DeepCopyInto(out)
+	return out
+}
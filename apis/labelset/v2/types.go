@@ -0,0 +1,215 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:XValidation:rule="has(self.labels) != has(self.labelsFrom)",message="exactly one of labels or labelsFrom must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.repositories) || has(self.organization) || has(self.repositorySelector)",message="one of repositories, organization or repositorySelector must be set"
+type LabelSetParameters struct {
+	// Labels is the label catalog applied to every targeted repository
+	// +optional
+	Labels []LabelDefinition `json:"labels,omitempty"`
+
+	// LabelsFrom reads the label catalog from a ConfigMap in the same
+	// namespace. The key holds a YAML list of label definitions.
+	// +optional
+	LabelsFrom *LabelSource `json:"labelsFrom,omitempty"`
+
+	// Repositories are repositories to apply the catalog to (owner/name
+	// format)
+	// +kubebuilder:validation:items:Pattern="^[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?/[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?$"
+	// +optional
+	Repositories []string `json:"repositories,omitempty"`
+
+	// Organization applies the catalog to every repository of the
+	// organization
+	// +optional
+	Organization *string `json:"organization,omitempty"`
+
+	// RepositorySelector applies the catalog to the repositories of the
+	// Repository resources in the same namespace matching the selector
+	// +optional
+	RepositorySelector *metav1.LabelSelector `json:"repositorySelector,omitempty"`
+
+	// Prune removes labels that are not in the catalog from the targeted
+	// repositories
+	// +kubebuilder:default=false
+	// +optional
+	Prune *bool `json:"prune,omitempty"`
+
+	// V2 Enhancement: Connection reference for multi-tenant support
+	// ConnectionRef specifies the Gitea connection to use
+	ConnectionRef *xpv1.Reference `json:"connectionRef,omitempty"`
+
+	// V2 Enhancement: Namespace-scoped provider config
+	// ProviderConfigRef references a ProviderConfig resource in the same namespace
+	ProviderConfigRef *xpv1.Reference `json:"providerConfigRef,omitempty"`
+}
+
+// LabelDefinition is a label of the catalog
+type LabelDefinition struct {
+	// Name is the label name. Scoped labels use a slash, such as priority/high.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Color is the label color as a hex code, such as #e11d21
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern="^#?[0-9a-fA-F]{6}$"
+	Color string `json:"color"`
+
+	// Description is the label description
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Exclusive makes labels with the same scope mutually exclusive
+	// +optional
+	Exclusive *bool `json:"exclusive,omitempty"`
+}
+
+// LabelSource selects the source of the label catalog
+type LabelSource struct {
+	// ConfigMapKeyRef selects a key of a ConfigMap in the same namespace
+	// +kubebuilder:validation:Required
+	ConfigMapKeyRef ConfigMapKeySelector `json:"configMapKeyRef"`
+}
+
+// ConfigMapKeySelector selects a key of a ConfigMap
+type ConfigMapKeySelector struct {
+	// Name of the ConfigMap
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Key within the ConfigMap
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+}
+
+type LabelSetObservation struct {
+	// Labels is the number of labels in the catalog
+	Labels *int64 `json:"labels,omitempty"`
+
+	// SyncedRepositories counts the targeted repositories whose labels match
+	// the catalog, out of TotalRepositories
+	SyncedRepositories *int64 `json:"syncedRepositories,omitempty"`
+
+	// TotalRepositories is the number of targeted repositories
+	TotalRepositories *int64 `json:"totalRepositories,omitempty"`
+
+	// Repositories reports the sync status of each targeted repository
+	// +listType=map
+	// +listMapKey=repository
+	Repositories []LabelSetRepositoryStatus `json:"repositories,omitempty"`
+}
+
+// LabelSetRepositoryStatus is the sync status of a targeted repository
+type LabelSetRepositoryStatus struct {
+	// Repository is the repository (owner/name format)
+	Repository string `json:"repository"`
+
+	// Synced is true when the labels of the repository match the catalog
+	Synced bool `json:"synced"`
+
+	// Message describes the pending changes or the last error
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// LabelSetSpec defines the desired state of LabelSet
+type LabelSetSpec struct {
+	xpv1.ManagedResourceSpec `json:",inline"`
+	ForProvider              LabelSetParameters `json:"forProvider"`
+}
+
+// LabelSetStatus defines the observed state of LabelSet
+type LabelSetStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+	AtProvider                 LabelSetObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,gitea}
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="LABELS",type="integer",JSONPath=".status.atProvider.labels"
+// +kubebuilder:printcolumn:name="SYNCED-REPOS",type="integer",JSONPath=".status.atProvider.syncedRepositories"
+// +kubebuilder:printcolumn:name="REPOS",type="integer",JSONPath=".status.atProvider.totalRepositories"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// LabelSet is the Schema for the label sets API v2 (namespaced)
+type LabelSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LabelSetSpec   `json:"spec,omitempty"`
+	Status LabelSetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LabelSetList contains a list of LabelSet
+type LabelSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LabelSet `json:"items"`
+}
+
+// GetCondition returns the condition for the given ConditionType if it exists, otherwise returns nil.
+func (r *LabelSet) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return r.Status.GetCondition(ct)
+}
+
+// SetConditions sets the supplied conditions, replacing any existing conditions of the same type.
+func (r *LabelSet) SetConditions(c ...xpv1.Condition) {
+	r.Status.SetConditions(c...)
+}
+
+// GetManagementPolicies returns the management policies for this resource.
+func (r *LabelSet) GetManagementPolicies() xpv1.ManagementPolicies {
+	return r.Spec.ManagementPolicies
+}
+
+// SetManagementPolicies sets the management policies for this resource.
+func (r *LabelSet) SetManagementPolicies(p xpv1.ManagementPolicies) {
+	r.Spec.ManagementPolicies = p
+}
+
+// GetProviderConfigReference of this LabelSet.
+func (r *LabelSet) GetProviderConfigReference() *xpv1.ProviderConfigReference {
+	return r.Spec.ProviderConfigReference
+}
+
+// SetProviderConfigReference of this LabelSet.
+func (r *LabelSet) SetProviderConfigReference(p *xpv1.ProviderConfigReference) {
+	r.Spec.ProviderConfigReference = p
+}
+
+// GetWriteConnectionSecretToReference of this LabelSet.
+func (r *LabelSet) GetWriteConnectionSecretToReference() *xpv1.LocalSecretReference {
+	return r.Spec.WriteConnectionSecretToReference
+}
+
+// SetWriteConnectionSecretToReference of this LabelSet.
+func (r *LabelSet) SetWriteConnectionSecretToReference(p *xpv1.LocalSecretReference) {
+	r.Spec.WriteConnectionSecretToReference = p
+}
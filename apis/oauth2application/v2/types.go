@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type OAuth2ApplicationParameters struct {
+	// Username is the user that owns the application. The application is
+	// managed as this user through the Sudo header, which needs an admin
+	// token. Defaults to the user the provider authenticates as.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="username is immutable"
+	// +optional
+	Username *string `json:"username,omitempty"`
+
+	// Name is the application name shown to users when they authorize it
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// RedirectURIs are the URIs users may be redirected to after authorizing
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	RedirectURIs []string `json:"redirectUris"`
+
+	// ConfidentialClient marks the application as able to keep its client
+	// secret confidential. Public clients, such as native and single-page
+	// apps, must use PKCE instead.
+	// +kubebuilder:default=true
+	// +optional
+	ConfidentialClient *bool `json:"confidentialClient,omitempty"`
+
+	// SkipSecondaryAuthorization skips the consent screen after the first
+	// authorization
+	// +optional
+	SkipSecondaryAuthorization *bool `json:"skipSecondaryAuthorization,omitempty"`
+
+	// V2 Enhancement: Connection reference for multi-tenant support
+	// ConnectionRef specifies the Gitea connection to use
+	ConnectionRef *xpv1.Reference `json:"connectionRef,omitempty"`
+
+	// V2 Enhancement: Namespace-scoped provider config
+	// ProviderConfigRef references a ProviderConfig resource in the same namespace
+	ProviderConfigRef *xpv1.Reference `json:"providerConfigRef,omitempty"`
+}
+
+type OAuth2ApplicationObservation struct {
+	// ID is the Gitea ID of the application
+	ID *int64 `json:"id,omitempty"`
+
+	// ClientID is the OAuth2 client ID
+	ClientID *string `json:"clientId,omitempty"`
+
+	// Created is when the application was created
+	Created *string `json:"created,omitempty"`
+}
+
+// OAuth2ApplicationSpec defines the desired state of OAuth2Application
+type OAuth2ApplicationSpec struct {
+	xpv1.ManagedResourceSpec `json:",inline"`
+	ForProvider              OAuth2ApplicationParameters `json:"forProvider"`
+}
+
+// OAuth2ApplicationStatus defines the observed state of OAuth2Application
+type OAuth2ApplicationStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+	AtProvider                 OAuth2ApplicationObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,gitea}
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="NAME",type="string",JSONPath=".spec.forProvider.name"
+// +kubebuilder:printcolumn:name="CLIENT-ID",type="string",JSONPath=".status.atProvider.clientId"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// OAuth2Application is the Schema for the oauth2applications API v2 (namespaced)
+type OAuth2Application struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OAuth2ApplicationSpec   `json:"spec,omitempty"`
+	Status OAuth2ApplicationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OAuth2ApplicationList contains a list of OAuth2Application
+type OAuth2ApplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OAuth2Application `json:"items"`
+}
+
+// GetCondition returns the condition for the given ConditionType if it exists, otherwise returns nil.
+func (r *OAuth2Application) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return r.Status.GetCondition(ct)
+}
+
+// SetConditions sets the supplied conditions, replacing any existing conditions of the same type.
+func (r *OAuth2Application) SetConditions(c ...xpv1.Condition) {
+	r.Status.SetConditions(c...)
+}
+
+// GetManagementPolicies returns the management policies for this resource.
+func (r *OAuth2Application) GetManagementPolicies() xpv1.ManagementPolicies {
+	return r.Spec.ManagementPolicies
+}
+
+// SetManagementPolicies sets the management policies for this resource.
+func (r *OAuth2Application) SetManagementPolicies(p xpv1.ManagementPolicies) {
+	r.Spec.ManagementPolicies = p
+}
+
+// GetProviderConfigReference of this OAuth2Application.
+func (r *OAuth2Application) GetProviderConfigReference() *xpv1.ProviderConfigReference {
+	return r.Spec.ProviderConfigReference
+}
+
+// SetProviderConfigReference of this OAuth2Application.
+func (r *OAuth2Application) SetProviderConfigReference(p *xpv1.ProviderConfigReference) {
+	r.Spec.ProviderConfigReference = p
+}
+
+// GetWriteConnectionSecretToReference of this OAuth2Application.
+func (r *OAuth2Application) GetWriteConnectionSecretToReference() *xpv1.LocalSecretReference {
+	return r.Spec.WriteConnectionSecretToReference
+}
+
+// SetWriteConnectionSecretToReference of this OAuth2Application.
+func (r *OAuth2Application) SetWriteConnectionSecretToReference(p *xpv1.LocalSecretReference) {
+	r.Spec.WriteConnectionSecretToReference = p
+}
@@ -49,6 +49,26 @@ type OrganizationParameters struct {
 	// +kubebuilder:default="public"
 	Visibility *string `json:"visibility,omitempty"`
 
+	// Avatar sets the organization avatar from an image in a ConfigMap or
+	// Secret in the same namespace. Removing it resets the avatar to the
+	// default
+	// +optional
+	Avatar *AvatarSource `json:"avatar,omitempty"`
+
+	// Labels are issue labels shared by every repository of the
+	// organization. Labels removed from this list are deleted; labels that
+	// were never listed are left alone.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Labels []OrganizationLabel `json:"labels,omitempty"`
+
+	// BlockedUsers are users blocked from the organization. Users removed
+	// from this list are unblocked; users blocked in Gitea but never listed
+	// are left alone.
+	// +optional
+	BlockedUsers []string `json:"blockedUsers,omitempty"`
+
 	// V2 Enhancement: Connection reference for multi-tenant support
 	// ConnectionRef specifies the Gitea connection to use
 	ConnectionRef *xpv1.Reference `json:"connectionRef,omitempty"`
@@ -58,6 +78,51 @@ type OrganizationParameters struct {
 	ProviderConfigRef *xpv1.Reference `json:"providerConfigRef,omitempty"`
 }
 
+// AvatarSource selects an avatar image. The image is read from binaryData
+// or data of a ConfigMap, or from a Secret.
+// +kubebuilder:validation:XValidation:rule="has(self.configMapKeyRef) != has(self.secretKeyRef)",message="exactly one of configMapKeyRef or secretKeyRef is required"
+type AvatarSource struct {
+	// ConfigMapKeyRef selects a key of a ConfigMap in the same namespace
+	// +optional
+	ConfigMapKeyRef *KeySelector `json:"configMapKeyRef,omitempty"`
+
+	// SecretKeyRef selects a key of a Secret in the same namespace
+	// +optional
+	SecretKeyRef *KeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// KeySelector selects a key of a ConfigMap or Secret
+type KeySelector struct {
+	// Name of the ConfigMap or Secret
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Key within the ConfigMap or Secret
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+}
+
+// OrganizationLabel is an issue label shared by the organization's repositories
+type OrganizationLabel struct {
+	// Name is the label name. Scoped labels use a slash, such as priority/high.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Color is the label color as a hex code, such as #e11d21
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern="^#?[0-9a-fA-F]{6}$"
+	Color string `json:"color"`
+
+	// Description is the label description
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Exclusive makes labels with the same scope mutually exclusive
+	// +optional
+	Exclusive *bool `json:"exclusive,omitempty"`
+}
+
 // OrganizationObservation reflects the observed state of a Gitea Organization
 type OrganizationObservation struct {
 	// ID is the unique identifier of the organization
@@ -90,6 +155,23 @@ type OrganizationObservation struct {
 
 	// Teams is the number of teams in the organization
 	Teams *int64 `json:"teams,omitempty"`
+
+	// AvatarHash is the SHA-256 of the avatar image last uploaded
+	AvatarHash *string `json:"avatarHash,omitempty"`
+
+	// Labels lists the names of the organization labels, when labels are managed
+	Labels []string `json:"labels,omitempty"`
+
+	// BlockedUsers lists the users blocked from the organization, when
+	// blocks are managed
+	BlockedUsers []string `json:"blockedUsers,omitempty"`
+
+	// ManagedLabels lists the labels last applied from spec.forProvider.labels
+	ManagedLabels []string `json:"managedLabels,omitempty"`
+
+	// ManagedBlockedUsers lists the users last blocked from
+	// spec.forProvider.blockedUsers
+	ManagedBlockedUsers []string `json:"managedBlockedUsers,omitempty"`
 }
 
 // OrganizationSpec defines the desired state of Organization
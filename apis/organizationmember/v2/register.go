@@ -22,10 +22,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
-// OrganizationMemberUserInfo type metadata.
+// OrganizationMember type metadata.
 var (
-	OrganizationMemberUserInfoKind             = reflect.TypeOf(OrganizationMemberUserInfo{}).Name()
-	OrganizationMemberUserInfoGroupKind        = schema.GroupKind{Group: Group, Kind: OrganizationMemberUserInfoKind}
-	OrganizationMemberUserInfoKindAPIVersion   = OrganizationMemberUserInfoKind + "." + SchemeGroupVersion.String()
-	OrganizationMemberUserInfoGroupVersionKind = SchemeGroupVersion.WithKind(OrganizationMemberUserInfoKind)
+	OrganizationMemberKind             = reflect.TypeOf(OrganizationMember{}).Name()
+	OrganizationMemberGroupKind        = schema.GroupKind{Group: Group, Kind: OrganizationMemberKind}
+	OrganizationMemberKindAPIVersion   = OrganizationMemberKind + "." + SchemeGroupVersion.String()
+	OrganizationMemberGroupVersionKind = SchemeGroupVersion.WithKind(OrganizationMemberKind)
 )
@@ -37,6 +37,7 @@ type OrganizationMemberUserInfo struct {
 	AvatarURL *string `json:"avatarUrl,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="self.role == 'owner' || has(self.defaultTeam)",message="defaultTeam is required unless role is owner"
 type OrganizationMemberParameters struct {
 	// Organization is the organization name
 	// +kubebuilder:validation:Required
@@ -50,12 +51,19 @@ type OrganizationMemberParameters struct {
 	// +kubebuilder:validation:MaxLength=40
 	Username string `json:"username"`
 
-	// Role defines the member's role in the organization
+	// Role defines the member's role in the organization. Gitea grants
+	// membership through teams: owner adds the user to the Owners team,
+	// while admin and member add them to DefaultTeam, whose permission
+	// decides what they can do.
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:Enum=owner;admin;member
 	// +kubebuilder:default=member
 	Role string `json:"role"`
 
+	// DefaultTeam is the team a user with role admin or member is added to
+	// +optional
+	DefaultTeam *string `json:"defaultTeam,omitempty"`
+
 	// Visibility defines if the membership is public or private
 	// +kubebuilder:validation:Enum=public;private
 	// +kubebuilder:default=private
@@ -74,14 +82,15 @@ type OrganizationMemberObservation struct {
 	// Username is the username of the member
 	Username *string `json:"username,omitempty"`
 
-	// Role is the member's role in the organization
+	// Role is owner when the user is in the Owners team, and member
+	// otherwise
 	Role *string `json:"role,omitempty"`
 
 	// Visibility indicates if the membership is public or private
 	Visibility *string `json:"visibility,omitempty"`
 
-	// JoinedAt is the timestamp when the user joined the organization
-	JoinedAt *string `json:"joinedAt,omitempty"`
+	// Teams lists the organization teams the user is a member of
+	Teams []string `json:"teams,omitempty"`
 
 	// Organization is the organization name
 	Organization *string `json:"organization,omitempty"`
@@ -112,6 +121,7 @@ type OrganizationMemberStatus struct {
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane.io/external-name"
+// +kubebuilder:printcolumn:name="ROLE",type="string",JSONPath=".status.atProvider.role"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // OrganizationMember is the Schema for the organizationmembers API v2 (namespaced)
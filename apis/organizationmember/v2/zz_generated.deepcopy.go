@@ -102,10 +102,10 @@ func (in *OrganizationMemberObservation) DeepCopyInto(out *OrganizationMemberObs
 		*out = new(string)
 		**out = **in
 	}
-	if in.JoinedAt != nil {
-		in, out := &in.JoinedAt, &out.JoinedAt
-		*out = new(string)
-		**out = **in
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	if in.Organization != nil {
 		in, out := &in.Organization, &out.Organization
@@ -132,6 +132,11 @@ func (in *OrganizationMemberObservation) DeepCopy() *OrganizationMemberObservati
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OrganizationMemberParameters) DeepCopyInto(out *OrganizationMemberParameters) {
 	*out = *in
+	if in.DefaultTeam != nil {
+		in, out := &in.DefaultTeam, &out.DefaultTeam
+		*out = new(string)
+		**out = **in
+	}
 	if in.Visibility != nil {
 		in, out := &in.Visibility, &out.Visibility
 		*out = new(string)
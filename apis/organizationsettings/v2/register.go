@@ -22,10 +22,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
-// AppliedOrganizationSettings type metadata.
+// OrganizationSettings type metadata.
 var (
-	AppliedOrganizationSettingsKind             = reflect.TypeOf(AppliedOrganizationSettings{}).Name()
-	AppliedOrganizationSettingsGroupKind        = schema.GroupKind{Group: Group, Kind: AppliedOrganizationSettingsKind}
-	AppliedOrganizationSettingsKindAPIVersion   = AppliedOrganizationSettingsKind + "." + SchemeGroupVersion.String()
-	AppliedOrganizationSettingsGroupVersionKind = SchemeGroupVersion.WithKind(AppliedOrganizationSettingsKind)
+	OrganizationSettingsKind             = reflect.TypeOf(OrganizationSettings{}).Name()
+	OrganizationSettingsGroupKind        = schema.GroupKind{Group: Group, Kind: OrganizationSettingsKind}
+	OrganizationSettingsKindAPIVersion   = OrganizationSettingsKind + "." + SchemeGroupVersion.String()
+	OrganizationSettingsGroupVersionKind = SchemeGroupVersion.WithKind(OrganizationSettingsKind)
 )
@@ -32,62 +32,105 @@ type AppliedOrganizationSettings struct {
 
 	// Location is the applied organization location
 	Location *string `json:"location,omitempty"`
+
+	// Visibility is the applied organization visibility
+	Visibility *string `json:"visibility,omitempty"`
+
+	// RepoAdminChangeTeamAccess is the applied team access setting
+	RepoAdminChangeTeamAccess *bool `json:"repoAdminChangeTeamAccess,omitempty"`
+
+	// DefaultRepoPermission is the permission of the default team
+	DefaultRepoPermission *string `json:"defaultRepoPermission,omitempty"`
+
+	// MembersCanCreateRepos is whether the default team can create repositories
+	MembersCanCreateRepos *bool `json:"membersCanCreateRepos,omitempty"`
+
+	// MaxRepoCreation is the repository limit last applied. Gitea does not
+	// report it, so it cannot be observed.
+	MaxRepoCreation *int64 `json:"maxRepoCreation,omitempty"`
 }
 
+// OrganizationSettingsParameters define the desired state of the settings.
+// Earlier versions defaulted DefaultRepoPermission to read and
+// MembersCanCreateRepos to true, so those values are ignored without a
+// DefaultTeam and objects created then still validate.
+// +kubebuilder:validation:XValidation:rule="has(self.defaultTeam) || ((!has(self.defaultRepoPermission) || self.defaultRepoPermission == 'read') && (!has(self.membersCanCreateRepos) || self.membersCanCreateRepos))",message="defaultTeam is required for defaultRepoPermission and membersCanCreateRepos"
 type OrganizationSettingsParameters struct {
 	// Organization is the organization name to configure
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
 	Organization string `json:"organization"`
 
-	// DefaultRepoPermission is the default permission for organization members on new repositories
+	// Visibility is the organization visibility
+	// +kubebuilder:validation:Enum=public;limited;private
+	// +optional
+	Visibility *string `json:"visibility,omitempty"`
+
+	// RepoAdminChangeTeamAccess allows repository admins to change team access
+	// +optional
+	RepoAdminChangeTeamAccess *bool `json:"repoAdminChangeTeamAccess,omitempty"`
+
+	// DefaultTeam is the team that carries the organization's repository
+	// defaults. It is given access to all repositories.
+	// +optional
+	DefaultTeam *string `json:"defaultTeam,omitempty"`
+
+	// DefaultRepoPermission is the permission of DefaultTeam on every
+	// organization repository
 	// +kubebuilder:validation:Enum=read;write;admin
-	// +kubebuilder:default="read"
+	// +optional
 	DefaultRepoPermission *string `json:"defaultRepoPermission,omitempty"`
 
-	// MembersCanCreateRepos controls whether organization members can create repositories
-	// +kubebuilder:default=true
+	// MembersCanCreateRepos controls whether members of DefaultTeam can create repositories
+	// +optional
 	MembersCanCreateRepos *bool `json:"membersCanCreateRepos,omitempty"`
 
-	// MembersCanCreatePrivate controls whether organization members can create private repositories
-	// +kubebuilder:default=true
+	// MaxRepoCreation is the maximum number of repositories the organization
+	// can own, -1 for the global default. Requires an admin token.
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	MaxRepoCreation *int64 `json:"maxRepoCreation,omitempty"`
+
+	// MembersCanCreatePrivate is not supported by Gitea; setting it fails reconciliation.
+	// +optional
 	MembersCanCreatePrivate *bool `json:"membersCanCreatePrivate,omitempty"`
 
-	// MembersCanCreateInternal controls whether organization members can create internal repositories
-	// +kubebuilder:default=true
+	// MembersCanCreateInternal is not supported by Gitea; setting it fails reconciliation.
+	// +optional
 	MembersCanCreateInternal *bool `json:"membersCanCreateInternal,omitempty"`
 
-	// MembersCanDeleteRepos controls whether organization members can delete repositories
-	// +kubebuilder:default=false
+	// MembersCanDeleteRepos is not supported by Gitea; setting it fails reconciliation.
+	// +optional
 	MembersCanDeleteRepos *bool `json:"membersCanDeleteRepos,omitempty"`
 
-	// MembersCanFork controls whether organization members can fork repositories
-	// +kubebuilder:default=true
+	// MembersCanFork is not supported by Gitea; setting it fails reconciliation.
+	// +optional
 	MembersCanFork *bool `json:"membersCanFork,omitempty"`
 
-	// MembersCanCreatePages controls whether organization members can create GitHub Pages
-	// +kubebuilder:default=true
+	// MembersCanCreatePages is not supported by Gitea; setting it fails reconciliation.
+	// +optional
 	MembersCanCreatePages *bool `json:"membersCanCreatePages,omitempty"`
 
-	// DefaultRepoVisibility is the default visibility for new repositories
-	// +kubebuilder:validation:Enum=public;private;internal
-	// +kubebuilder:default="public"
+	// DefaultRepoVisibility is not supported by Gitea; setting it fails reconciliation.
+	// +optional
 	DefaultRepoVisibility *string `json:"defaultRepoVisibility,omitempty"`
 
-	// RequireSignedCommits controls whether signed commits are required
-	// +kubebuilder:default=false
+	// RequireSignedCommits is not supported by Gitea; setting it fails
+	// reconciliation. Use BranchProtection requireSignedCommits instead.
+	// +optional
 	RequireSignedCommits *bool `json:"requireSignedCommits,omitempty"`
 
-	// EnableDependencyGraph controls whether dependency graph is enabled
-	// +kubebuilder:default=false
+	// EnableDependencyGraph is not supported by Gitea; setting it fails reconciliation.
+	// +optional
 	EnableDependencyGraph *bool `json:"enableDependencyGraph,omitempty"`
 
-	// AllowGitHooks controls whether Git hooks are allowed in organization repositories
-	// +kubebuilder:default=false
+	// AllowGitHooks is not supported by Gitea; setting it fails
+	// reconciliation. Git hook permission is granted per user.
+	// +optional
 	AllowGitHooks *bool `json:"allowGitHooks,omitempty"`
 
-	// AllowCustomGitHooks controls whether custom Git hooks are allowed
-	// +kubebuilder:default=false
+	// AllowCustomGitHooks is not supported by Gitea; setting it fails reconciliation.
+	// +optional
 	AllowCustomGitHooks *bool `json:"allowCustomGitHooks,omitempty"`
 
 	// V2 Enhancement: Connection reference for multi-tenant support
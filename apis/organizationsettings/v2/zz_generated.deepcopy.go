@@ -43,6 +43,31 @@ func (in *AppliedOrganizationSettings) DeepCopyInto(out *AppliedOrganizationSett
 		*out = new(string)
 		**out = **in
 	}
+	if in.Visibility != nil {
+		in, out := &in.Visibility, &out.Visibility
+		*out = new(string)
+		**out = **in
+	}
+	if in.RepoAdminChangeTeamAccess != nil {
+		in, out := &in.RepoAdminChangeTeamAccess, &out.RepoAdminChangeTeamAccess
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DefaultRepoPermission != nil {
+		in, out := &in.DefaultRepoPermission, &out.DefaultRepoPermission
+		*out = new(string)
+		**out = **in
+	}
+	if in.MembersCanCreateRepos != nil {
+		in, out := &in.MembersCanCreateRepos, &out.MembersCanCreateRepos
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxRepoCreation != nil {
+		in, out := &in.MaxRepoCreation, &out.MaxRepoCreation
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppliedOrganizationSettings.
@@ -147,6 +172,21 @@ func (in *OrganizationSettingsObservation) DeepCopy() *OrganizationSettingsObser
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OrganizationSettingsParameters) DeepCopyInto(out *OrganizationSettingsParameters) {
 	*out = *in
+	if in.Visibility != nil {
+		in, out := &in.Visibility, &out.Visibility
+		*out = new(string)
+		**out = **in
+	}
+	if in.RepoAdminChangeTeamAccess != nil {
+		in, out := &in.RepoAdminChangeTeamAccess, &out.RepoAdminChangeTeamAccess
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DefaultTeam != nil {
+		in, out := &in.DefaultTeam, &out.DefaultTeam
+		*out = new(string)
+		**out = **in
+	}
 	if in.DefaultRepoPermission != nil {
 		in, out := &in.DefaultRepoPermission, &out.DefaultRepoPermission
 		*out = new(string)
@@ -157,6 +197,11 @@ func (in *OrganizationSettingsParameters) DeepCopyInto(out *OrganizationSettings
 		*out = new(bool)
 		**out = **in
 	}
+	if in.MaxRepoCreation != nil {
+		in, out := &in.MaxRepoCreation, &out.MaxRepoCreation
+		*out = new(int64)
+		**out = **in
+	}
 	if in.MembersCanCreatePrivate != nil {
 		in, out := &in.MembersCanCreatePrivate, &out.MembersCanCreatePrivate
 		*out = new(bool)
@@ -22,10 +22,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
-// ReleaseAsset type metadata.
+// Release type metadata.
 var (
-	ReleaseAssetKind             = reflect.TypeOf(ReleaseAsset{}).Name()
-	ReleaseAssetGroupKind        = schema.GroupKind{Group: Group, Kind: ReleaseAssetKind}
-	ReleaseAssetKindAPIVersion   = ReleaseAssetKind + "." + SchemeGroupVersion.String()
-	ReleaseAssetGroupVersionKind = SchemeGroupVersion.WithKind(ReleaseAssetKind)
+	ReleaseKind             = reflect.TypeOf(Release{}).Name()
+	ReleaseGroupKind        = schema.GroupKind{Group: Group, Kind: ReleaseKind}
+	ReleaseKindAPIVersion   = ReleaseKind + "." + SchemeGroupVersion.String()
+	ReleaseGroupVersionKind = SchemeGroupVersion.WithKind(ReleaseKind)
 )
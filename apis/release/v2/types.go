@@ -23,21 +23,41 @@ import (
 
 
 // ReleaseAsset represents a file attached to a release
+// +kubebuilder:validation:XValidation:rule="[has(self.content), has(self.url), has(self.configMapKeyRef), has(self.secretKeyRef)].filter(x, x).size() == 1",message="exactly one of content, url, configMapKeyRef or secretKeyRef must be set"
 type ReleaseAsset struct {
 	// Name is the filename for the asset
 	// +kubebuilder:validation:Required
 	Name string `json:"name"`
 
 	// ContentType is the MIME type of the asset
+	// Defaults to application/octet-stream
 	ContentType *string `json:"contentType,omitempty"`
 
 	// Content is the base64-encoded content of the asset
-	// For large files, consider using a separate storage solution
+	// For large files, use a URL instead
 	Content *string `json:"content,omitempty"`
 
-	// URL is a URL to download the asset content from
-	// Alternative to inline content for large files
+	// URL is a URL the provider downloads the asset content from
+	// The asset is only uploaded again when the URL changes
 	URL *string `json:"url,omitempty"`
+
+	// ConfigMapKeyRef selects a binaryData or data key of a ConfigMap in the
+	// same namespace
+	ConfigMapKeyRef *KeySelector `json:"configMapKeyRef,omitempty"`
+
+	// SecretKeyRef selects a key of a Secret in the same namespace
+	SecretKeyRef *KeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// KeySelector selects a key of a ConfigMap or Secret
+type KeySelector struct {
+	// Name of the ConfigMap or Secret
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Key within the ConfigMap or Secret
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
 }
 
 // ReleaseAssetObservation contains the observed state of a release asset
@@ -57,6 +77,12 @@ type ReleaseAssetObservation struct {
 	// ContentType is the MIME type of the asset
 	ContentType string `json:"contentType,omitempty"`
 
+	// SHA256 is the checksum of the content last uploaded by the provider
+	SHA256 string `json:"sha256,omitempty"`
+
+	// SourceURL is the URL the content was last downloaded from
+	SourceURL string `json:"sourceUrl,omitempty"`
+
 	// BrowserDownloadURL is the direct download URL for the asset
 	BrowserDownloadURL string `json:"browserDownloadUrl,omitempty"`
 
@@ -77,11 +103,15 @@ type ReleaseParameters struct {
 	Owner string `json:"owner"`
 
 	// TagName is the name of the tag for this release
+	// An existing release for this tag is adopted, and the tag is created
+	// if it does not exist
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="tagName is immutable"
 	TagName string `json:"tagName"`
 
 	// TargetCommitish specifies the commitish value that determines where the Git tag is created from
 	// Can be any branch or commit SHA. Defaults to repository default branch if omitted
+	// Only used when the tag is created
 	TargetCommitish *string `json:"targetCommitish,omitempty"`
 
 	// Name is the title of the release
@@ -100,11 +130,23 @@ type ReleaseParameters struct {
 	// +kubebuilder:default=false
 	Prerelease *bool `json:"prerelease,omitempty"`
 
-	// GenerateNotes automatically generates release notes from commits and PRs
+	// GenerateNotes lists the commits since the previous release in the
+	// release notes, below Body, when the release is created
 	// +kubebuilder:default=false
 	GenerateNotes *bool `json:"generateNotes,omitempty"`
 
+	// DeletionMode controls what deleting the resource removes: the release
+	// only, or the release and its tag
+	// +kubebuilder:validation:Enum=Release;ReleaseAndTag
+	// +kubebuilder:default=Release
+	DeletionMode *string `json:"deletionMode,omitempty"`
+
 	// Assets is a list of assets to attach to this release
+	// Assets are matched by name. Changed content replaces the asset, and
+	// assets the provider uploaded are deleted once removed from this list.
+	// Assets attached by other means are left alone
+	// +listType=map
+	// +listMapKey=name
 	Assets []ReleaseAsset `json:"assets,omitempty"`
 
 	// V2 Enhancement: Connection reference for multi-tenant support
@@ -187,6 +229,9 @@ type ReleaseStatus struct {
 // +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,gitea},shortName=rele
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TAG",type="string",JSONPath=".spec.forProvider.tagName"
+// +kubebuilder:printcolumn:name="DRAFT",type="boolean",JSONPath=".status.atProvider.draft"
+// +kubebuilder:printcolumn:name="PRERELEASE",type="boolean",JSONPath=".status.atProvider.prerelease"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane.io/external-name"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
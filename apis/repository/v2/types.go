@@ -23,16 +23,22 @@ import (
 
 
 // RepositoryParameters define the desired state of a Gitea Repository v2
+// +kubebuilder:validation:XValidation:rule="!has(self.deletionMode) || self.deletionMode != 'Transfer' || has(self.graveyardOrganization)",message="graveyardOrganization is required when deletionMode is Transfer"
 type RepositoryParameters struct {
-	// Name is the repository name
+	// Name is the repository name. Changing it renames the repository.
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:Pattern="^[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?$"
 	Name string `json:"name"`
 
 	// Owner is the username or organization name that owns the repository
 	// If not specified, the repository will be created under the authenticated user
+	// Changing it transfers the repository to the new owner
 	Owner *string `json:"owner,omitempty"`
 
+	// TransferTeams are the teams of the new owner organization that get
+	// access to the repository when it is transferred there
+	TransferTeams []string `json:"transferTeams,omitempty"`
+
 	// Description is the repository description
 	Description *string `json:"description,omitempty"`
 
@@ -52,7 +58,9 @@ type RepositoryParameters struct {
 	// +kubebuilder:default=false
 	Archived *bool `json:"archived,omitempty"`
 
-	// DefaultBranch is the default branch name
+	// DefaultBranch is the default branch name. Switching to a branch that
+	// does not exist yet, such as one created by a Branch resource, waits
+	// until the branch exists.
 	// +kubebuilder:default="master"
 	DefaultBranch *string `json:"defaultBranch,omitempty"`
 
@@ -61,6 +69,36 @@ type RepositoryParameters struct {
 	// +kubebuilder:default="default"
 	TrustModel *string `json:"trustModel,omitempty"`
 
+	// ConnectionSecretFormat selects the layout of the connection secret
+	// written to writeConnectionSecretToRef.
+	// +kubebuilder:validation:Enum=Default;ArgoCD;Flux;GitCredentials
+	// +kubebuilder:default="Default"
+	ConnectionSecretFormat *string `json:"connectionSecretFormat,omitempty"`
+
+	// DeletionMode controls what deleting the resource does to the
+	// repository. Delete removes it, Archive archives it and renames it with
+	// a "-deleted-<timestamp>" suffix, and Transfer moves it to the
+	// GraveyardOrganization.
+	// +kubebuilder:validation:Enum=Delete;Archive;Transfer
+	// +kubebuilder:default="Delete"
+	DeletionMode *string `json:"deletionMode,omitempty"`
+
+	// GraveyardOrganization receives the repository when DeletionMode is
+	// Transfer.
+	GraveyardOrganization *string `json:"graveyardOrganization,omitempty"`
+
+	// DeletionGracePeriod delays the deletion after the resource is deleted.
+	// Setting spec.deletionPolicy to Orphan or adding the protection
+	// annotation before it ends cancels the deletion.
+	DeletionGracePeriod *metav1.Duration `json:"deletionGracePeriod,omitempty"`
+
+	// Exclusive makes the selected labels, collaborators, webhooks and
+	// deploy keys of the repository authoritative: entries that no Label,
+	// LabelSet, RepositoryCollaborator, Webhook, DeployKey or RepositoryKey in
+	// the same namespace manages are reported and, in Enforce mode, removed.
+	// +optional
+	Exclusive *RepositoryExclusivity `json:"exclusive,omitempty"`
+
 	// V2 Enhancement: Connection reference for multi-tenant support
 	// ConnectionRef specifies the Gitea connection to use
 	ConnectionRef *xpv1.Reference `json:"connectionRef,omitempty"`
@@ -70,6 +108,51 @@ type RepositoryParameters struct {
 	ProviderConfigRef *xpv1.Reference `json:"providerConfigRef,omitempty"`
 }
 
+// RepositoryExclusivity selects the repository settings that only managed
+// resources may add to.
+type RepositoryExclusivity struct {
+	// Labels reports labels no Label or LabelSet resource manages
+	// +optional
+	Labels *bool `json:"labels,omitempty"`
+
+	// Collaborators reports collaborators no RepositoryCollaborator resource
+	// manages
+	// +optional
+	Collaborators *bool `json:"collaborators,omitempty"`
+
+	// Webhooks reports webhooks no Webhook resource manages
+	// +optional
+	Webhooks *bool `json:"webhooks,omitempty"`
+
+	// DeployKeys reports deploy keys no DeployKey or RepositoryKey resource
+	// manages
+	// +optional
+	DeployKeys *bool `json:"deployKeys,omitempty"`
+
+	// Mode is DryRun to only list the unmanaged entries in
+	// status.atProvider.unmanaged, or Enforce to remove them.
+	// +kubebuilder:validation:Enum=DryRun;Enforce
+	// +kubebuilder:default="DryRun"
+	// +optional
+	Mode *string `json:"mode,omitempty"`
+}
+
+// UnmanagedEntries lists repository settings no managed resource accounts
+// for. Webhooks and deploy keys are listed with their ID.
+type UnmanagedEntries struct {
+	// Labels are the names of unmanaged labels
+	Labels []string `json:"labels,omitempty"`
+
+	// Collaborators are the usernames of unmanaged collaborators
+	Collaborators []string `json:"collaborators,omitempty"`
+
+	// Webhooks are the URLs of unmanaged webhooks
+	Webhooks []string `json:"webhooks,omitempty"`
+
+	// DeployKeys are the titles of unmanaged deploy keys
+	DeployKeys []string `json:"deployKeys,omitempty"`
+}
+
 // RepositoryObservation reflects the observed state of a Gitea Repository
 type RepositoryObservation struct {
 	// ID is the unique identifier of the repository
@@ -87,6 +170,9 @@ type RepositoryObservation struct {
 	// CloneURL is the HTTPS URL for cloning
 	CloneURL *string `json:"cloneUrl,omitempty"`
 
+	// DefaultBranch is the current default branch
+	DefaultBranch *string `json:"defaultBranch,omitempty"`
+
 	// CreatedAt is the creation timestamp
 	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
 
@@ -105,6 +191,18 @@ type RepositoryObservation struct {
 
 	// Language is the primary programming language
 	Language *string `json:"language,omitempty"`
+
+	// PendingTransferTo is the owner a transfer awaits acceptance by
+	PendingTransferTo *string `json:"pendingTransferTo,omitempty"`
+
+	// DeletionScheduledAt is when a deletion delayed by DeletionGracePeriod
+	// takes effect
+	DeletionScheduledAt *metav1.Time `json:"deletionScheduledAt,omitempty"`
+
+	// Unmanaged lists the entries selected by spec.forProvider.exclusive
+	// that no resource manages. In Enforce mode they are removed on the
+	// next update.
+	Unmanaged *UnmanagedEntries `json:"unmanaged,omitempty"`
 }
 
 // RepositorySpec defines the desired state of Repository
@@ -0,0 +1,224 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:XValidation:rule="has(self.content) != has(self.contentFrom)",message="exactly one of content or contentFrom must be set"
+type RepositoryFileParameters struct {
+	// Owner is the username or organization name that owns the repository
+	// +kubebuilder:validation:Required
+	Owner string `json:"owner"`
+
+	// Repository is the name of the repository
+	// +kubebuilder:validation:Required
+	Repository string `json:"repository"`
+
+	// Branch the file is committed to. Defaults to the repository's default
+	// branch.
+	// +optional
+	Branch *string `json:"branch,omitempty"`
+
+	// Path of the file within the repository
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+
+	// Content of the file
+	// +optional
+	Content *string `json:"content,omitempty"`
+
+	// ContentFrom reads the content of the file from a ConfigMap in the
+	// same namespace
+	// +optional
+	ContentFrom *ContentSource `json:"contentFrom,omitempty"`
+
+	// CommitMessage used for commits made by the provider. Defaults to a
+	// message naming the file.
+	// +optional
+	CommitMessage *string `json:"commitMessage,omitempty"`
+
+	// Author of commits made by the provider. Defaults to the token owner.
+	// +optional
+	Author *Identity `json:"author,omitempty"`
+
+	// Committer of commits made by the provider. Defaults to the author.
+	// +optional
+	Committer *Identity `json:"committer,omitempty"`
+
+	// PullRequest, when set, proposes changes through a pull request from a
+	// head branch instead of committing directly to Branch. Use this for
+	// protected branches.
+	// +optional
+	PullRequest *PullRequestOptions `json:"pullRequest,omitempty"`
+
+	// V2 Enhancement: Connection reference for multi-tenant support
+	// ConnectionRef specifies the Gitea connection to use
+	ConnectionRef *xpv1.Reference `json:"connectionRef,omitempty"`
+
+	// V2 Enhancement: Namespace-scoped provider config
+	// ProviderConfigRef references a ProviderConfig resource in the same namespace
+	ProviderConfigRef *xpv1.Reference `json:"providerConfigRef,omitempty"`
+}
+
+// ContentSource selects the source of file content
+type ContentSource struct {
+	// ConfigMapKeyRef selects a key of a ConfigMap in the same namespace
+	// +kubebuilder:validation:Required
+	ConfigMapKeyRef ConfigMapKeySelector `json:"configMapKeyRef"`
+}
+
+// ConfigMapKeySelector selects a key of a ConfigMap
+type ConfigMapKeySelector struct {
+	// Name of the ConfigMap
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Key within the ConfigMap
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+}
+
+// Identity is the author or committer of a commit
+type Identity struct {
+	// Name of the identity
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Email of the identity
+	// +kubebuilder:validation:Required
+	Email string `json:"email"`
+}
+
+// PullRequestOptions configures changes proposed through a pull request
+type PullRequestOptions struct {
+	// HeadBranch the changes are committed to. It is created from Branch
+	// when it does not exist.
+	// +kubebuilder:validation:Required
+	HeadBranch string `json:"headBranch"`
+
+	// Title of the pull request. Defaults to the commit message.
+	// +optional
+	Title *string `json:"title,omitempty"`
+
+	// Body of the pull request
+	// +optional
+	Body *string `json:"body,omitempty"`
+}
+
+type RepositoryFileObservation struct {
+	// SHA is the blob SHA of the file on Branch
+	SHA *string `json:"sha,omitempty"`
+
+	// LastCommitSHA is the SHA of the last commit that touched the file
+	LastCommitSHA *string `json:"lastCommitSha,omitempty"`
+
+	// Branch the file was observed on
+	Branch *string `json:"branch,omitempty"`
+
+	// HTMLURL is the web URL of the file
+	HTMLURL *string `json:"htmlUrl,omitempty"`
+
+	// PullRequestNumber is the number of the pull request proposing changes
+	PullRequestNumber *int64 `json:"pullRequestNumber,omitempty"`
+
+	// PullRequestURL is the web URL of the pull request proposing changes
+	PullRequestURL *string `json:"pullRequestUrl,omitempty"`
+}
+
+// RepositoryFileSpec defines the desired state of RepositoryFile
+type RepositoryFileSpec struct {
+	xpv1.ManagedResourceSpec `json:",inline"`
+	ForProvider              RepositoryFileParameters `json:"forProvider"`
+}
+
+// RepositoryFileStatus defines the observed state of RepositoryFile
+type RepositoryFileStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+	AtProvider                 RepositoryFileObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,gitea}
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="PATH",type="string",JSONPath=".spec.forProvider.path"
+// +kubebuilder:printcolumn:name="SHA",type="string",JSONPath=".status.atProvider.sha"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// RepositoryFile is the Schema for the repositoryfiles API v2 (namespaced)
+type RepositoryFile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RepositoryFileSpec   `json:"spec,omitempty"`
+	Status RepositoryFileStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RepositoryFileList contains a list of RepositoryFile
+type RepositoryFileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RepositoryFile `json:"items"`
+}
+
+// GetCondition returns the condition for the given ConditionType if it exists, otherwise returns nil.
+func (r *RepositoryFile) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return r.Status.GetCondition(ct)
+}
+
+// SetConditions sets the supplied conditions, replacing any existing conditions of the same type.
+func (r *RepositoryFile) SetConditions(c ...xpv1.Condition) {
+	r.Status.SetConditions(c...)
+}
+
+// GetManagementPolicies returns the management policies for this resource.
+func (r *RepositoryFile) GetManagementPolicies() xpv1.ManagementPolicies {
+	return r.Spec.ManagementPolicies
+}
+
+// SetManagementPolicies sets the management policies for this resource.
+func (r *RepositoryFile) SetManagementPolicies(p xpv1.ManagementPolicies) {
+	r.Spec.ManagementPolicies = p
+}
+
+// GetProviderConfigReference of this RepositoryFile.
+func (r *RepositoryFile) GetProviderConfigReference() *xpv1.ProviderConfigReference {
+	return r.Spec.ProviderConfigReference
+}
+
+// SetProviderConfigReference of this RepositoryFile.
+func (r *RepositoryFile) SetProviderConfigReference(p *xpv1.ProviderConfigReference) {
+	r.Spec.ProviderConfigReference = p
+}
+
+// GetWriteConnectionSecretToReference of this RepositoryFile.
+func (r *RepositoryFile) GetWriteConnectionSecretToReference() *xpv1.LocalSecretReference {
+	return r.Spec.WriteConnectionSecretToReference
+}
+
+// SetWriteConnectionSecretToReference of this RepositoryFile.
+func (r *RepositoryFile) SetWriteConnectionSecretToReference(p *xpv1.LocalSecretReference) {
+	r.Spec.WriteConnectionSecretToReference = p
+}
@@ -25,6 +25,26 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyGeneration) DeepCopyInto(out *KeyGeneration) {
+	*out = *in
+	if in.Bits != nil {
+		in, out := &in.Bits, &out.Bits
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyGeneration.
+func (in *KeyGeneration) DeepCopy() *KeyGeneration {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyGeneration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RepositoryKey) DeepCopyInto(out *RepositoryKey) {
 	*out = *in
@@ -102,6 +122,11 @@ func (in *RepositoryKeyObservation) DeepCopyInto(out *RepositoryKeyObservation)
 		*out = new(string)
 		**out = **in
 	}
+	if in.PublicKey != nil {
+		in, out := &in.PublicKey, &out.PublicKey
+		*out = new(string)
+		**out = **in
+	}
 	if in.ReadOnly != nil {
 		in, out := &in.ReadOnly, &out.ReadOnly
 		*out = new(bool)
@@ -137,6 +162,11 @@ func (in *RepositoryKeyObservation) DeepCopy() *RepositoryKeyObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RepositoryKeyParameters) DeepCopyInto(out *RepositoryKeyParameters) {
 	*out = *in
+	if in.GenerateKey != nil {
+		in, out := &in.GenerateKey, &out.GenerateKey
+		*out = new(KeyGeneration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ReadOnly != nil {
 		in, out := &in.ReadOnly, &out.ReadOnly
 		*out = new(bool)
@@ -22,10 +22,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
-// RunnerGroupInfo type metadata.
+// Runner type metadata.
 var (
-	RunnerGroupInfoKind             = reflect.TypeOf(RunnerGroupInfo{}).Name()
-	RunnerGroupInfoGroupKind        = schema.GroupKind{Group: Group, Kind: RunnerGroupInfoKind}
-	RunnerGroupInfoKindAPIVersion   = RunnerGroupInfoKind + "." + SchemeGroupVersion.String()
-	RunnerGroupInfoGroupVersionKind = SchemeGroupVersion.WithKind(RunnerGroupInfoKind)
+	RunnerKind             = reflect.TypeOf(Runner{}).Name()
+	RunnerGroupKind        = schema.GroupKind{Group: Group, Kind: RunnerKind}
+	RunnerKindAPIVersion   = RunnerKind + "." + SchemeGroupVersion.String()
+	RunnerGroupVersionKind = SchemeGroupVersion.WithKind(RunnerKind)
 )
@@ -22,18 +22,10 @@ import (
 )
 
 
-// RunnerGroupInfo contains runner group information
-type RunnerGroupInfo struct {
-	// ID is the group identifier
-	ID *int64 `json:"id,omitempty"`
-
-	// Name is the group name
-	Name *string `json:"name,omitempty"`
-
-	// Description is the group description
-	Description *string `json:"description,omitempty"`
-}
-
+// RunnerParameters identify a runner registered through act_runner. Gitea
+// has no API to create or edit runners, so a Runner adopts the registered
+// runner matching Name and Labels, reports its state, and unregisters it on
+// deletion.
 type RunnerParameters struct {
 	// Scope defines where the runner is registered (repository, organization, or system)
 	// +kubebuilder:validation:Required
@@ -45,23 +37,16 @@ type RunnerParameters struct {
 	// +kubebuilder:validation:Pattern="^([a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?(/[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?)?)?$"
 	ScopeValue *string `json:"scopeValue,omitempty"`
 
-	// Name is the display name for the runner
+	// Name is the name the runner registered with
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=100
 	Name string `json:"name"`
 
-	// Labels are the labels assigned to this runner for job targeting
-	// +kubebuilder:validation:MinItems=1
+	// Labels the runner must have to be adopted. Runners may have
+	// additional labels.
 	// +kubebuilder:validation:UniqueItems=true
-	Labels []string `json:"labels"`
-
-	// Description is an optional description for the runner
-	// +kubebuilder:validation:MaxLength=500
-	Description *string `json:"description,omitempty"`
-
-	// RunnerGroupID is the ID of the runner group (for organization/system runners)
-	RunnerGroupID *int64 `json:"runnerGroupId,omitempty"`
+	Labels []string `json:"labels,omitempty"`
 
 	// V2 Enhancement: Connection reference for multi-tenant support
 	// ConnectionRef specifies the Gitea connection to use
@@ -79,48 +64,30 @@ type RunnerObservation struct {
 	// Name is the display name for the runner
 	Name *string `json:"name,omitempty"`
 
-	// UUID is the unique UUID of the runner
-	UUID *string `json:"uuid,omitempty"`
-
 	// Status indicates the runner status (online, offline, idle, active)
 	Status *string `json:"status,omitempty"`
 
-	// LastOnline is the timestamp when the runner was last online
-	LastOnline *string `json:"lastOnline,omitempty"`
+	// Online indicates whether the runner is connected to Gitea
+	Online *bool `json:"online,omitempty"`
 
-	// CreatedAt is the timestamp when the runner was registered
-	CreatedAt *string `json:"createdAt,omitempty"`
+	// Busy indicates whether the runner is executing a job
+	Busy *bool `json:"busy,omitempty"`
 
-	// UpdatedAt is the timestamp when the runner was last updated
-	UpdatedAt *string `json:"updatedAt,omitempty"`
+	// Ephemeral indicates whether the runner unregisters after one job
+	Ephemeral *bool `json:"ephemeral,omitempty"`
 
 	// Labels are the current labels assigned to this runner
 	Labels []string `json:"labels,omitempty"`
 
-	// Description is the runner description
-	Description *string `json:"description,omitempty"`
-
 	// Scope indicates where the runner is registered
 	Scope *string `json:"scope,omitempty"`
 
 	// ScopeValue is the repository or organization name
 	ScopeValue *string `json:"scopeValue,omitempty"`
 
-	// RunnerGroup contains runner group information
-	RunnerGroup *RunnerGroupInfo `json:"runnerGroup,omitempty"`
-
-	// Version is the runner agent version
+	// Version is the runner agent version, where Gitea reports it
 	Version *string `json:"version,omitempty"`
 
-	// Architecture is the runner system architecture
-	Architecture *string `json:"architecture,omitempty"`
-
-	// OperatingSystem is the runner OS
-	OperatingSystem *string `json:"operatingSystem,omitempty"`
-
-	// TokenExpiresAt is when the runner token expires
-	TokenExpiresAt *string `json:"tokenExpiresAt,omitempty"`
-
 	// V2 Enhancement: Enhanced observability
 	// Additional fields can be added here for better monitoring
 }
@@ -144,6 +111,7 @@ type RunnerStatus struct {
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane.io/external-name"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.status"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Runner is the Schema for the runners API v2 (namespaced)
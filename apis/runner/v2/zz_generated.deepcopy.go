@@ -52,36 +52,6 @@ func (in *Runner) DeepCopyObject() runtime.Object {
 	return nil
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RunnerGroupInfo) DeepCopyInto(out *RunnerGroupInfo) {
-	*out = *in
-	if in.ID != nil {
-		in, out := &in.ID, &out.ID
-		*out = new(int64)
-		**out = **in
-	}
-	if in.Name != nil {
-		in, out := &in.Name, &out.Name
-		*out = new(string)
-		**out = **in
-	}
-	if in.Description != nil {
-		in, out := &in.Description, &out.Description
-		*out = new(string)
-		**out = **in
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerGroupInfo.
-func (in *RunnerGroupInfo) DeepCopy() *RunnerGroupInfo {
-	if in == nil {
-		return nil
-	}
-	out := new(RunnerGroupInfo)
-	in.DeepCopyInto(out)
-	return out
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RunnerList) DeepCopyInto(out *RunnerList) {
 	*out = *in
@@ -127,29 +97,24 @@ func (in *RunnerObservation) DeepCopyInto(out *RunnerObservation) {
 		*out = new(string)
 		**out = **in
 	}
-	if in.UUID != nil {
-		in, out := &in.UUID, &out.UUID
-		*out = new(string)
-		**out = **in
-	}
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
 		*out = new(string)
 		**out = **in
 	}
-	if in.LastOnline != nil {
-		in, out := &in.LastOnline, &out.LastOnline
-		*out = new(string)
+	if in.Online != nil {
+		in, out := &in.Online, &out.Online
+		*out = new(bool)
 		**out = **in
 	}
-	if in.CreatedAt != nil {
-		in, out := &in.CreatedAt, &out.CreatedAt
-		*out = new(string)
+	if in.Busy != nil {
+		in, out := &in.Busy, &out.Busy
+		*out = new(bool)
 		**out = **in
 	}
-	if in.UpdatedAt != nil {
-		in, out := &in.UpdatedAt, &out.UpdatedAt
-		*out = new(string)
+	if in.Ephemeral != nil {
+		in, out := &in.Ephemeral, &out.Ephemeral
+		*out = new(bool)
 		**out = **in
 	}
 	if in.Labels != nil {
@@ -157,11 +122,6 @@ func (in *RunnerObservation) DeepCopyInto(out *RunnerObservation) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Description != nil {
-		in, out := &in.Description, &out.Description
-		*out = new(string)
-		**out = **in
-	}
 	if in.Scope != nil {
 		in, out := &in.Scope, &out.Scope
 		*out = new(string)
@@ -172,31 +132,11 @@ func (in *RunnerObservation) DeepCopyInto(out *RunnerObservation) {
 		*out = new(string)
 		**out = **in
 	}
-	if in.RunnerGroup != nil {
-		in, out := &in.RunnerGroup, &out.RunnerGroup
-		*out = new(RunnerGroupInfo)
-		(*in).DeepCopyInto(*out)
-	}
 	if in.Version != nil {
 		in, out := &in.Version, &out.Version
 		*out = new(string)
 		**out = **in
 	}
-	if in.Architecture != nil {
-		in, out := &in.Architecture, &out.Architecture
-		*out = new(string)
-		**out = **in
-	}
-	if in.OperatingSystem != nil {
-		in, out := &in.OperatingSystem, &out.OperatingSystem
-		*out = new(string)
-		**out = **in
-	}
-	if in.TokenExpiresAt != nil {
-		in, out := &in.TokenExpiresAt, &out.TokenExpiresAt
-		*out = new(string)
-		**out = **in
-	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerObservation.
@@ -222,16 +162,6 @@ func (in *RunnerParameters) DeepCopyInto(out *RunnerParameters) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Description != nil {
-		in, out := &in.Description, &out.Description
-		*out = new(string)
-		**out = **in
-	}
-	if in.RunnerGroupID != nil {
-		in, out := &in.RunnerGroupID, &out.RunnerGroupID
-		*out = new(int64)
-		**out = **in
-	}
 	if in.ConnectionRef != nil {
 		in, out := &in.ConnectionRef, &out.ConnectionRef
 		*out = new(corev2.Reference)
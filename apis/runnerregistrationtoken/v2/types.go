@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:XValidation:rule="self.scope == 'system' || has(self.scopeValue)",message="scopeValue is required for repository and organization scopes"
+type RunnerRegistrationTokenParameters struct {
+	// Scope defines where runners registered with the token are registered
+	// (repository, organization, or system)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=repository;organization;system
+	Scope string `json:"scope"`
+
+	// ScopeValue is the repository (owner/name) or organization name for the scope
+	// Required for repository and organization scopes, ignored for system scope
+	// +kubebuilder:validation:Pattern="^([a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?(/[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?)?)?$"
+	ScopeValue *string `json:"scopeValue,omitempty"`
+
+	// V2 Enhancement: Connection reference for multi-tenant support
+	// ConnectionRef specifies the Gitea connection to use
+	ConnectionRef *xpv1.Reference `json:"connectionRef,omitempty"`
+
+	// V2 Enhancement: Namespace-scoped provider config
+	// ProviderConfigRef references a ProviderConfig resource in the same namespace
+	ProviderConfigRef *xpv1.Reference `json:"providerConfigRef,omitempty"`
+}
+
+type RunnerRegistrationTokenObservation struct {
+	// Scope indicates where runners registered with the token are registered
+	Scope *string `json:"scope,omitempty"`
+
+	// ScopeValue is the repository or organization name
+	ScopeValue *string `json:"scopeValue,omitempty"`
+
+	// InstanceURL is the Gitea URL act_runner registers against
+	InstanceURL *string `json:"instanceUrl,omitempty"`
+}
+
+// RunnerRegistrationTokenSpec defines the desired state of RunnerRegistrationToken
+type RunnerRegistrationTokenSpec struct {
+	xpv1.ManagedResourceSpec `json:",inline"`
+	ForProvider              RunnerRegistrationTokenParameters `json:"forProvider"`
+}
+
+// RunnerRegistrationTokenStatus defines the observed state of RunnerRegistrationToken
+type RunnerRegistrationTokenStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+	AtProvider                 RunnerRegistrationTokenObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,gitea}
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="SCOPE",type="string",JSONPath=".spec.forProvider.scope"
+// +kubebuilder:printcolumn:name="SCOPE-VALUE",type="string",JSONPath=".spec.forProvider.scopeValue"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// RunnerRegistrationToken is the Schema for the runnerregistrationtokens API v2 (namespaced)
+type RunnerRegistrationToken struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RunnerRegistrationTokenSpec   `json:"spec,omitempty"`
+	Status RunnerRegistrationTokenStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RunnerRegistrationTokenList contains a list of RunnerRegistrationToken
+type RunnerRegistrationTokenList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RunnerRegistrationToken `json:"items"`
+}
+
+// GetCondition returns the condition for the given ConditionType if it exists, otherwise returns nil.
+func (r *RunnerRegistrationToken) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return r.Status.GetCondition(ct)
+}
+
+// SetConditions sets the supplied conditions, replacing any existing conditions of the same type.
+func (r *RunnerRegistrationToken) SetConditions(c ...xpv1.Condition) {
+	r.Status.SetConditions(c...)
+}
+
+// GetManagementPolicies returns the management policies for this resource.
+func (r *RunnerRegistrationToken) GetManagementPolicies() xpv1.ManagementPolicies {
+	return r.Spec.ManagementPolicies
+}
+
+// SetManagementPolicies sets the management policies for this resource.
+func (r *RunnerRegistrationToken) SetManagementPolicies(p xpv1.ManagementPolicies) {
+	r.Spec.ManagementPolicies = p
+}
+
+// GetProviderConfigReference of this RunnerRegistrationToken.
+func (r *RunnerRegistrationToken) GetProviderConfigReference() *xpv1.ProviderConfigReference {
+	return r.Spec.ProviderConfigReference
+}
+
+// SetProviderConfigReference of this RunnerRegistrationToken.
+func (r *RunnerRegistrationToken) SetProviderConfigReference(p *xpv1.ProviderConfigReference) {
+	r.Spec.ProviderConfigReference = p
+}
+
+// GetWriteConnectionSecretToReference of this RunnerRegistrationToken.
+func (r *RunnerRegistrationToken) GetWriteConnectionSecretToReference() *xpv1.LocalSecretReference {
+	return r.Spec.WriteConnectionSecretToReference
+}
+
+// SetWriteConnectionSecretToReference of this RunnerRegistrationToken.
+func (r *RunnerRegistrationToken) SetWriteConnectionSecretToReference(p *xpv1.LocalSecretReference) {
+	r.Spec.WriteConnectionSecretToReference = p
+}
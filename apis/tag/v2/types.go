@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type TagParameters struct {
+	// Repository is the repository the tag belongs to (owner/name format)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern="^[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?/[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?$"
+	Repository string `json:"repository"`
+
+	// Name is the tag name
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="name is immutable"
+	Name string `json:"name"`
+
+	// Target is the branch, tag or commit SHA the tag points to. Defaults to
+	// the repository default branch.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="target is immutable"
+	// +optional
+	Target *string `json:"target,omitempty"`
+
+	// Message makes the tag an annotated tag with this message. Tags without
+	// a message are lightweight.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="message is immutable"
+	// +optional
+	Message *string `json:"message,omitempty"`
+
+	// V2 Enhancement: Connection reference for multi-tenant support
+	// ConnectionRef specifies the Gitea connection to use
+	ConnectionRef *xpv1.Reference `json:"connectionRef,omitempty"`
+
+	// V2 Enhancement: Namespace-scoped provider config
+	// ProviderConfigRef references a ProviderConfig resource in the same namespace
+	ProviderConfigRef *xpv1.Reference `json:"providerConfigRef,omitempty"`
+}
+
+type TagObservation struct {
+	// Name is the tag name
+	Name *string `json:"name,omitempty"`
+
+	// ID is the SHA of the tag object, or of the commit for lightweight tags
+	ID *string `json:"id,omitempty"`
+
+	// CommitSHA is the SHA of the tagged commit
+	CommitSHA *string `json:"commitSha,omitempty"`
+
+	// Message is the annotated tag message
+	Message *string `json:"message,omitempty"`
+
+	// ZipballURL is the URL of the zip archive of the tagged tree
+	ZipballURL *string `json:"zipballUrl,omitempty"`
+
+	// TarballURL is the URL of the tar.gz archive of the tagged tree
+	TarballURL *string `json:"tarballUrl,omitempty"`
+}
+
+// TagSpec defines the desired state of Tag
+type TagSpec struct {
+	xpv1.ManagedResourceSpec `json:",inline"`
+	ForProvider              TagParameters `json:"forProvider"`
+}
+
+// TagStatus defines the observed state of Tag
+type TagStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+	AtProvider                 TagObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,gitea}
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TAG",type="string",JSONPath=".spec.forProvider.name"
+// +kubebuilder:printcolumn:name="COMMIT",type="string",JSONPath=".status.atProvider.commitSha"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Tag is the Schema for the tags API v2 (namespaced)
+type Tag struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TagSpec   `json:"spec,omitempty"`
+	Status TagStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TagList contains a list of Tag
+type TagList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Tag `json:"items"`
+}
+
+// GetCondition returns the condition for the given ConditionType if it exists, otherwise returns nil.
+func (r *Tag) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return r.Status.GetCondition(ct)
+}
+
+// SetConditions sets the supplied conditions, replacing any existing conditions of the same type.
+func (r *Tag) SetConditions(c ...xpv1.Condition) {
+	r.Status.SetConditions(c...)
+}
+
+// GetManagementPolicies returns the management policies for this resource.
+func (r *Tag) GetManagementPolicies() xpv1.ManagementPolicies {
+	return r.Spec.ManagementPolicies
+}
+
+// SetManagementPolicies sets the management policies for this resource.
+func (r *Tag) SetManagementPolicies(p xpv1.ManagementPolicies) {
+	r.Spec.ManagementPolicies = p
+}
+
+// GetProviderConfigReference of this Tag.
+func (r *Tag) GetProviderConfigReference() *xpv1.ProviderConfigReference {
+	return r.Spec.ProviderConfigReference
+}
+
+// SetProviderConfigReference of this Tag.
+func (r *Tag) SetProviderConfigReference(p *xpv1.ProviderConfigReference) {
+	r.Spec.ProviderConfigReference = p
+}
+
+// GetWriteConnectionSecretToReference of this Tag.
+func (r *Tag) GetWriteConnectionSecretToReference() *xpv1.LocalSecretReference {
+	return r.Spec.WriteConnectionSecretToReference
+}
+
+// SetWriteConnectionSecretToReference of this Tag.
+func (r *Tag) SetWriteConnectionSecretToReference(p *xpv1.LocalSecretReference) {
+	r.Spec.WriteConnectionSecretToReference = p
+}
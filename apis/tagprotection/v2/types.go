@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type TagProtectionParameters struct {
+	// Repository is the repository that owns this tag protection rule (owner/name format)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern="^[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?/[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?$"
+	Repository string `json:"repository"`
+
+	// NamePattern is the tag name, glob pattern (e.g. v*) or regular
+	// expression enclosed in slashes (e.g. /^v[0-9]+/) the rule applies to
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	NamePattern string `json:"namePattern"`
+
+	// WhitelistUsernames is the list of usernames allowed to push matching tags
+	WhitelistUsernames []string `json:"whitelistUsernames,omitempty"`
+
+	// WhitelistTeams is the list of teams allowed to push matching tags
+	WhitelistTeams []string `json:"whitelistTeams,omitempty"`
+
+	// V2 Enhancement: Connection reference for multi-tenant support
+	// ConnectionRef specifies the Gitea connection to use
+	ConnectionRef *xpv1.Reference `json:"connectionRef,omitempty"`
+
+	// V2 Enhancement: Namespace-scoped provider config
+	// ProviderConfigRef references a ProviderConfig resource in the same namespace
+	ProviderConfigRef *xpv1.Reference `json:"providerConfigRef,omitempty"`
+}
+
+type TagProtectionObservation struct {
+	// ID is the tag protection rule ID
+	ID *int64 `json:"id,omitempty"`
+
+	// NamePattern is the pattern the rule applies to
+	NamePattern *string `json:"namePattern,omitempty"`
+
+	// WhitelistUsernames lists the users allowed to push matching tags
+	WhitelistUsernames []string `json:"whitelistUsernames,omitempty"`
+
+	// WhitelistTeams lists the teams allowed to push matching tags
+	WhitelistTeams []string `json:"whitelistTeams,omitempty"`
+
+	// CreatedAt is the timestamp when the rule was created
+	CreatedAt *string `json:"createdAt,omitempty"`
+
+	// UpdatedAt is the timestamp when the rule was last updated
+	UpdatedAt *string `json:"updatedAt,omitempty"`
+}
+
+// TagProtectionSpec defines the desired state of TagProtection
+type TagProtectionSpec struct {
+	xpv1.ManagedResourceSpec `json:",inline"`
+	ForProvider              TagProtectionParameters `json:"forProvider"`
+}
+
+// TagProtectionStatus defines the observed state of TagProtection
+type TagProtectionStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+	AtProvider                 TagProtectionObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,gitea}
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="PATTERN",type="string",JSONPath=".spec.forProvider.namePattern"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// TagProtection is the Schema for the tagprotections API v2 (namespaced)
+type TagProtection struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TagProtectionSpec   `json:"spec,omitempty"`
+	Status TagProtectionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TagProtectionList contains a list of TagProtection
+type TagProtectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TagProtection `json:"items"`
+}
+
+// GetCondition returns the condition for the given ConditionType if it exists, otherwise returns nil.
+func (r *TagProtection) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return r.Status.GetCondition(ct)
+}
+
+// SetConditions sets the supplied conditions, replacing any existing conditions of the same type.
+func (r *TagProtection) SetConditions(c ...xpv1.Condition) {
+	r.Status.SetConditions(c...)
+}
+
+// GetManagementPolicies returns the management policies for this resource.
+func (r *TagProtection) GetManagementPolicies() xpv1.ManagementPolicies {
+	return r.Spec.ManagementPolicies
+}
+
+// SetManagementPolicies sets the management policies for this resource.
+func (r *TagProtection) SetManagementPolicies(p xpv1.ManagementPolicies) {
+	r.Spec.ManagementPolicies = p
+}
+
+// GetProviderConfigReference of this TagProtection.
+func (r *TagProtection) GetProviderConfigReference() *xpv1.ProviderConfigReference {
+	return r.Spec.ProviderConfigReference
+}
+
+// SetProviderConfigReference of this TagProtection.
+func (r *TagProtection) SetProviderConfigReference(p *xpv1.ProviderConfigReference) {
+	r.Spec.ProviderConfigReference = p
+}
+
+// GetWriteConnectionSecretToReference of this TagProtection.
+func (r *TagProtection) GetWriteConnectionSecretToReference() *xpv1.LocalSecretReference {
+	return r.Spec.WriteConnectionSecretToReference
+}
+
+// SetWriteConnectionSecretToReference of this TagProtection.
+func (r *TagProtection) SetWriteConnectionSecretToReference(p *xpv1.LocalSecretReference) {
+	r.Spec.WriteConnectionSecretToReference = p
+}
@@ -52,6 +52,33 @@ type TeamParameters struct {
 	// Valid values: repo.code, repo.issues, repo.pulls, repo.releases, repo.wiki, repo.ext_wiki, repo.ext_issues
 	Units []string `json:"units,omitempty"`
 
+	// UnitsMap sets the access level of each unit, such as write on
+	// repo.code and read on repo.issues. Units that are not listed have no
+	// access. When set, Gitea derives the team permission from it, and
+	// Permission and Units are not compared.
+	// Valid units: repo.code, repo.issues, repo.pulls, repo.releases, repo.wiki, repo.ext_wiki, repo.ext_issues, repo.projects, repo.packages, repo.actions
+	// +kubebuilder:validation:XValidation:rule="self.all(k, k.startsWith('repo.'))",message="units must start with repo."
+	// +kubebuilder:validation:XValidation:rule="self.all(k, self[k] in ['none', 'read', 'write', 'admin'])",message="access must be one of none, read, write or admin"
+	// +optional
+	UnitsMap map[string]string `json:"unitsMap,omitempty"`
+
+	// Members is the list of usernames that are members of the team
+	// +optional
+	Members []string `json:"members,omitempty"`
+
+	// Repositories is the list of organization repositories, by name, the
+	// team has access to
+	// +optional
+	Repositories []string `json:"repositories,omitempty"`
+
+	// Exclusive removes members and repositories that are not declared,
+	// either in this spec or by a TeamMembership or TeamRepository in the
+	// same namespace. Repositories are not pruned when the team includes all
+	// repositories.
+	// +kubebuilder:default=false
+	// +optional
+	Exclusive *bool `json:"exclusive,omitempty"`
+
 	// V2 Enhancement: Connection reference for multi-tenant support
 	// ConnectionRef specifies the Gitea connection to use
 	ConnectionRef *xpv1.Reference `json:"connectionRef,omitempty"`
@@ -68,6 +95,15 @@ type TeamObservation struct {
 	// OrganizationID is the organization ID that owns this team
 	OrganizationID *int64 `json:"organizationId,omitempty"`
 
+	// Members lists the usernames of the team members
+	Members []string `json:"members,omitempty"`
+
+	// Repositories lists the repositories the team has access to
+	Repositories []string `json:"repositories,omitempty"`
+
+	// UnitsMap is the access level of each unit
+	UnitsMap map[string]string `json:"unitsMap,omitempty"`
+
 	// V2 Enhancement: Enhanced observability
 	// Additional fields can be added here for better monitoring
 }
@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:XValidation:rule="has(self.teamRef) || (has(self.organization) && has(self.team))",message="either teamRef or organization and team is required"
+// +kubebuilder:validation:XValidation:rule="has(self.repository) || has(self.repositoryRef)",message="either repository or repositoryRef is required"
+type TeamRepositoryParameters struct {
+	// TeamRef references a Team in the same namespace
+	// +optional
+	TeamRef *xpv1.Reference `json:"teamRef,omitempty"`
+
+	// Organization is the organization that owns the team. Used with Team
+	// when TeamRef is not set.
+	// +optional
+	Organization *string `json:"organization,omitempty"`
+
+	// Team is the team name. Used with Organization when TeamRef is not set.
+	// +optional
+	Team *string `json:"team,omitempty"`
+
+	// Repository is the name of an organization repository
+	// +optional
+	Repository *string `json:"repository,omitempty"`
+
+	// RepositoryRef references a Repository in the same namespace
+	// +optional
+	RepositoryRef *xpv1.Reference `json:"repositoryRef,omitempty"`
+
+	// V2 Enhancement: Connection reference for multi-tenant support
+	// ConnectionRef specifies the Gitea connection to use
+	ConnectionRef *xpv1.Reference `json:"connectionRef,omitempty"`
+
+	// V2 Enhancement: Namespace-scoped provider config
+	// ProviderConfigRef references a ProviderConfig resource in the same namespace
+	ProviderConfigRef *xpv1.Reference `json:"providerConfigRef,omitempty"`
+}
+
+type TeamRepositoryObservation struct {
+	// TeamID is the ID of the team
+	TeamID *int64 `json:"teamId,omitempty"`
+
+	// Organization is the organization that owns the team
+	Organization *string `json:"organization,omitempty"`
+
+	// Team is the team name
+	Team *string `json:"team,omitempty"`
+
+	// Repository is the full name of the repository (owner/name)
+	Repository *string `json:"repository,omitempty"`
+}
+
+// TeamRepositorySpec defines the desired state of TeamRepository
+type TeamRepositorySpec struct {
+	xpv1.ManagedResourceSpec `json:",inline"`
+	ForProvider              TeamRepositoryParameters `json:"forProvider"`
+}
+
+// TeamRepositoryStatus defines the observed state of TeamRepository
+type TeamRepositoryStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+	AtProvider                 TeamRepositoryObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,gitea}
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TEAM",type="string",JSONPath=".status.atProvider.team"
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".status.atProvider.repository"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// TeamRepository is the Schema for the teamrepositories API v2 (namespaced)
+type TeamRepository struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TeamRepositorySpec   `json:"spec,omitempty"`
+	Status TeamRepositoryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TeamRepositoryList contains a list of TeamRepository
+type TeamRepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TeamRepository `json:"items"`
+}
+
+// GetCondition returns the condition for the given ConditionType if it exists, otherwise returns nil.
+func (r *TeamRepository) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return r.Status.GetCondition(ct)
+}
+
+// SetConditions sets the supplied conditions, replacing any existing conditions of the same type.
+func (r *TeamRepository) SetConditions(c ...xpv1.Condition) {
+	r.Status.SetConditions(c...)
+}
+
+// GetManagementPolicies returns the management policies for this resource.
+func (r *TeamRepository) GetManagementPolicies() xpv1.ManagementPolicies {
+	return r.Spec.ManagementPolicies
+}
+
+// SetManagementPolicies sets the management policies for this resource.
+func (r *TeamRepository) SetManagementPolicies(p xpv1.ManagementPolicies) {
+	r.Spec.ManagementPolicies = p
+}
+
+// GetProviderConfigReference of this TeamRepository.
+func (r *TeamRepository) GetProviderConfigReference() *xpv1.ProviderConfigReference {
+	return r.Spec.ProviderConfigReference
+}
+
+// SetProviderConfigReference of this TeamRepository.
+func (r *TeamRepository) SetProviderConfigReference(p *xpv1.ProviderConfigReference) {
+	r.Spec.ProviderConfigReference = p
+}
+
+// GetWriteConnectionSecretToReference of this TeamRepository.
+func (r *TeamRepository) GetWriteConnectionSecretToReference() *xpv1.LocalSecretReference {
+	return r.Spec.WriteConnectionSecretToReference
+}
+
+// SetWriteConnectionSecretToReference of this TeamRepository.
+func (r *TeamRepository) SetWriteConnectionSecretToReference(p *xpv1.LocalSecretReference) {
+	r.Spec.WriteConnectionSecretToReference = p
+}
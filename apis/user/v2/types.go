@@ -21,7 +21,8 @@ import (
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 )
 
-
+// UserParameters define the desired state of a Gitea User
+// +kubebuilder:validation:XValidation:rule="!has(self.deletionMode) || self.deletionMode != 'TransferTo' || has(self.transferTo)",message="transferTo is required when deletionMode is TransferTo"
 type UserParameters struct {
 	// Username is the user's username
 	// +kubebuilder:validation:Required
@@ -89,6 +90,26 @@ type UserParameters struct {
 	// AllowCreateOrganization allows the user to create organizations (admin only)
 	AllowCreateOrganization *bool `json:"allowCreateOrganization,omitempty"`
 
+	// Settings are the user's own preferences, which Gitea only lets the
+	// user change. They are applied by acting as the user.
+	// +optional
+	Settings *UserSettings `json:"settings,omitempty"`
+
+	// DeletionMode controls what deleting the resource does to the user.
+	// Delete removes the user, which Gitea refuses while the user owns
+	// repositories or belongs to organizations. Deactivate keeps the account
+	// but sets it inactive and prohibits login. Purge removes the user along
+	// with everything the user owns. TransferTo moves the user's repositories
+	// to the TransferTo organization and removes the user from its
+	// organizations before deleting it.
+	// +kubebuilder:validation:Enum=Delete;Deactivate;Purge;TransferTo
+	// +kubebuilder:default="Delete"
+	DeletionMode *string `json:"deletionMode,omitempty"`
+
+	// TransferTo is the organization that receives the user's repositories
+	// when DeletionMode is TransferTo.
+	TransferTo *string `json:"transferTo,omitempty"`
+
 	// V2 Enhancement: Connection reference for multi-tenant support
 	// ConnectionRef specifies the Gitea connection to use
 	ConnectionRef *xpv1.Reference `json:"connectionRef,omitempty"`
@@ -117,10 +138,48 @@ type UserObservation struct {
 	// Language is the user's preferred language
 	Language *string `json:"language,omitempty"`
 
+	// DeletionSteps reports the progress of each step taken to delete the
+	// user according to DeletionMode
+	// +listType=map
+	// +listMapKey=name
+	DeletionSteps []UserDeletionStep `json:"deletionSteps,omitempty"`
+
 	// V2 Enhancement: Enhanced observability
 	// Additional fields can be added here for better monitoring
 }
 
+// UserSettings are the preferences a user sets for themselves
+type UserSettings struct {
+	// Theme is the name of the web interface theme
+	Theme *string `json:"theme,omitempty"`
+
+	// DiffViewStyle is how diffs are shown
+	// +kubebuilder:validation:Enum=unified;split
+	DiffViewStyle *string `json:"diffViewStyle,omitempty"`
+
+	// HideEmail hides the email address from the user's profile
+	HideEmail *bool `json:"hideEmail,omitempty"`
+
+	// HideActivity hides the user's activity from the profile page
+	HideActivity *bool `json:"hideActivity,omitempty"`
+
+	// Language is the interface language, such as en-US
+	Language *string `json:"language,omitempty"`
+}
+
+// UserDeletionStep is the outcome of one step of a user deletion
+type UserDeletionStep struct {
+	// Name identifies the step, such as "transfer alice/app", "leave acme"
+	// or "delete"
+	Name string `json:"name"`
+
+	// State is Completed, Pending or Failed
+	State string `json:"state"`
+
+	// Message describes why the step is pending or failed
+	Message string `json:"message,omitempty"`
+}
+
 // UserSpec defines the desired state of User
 type UserSpec struct {
 	xpv1.ManagedResourceSpec `json:",inline"`
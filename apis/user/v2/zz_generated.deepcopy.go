@@ -52,6 +52,21 @@ func (in *User) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserDeletionStep) DeepCopyInto(out *UserDeletionStep) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserDeletionStep.
+func (in *UserDeletionStep) DeepCopy() *UserDeletionStep {
+	if in == nil {
+		return nil
+	}
+	out := new(UserDeletionStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserList) DeepCopyInto(out *UserList) {
 	*out = *in
@@ -117,6 +132,11 @@ func (in *UserObservation) DeepCopyInto(out *UserObservation) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.DeletionSteps != nil {
+		in, out := &in.DeletionSteps, &out.DeletionSteps
+		*out = make([]UserDeletionStep, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserObservation.
@@ -212,6 +232,21 @@ func (in *UserParameters) DeepCopyInto(out *UserParameters) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(UserSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeletionMode != nil {
+		in, out := &in.DeletionMode, &out.DeletionMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.TransferTo != nil {
+		in, out := &in.TransferTo, &out.TransferTo
+		*out = new(string)
+		**out = **in
+	}
 	if in.ConnectionRef != nil {
 		in, out := &in.ConnectionRef, &out.ConnectionRef
 		*out = new(corev2.Reference)
@@ -234,6 +269,46 @@ func (in *UserParameters) DeepCopy() *UserParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserSettings) DeepCopyInto(out *UserSettings) {
+	*out = *in
+	if in.Theme != nil {
+		in, out := &in.Theme, &out.Theme
+		*out = new(string)
+		**out = **in
+	}
+	if in.DiffViewStyle != nil {
+		in, out := &in.DiffViewStyle, &out.DiffViewStyle
+		*out = new(string)
+		**out = **in
+	}
+	if in.HideEmail != nil {
+		in, out := &in.HideEmail, &out.HideEmail
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HideActivity != nil {
+		in, out := &in.HideActivity, &out.HideActivity
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Language != nil {
+		in, out := &in.Language, &out.Language
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserSettings.
+func (in *UserSettings) DeepCopy() *UserSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(UserSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserSpec) DeepCopyInto(out *UserSpec) {
 	*out = *in
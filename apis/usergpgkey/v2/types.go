@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type UserGPGKeyParameters struct {
+	// Username is the user the GPG key belongs to
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="username is immutable"
+	Username string `json:"username"`
+
+	// ArmoredPublicKey is the ASCII-armored GPG public key
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern="^-----BEGIN PGP PUBLIC KEY BLOCK-----"
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="armoredPublicKey is immutable"
+	ArmoredPublicKey string `json:"armoredPublicKey"`
+
+	// ArmoredSignature is an ASCII-armored signature of the verification
+	// token, used to verify the key when it carries no verified email address
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="armoredSignature is immutable"
+	// +optional
+	ArmoredSignature *string `json:"armoredSignature,omitempty"`
+
+	// V2 Enhancement: Connection reference for multi-tenant support
+	// ConnectionRef specifies the Gitea connection to use
+	ConnectionRef *xpv1.Reference `json:"connectionRef,omitempty"`
+
+	// V2 Enhancement: Namespace-scoped provider config
+	// ProviderConfigRef references a ProviderConfig resource in the same namespace
+	ProviderConfigRef *xpv1.Reference `json:"providerConfigRef,omitempty"`
+}
+
+type UserGPGKeyObservation struct {
+	// ID is the Gitea ID of the key
+	ID *int64 `json:"id,omitempty"`
+
+	// KeyID is the GPG key ID
+	KeyID *string `json:"keyId,omitempty"`
+
+	// PrimaryKeyID is the GPG key ID of the primary key, for subkeys
+	PrimaryKeyID *string `json:"primaryKeyId,omitempty"`
+
+	// Emails are the email addresses listed in the key
+	Emails []string `json:"emails,omitempty"`
+
+	// Verified reports whether the key has been verified
+	Verified *bool `json:"verified,omitempty"`
+
+	// CanSign reports whether the key can sign commits
+	CanSign *bool `json:"canSign,omitempty"`
+
+	// CreatedAt is when the key was added
+	CreatedAt *string `json:"createdAt,omitempty"`
+
+	// ExpiresAt is when the key expires
+	ExpiresAt *string `json:"expiresAt,omitempty"`
+}
+
+// UserGPGKeySpec defines the desired state of UserGPGKey
+type UserGPGKeySpec struct {
+	xpv1.ManagedResourceSpec `json:",inline"`
+	ForProvider              UserGPGKeyParameters `json:"forProvider"`
+}
+
+// UserGPGKeyStatus defines the observed state of UserGPGKey
+type UserGPGKeyStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+	AtProvider                 UserGPGKeyObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,gitea}
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="USER",type="string",JSONPath=".spec.forProvider.username"
+// +kubebuilder:printcolumn:name="KEY-ID",type="string",JSONPath=".status.atProvider.keyId"
+// +kubebuilder:printcolumn:name="VERIFIED",type="boolean",JSONPath=".status.atProvider.verified"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// UserGPGKey is the Schema for the usergpgkeys API v2 (namespaced)
+type UserGPGKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   UserGPGKeySpec   `json:"spec,omitempty"`
+	Status UserGPGKeyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// UserGPGKeyList contains a list of UserGPGKey
+type UserGPGKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []UserGPGKey `json:"items"`
+}
+
+// GetCondition returns the condition for the given ConditionType if it exists, otherwise returns nil.
+func (r *UserGPGKey) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return r.Status.GetCondition(ct)
+}
+
+// SetConditions sets the supplied conditions, replacing any existing conditions of the same type.
+func (r *UserGPGKey) SetConditions(c ...xpv1.Condition) {
+	r.Status.SetConditions(c...)
+}
+
+// GetManagementPolicies returns the management policies for this resource.
+func (r *UserGPGKey) GetManagementPolicies() xpv1.ManagementPolicies {
+	return r.Spec.ManagementPolicies
+}
+
+// SetManagementPolicies sets the management policies for this resource.
+func (r *UserGPGKey) SetManagementPolicies(p xpv1.ManagementPolicies) {
+	r.Spec.ManagementPolicies = p
+}
+
+// GetProviderConfigReference of this UserGPGKey.
+func (r *UserGPGKey) GetProviderConfigReference() *xpv1.ProviderConfigReference {
+	return r.Spec.ProviderConfigReference
+}
+
+// SetProviderConfigReference of this UserGPGKey.
+func (r *UserGPGKey) SetProviderConfigReference(p *xpv1.ProviderConfigReference) {
+	r.Spec.ProviderConfigReference = p
+}
+
+// GetWriteConnectionSecretToReference of this UserGPGKey.
+func (r *UserGPGKey) GetWriteConnectionSecretToReference() *xpv1.LocalSecretReference {
+	return r.Spec.WriteConnectionSecretToReference
+}
+
+// SetWriteConnectionSecretToReference of this UserGPGKey.
+func (r *UserGPGKey) SetWriteConnectionSecretToReference(p *xpv1.LocalSecretReference) {
+	r.Spec.WriteConnectionSecretToReference = p
+}
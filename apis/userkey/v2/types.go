@@ -22,6 +22,7 @@ import (
 )
 
 
+// +kubebuilder:validation:XValidation:rule="has(self.key) != has(self.generateKey)",message="exactly one of key or generateKey must be set"
 type UserKeyParameters struct {
 	// Username is the user that owns this SSH key
 	// +kubebuilder:validation:Required
@@ -35,10 +36,17 @@ type UserKeyParameters struct {
 	// +kubebuilder:validation:MaxLength=100
 	Title string `json:"title"`
 
-	// Key is the SSH public key content
-	// +kubebuilder:validation:Required
+	// Key is the SSH public key content. Leave unset when GenerateKey is used.
+	// +optional
 	// +kubebuilder:validation:Pattern="^(ssh-rsa|ssh-ed25519|ssh-dss|ecdsa-sha2-nistp256|ecdsa-sha2-nistp384|ecdsa-sha2-nistp521) AAAA[0-9A-Za-z+/]+[=]{0,3}( .*)?$"
-	Key string `json:"key"`
+	Key string `json:"key,omitempty"`
+
+	// GenerateKey has the provider generate the keypair rather than
+	// registering Key. The private key, public key and known_hosts entry are
+	// written to the connection secret. Changing the
+	// gitea.m.crossplane.io/regenerate-key annotation replaces the key.
+	// +optional
+	GenerateKey *KeyGeneration `json:"generateKey,omitempty"`
 
 	// V2 Enhancement: Connection reference for multi-tenant support
 	// ConnectionRef specifies the Gitea connection to use
@@ -49,6 +57,20 @@ type UserKeyParameters struct {
 	ProviderConfigRef *xpv1.Reference `json:"providerConfigRef,omitempty"`
 }
 
+// KeyGeneration configures an SSH keypair generated by the provider.
+type KeyGeneration struct {
+	// Algorithm of the generated key.
+	// +kubebuilder:validation:Enum=ed25519;rsa
+	// +kubebuilder:default=ed25519
+	// +optional
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// Bits is the size of a generated RSA key. Defaults to 4096.
+	// +kubebuilder:validation:Enum=2048;3072;4096
+	// +optional
+	Bits *int `json:"bits,omitempty"`
+}
+
 type UserKeyObservation struct {
 	// ID is the unique identifier of the SSH key
 	ID *int64 `json:"id,omitempty"`
@@ -59,6 +81,9 @@ type UserKeyObservation struct {
 	// Fingerprint is the SSH key fingerprint
 	Fingerprint *string `json:"fingerprint,omitempty"`
 
+	// PublicKey is the SSH public key registered with Gitea
+	PublicKey *string `json:"publicKey,omitempty"`
+
 	// CreatedAt is the timestamp when the key was created
 	CreatedAt *string `json:"createdAt,omitempty"`
 
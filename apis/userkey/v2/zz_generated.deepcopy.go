@@ -25,6 +25,26 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyGeneration) DeepCopyInto(out *KeyGeneration) {
+	*out = *in
+	if in.Bits != nil {
+		in, out := &in.Bits, &out.Bits
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyGeneration.
+func (in *KeyGeneration) DeepCopy() *KeyGeneration {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyGeneration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserKey) DeepCopyInto(out *UserKey) {
 	*out = *in
@@ -102,6 +122,11 @@ func (in *UserKeyObservation) DeepCopyInto(out *UserKeyObservation) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.PublicKey != nil {
+		in, out := &in.PublicKey, &out.PublicKey
+		*out = new(string)
+		**out = **in
+	}
 	if in.CreatedAt != nil {
 		in, out := &in.CreatedAt, &out.CreatedAt
 		*out = new(string)
@@ -132,6 +157,11 @@ func (in *UserKeyObservation) DeepCopy() *UserKeyObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserKeyParameters) DeepCopyInto(out *UserKeyParameters) {
 	*out = *in
+	if in.GenerateKey != nil {
+		in, out := &in.GenerateKey, &out.GenerateKey
+		*out = new(KeyGeneration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ConnectionRef != nil {
 		in, out := &in.ConnectionRef, &out.ConnectionRef
 		*out = new(corev2.Reference)
@@ -21,7 +21,8 @@ import (
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 )
 
-
+// WebhookParameters define the desired state of a Gitea Webhook
+// +kubebuilder:validation:XValidation:rule="!has(self.adminScope) || (!has(self.owner) && !has(self.repository) && !has(self.organization))",message="adminScope cannot be combined with owner, repository or organization"
 type WebhookParameters struct {
 	// Repository is the repository name (required for repository webhooks)
 	Repository *string `json:"repository,omitempty"`
@@ -32,6 +33,14 @@ type WebhookParameters struct {
 	// Organization is the organization name (for organization webhooks)
 	Organization *string `json:"organization,omitempty"`
 
+	// AdminScope makes this an instance-wide webhook managed through the
+	// admin API. system webhooks fire for events in every repository, and
+	// default webhooks are copied into each repository created afterwards.
+	// Leave Owner, Repository and Organization unset.
+	// +kubebuilder:validation:Enum=system;default
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="adminScope is immutable"
+	AdminScope *string `json:"adminScope,omitempty"`
+
 	// Type is the webhook type
 	// +kubebuilder:validation:Enum=gitea;gogs;slack;discord;dingtalk;telegram;msteams;feishu;wechatwork;packagist
 	// +kubebuilder:default="gitea"
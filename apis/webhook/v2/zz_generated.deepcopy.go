@@ -132,6 +132,11 @@ func (in *WebhookParameters) DeepCopyInto(out *WebhookParameters) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AdminScope != nil {
+		in, out := &in.AdminScope, &out.AdminScope
+		*out = new(string)
+		**out = **in
+	}
 	if in.Type != nil {
 		in, out := &in.Type, &out.Type
 		*out = new(string)
@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WorkflowDispatchParameters describe a workflow_dispatch run. The workflow
+// is dispatched once for every generation of the spec.
+type WorkflowDispatchParameters struct {
+	// Repository is the repository that owns the workflow (owner/name format)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern="^[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?/[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?$"
+	Repository string `json:"repository"`
+
+	// WorkflowName is the name of the workflow file in .gitea/workflows
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	WorkflowName string `json:"workflowName"`
+
+	// Ref is the branch or tag to run the workflow on
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Ref string `json:"ref"`
+
+	// Inputs are the workflow_dispatch inputs of the run
+	// +optional
+	Inputs map[string]string `json:"inputs,omitempty"`
+
+	// V2 Enhancement: Connection reference for multi-tenant support
+	// ConnectionRef specifies the Gitea connection to use
+	ConnectionRef *xpv1.Reference `json:"connectionRef,omitempty"`
+
+	// V2 Enhancement: Namespace-scoped provider config
+	// ProviderConfigRef references a ProviderConfig resource in the same namespace
+	ProviderConfigRef *xpv1.Reference `json:"providerConfigRef,omitempty"`
+}
+
+// WorkflowJob summarises a job of the dispatched run
+type WorkflowJob struct {
+	// Name is the job name
+	Name string `json:"name"`
+
+	// Status is the job status (queued, in_progress, completed)
+	Status string `json:"status,omitempty"`
+
+	// Conclusion is the job conclusion (success, failure, cancelled, skipped)
+	Conclusion string `json:"conclusion,omitempty"`
+
+	// StartedAt is when the job started
+	StartedAt string `json:"startedAt,omitempty"`
+
+	// CompletedAt is when the job completed
+	CompletedAt string `json:"completedAt,omitempty"`
+}
+
+type WorkflowDispatchObservation struct {
+	// RunID is the identifier of the dispatched run
+	RunID *int64 `json:"runId,omitempty"`
+
+	// RunNumber is the sequential run number
+	RunNumber *int64 `json:"runNumber,omitempty"`
+
+	// Status is the run status (queued, in_progress, completed)
+	Status *string `json:"status,omitempty"`
+
+	// Conclusion is the run conclusion (success, failure, cancelled, skipped)
+	Conclusion *string `json:"conclusion,omitempty"`
+
+	// RunURL is the web URL of the run
+	RunURL *string `json:"runUrl,omitempty"`
+
+	// StartedAt is when the run started
+	StartedAt *string `json:"startedAt,omitempty"`
+
+	// CompletedAt is when the run completed
+	CompletedAt *string `json:"completedAt,omitempty"`
+
+	// Jobs summarise the jobs of the run
+	Jobs []WorkflowJob `json:"jobs,omitempty"`
+}
+
+// WorkflowDispatchSpec defines the desired state of WorkflowDispatch
+type WorkflowDispatchSpec struct {
+	xpv1.ManagedResourceSpec `json:",inline"`
+	ForProvider              WorkflowDispatchParameters `json:"forProvider"`
+}
+
+// WorkflowDispatchStatus defines the observed state of WorkflowDispatch
+type WorkflowDispatchStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+	AtProvider                 WorkflowDispatchObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,gitea}
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.status"
+// +kubebuilder:printcolumn:name="CONCLUSION",type="string",JSONPath=".status.atProvider.conclusion"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// WorkflowDispatch is the Schema for the workflowdispatches API v2 (namespaced)
+type WorkflowDispatch struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WorkflowDispatchSpec   `json:"spec,omitempty"`
+	Status WorkflowDispatchStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkflowDispatchList contains a list of WorkflowDispatch
+type WorkflowDispatchList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkflowDispatch `json:"items"`
+}
+
+// GetCondition returns the condition for the given ConditionType if it exists, otherwise returns nil.
+func (r *WorkflowDispatch) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return r.Status.GetCondition(ct)
+}
+
+// SetConditions sets the supplied conditions, replacing any existing conditions of the same type.
+func (r *WorkflowDispatch) SetConditions(c ...xpv1.Condition) {
+	r.Status.SetConditions(c...)
+}
+
+// GetManagementPolicies returns the management policies for this resource.
+func (r *WorkflowDispatch) GetManagementPolicies() xpv1.ManagementPolicies {
+	return r.Spec.ManagementPolicies
+}
+
+// SetManagementPolicies sets the management policies for this resource.
+func (r *WorkflowDispatch) SetManagementPolicies(p xpv1.ManagementPolicies) {
+	r.Spec.ManagementPolicies = p
+}
+
+// GetProviderConfigReference of this WorkflowDispatch.
+func (r *WorkflowDispatch) GetProviderConfigReference() *xpv1.ProviderConfigReference {
+	return r.Spec.ProviderConfigReference
+}
+
+// SetProviderConfigReference of this WorkflowDispatch.
+func (r *WorkflowDispatch) SetProviderConfigReference(p *xpv1.ProviderConfigReference) {
+	r.Spec.ProviderConfigReference = p
+}
+
+// GetWriteConnectionSecretToReference of this WorkflowDispatch.
+func (r *WorkflowDispatch) GetWriteConnectionSecretToReference() *xpv1.LocalSecretReference {
+	return r.Spec.WriteConnectionSecretToReference
+}
+
+// SetWriteConnectionSecretToReference of this WorkflowDispatch.
+func (r *WorkflowDispatch) SetWriteConnectionSecretToReference(p *xpv1.LocalSecretReference) {
+	r.Spec.WriteConnectionSecretToReference = p
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ContentsResponse represents a file or directory entry returned by the
+// contents API
+type ContentsResponse struct {
+	Name          string  `json:"name"`
+	Path          string  `json:"path"`
+	SHA           string  `json:"sha"`
+	LastCommitSHA string  `json:"last_commit_sha"`
+	Type          string  `json:"type"`
+	Size          int64   `json:"size"`
+	Encoding      *string `json:"encoding"`
+	Content       *string `json:"content"`
+	HTMLURL       string  `json:"html_url"`
+	DownloadURL   string  `json:"download_url"`
+}
+
+// FileCommitResponse represents the commit created by a contents API write
+type FileCommitResponse struct {
+	SHA     string `json:"sha"`
+	HTMLURL string `json:"html_url"`
+	Message string `json:"message"`
+}
+
+// FileResponse represents the response to a contents API write
+type FileResponse struct {
+	Content *ContentsResponse   `json:"content"`
+	Commit  *FileCommitResponse `json:"commit"`
+}
+
+// Identity represents the author or committer of a commit
+type Identity struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// FileOptions holds the options shared by all contents API writes
+type FileOptions struct {
+	Message   string    `json:"message,omitempty"`
+	Branch    string    `json:"branch,omitempty"`
+	NewBranch string    `json:"new_branch,omitempty"`
+	Author    *Identity `json:"author,omitempty"`
+	Committer *Identity `json:"committer,omitempty"`
+}
+
+// CreateFileOptions represents the request body for creating a file
+type CreateFileOptions struct {
+	FileOptions
+	// Content is the base64 encoded file content
+	Content string `json:"content"`
+}
+
+// UpdateFileOptions represents the request body for updating a file
+type UpdateFileOptions struct {
+	FileOptions
+	// Content is the base64 encoded file content
+	Content string `json:"content"`
+	// SHA is the blob SHA of the file being replaced
+	SHA string `json:"sha"`
+}
+
+// DeleteFileOptions represents the request body for deleting a file
+type DeleteFileOptions struct {
+	FileOptions
+	// SHA is the blob SHA of the file being deleted
+	SHA string `json:"sha"`
+}
+
+// BranchCommit represents the head commit of a branch
+type BranchCommit struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+	URL     string `json:"url"`
+}
+
+// RepositoryBranch represents a branch of a repository
+type RepositoryBranch struct {
+	Name      string        `json:"name"`
+	Commit    *BranchCommit `json:"commit"`
+	Protected bool          `json:"protected"`
+}
+
+// contentsPath escapes each segment of a repository file path
+func contentsPath(owner, repo, filepath string) string {
+	segments := strings.Split(strings.Trim(filepath, "/"), "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+	return fmt.Sprintf("/repos/%s/%s/contents/%s", owner, repo, strings.Join(segments, "/"))
+}
+
+// GetRepositoryFile retrieves a file at the supplied ref. An empty ref reads
+// the default branch.
+func (c *giteaClient) GetRepositoryFile(ctx context.Context, owner, repo, filepath, ref string) (*ContentsResponse, error) {
+	path := contentsPath(owner, repo, filepath)
+	if ref != "" {
+		path += "?ref=" + url.QueryEscape(ref)
+	}
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var contents ContentsResponse
+	if err := handleResponse(resp, &contents); err != nil {
+		return nil, err
+	}
+
+	return &contents, nil
+}
+
+// CreateRepositoryFile creates a file in a repository
+func (c *giteaClient) CreateRepositoryFile(ctx context.Context, owner, repo, filepath string, req *CreateFileOptions) (*FileResponse, error) {
+	resp, err := c.doRequest(ctx, "POST", contentsPath(owner, repo, filepath), req)
+	if err != nil {
+		return nil, err
+	}
+
+	var file FileResponse
+	if err := handleResponse(resp, &file); err != nil {
+		return nil, err
+	}
+
+	return &file, nil
+}
+
+// UpdateRepositoryFile replaces the content of a file in a repository. The
+// request is rejected if SHA no longer matches the file.
+func (c *giteaClient) UpdateRepositoryFile(ctx context.Context, owner, repo, filepath string, req *UpdateFileOptions) (*FileResponse, error) {
+	resp, err := c.doRequest(ctx, "PUT", contentsPath(owner, repo, filepath), req)
+	if err != nil {
+		return nil, err
+	}
+
+	var file FileResponse
+	if err := handleResponse(resp, &file); err != nil {
+		return nil, err
+	}
+
+	return &file, nil
+}
+
+// DeleteRepositoryFile deletes a file from a repository
+func (c *giteaClient) DeleteRepositoryFile(ctx context.Context, owner, repo, filepath string, req *DeleteFileOptions) error {
+	resp, err := c.doRequest(ctx, "DELETE", contentsPath(owner, repo, filepath), req)
+	if err != nil {
+		return err
+	}
+
+	return handleResponse(resp, nil)
+}
+
+// GetRepositoryBranch retrieves a branch of a repository
+func (c *giteaClient) GetRepositoryBranch(ctx context.Context, owner, repo, branch string) (*RepositoryBranch, error) {
+	path := fmt.Sprintf("/repos/%s/%s/branches/%s", owner, repo, url.PathEscape(branch))
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var b RepositoryBranch
+	if err := handleResponse(resp, &b); err != nil {
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+// GetPullRequestByBranches retrieves the pull request merging head into base
+func (c *giteaClient) GetPullRequestByBranches(ctx context.Context, owner, repo, base, head string) (*PullRequest, error) {
+	path := fmt.Sprintf("/repos/%s/%s/pulls/%s/%s", owner, repo, url.PathEscape(base), url.PathEscape(head))
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if err := handleResponse(resp, &pr); err != nil {
+		return nil, err
+	}
+
+	return &pr, nil
+}
@@ -73,6 +73,27 @@ func (c *giteaClient) CreateDeployKey(ctx context.Context, owner, repo string, r
 	return &deployKey, nil
 }
 
+// ListDeployKeys lists all deploy keys of a repository
+func (c *giteaClient) ListDeployKeys(ctx context.Context, owner, repo string) ([]*DeployKey, error) {
+	var keys []*DeployKey
+	for page := 1; ; page++ {
+		resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/repos/%s/%s/keys?page=%d&limit=50", owner, repo, page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var list []*DeployKey
+		if err := handleResponse(resp, &list); err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, list...)
+		if len(list) < 50 {
+			return keys, nil
+		}
+	}
+}
+
 // DeleteDeployKey deletes a deploy key
 func (c *giteaClient) DeleteDeployKey(ctx context.Context, owner, repo string, id int64) error {
 	path := fmt.Sprintf("/repos/%s/%s/keys/%d", owner, repo, id)
@@ -20,10 +20,14 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
+	"net/url"
 	"strings"
 	"time"
 
@@ -49,8 +53,10 @@ type Client interface {
 	GetRepository(ctx context.Context, owner, name string) (*Repository, error)
 	CreateRepository(ctx context.Context, req *CreateRepositoryRequest) (*Repository, error)
 	CreateOrganizationRepository(ctx context.Context, org string, req *CreateRepositoryRequest) (*Repository, error)
+	ListOrganizationRepositories(ctx context.Context, org string) ([]*Repository, error)
 	UpdateRepository(ctx context.Context, owner, name string, req *UpdateRepositoryRequest) (*Repository, error)
 	DeleteRepository(ctx context.Context, owner, name string) error
+	TransferRepository(ctx context.Context, owner, name string, req *TransferRepositoryRequest) (*Repository, error)
 
 	// Organization operations
 	GetOrganization(ctx context.Context, name string) (*Organization, error)
@@ -63,21 +69,30 @@ type Client interface {
 	CreateUser(ctx context.Context, req *CreateUserRequest) (*User, error)
 	UpdateUser(ctx context.Context, username string, req *UpdateUserRequest) (*User, error)
 	DeleteUser(ctx context.Context, username string) error
+	PurgeUser(ctx context.Context, username string) error
+	ListUserRepositories(ctx context.Context, username string) ([]*Repository, error)
+	ListUserOrganizations(ctx context.Context, username string) ([]*Organization, error)
 
 	// Webhook operations
 	GetRepositoryWebhook(ctx context.Context, owner, repo string, id int64) (*Webhook, error)
 	CreateRepositoryWebhook(ctx context.Context, owner, repo string, req *CreateWebhookRequest) (*Webhook, error)
 	UpdateRepositoryWebhook(ctx context.Context, owner, repo string, id int64, req *UpdateWebhookRequest) (*Webhook, error)
 	DeleteRepositoryWebhook(ctx context.Context, owner, repo string, id int64) error
+	ListRepositoryWebhooks(ctx context.Context, owner, repo string) ([]*Webhook, error)
 	GetOrganizationWebhook(ctx context.Context, org string, id int64) (*Webhook, error)
 	CreateOrganizationWebhook(ctx context.Context, org string, req *CreateWebhookRequest) (*Webhook, error)
 	UpdateOrganizationWebhook(ctx context.Context, org string, id int64, req *UpdateWebhookRequest) (*Webhook, error)
 	DeleteOrganizationWebhook(ctx context.Context, org string, id int64) error
+	GetAdminWebhook(ctx context.Context, id int64) (*Webhook, error)
+	CreateAdminWebhook(ctx context.Context, req *CreateWebhookRequest) (*Webhook, error)
+	UpdateAdminWebhook(ctx context.Context, id int64, req *UpdateWebhookRequest) (*Webhook, error)
+	DeleteAdminWebhook(ctx context.Context, id int64) error
 
 	// Deploy Key operations
 	GetDeployKey(ctx context.Context, owner, repo string, id int64) (*DeployKey, error)
 	CreateDeployKey(ctx context.Context, owner, repo string, req *CreateDeployKeyRequest) (*DeployKey, error)
 	DeleteDeployKey(ctx context.Context, owner, repo string, id int64) error
+	ListDeployKeys(ctx context.Context, owner, repo string) ([]*DeployKey, error)
 
 	// Organization Secret operations
 	GetOrganizationSecret(ctx context.Context, org, secretName string) (*OrganizationSecret, error)
@@ -106,10 +121,6 @@ type Client interface {
 	RemoveRepositoryCollaborator(ctx context.Context, owner, repo, username string) error
 	ListRepositoryCollaborators(ctx context.Context, owner, repo string) ([]*RepositoryCollaborator, error)
 
-	// Organization Settings operations
-	GetOrganizationSettings(ctx context.Context, org string) (*OrganizationSettings, error)
-	UpdateOrganizationSettings(ctx context.Context, org string, req *UpdateOrganizationSettingsRequest) (*OrganizationSettings, error)
-
 	// Git Hooks operations
 	GetGitHook(ctx context.Context, repository, hookType string) (*GitHook, error)
 	CreateGitHook(ctx context.Context, repository string, req *CreateGitHookRequest) (*GitHook, error)
@@ -117,10 +128,10 @@ type Client interface {
 	DeleteGitHook(ctx context.Context, repository, hookType string) error
 
 	// Branch Protection operations
-	GetBranchProtection(ctx context.Context, repository, branch string) (*BranchProtection, error)
-	CreateBranchProtection(ctx context.Context, repository, branch string, req *CreateBranchProtectionRequest) (*BranchProtection, error)
-	UpdateBranchProtection(ctx context.Context, repository, branch string, req *UpdateBranchProtectionRequest) (*BranchProtection, error)
-	DeleteBranchProtection(ctx context.Context, repository, branch string) error
+	GetBranchProtection(ctx context.Context, repository, ruleName string) (*BranchProtection, error)
+	CreateBranchProtection(ctx context.Context, repository string, req *CreateBranchProtectionRequest) (*BranchProtection, error)
+	UpdateBranchProtection(ctx context.Context, repository, ruleName string, req *UpdateBranchProtectionRequest) (*BranchProtection, error)
+	DeleteBranchProtection(ctx context.Context, repository, ruleName string) error
 
 	// Repository Key operations
 	GetRepositoryKey(ctx context.Context, repository string, keyID int64) (*RepositoryKey, error)
@@ -162,37 +173,108 @@ type Client interface {
 	// Release operations
 	GetRelease(ctx context.Context, owner, repo string, id int64) (*Release, error)
 	GetReleaseByTag(ctx context.Context, owner, repo, tag string) (*Release, error)
+	ListReleases(ctx context.Context, owner, repo string) ([]*Release, error)
 	CreateRelease(ctx context.Context, owner, repo string, req *CreateReleaseOptions) (*Release, error)
 	UpdateRelease(ctx context.Context, owner, repo string, id int64, req *UpdateReleaseOptions) (*Release, error)
 	DeleteRelease(ctx context.Context, owner, repo string, id int64) error
-	CreateReleaseAttachment(ctx context.Context, owner, repo string, releaseID int64, filename, contentType string, content []byte) (*ReleaseAttachment, error)
+	CreateReleaseAttachment(ctx context.Context, owner, repo string, releaseID int64, filename, contentType string, content io.Reader) (*ReleaseAttachment, error)
 	DeleteReleaseAttachment(ctx context.Context, owner, repo string, releaseID, attachmentID int64) error
 
 	// Organization Member operations
-	GetOrganizationMember(ctx context.Context, org, username string) (*OrganizationMember, error)
-	AddOrganizationMember(ctx context.Context, org, username string, req *AddOrganizationMemberRequest) (*OrganizationMember, error)
-	UpdateOrganizationMember(ctx context.Context, org, username string, req *UpdateOrganizationMemberRequest) (*OrganizationMember, error)
+	IsOrganizationMember(ctx context.Context, org, username string) (bool, error)
+	IsPublicOrganizationMember(ctx context.Context, org, username string) (bool, error)
+	SetOrganizationMemberVisibility(ctx context.Context, org, username string, public bool) error
 	RemoveOrganizationMember(ctx context.Context, org, username string) error
 
 	// Action operations
-	GetAction(ctx context.Context, repository, workflowName string) (*Action, error)
+	GetAction(ctx context.Context, repository, workflowName, branch string) (*Action, error)
 	CreateAction(ctx context.Context, repository string, req *CreateActionRequest) (*Action, error)
 	UpdateAction(ctx context.Context, repository, workflowName string, req *UpdateActionRequest) (*Action, error)
-	DeleteAction(ctx context.Context, repository, workflowName string) error
+	DeleteAction(ctx context.Context, repository, workflowName string, req *DeleteActionRequest) error
+	GetActionWorkflow(ctx context.Context, repository, workflowName string) (*ActionWorkflow, error)
 	EnableAction(ctx context.Context, repository, workflowName string) error
 	DisableAction(ctx context.Context, repository, workflowName string) error
+	GetActionLastRun(ctx context.Context, repository, workflowName string) (*ActionRun, error)
 
 	// Runner operations
 	GetRunner(ctx context.Context, scope, scopeValue string, runnerID int64) (*Runner, error)
-	CreateRunner(ctx context.Context, scope, scopeValue string, req *CreateRunnerRequest) (*Runner, error)
-	UpdateRunner(ctx context.Context, scope, scopeValue string, runnerID int64, req *UpdateRunnerRequest) (*Runner, error)
+	ListRunners(ctx context.Context, scope, scopeValue string) ([]Runner, error)
 	DeleteRunner(ctx context.Context, scope, scopeValue string, runnerID int64) error
+	GetRunnerRegistrationToken(ctx context.Context, scope, scopeValue string) (*RunnerRegistrationToken, error)
+	CreateRunnerRegistrationToken(ctx context.Context, scope, scopeValue string) (*RunnerRegistrationToken, error)
 
 	// Admin User operations
 	GetAdminUser(ctx context.Context, username string) (*AdminUser, error)
 	CreateAdminUser(ctx context.Context, req *CreateAdminUserRequest) (*AdminUser, error)
 	UpdateAdminUser(ctx context.Context, username string, req *UpdateAdminUserRequest) (*AdminUser, error)
 	DeleteAdminUser(ctx context.Context, username string) error
+
+	// Repository File operations
+	GetRepositoryFile(ctx context.Context, owner, repo, filepath, ref string) (*ContentsResponse, error)
+	CreateRepositoryFile(ctx context.Context, owner, repo, filepath string, req *CreateFileOptions) (*FileResponse, error)
+	UpdateRepositoryFile(ctx context.Context, owner, repo, filepath string, req *UpdateFileOptions) (*FileResponse, error)
+	DeleteRepositoryFile(ctx context.Context, owner, repo, filepath string, req *DeleteFileOptions) error
+	GetRepositoryBranch(ctx context.Context, owner, repo, branch string) (*RepositoryBranch, error)
+	GetPullRequestByBranches(ctx context.Context, owner, repo, base, head string) (*PullRequest, error)
+
+	// Workflow run operations
+	ListActionRuns(ctx context.Context, repository, workflowName, event string) ([]ActionRun, error)
+	GetActionRun(ctx context.Context, repository string, runID int64) (*ActionRun, error)
+	ListActionRunJobs(ctx context.Context, repository string, runID int64) ([]ActionJob, error)
+	DispatchWorkflow(ctx context.Context, repository, workflowName string, req *DispatchWorkflowRequest) error
+
+	// Tag protection operations
+	ListTagProtections(ctx context.Context, repository string) ([]TagProtection, error)
+	GetTagProtection(ctx context.Context, repository string, id int64) (*TagProtection, error)
+	CreateTagProtection(ctx context.Context, repository string, req *CreateTagProtectionRequest) (*TagProtection, error)
+	UpdateTagProtection(ctx context.Context, repository string, id int64, req *UpdateTagProtectionRequest) (*TagProtection, error)
+	DeleteTagProtection(ctx context.Context, repository string, id int64) error
+
+	// Branch and tag operations
+	CreateRepositoryBranch(ctx context.Context, owner, repo string, req *CreateBranchRequest) (*RepositoryBranch, error)
+	DeleteRepositoryBranch(ctx context.Context, owner, repo, branch string) error
+	GetRepositoryTag(ctx context.Context, owner, repo, tag string) (*RepositoryTag, error)
+	CreateRepositoryTag(ctx context.Context, owner, repo string, req *CreateTagRequest) (*RepositoryTag, error)
+	DeleteRepositoryTag(ctx context.Context, owner, repo, tag string) error
+	CompareCommits(ctx context.Context, owner, repo, base, head string) (*Compare, error)
+
+	// Team membership operations
+	ListTeamMembers(ctx context.Context, teamID int64) ([]*User, error)
+	GetTeamMember(ctx context.Context, teamID int64, username string) (*User, error)
+	AddTeamMember(ctx context.Context, teamID int64, username string) error
+	RemoveTeamMember(ctx context.Context, teamID int64, username string) error
+	ListTeamRepositories(ctx context.Context, teamID int64) ([]*Repository, error)
+	GetTeamRepository(ctx context.Context, teamID int64, org, repo string) (*Repository, error)
+	AddTeamRepository(ctx context.Context, teamID int64, org, repo string) error
+	RemoveTeamRepository(ctx context.Context, teamID int64, org, repo string) error
+
+	// Organization avatar, label and block operations
+	UpdateOrganizationAvatar(ctx context.Context, org string, image []byte) error
+	DeleteOrganizationAvatar(ctx context.Context, org string) error
+	ListOrganizationLabels(ctx context.Context, org string) ([]*Label, error)
+	CreateOrganizationLabel(ctx context.Context, org string, req *CreateLabelRequest) (*Label, error)
+	UpdateOrganizationLabel(ctx context.Context, org string, labelID int64, req *UpdateLabelRequest) (*Label, error)
+	DeleteOrganizationLabel(ctx context.Context, org string, labelID int64) error
+	ListOrganizationBlocks(ctx context.Context, org string) ([]*User, error)
+	BlockOrganizationUser(ctx context.Context, org, username string) error
+	UnblockOrganizationUser(ctx context.Context, org, username string) error
+
+	// User account operations
+	ListUserEmails(ctx context.Context, username string) ([]*Email, error)
+	AddUserEmails(ctx context.Context, username string, emails []string) ([]*Email, error)
+	DeleteUserEmails(ctx context.Context, username string, emails []string) error
+	ListUserGPGKeys(ctx context.Context, username string) ([]*GPGKey, error)
+	GetUserGPGKey(ctx context.Context, username string, id int64) (*GPGKey, error)
+	CreateUserGPGKey(ctx context.Context, username string, req *CreateGPGKeyRequest) (*GPGKey, error)
+	DeleteUserGPGKey(ctx context.Context, username string, id int64) error
+	GetUserSettings(ctx context.Context, username string) (*UserSettings, error)
+	UpdateUserSettings(ctx context.Context, username string, req *UpdateUserSettingsRequest) (*UserSettings, error)
+
+	// OAuth2 application operations
+	GetOAuth2Application(ctx context.Context, username string, id int64) (*OAuth2Application, error)
+	CreateOAuth2Application(ctx context.Context, username string, req *OAuth2ApplicationRequest) (*OAuth2Application, error)
+	UpdateOAuth2Application(ctx context.Context, username string, id int64, req *OAuth2ApplicationRequest) (*OAuth2Application, error)
+	DeleteOAuth2Application(ctx context.Context, username string, id int64) error
 }
 
 // giteaClient implements the Client interface
@@ -257,6 +339,8 @@ type Repository struct {
 	CreatedAt     string `json:"created_at"`
 	UpdatedAt     string `json:"updated_at"`
 	Owner         *User  `json:"owner"`
+	// RepoTransfer is set while a transfer awaits acceptance
+	RepoTransfer *RepoTransfer `json:"repo_transfer,omitempty"`
 }
 
 // CreateRepositoryRequest represents the request body for creating a repository
@@ -396,6 +480,7 @@ type UpdateUserRequest struct {
 	Location                *string `json:"location,omitempty"`
 	Description             *string `json:"description,omitempty"`
 	Visibility              *string `json:"visibility,omitempty"`
+	MaxRepoCreation         *int64  `json:"max_repo_creation,omitempty"`
 }
 
 // Issue represents a Gitea issue
@@ -668,27 +753,30 @@ type Team struct {
 	Permission              string            `json:"permission"`
 	CanCreateOrgRepo        bool              `json:"can_create_org_repo"`
 	IncludesAllRepositories bool              `json:"includes_all_repositories"`
+	Units                   []string          `json:"units"`
 	UnitsMap                map[string]string `json:"units_map"`
 }
 
 // CreateTeamRequest represents the request body for creating a team
 type CreateTeamRequest struct {
-	Name                    string   `json:"name"`
-	Description             string   `json:"description,omitempty"`
-	Permission              string   `json:"permission,omitempty"`
-	CanCreateOrgRepo        bool     `json:"can_create_org_repo,omitempty"`
-	IncludesAllRepositories bool     `json:"includes_all_repositories,omitempty"`
-	Units                   []string `json:"units,omitempty"`
+	Name                    string            `json:"name"`
+	Description             string            `json:"description,omitempty"`
+	Permission              string            `json:"permission,omitempty"`
+	CanCreateOrgRepo        bool              `json:"can_create_org_repo,omitempty"`
+	IncludesAllRepositories bool              `json:"includes_all_repositories,omitempty"`
+	Units                   []string          `json:"units,omitempty"`
+	UnitsMap                map[string]string `json:"units_map,omitempty"`
 }
 
 // UpdateTeamRequest represents the request body for updating a team
 type UpdateTeamRequest struct {
-	Name                    *string  `json:"name,omitempty"`
-	Description             *string  `json:"description,omitempty"`
-	Permission              *string  `json:"permission,omitempty"`
-	CanCreateOrgRepo        *bool    `json:"can_create_org_repo,omitempty"`
-	IncludesAllRepositories *bool    `json:"includes_all_repositories,omitempty"`
-	Units                   []string `json:"units,omitempty"`
+	Name                    *string           `json:"name,omitempty"`
+	Description             *string           `json:"description,omitempty"`
+	Permission              *string           `json:"permission,omitempty"`
+	CanCreateOrgRepo        *bool             `json:"can_create_org_repo,omitempty"`
+	IncludesAllRepositories *bool             `json:"includes_all_repositories,omitempty"`
+	Units                   []string          `json:"units,omitempty"`
+	UnitsMap                map[string]string `json:"units_map,omitempty"`
 }
 
 // Team API methods
@@ -699,10 +787,6 @@ func (c *giteaClient) GetTeam(ctx context.Context, teamID int64) (*Team, error)
 		return nil, err
 	}
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, errors.New("team not found")
-	}
-
 	var team Team
 	if err := handleResponse(resp, &team); err != nil {
 		return nil, err
@@ -766,6 +850,124 @@ func (c *giteaClient) ListOrganizationTeams(ctx context.Context, org string) ([]
 	return teams, nil
 }
 
+// ListTeamMembers lists the members of a team
+func (c *giteaClient) ListTeamMembers(ctx context.Context, teamID int64) ([]*User, error) {
+	var members []*User
+	for page := 1; ; page++ {
+		resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/teams/%d/members?page=%d&limit=50", teamID, page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var list []*User
+		if err := handleResponse(resp, &list); err != nil {
+			return nil, err
+		}
+
+		members = append(members, list...)
+		if len(list) < 50 {
+			return members, nil
+		}
+	}
+}
+
+// GetTeamMember returns the user if they are a member of the team
+func (c *giteaClient) GetTeamMember(ctx context.Context, teamID int64, username string) (*User, error) {
+	path := fmt.Sprintf("/teams/%d/members/%s", teamID, url.PathEscape(username))
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var user User
+	if err := handleResponse(resp, &user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// AddTeamMember adds a user to a team
+func (c *giteaClient) AddTeamMember(ctx context.Context, teamID int64, username string) error {
+	path := fmt.Sprintf("/teams/%d/members/%s", teamID, url.PathEscape(username))
+	resp, err := c.doRequest(ctx, "PUT", path, nil)
+	if err != nil {
+		return err
+	}
+
+	return handleResponse(resp, nil)
+}
+
+// RemoveTeamMember removes a user from a team
+func (c *giteaClient) RemoveTeamMember(ctx context.Context, teamID int64, username string) error {
+	path := fmt.Sprintf("/teams/%d/members/%s", teamID, url.PathEscape(username))
+	resp, err := c.doRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	return handleResponse(resp, nil)
+}
+
+// ListTeamRepositories lists the repositories a team has access to
+func (c *giteaClient) ListTeamRepositories(ctx context.Context, teamID int64) ([]*Repository, error) {
+	var repos []*Repository
+	for page := 1; ; page++ {
+		resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/teams/%d/repos?page=%d&limit=50", teamID, page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var list []*Repository
+		if err := handleResponse(resp, &list); err != nil {
+			return nil, err
+		}
+
+		repos = append(repos, list...)
+		if len(list) < 50 {
+			return repos, nil
+		}
+	}
+}
+
+// GetTeamRepository returns the repository if the team has access to it
+func (c *giteaClient) GetTeamRepository(ctx context.Context, teamID int64, org, repo string) (*Repository, error) {
+	path := fmt.Sprintf("/teams/%d/repos/%s/%s", teamID, org, repo)
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var repository Repository
+	if err := handleResponse(resp, &repository); err != nil {
+		return nil, err
+	}
+
+	return &repository, nil
+}
+
+// AddTeamRepository gives a team access to an organization repository
+func (c *giteaClient) AddTeamRepository(ctx context.Context, teamID int64, org, repo string) error {
+	path := fmt.Sprintf("/teams/%d/repos/%s/%s", teamID, org, repo)
+	resp, err := c.doRequest(ctx, "PUT", path, nil)
+	if err != nil {
+		return err
+	}
+
+	return handleResponse(resp, nil)
+}
+
+// RemoveTeamRepository removes a team's access to an organization repository
+func (c *giteaClient) RemoveTeamRepository(ctx context.Context, teamID int64, org, repo string) error {
+	path := fmt.Sprintf("/teams/%d/repos/%s/%s", teamID, org, repo)
+	resp, err := c.doRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	return handleResponse(resp, nil)
+}
+
 // Label represents a Gitea repository label
 type Label struct {
 	ID          int64  `json:"id"`
@@ -818,45 +1020,6 @@ type UpdateCollaboratorRequest struct {
 	Permission string `json:"permission"` // read, write, admin
 }
 
-// OrganizationSettings represents organization-wide settings and policies
-type OrganizationSettings struct {
-	// Default repository settings
-	DefaultRepoPermission    string `json:"default_repo_permission"` // read, write, admin
-	MembersCanCreateRepos    bool   `json:"members_can_create_repos"`
-	MembersCanCreatePrivate  bool   `json:"members_can_create_private"`
-	MembersCanCreateInternal bool   `json:"members_can_create_internal"`
-
-	// Member management settings
-	MembersCanDeleteRepos bool `json:"members_can_delete_repos"`
-	MembersCanFork        bool `json:"members_can_fork"`
-	MembersCanCreatePages bool `json:"members_can_create_pages"`
-
-	// Security and visibility settings
-	DefaultRepoVisibility string `json:"default_repo_visibility"` // public, private, internal
-	RequireSignedCommits  bool   `json:"require_signed_commits"`
-	EnableDependencyGraph bool   `json:"enable_dependency_graph"`
-
-	// Git hooks and automation
-	AllowGitHooks       bool `json:"allow_git_hooks"`
-	AllowCustomGitHooks bool `json:"allow_custom_git_hooks"`
-}
-
-// UpdateOrganizationSettingsRequest represents the request body for updating organization settings
-type UpdateOrganizationSettingsRequest struct {
-	DefaultRepoPermission    *string `json:"default_repo_permission,omitempty"`
-	MembersCanCreateRepos    *bool   `json:"members_can_create_repos,omitempty"`
-	MembersCanCreatePrivate  *bool   `json:"members_can_create_private,omitempty"`
-	MembersCanCreateInternal *bool   `json:"members_can_create_internal,omitempty"`
-	MembersCanDeleteRepos    *bool   `json:"members_can_delete_repos,omitempty"`
-	MembersCanFork           *bool   `json:"members_can_fork,omitempty"`
-	MembersCanCreatePages    *bool   `json:"members_can_create_pages,omitempty"`
-	DefaultRepoVisibility    *string `json:"default_repo_visibility,omitempty"`
-	RequireSignedCommits     *bool   `json:"require_signed_commits,omitempty"`
-	EnableDependencyGraph    *bool   `json:"enable_dependency_graph,omitempty"`
-	AllowGitHooks            *bool   `json:"allow_git_hooks,omitempty"`
-	AllowCustomGitHooks      *bool   `json:"allow_custom_git_hooks,omitempty"`
-}
-
 // GitHook represents a Git hook
 type GitHook struct {
 	Name     string `json:"name"`
@@ -878,14 +1041,21 @@ type UpdateGitHookRequest struct {
 	IsActive bool   `json:"is_active"`
 }
 
-// BranchProtection represents a Git branch protection rule
+// BranchProtection represents a Git branch protection rule. Gitea identifies
+// rules by RuleName, which may be a glob such as release/*.
 type BranchProtection struct {
 	RuleName                      string   `json:"rule_name"`
+	Priority                      int64    `json:"priority"`
 	EnablePush                    bool     `json:"enable_push"`
 	EnablePushWhitelist           bool     `json:"enable_push_whitelist"`
 	PushWhitelistUsernames        []string `json:"push_whitelist_usernames"`
 	PushWhitelistTeams            []string `json:"push_whitelist_teams"`
 	PushWhitelistDeployKeys       bool     `json:"push_whitelist_deploy_keys"`
+	EnableForcePush               bool     `json:"enable_force_push"`
+	EnableForcePushAllowlist      bool     `json:"enable_force_push_allowlist"`
+	ForcePushAllowlistUsernames   []string `json:"force_push_allowlist_usernames"`
+	ForcePushAllowlistTeams       []string `json:"force_push_allowlist_teams"`
+	ForcePushAllowlistDeployKeys  bool     `json:"force_push_allowlist_deploy_keys"`
 	EnableMergeWhitelist          bool     `json:"enable_merge_whitelist"`
 	MergeWhitelistUsernames       []string `json:"merge_whitelist_usernames"`
 	MergeWhitelistTeams           []string `json:"merge_whitelist_teams"`
@@ -893,12 +1063,14 @@ type BranchProtection struct {
 	StatusCheckContexts           []string `json:"status_check_contexts"`
 	RequiredApprovals             int      `json:"required_approvals"`
 	EnableApprovalsWhitelist      bool     `json:"enable_approvals_whitelist"`
-	ApprovalsWhitelistUsernames   []string `json:"approvals_whitelist_usernames"`
+	ApprovalsWhitelistUsernames   []string `json:"approvals_whitelist_username"`
 	ApprovalsWhitelistTeams       []string `json:"approvals_whitelist_teams"`
 	BlockOnRejectedReviews        bool     `json:"block_on_rejected_reviews"`
 	BlockOnOfficialReviewRequests bool     `json:"block_on_official_review_requests"`
 	BlockOnOutdatedBranch         bool     `json:"block_on_outdated_branch"`
+	BlockAdminMergeOverride       bool     `json:"block_admin_merge_override"`
 	DismissStaleApprovals         bool     `json:"dismiss_stale_approvals"`
+	IgnoreStaleApprovals          bool     `json:"ignore_stale_approvals"`
 	RequireSignedCommits          bool     `json:"require_signed_commits"`
 	ProtectedFilePatterns         string   `json:"protected_file_patterns"`
 	UnprotectedFilePatterns       string   `json:"unprotected_file_patterns"`
@@ -909,11 +1081,17 @@ type BranchProtection struct {
 // CreateBranchProtectionRequest represents the request body for creating branch protection
 type CreateBranchProtectionRequest struct {
 	RuleName                      string   `json:"rule_name"`
+	Priority                      *int64   `json:"priority,omitempty"`
 	EnablePush                    *bool    `json:"enable_push,omitempty"`
 	EnablePushWhitelist           *bool    `json:"enable_push_whitelist,omitempty"`
 	PushWhitelistUsernames        []string `json:"push_whitelist_usernames,omitempty"`
 	PushWhitelistTeams            []string `json:"push_whitelist_teams,omitempty"`
 	PushWhitelistDeployKeys       *bool    `json:"push_whitelist_deploy_keys,omitempty"`
+	EnableForcePush               *bool    `json:"enable_force_push,omitempty"`
+	EnableForcePushAllowlist      *bool    `json:"enable_force_push_allowlist,omitempty"`
+	ForcePushAllowlistUsernames   []string `json:"force_push_allowlist_usernames,omitempty"`
+	ForcePushAllowlistTeams       []string `json:"force_push_allowlist_teams,omitempty"`
+	ForcePushAllowlistDeployKeys  *bool    `json:"force_push_allowlist_deploy_keys,omitempty"`
 	EnableMergeWhitelist          *bool    `json:"enable_merge_whitelist,omitempty"`
 	MergeWhitelistUsernames       []string `json:"merge_whitelist_usernames,omitempty"`
 	MergeWhitelistTeams           []string `json:"merge_whitelist_teams,omitempty"`
@@ -921,42 +1099,79 @@ type CreateBranchProtectionRequest struct {
 	StatusCheckContexts           []string `json:"status_check_contexts,omitempty"`
 	RequiredApprovals             *int     `json:"required_approvals,omitempty"`
 	EnableApprovalsWhitelist      *bool    `json:"enable_approvals_whitelist,omitempty"`
-	ApprovalsWhitelistUsernames   []string `json:"approvals_whitelist_usernames,omitempty"`
+	ApprovalsWhitelistUsernames   []string `json:"approvals_whitelist_username,omitempty"`
 	ApprovalsWhitelistTeams       []string `json:"approvals_whitelist_teams,omitempty"`
 	BlockOnRejectedReviews        *bool    `json:"block_on_rejected_reviews,omitempty"`
 	BlockOnOfficialReviewRequests *bool    `json:"block_on_official_review_requests,omitempty"`
 	BlockOnOutdatedBranch         *bool    `json:"block_on_outdated_branch,omitempty"`
+	BlockAdminMergeOverride       *bool    `json:"block_admin_merge_override,omitempty"`
 	DismissStaleApprovals         *bool    `json:"dismiss_stale_approvals,omitempty"`
+	IgnoreStaleApprovals          *bool    `json:"ignore_stale_approvals,omitempty"`
 	RequireSignedCommits          *bool    `json:"require_signed_commits,omitempty"`
 	ProtectedFilePatterns         *string  `json:"protected_file_patterns,omitempty"`
 	UnprotectedFilePatterns       *string  `json:"unprotected_file_patterns,omitempty"`
 }
 
-// UpdateBranchProtectionRequest represents the request body for updating branch protection
+// UpdateBranchProtectionRequest represents the request body for updating
+// branch protection. Lists are always sent so that emptying one in the spec
+// clears it in Gitea.
 type UpdateBranchProtectionRequest struct {
+	Priority                      *int64   `json:"priority,omitempty"`
 	EnablePush                    *bool    `json:"enable_push,omitempty"`
 	EnablePushWhitelist           *bool    `json:"enable_push_whitelist,omitempty"`
-	PushWhitelistUsernames        []string `json:"push_whitelist_usernames,omitempty"`
-	PushWhitelistTeams            []string `json:"push_whitelist_teams,omitempty"`
+	PushWhitelistUsernames        []string `json:"push_whitelist_usernames"`
+	PushWhitelistTeams            []string `json:"push_whitelist_teams"`
 	PushWhitelistDeployKeys       *bool    `json:"push_whitelist_deploy_keys,omitempty"`
+	EnableForcePush               *bool    `json:"enable_force_push,omitempty"`
+	EnableForcePushAllowlist      *bool    `json:"enable_force_push_allowlist,omitempty"`
+	ForcePushAllowlistUsernames   []string `json:"force_push_allowlist_usernames"`
+	ForcePushAllowlistTeams       []string `json:"force_push_allowlist_teams"`
+	ForcePushAllowlistDeployKeys  *bool    `json:"force_push_allowlist_deploy_keys,omitempty"`
 	EnableMergeWhitelist          *bool    `json:"enable_merge_whitelist,omitempty"`
-	MergeWhitelistUsernames       []string `json:"merge_whitelist_usernames,omitempty"`
-	MergeWhitelistTeams           []string `json:"merge_whitelist_teams,omitempty"`
+	MergeWhitelistUsernames       []string `json:"merge_whitelist_usernames"`
+	MergeWhitelistTeams           []string `json:"merge_whitelist_teams"`
 	EnableStatusCheck             *bool    `json:"enable_status_check,omitempty"`
-	StatusCheckContexts           []string `json:"status_check_contexts,omitempty"`
+	StatusCheckContexts           []string `json:"status_check_contexts"`
 	RequiredApprovals             *int     `json:"required_approvals,omitempty"`
 	EnableApprovalsWhitelist      *bool    `json:"enable_approvals_whitelist,omitempty"`
-	ApprovalsWhitelistUsernames   []string `json:"approvals_whitelist_usernames,omitempty"`
-	ApprovalsWhitelistTeams       []string `json:"approvals_whitelist_teams,omitempty"`
+	ApprovalsWhitelistUsernames   []string `json:"approvals_whitelist_username"`
+	ApprovalsWhitelistTeams       []string `json:"approvals_whitelist_teams"`
 	BlockOnRejectedReviews        *bool    `json:"block_on_rejected_reviews,omitempty"`
 	BlockOnOfficialReviewRequests *bool    `json:"block_on_official_review_requests,omitempty"`
 	BlockOnOutdatedBranch         *bool    `json:"block_on_outdated_branch,omitempty"`
+	BlockAdminMergeOverride       *bool    `json:"block_admin_merge_override,omitempty"`
 	DismissStaleApprovals         *bool    `json:"dismiss_stale_approvals,omitempty"`
+	IgnoreStaleApprovals          *bool    `json:"ignore_stale_approvals,omitempty"`
 	RequireSignedCommits          *bool    `json:"require_signed_commits,omitempty"`
 	ProtectedFilePatterns         *string  `json:"protected_file_patterns,omitempty"`
 	UnprotectedFilePatterns       *string  `json:"unprotected_file_patterns,omitempty"`
 }
 
+// TagProtection represents a Git tag protection rule
+type TagProtection struct {
+	ID                 int64    `json:"id"`
+	NamePattern        string   `json:"name_pattern"`
+	WhitelistUsernames []string `json:"whitelist_usernames"`
+	WhitelistTeams     []string `json:"whitelist_teams"`
+	CreatedAt          string   `json:"created_at"`
+	UpdatedAt          string   `json:"updated_at"`
+}
+
+// CreateTagProtectionRequest represents the request body for creating tag protection
+type CreateTagProtectionRequest struct {
+	NamePattern        string   `json:"name_pattern"`
+	WhitelistUsernames []string `json:"whitelist_usernames,omitempty"`
+	WhitelistTeams     []string `json:"whitelist_teams,omitempty"`
+}
+
+// UpdateTagProtectionRequest represents the request body for updating tag
+// protection. Lists are always sent so that emptying one clears it in Gitea.
+type UpdateTagProtectionRequest struct {
+	NamePattern        *string  `json:"name_pattern,omitempty"`
+	WhitelistUsernames []string `json:"whitelist_usernames"`
+	WhitelistTeams     []string `json:"whitelist_teams"`
+}
+
 // RepositoryKey represents a repository SSH key
 type RepositoryKey struct {
 	ID          int64  `json:"id"`
@@ -1043,116 +1258,124 @@ type UpdateUserKeyRequest struct {
 	ReadOnly *bool   `json:"read_only,omitempty"`
 }
 
-// OrganizationMember represents an organization member
-type OrganizationMember struct {
-	Username   string `json:"username"`
-	FullName   string `json:"full_name"`
-	Email      string `json:"email"`
-	AvatarURL  string `json:"avatar_url"`
-	Role       string `json:"role"`       // owner, admin, member
-	Visibility string `json:"visibility"` // public, private
-	IsPublic   bool   `json:"is_public"`
+// Action represents a Gitea Actions workflow file stored under .gitea/workflows
+type Action struct {
+	WorkflowName string `json:"workflow_name"`
+	Path         string `json:"path"`
+	SHA          string `json:"sha"`
+	Content      string `json:"content"` // YAML content
+	HTMLURL      string `json:"html_url"`
+}
+
+// ActionWorkflow represents a workflow as reported by the Actions workflows API
+type ActionWorkflow struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Path      string `json:"path"`
+	State     string `json:"state"` // active, disabled_manually
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	URL       string `json:"url"`
+	HTMLURL   string `json:"html_url"`
+	BadgeURL  string `json:"badge_url"`
+}
+
+// ActionRun represents a workflow run returned by the Actions runs API
+type ActionRun struct {
+	ID          int64  `json:"id"`
+	RunNumber   int64  `json:"run_number"`
+	Status      string `json:"status"`     // queued, in_progress, completed
+	Conclusion  string `json:"conclusion"` // success, failure, cancelled, skipped
+	Event       string `json:"event"`      // push, pull_request, workflow_dispatch, etc.
+	Path        string `json:"path"`       // <workflow>@<ref>
+	HeadBranch  string `json:"head_branch"`
+	HeadSHA     string `json:"head_sha"`
+	StartedAt   string `json:"started_at"`
+	CompletedAt string `json:"completed_at"`
+	HTMLURL     string `json:"html_url"`
+}
+
+// ActionRunList represents the response of the Actions runs API
+type ActionRunList struct {
+	TotalCount   int64       `json:"total_count"`
+	WorkflowRuns []ActionRun `json:"workflow_runs"`
+}
+
+// ActionJob represents a job of a workflow run
+type ActionJob struct {
+	ID          int64  `json:"id"`
+	RunID       int64  `json:"run_id"`
+	Name        string `json:"name"`
+	Status      string `json:"status"`     // queued, in_progress, completed
+	Conclusion  string `json:"conclusion"` // success, failure, cancelled, skipped
+	StartedAt   string `json:"started_at"`
+	CompletedAt string `json:"completed_at"`
+	HTMLURL     string `json:"html_url"`
 }
 
-// AddOrganizationMemberRequest represents the request body for adding an organization member
-type AddOrganizationMemberRequest struct {
-	Role string `json:"role"` // owner, admin, member
+// ActionJobList represents the response of the Actions run jobs API
+type ActionJobList struct {
+	TotalCount int64       `json:"total_count"`
+	Jobs       []ActionJob `json:"jobs"`
 }
 
-// UpdateOrganizationMemberRequest represents the request body for updating an organization member
-type UpdateOrganizationMemberRequest struct {
-	Role       *string `json:"role,omitempty"`       // owner, admin, member
-	Visibility *string `json:"visibility,omitempty"` // public, private
+// DispatchWorkflowRequest represents the request body for dispatching a workflow
+type DispatchWorkflowRequest struct {
+	Ref    string            `json:"ref"`
+	Inputs map[string]string `json:"inputs,omitempty"`
 }
 
-// Action represents a Gitea Actions workflow
-type Action struct {
-	WorkflowName string             `json:"workflow_name"`
-	State        string             `json:"state"` // active, disabled
-	Badge        string             `json:"badge_url"`
-	CreatedAt    string             `json:"created_at"`
-	UpdatedAt    string             `json:"updated_at"`
-	WorkflowFile ActionWorkflowFile `json:"workflow_file"`
-	LastRun      *ActionLastRun     `json:"last_run,omitempty"`
-}
-
-// ActionWorkflowFile represents the workflow file details
-type ActionWorkflowFile struct {
-	Path    string `json:"path"`
-	Content string `json:"content"`
-	Size    int64  `json:"size"`
-}
-
-// ActionLastRun represents the last workflow run information
-type ActionLastRun struct {
-	ID         int64  `json:"id"`
-	Number     int64  `json:"number"`
-	Status     string `json:"status"`     // success, failure, pending, cancelled
-	Conclusion string `json:"conclusion"` // success, failure, cancelled, skipped
-	Event      string `json:"event"`      // push, pull_request, manual, etc.
-	Branch     string `json:"branch"`
-	Commit     string `json:"commit"`
-	StartedAt  string `json:"started_at"`
-	UpdatedAt  string `json:"updated_at"`
-}
-
-// CreateActionRequest represents the request body for creating an action workflow
+// CreateActionRequest represents a workflow file to commit
 type CreateActionRequest struct {
 	WorkflowName string `json:"workflow_name"`
-	WorkflowFile string `json:"workflow_file"` // YAML content
-	Path         string `json:"path"`          // .github/workflows/name.yml
+	Content      string `json:"content"` // YAML content
 	Message      string `json:"message,omitempty"`
 	Branch       string `json:"branch,omitempty"`
 }
 
-// UpdateActionRequest represents the request body for updating an action workflow
+// UpdateActionRequest represents a change to a committed workflow file
 type UpdateActionRequest struct {
-	WorkflowFile *string `json:"workflow_file,omitempty"` // YAML content
-	Message      *string `json:"message,omitempty"`
-	Branch       *string `json:"branch,omitempty"`
+	Content string `json:"content"` // YAML content
+	SHA     string `json:"sha"`     // blob SHA of the file being replaced
+	Message string `json:"message,omitempty"`
+	Branch  string `json:"branch,omitempty"`
+}
+
+// DeleteActionRequest represents the removal of a committed workflow file
+type DeleteActionRequest struct {
+	SHA     string `json:"sha"` // blob SHA of the file being deleted
+	Message string `json:"message,omitempty"`
+	Branch  string `json:"branch,omitempty"`
 }
 
-// Runner represents a Gitea Actions runner
+// Runner represents a Gitea Actions runner. Runners register themselves
+// through act_runner using a RunnerRegistrationToken.
 type Runner struct {
-	ID              int64           `json:"id"`
-	UUID            string          `json:"uuid"`
-	Name            string          `json:"name"`
-	Status          string          `json:"status"` // online, offline, idle, active
-	LastOnline      string          `json:"last_online"`
-	CreatedAt       string          `json:"created_at"`
-	UpdatedAt       string          `json:"updated_at"`
-	Labels          []string        `json:"labels"`
-	Description     string          `json:"description"`
-	Scope           string          `json:"scope"`       // repository, organization, system
-	ScopeValue      string          `json:"scope_value"` // repo name or org name
-	RunnerGroup     *RunnerGroupRef `json:"runner_group,omitempty"`
-	Version         string          `json:"version"`
-	Architecture    string          `json:"architecture"`
-	OperatingSystem string          `json:"operating_system"`
-	TokenExpiresAt  string          `json:"token_expires_at"`
-}
-
-// RunnerGroupRef represents a reference to a runner group
-type RunnerGroupRef struct {
-	ID        int64  `json:"id"`
-	Name      string `json:"name"`
-	IsDefault bool   `json:"is_default"`
+	ID        int64         `json:"id"`
+	Name      string        `json:"name"`
+	Status    string        `json:"status"` // online, offline, idle, active
+	Busy      bool          `json:"busy"`
+	Ephemeral bool          `json:"ephemeral"`
+	Labels    []RunnerLabel `json:"labels"`
+	Version   string        `json:"version,omitempty"`
 }
 
-// CreateRunnerRequest represents the request body for creating a runner
-type CreateRunnerRequest struct {
-	Name          string   `json:"name"`
-	Labels        []string `json:"labels"`
-	Description   string   `json:"description,omitempty"`
-	RunnerGroupID *int64   `json:"runner_group_id,omitempty"`
+// RunnerLabel represents a label of a Gitea Actions runner
+type RunnerLabel struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"` // system, custom
 }
 
-// UpdateRunnerRequest represents the request body for updating a runner
-type UpdateRunnerRequest struct {
-	Name          *string  `json:"name,omitempty"`
-	Labels        []string `json:"labels,omitempty"`
-	Description   *string  `json:"description,omitempty"`
-	RunnerGroupID *int64   `json:"runner_group_id,omitempty"`
+// RunnerList represents the response of the runners list API
+type RunnerList struct {
+	TotalCount int64    `json:"total_count"`
+	Runners    []Runner `json:"runners"`
+}
+
+// RunnerRegistrationToken represents a token act_runner registers with
+type RunnerRegistrationToken struct {
+	Token string `json:"token"`
 }
 
 // AdminUser represents a Gitea administrative user
@@ -1281,18 +1504,23 @@ func (c *giteaClient) DeleteLabel(ctx context.Context, owner, repo string, label
 }
 
 func (c *giteaClient) ListRepositoryLabels(ctx context.Context, owner, repo string) ([]*Label, error) {
-	path := fmt.Sprintf("/repos/%s/%s/labels", owner, repo)
-	resp, err := c.doRequest(ctx, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-
 	var labels []*Label
-	if err := handleResponse(resp, &labels); err != nil {
-		return nil, err
-	}
+	for page := 1; ; page++ {
+		resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/repos/%s/%s/labels?page=%d&limit=50", owner, repo, page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var list []*Label
+		if err := handleResponse(resp, &list); err != nil {
+			return nil, err
+		}
 
-	return labels, nil
+		labels = append(labels, list...)
+		if len(list) < 50 {
+			return labels, nil
+		}
+	}
 }
 
 // Repository Collaborator API methods
@@ -1360,57 +1588,6 @@ func (c *giteaClient) ListRepositoryCollaborators(ctx context.Context, owner, re
 	return collaborators, nil
 }
 
-// Organization Settings API methods
-func (c *giteaClient) GetOrganizationSettings(ctx context.Context, org string) (*OrganizationSettings, error) {
-	// Note: This is a conceptual implementation - Gitea API may not have a single endpoint
-	// for all organization settings. This might need to aggregate multiple API calls.
-	path := fmt.Sprintf("/orgs/%s", org)
-	resp, err := c.doRequest(ctx, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	// For now, we'll get the basic organization and map what we can
-	var orgData Organization
-	if err := handleResponse(resp, &orgData); err != nil {
-		return nil, err
-	}
-
-	// Map Organization data to OrganizationSettings
-	// Note: This is a simplified mapping - real implementation would aggregate multiple endpoints
-	settings := &OrganizationSettings{
-		DefaultRepoPermission:   "read",   // Default fallback
-		MembersCanCreateRepos:   true,     // Default fallback
-		MembersCanCreatePrivate: true,     // Default fallback
-		DefaultRepoVisibility:   "public", // Default fallback
-		AllowGitHooks:           false,    // Default fallback
-	}
-
-	return settings, nil
-}
-
-func (c *giteaClient) UpdateOrganizationSettings(ctx context.Context, org string, req *UpdateOrganizationSettingsRequest) (*OrganizationSettings, error) {
-	// Note: This is a conceptual implementation - real implementation would update
-	// organization settings through appropriate Gitea admin endpoints
-	path := fmt.Sprintf("/orgs/%s", org)
-
-	// For now, we'll use the organization update endpoint as a placeholder
-	orgUpdateReq := &UpdateOrganizationRequest{}
-
-	resp, err := c.doRequest(ctx, "PATCH", path, orgUpdateReq)
-	if err != nil {
-		return nil, err
-	}
-
-	var orgData Organization
-	if err := handleResponse(resp, &orgData); err != nil {
-		return nil, err
-	}
-
-	// Return updated settings (simplified)
-	return c.GetOrganizationSettings(ctx, org)
-}
-
 // Git Hooks API methods
 func (c *giteaClient) GetGitHook(ctx context.Context, repository, hookType string) (*GitHook, error) {
 	// Parse repository format "owner/repo"
@@ -1500,22 +1677,22 @@ func (c *giteaClient) DeleteGitHook(ctx context.Context, repository, hookType st
 }
 
 // Branch Protection API methods
-func (c *giteaClient) GetBranchProtection(ctx context.Context, repository, branch string) (*BranchProtection, error) {
-	// Parse repository format "owner/repo"
-	parts := strings.Split(repository, "/")
-	if len(parts) != 2 {
-		return nil, errors.New("repository must be in format 'owner/repo'")
-	}
-	owner, repo := parts[0], parts[1]
 
-	path := fmt.Sprintf("/repos/%s/%s/branch_protections/%s", owner, repo, branch)
-	resp, err := c.doRequest(ctx, "GET", path, nil)
+// branchProtectionPath returns the API path of a branch protection rule.
+// Rule names are escaped as they may contain slashes and glob characters.
+func branchProtectionPath(owner, repo, ruleName string) string {
+	return fmt.Sprintf("/repos/%s/%s/branch_protections/%s", owner, repo, url.PathEscape(ruleName))
+}
+
+func (c *giteaClient) GetBranchProtection(ctx context.Context, repository, ruleName string) (*BranchProtection, error) {
+	owner, repo, err := SplitRepository(repository)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, errors.New("branch protection not found")
+	resp, err := c.doRequest(ctx, "GET", branchProtectionPath(owner, repo, ruleName), nil)
+	if err != nil {
+		return nil, err
 	}
 
 	var protection BranchProtection
@@ -1526,13 +1703,11 @@ func (c *giteaClient) GetBranchProtection(ctx context.Context, repository, branc
 	return &protection, nil
 }
 
-func (c *giteaClient) CreateBranchProtection(ctx context.Context, repository, branch string, req *CreateBranchProtectionRequest) (*BranchProtection, error) {
-	// Parse repository format "owner/repo"
-	parts := strings.Split(repository, "/")
-	if len(parts) != 2 {
-		return nil, errors.New("repository must be in format 'owner/repo'")
+func (c *giteaClient) CreateBranchProtection(ctx context.Context, repository string, req *CreateBranchProtectionRequest) (*BranchProtection, error) {
+	owner, repo, err := SplitRepository(repository)
+	if err != nil {
+		return nil, err
 	}
-	owner, repo := parts[0], parts[1]
 
 	path := fmt.Sprintf("/repos/%s/%s/branch_protections", owner, repo)
 	resp, err := c.doRequest(ctx, "POST", path, req)
@@ -1548,16 +1723,13 @@ func (c *giteaClient) CreateBranchProtection(ctx context.Context, repository, br
 	return &protection, nil
 }
 
-func (c *giteaClient) UpdateBranchProtection(ctx context.Context, repository, branch string, req *UpdateBranchProtectionRequest) (*BranchProtection, error) {
-	// Parse repository format "owner/repo"
-	parts := strings.Split(repository, "/")
-	if len(parts) != 2 {
-		return nil, errors.New("repository must be in format 'owner/repo'")
+func (c *giteaClient) UpdateBranchProtection(ctx context.Context, repository, ruleName string, req *UpdateBranchProtectionRequest) (*BranchProtection, error) {
+	owner, repo, err := SplitRepository(repository)
+	if err != nil {
+		return nil, err
 	}
-	owner, repo := parts[0], parts[1]
 
-	path := fmt.Sprintf("/repos/%s/%s/branch_protections/%s", owner, repo, branch)
-	resp, err := c.doRequest(ctx, "PATCH", path, req)
+	resp, err := c.doRequest(ctx, "PATCH", branchProtectionPath(owner, repo, ruleName), req)
 	if err != nil {
 		return nil, err
 	}
@@ -1570,16 +1742,13 @@ func (c *giteaClient) UpdateBranchProtection(ctx context.Context, repository, br
 	return &protection, nil
 }
 
-func (c *giteaClient) DeleteBranchProtection(ctx context.Context, repository, branch string) error {
-	// Parse repository format "owner/repo"
-	parts := strings.Split(repository, "/")
-	if len(parts) != 2 {
-		return errors.New("repository must be in format 'owner/repo'")
+func (c *giteaClient) DeleteBranchProtection(ctx context.Context, repository, ruleName string) error {
+	owner, repo, err := SplitRepository(repository)
+	if err != nil {
+		return err
 	}
-	owner, repo := parts[0], parts[1]
 
-	path := fmt.Sprintf("/repos/%s/%s/branch_protections/%s", owner, repo, branch)
-	resp, err := c.doRequest(ctx, "DELETE", path, nil)
+	resp, err := c.doRequest(ctx, "DELETE", branchProtectionPath(owner, repo, ruleName), nil)
 	if err != nil {
 		return err
 	}
@@ -1587,86 +1756,94 @@ func (c *giteaClient) DeleteBranchProtection(ctx context.Context, repository, br
 	return handleResponse(resp, nil)
 }
 
-// Repository Key API methods
-func (c *giteaClient) GetRepositoryKey(ctx context.Context, repository string, keyID int64) (*RepositoryKey, error) {
-	// Parse repository format "owner/repo"
-	parts := strings.Split(repository, "/")
-	if len(parts) != 2 {
-		return nil, errors.New("repository must be in format 'owner/repo'")
+// Tag Protection API methods
+func (c *giteaClient) ListTagProtections(ctx context.Context, repository string) ([]TagProtection, error) {
+	owner, repo, err := SplitRepository(repository)
+	if err != nil {
+		return nil, err
 	}
-	owner, repo := parts[0], parts[1]
 
-	path := fmt.Sprintf("/repos/%s/%s/keys/%d", owner, repo, keyID)
+	path := fmt.Sprintf("/repos/%s/%s/tag_protections", owner, repo)
 	resp, err := c.doRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, errors.New("repository key not found")
+	var protections []TagProtection
+	if err := handleResponse(resp, &protections); err != nil {
+		return nil, err
 	}
 
-	var key RepositoryKey
-	if err := handleResponse(resp, &key); err != nil {
+	return protections, nil
+}
+
+func (c *giteaClient) GetTagProtection(ctx context.Context, repository string, id int64) (*TagProtection, error) {
+	owner, repo, err := SplitRepository(repository)
+	if err != nil {
 		return nil, err
 	}
 
-	return &key, nil
+	path := fmt.Sprintf("/repos/%s/%s/tag_protections/%d", owner, repo, id)
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var protection TagProtection
+	if err := handleResponse(resp, &protection); err != nil {
+		return nil, err
+	}
+
+	return &protection, nil
 }
 
-func (c *giteaClient) CreateRepositoryKey(ctx context.Context, repository string, req *CreateRepositoryKeyRequest) (*RepositoryKey, error) {
-	// Parse repository format "owner/repo"
-	parts := strings.Split(repository, "/")
-	if len(parts) != 2 {
-		return nil, errors.New("repository must be in format 'owner/repo'")
+func (c *giteaClient) CreateTagProtection(ctx context.Context, repository string, req *CreateTagProtectionRequest) (*TagProtection, error) {
+	owner, repo, err := SplitRepository(repository)
+	if err != nil {
+		return nil, err
 	}
-	owner, repo := parts[0], parts[1]
 
-	path := fmt.Sprintf("/repos/%s/%s/keys", owner, repo)
+	path := fmt.Sprintf("/repos/%s/%s/tag_protections", owner, repo)
 	resp, err := c.doRequest(ctx, "POST", path, req)
 	if err != nil {
 		return nil, err
 	}
 
-	var key RepositoryKey
-	if err := handleResponse(resp, &key); err != nil {
+	var protection TagProtection
+	if err := handleResponse(resp, &protection); err != nil {
 		return nil, err
 	}
 
-	return &key, nil
+	return &protection, nil
 }
 
-func (c *giteaClient) UpdateRepositoryKey(ctx context.Context, repository string, keyID int64, req *UpdateRepositoryKeyRequest) (*RepositoryKey, error) {
-	// Parse repository format "owner/repo"
-	parts := strings.Split(repository, "/")
-	if len(parts) != 2 {
-		return nil, errors.New("repository must be in format 'owner/repo'")
+func (c *giteaClient) UpdateTagProtection(ctx context.Context, repository string, id int64, req *UpdateTagProtectionRequest) (*TagProtection, error) {
+	owner, repo, err := SplitRepository(repository)
+	if err != nil {
+		return nil, err
 	}
-	owner, repo := parts[0], parts[1]
 
-	path := fmt.Sprintf("/repos/%s/%s/keys/%d", owner, repo, keyID)
+	path := fmt.Sprintf("/repos/%s/%s/tag_protections/%d", owner, repo, id)
 	resp, err := c.doRequest(ctx, "PATCH", path, req)
 	if err != nil {
 		return nil, err
 	}
 
-	var key RepositoryKey
-	if err := handleResponse(resp, &key); err != nil {
+	var protection TagProtection
+	if err := handleResponse(resp, &protection); err != nil {
 		return nil, err
 	}
 
-	return &key, nil
+	return &protection, nil
 }
 
-func (c *giteaClient) DeleteRepositoryKey(ctx context.Context, repository string, keyID int64) error {
-	// Parse repository format "owner/repo"
-	parts := strings.Split(repository, "/")
-	if len(parts) != 2 {
-		return errors.New("repository must be in format 'owner/repo'")
+func (c *giteaClient) DeleteTagProtection(ctx context.Context, repository string, id int64) error {
+	owner, repo, err := SplitRepository(repository)
+	if err != nil {
+		return err
 	}
-	owner, repo := parts[0], parts[1]
 
-	path := fmt.Sprintf("/repos/%s/%s/keys/%d", owner, repo, keyID)
+	path := fmt.Sprintf("/repos/%s/%s/tag_protections/%d", owner, repo, id)
 	resp, err := c.doRequest(ctx, "DELETE", path, nil)
 	if err != nil {
 		return err
@@ -1675,24 +1852,117 @@ func (c *giteaClient) DeleteRepositoryKey(ctx context.Context, repository string
 	return handleResponse(resp, nil)
 }
 
-// Access Token API methods
-func (c *giteaClient) GetAccessToken(ctx context.Context, username string, tokenID int64) (*AccessToken, error) {
-	path := fmt.Sprintf("/users/%s/tokens/%d", username, tokenID)
+// Repository Key API methods
+func (c *giteaClient) GetRepositoryKey(ctx context.Context, repository string, keyID int64) (*RepositoryKey, error) {
+	// Parse repository format "owner/repo"
+	parts := strings.Split(repository, "/")
+	if len(parts) != 2 {
+		return nil, errors.New("repository must be in format 'owner/repo'")
+	}
+	owner, repo := parts[0], parts[1]
+
+	path := fmt.Sprintf("/repos/%s/%s/keys/%d", owner, repo, keyID)
 	resp, err := c.doRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, errors.New("access token not found")
+		_ = resp.Body.Close()
+		return nil, NewNotFoundError("repository key", fmt.Sprintf("%d", keyID))
 	}
 
-	var token AccessToken
-	if err := handleResponse(resp, &token); err != nil {
+	var key RepositoryKey
+	if err := handleResponse(resp, &key); err != nil {
 		return nil, err
 	}
 
-	return &token, nil
+	return &key, nil
+}
+
+func (c *giteaClient) CreateRepositoryKey(ctx context.Context, repository string, req *CreateRepositoryKeyRequest) (*RepositoryKey, error) {
+	// Parse repository format "owner/repo"
+	parts := strings.Split(repository, "/")
+	if len(parts) != 2 {
+		return nil, errors.New("repository must be in format 'owner/repo'")
+	}
+	owner, repo := parts[0], parts[1]
+
+	path := fmt.Sprintf("/repos/%s/%s/keys", owner, repo)
+	resp, err := c.doRequest(ctx, "POST", path, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var key RepositoryKey
+	if err := handleResponse(resp, &key); err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+func (c *giteaClient) UpdateRepositoryKey(ctx context.Context, repository string, keyID int64, req *UpdateRepositoryKeyRequest) (*RepositoryKey, error) {
+	// Parse repository format "owner/repo"
+	parts := strings.Split(repository, "/")
+	if len(parts) != 2 {
+		return nil, errors.New("repository must be in format 'owner/repo'")
+	}
+	owner, repo := parts[0], parts[1]
+
+	path := fmt.Sprintf("/repos/%s/%s/keys/%d", owner, repo, keyID)
+	resp, err := c.doRequest(ctx, "PATCH", path, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var key RepositoryKey
+	if err := handleResponse(resp, &key); err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+func (c *giteaClient) DeleteRepositoryKey(ctx context.Context, repository string, keyID int64) error {
+	// Parse repository format "owner/repo"
+	parts := strings.Split(repository, "/")
+	if len(parts) != 2 {
+		return errors.New("repository must be in format 'owner/repo'")
+	}
+	owner, repo := parts[0], parts[1]
+
+	path := fmt.Sprintf("/repos/%s/%s/keys/%d", owner, repo, keyID)
+	resp, err := c.doRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	return handleResponse(resp, nil)
+}
+
+// Access Token API methods
+func (c *giteaClient) GetAccessToken(ctx context.Context, username string, tokenID int64) (*AccessToken, error) {
+	// Gitea has no endpoint for a single token, so list the user's tokens
+	// and pick the requested one.
+	path := fmt.Sprintf("/users/%s/tokens", username)
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []*AccessToken
+	if err := handleResponse(resp, &tokens); err != nil {
+		return nil, err
+	}
+
+	for _, token := range tokens {
+		if token.ID == tokenID {
+			return token, nil
+		}
+	}
+
+	return nil, NewNotFoundError("access token", fmt.Sprintf("%d", tokenID))
 }
 
 func (c *giteaClient) CreateAccessToken(ctx context.Context, username string, req *CreateAccessTokenRequest) (*AccessToken, error) {
@@ -1815,26 +2085,29 @@ func (c *giteaClient) DeleteRepositorySecret(ctx context.Context, repository, se
 
 // User Key API methods
 func (c *giteaClient) GetUserKey(ctx context.Context, username string, keyID int64) (*UserKey, error) {
-	path := fmt.Sprintf("/users/%s/keys/%d", username, keyID)
+	// Gitea only lists the public keys of other users, so find the key in the list
+	path := fmt.Sprintf("/users/%s/keys", username)
 	resp, err := c.doRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, errors.New("user key not found")
+	var keys []UserKey
+	if err := handleResponse(resp, &keys); err != nil {
+		return nil, err
 	}
 
-	var key UserKey
-	if err := handleResponse(resp, &key); err != nil {
-		return nil, err
+	for i := range keys {
+		if keys[i].ID == keyID {
+			return &keys[i], nil
+		}
 	}
 
-	return &key, nil
+	return nil, NewNotFoundError("user key", fmt.Sprintf("%d", keyID))
 }
 
 func (c *giteaClient) CreateUserKey(ctx context.Context, username string, req *CreateUserKeyRequest) (*UserKey, error) {
-	path := fmt.Sprintf("/users/%s/keys", username)
+	path := fmt.Sprintf("/admin/users/%s/keys", username)
 	resp, err := c.doRequest(ctx, "POST", path, req)
 	if err != nil {
 		return nil, err
@@ -1864,7 +2137,7 @@ func (c *giteaClient) UpdateUserKey(ctx context.Context, username string, keyID
 }
 
 func (c *giteaClient) DeleteUserKey(ctx context.Context, username string, keyID int64) error {
-	path := fmt.Sprintf("/users/%s/keys/%d", username, keyID)
+	path := fmt.Sprintf("/admin/users/%s/keys/%d", username, keyID)
 	resp, err := c.doRequest(ctx, "DELETE", path, nil)
 	if err != nil {
 		return err
@@ -1874,53 +2147,53 @@ func (c *giteaClient) DeleteUserKey(ctx context.Context, username string, keyID
 }
 
 // Organization Member API methods
-func (c *giteaClient) GetOrganizationMember(ctx context.Context, org, username string) (*OrganizationMember, error) {
-	path := fmt.Sprintf("/orgs/%s/members/%s", org, username)
-	resp, err := c.doRequest(ctx, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, errors.New("organization member not found")
-	}
-
-	var member OrganizationMember
-	if err := handleResponse(resp, &member); err != nil {
-		return nil, err
-	}
+// IsOrganizationMember reports whether the user is a member of the
+// organization, through any of its teams.
+func (c *giteaClient) IsOrganizationMember(ctx context.Context, org, username string) (bool, error) {
+	path := fmt.Sprintf("/orgs/%s/members/%s", org, url.PathEscape(username))
+	return c.checkMembership(ctx, path)
+}
 
-	return &member, nil
+// IsPublicOrganizationMember reports whether the user's membership of the
+// organization is public.
+func (c *giteaClient) IsPublicOrganizationMember(ctx context.Context, org, username string) (bool, error) {
+	path := fmt.Sprintf("/orgs/%s/public_members/%s", org, url.PathEscape(username))
+	return c.checkMembership(ctx, path)
 }
 
-func (c *giteaClient) AddOrganizationMember(ctx context.Context, org, username string, req *AddOrganizationMemberRequest) (*OrganizationMember, error) {
-	path := fmt.Sprintf("/orgs/%s/members/%s", org, username)
-	resp, err := c.doRequest(ctx, "PUT", path, req)
-	if err != nil {
-		return nil, err
+// SetOrganizationMemberVisibility publicizes or conceals the user's
+// membership of the organization. Gitea only lets users change their own
+// visibility, so the request is made as the user.
+func (c *giteaClient) SetOrganizationMemberVisibility(ctx context.Context, org, username string, public bool) error {
+	method := "DELETE"
+	if public {
+		method = "PUT"
 	}
-
-	var member OrganizationMember
-	if err := handleResponse(resp, &member); err != nil {
-		return nil, err
+	path := fmt.Sprintf("/orgs/%s/public_members/%s", org, url.PathEscape(username))
+	resp, err := c.doRequest(withSudo(ctx, username), method, path, nil)
+	if err != nil {
+		return err
 	}
 
-	return &member, nil
+	return handleResponse(resp, nil)
 }
 
-func (c *giteaClient) UpdateOrganizationMember(ctx context.Context, org, username string, req *UpdateOrganizationMemberRequest) (*OrganizationMember, error) {
-	path := fmt.Sprintf("/orgs/%s/members/%s", org, username)
-	resp, err := c.doRequest(ctx, "PATCH", path, req)
+// checkMembership calls a membership check endpoint, which answers 204 for
+// members and 404 otherwise.
+func (c *giteaClient) checkMembership(ctx context.Context, path string) (bool, error) {
+	resp, err := c.doRequest(ctx, "GET", path, nil)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
 
-	var member OrganizationMember
-	if err := handleResponse(resp, &member); err != nil {
-		return nil, err
+	if err := handleResponse(resp, nil); err != nil {
+		if IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
 	}
-
-	return &member, nil
+	return true, nil
 }
 
 func (c *giteaClient) RemoveOrganizationMember(ctx context.Context, org, username string) error {
@@ -1934,104 +2207,136 @@ func (c *giteaClient) RemoveOrganizationMember(ctx context.Context, org, usernam
 }
 
 // Action API methods
-func (c *giteaClient) GetAction(ctx context.Context, repository, workflowName string) (*Action, error) {
-	// Parse repository format "owner/repo"
+
+// ActionWorkflowPath returns the repository path of a workflow file
+func ActionWorkflowPath(workflowName string) string {
+	return ".gitea/workflows/" + workflowName
+}
+
+// SplitRepository splits a repository in "owner/repo" format
+func SplitRepository(repository string) (string, string, error) {
 	parts := strings.Split(repository, "/")
 	if len(parts) != 2 {
-		return nil, errors.New("repository must be in format 'owner/repo'")
+		return "", "", errors.New("repository must be in format 'owner/repo'")
 	}
-	owner, repo := parts[0], parts[1]
+	return parts[0], parts[1], nil
+}
 
-	path := fmt.Sprintf("/repos/%s/%s/actions/workflows/%s", owner, repo, workflowName)
-	resp, err := c.doRequest(ctx, "GET", path, nil)
+func (c *giteaClient) GetAction(ctx context.Context, repository, workflowName, branch string) (*Action, error) {
+	owner, repo, err := SplitRepository(repository)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, errors.New("action workflow not found")
+	file, err := c.GetRepositoryFile(ctx, owner, repo, ActionWorkflowPath(workflowName), branch)
+	if err != nil {
+		return nil, err
 	}
 
-	var action Action
-	if err := handleResponse(resp, &action); err != nil {
-		return nil, err
+	action := &Action{
+		WorkflowName: workflowName,
+		Path:         file.Path,
+		SHA:          file.SHA,
+		HTMLURL:      file.HTMLURL,
+	}
+	if file.Content != nil {
+		content := []byte(*file.Content)
+		if file.Encoding != nil && *file.Encoding == "base64" {
+			content, err = base64.StdEncoding.DecodeString(strings.ReplaceAll(*file.Content, "\n", ""))
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to decode workflow content")
+			}
+		}
+		action.Content = string(content)
 	}
 
-	return &action, nil
+	return action, nil
 }
 
 func (c *giteaClient) CreateAction(ctx context.Context, repository string, req *CreateActionRequest) (*Action, error) {
-	// Parse repository format "owner/repo"
-	parts := strings.Split(repository, "/")
-	if len(parts) != 2 {
-		return nil, errors.New("repository must be in format 'owner/repo'")
-	}
-	owner, repo := parts[0], parts[1]
-
-	// For Gitea, creating a workflow typically involves creating a file in .github/workflows/
-	// This is a conceptual implementation - actual API might differ
-	path := fmt.Sprintf("/repos/%s/%s/actions/workflows", owner, repo)
-	resp, err := c.doRequest(ctx, "POST", path, req)
+	owner, repo, err := SplitRepository(repository)
 	if err != nil {
 		return nil, err
 	}
 
-	var action Action
-	if err := handleResponse(resp, &action); err != nil {
+	file, err := c.CreateRepositoryFile(ctx, owner, repo, ActionWorkflowPath(req.WorkflowName), &CreateFileOptions{
+		FileOptions: FileOptions{Message: req.Message, Branch: req.Branch},
+		Content:     base64.StdEncoding.EncodeToString([]byte(req.Content)),
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return &action, nil
+	return actionFromFile(req.WorkflowName, req.Content, file), nil
 }
 
 func (c *giteaClient) UpdateAction(ctx context.Context, repository, workflowName string, req *UpdateActionRequest) (*Action, error) {
-	// Parse repository format "owner/repo"
-	parts := strings.Split(repository, "/")
-	if len(parts) != 2 {
-		return nil, errors.New("repository must be in format 'owner/repo'")
+	owner, repo, err := SplitRepository(repository)
+	if err != nil {
+		return nil, err
 	}
-	owner, repo := parts[0], parts[1]
 
-	path := fmt.Sprintf("/repos/%s/%s/actions/workflows/%s", owner, repo, workflowName)
-	resp, err := c.doRequest(ctx, "PATCH", path, req)
+	file, err := c.UpdateRepositoryFile(ctx, owner, repo, ActionWorkflowPath(workflowName), &UpdateFileOptions{
+		FileOptions: FileOptions{Message: req.Message, Branch: req.Branch},
+		Content:     base64.StdEncoding.EncodeToString([]byte(req.Content)),
+		SHA:         req.SHA,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var action Action
-	if err := handleResponse(resp, &action); err != nil {
-		return nil, err
+	return actionFromFile(workflowName, req.Content, file), nil
+}
+
+func (c *giteaClient) DeleteAction(ctx context.Context, repository, workflowName string, req *DeleteActionRequest) error {
+	owner, repo, err := SplitRepository(repository)
+	if err != nil {
+		return err
 	}
 
-	return &action, nil
+	return c.DeleteRepositoryFile(ctx, owner, repo, ActionWorkflowPath(workflowName), &DeleteFileOptions{
+		FileOptions: FileOptions{Message: req.Message, Branch: req.Branch},
+		SHA:         req.SHA,
+	})
 }
 
-func (c *giteaClient) DeleteAction(ctx context.Context, repository, workflowName string) error {
-	// Parse repository format "owner/repo"
-	parts := strings.Split(repository, "/")
-	if len(parts) != 2 {
-		return errors.New("repository must be in format 'owner/repo'")
+// actionFromFile builds an Action from the response to a contents API write
+func actionFromFile(workflowName, content string, file *FileResponse) *Action {
+	action := &Action{WorkflowName: workflowName, Path: ActionWorkflowPath(workflowName), Content: content}
+	if file.Content != nil {
+		action.SHA = file.Content.SHA
+		action.HTMLURL = file.Content.HTMLURL
 	}
-	owner, repo := parts[0], parts[1]
+	return action
+}
 
-	path := fmt.Sprintf("/repos/%s/%s/actions/workflows/%s", owner, repo, workflowName)
-	resp, err := c.doRequest(ctx, "DELETE", path, nil)
+func (c *giteaClient) GetActionWorkflow(ctx context.Context, repository, workflowName string) (*ActionWorkflow, error) {
+	owner, repo, err := SplitRepository(repository)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return handleResponse(resp, nil)
+	path := fmt.Sprintf("/repos/%s/%s/actions/workflows/%s", owner, repo, url.PathEscape(workflowName))
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var workflow ActionWorkflow
+	if err := handleResponse(resp, &workflow); err != nil {
+		return nil, err
+	}
+
+	return &workflow, nil
 }
 
 func (c *giteaClient) EnableAction(ctx context.Context, repository, workflowName string) error {
-	// Parse repository format "owner/repo"
-	parts := strings.Split(repository, "/")
-	if len(parts) != 2 {
-		return errors.New("repository must be in format 'owner/repo'")
+	owner, repo, err := SplitRepository(repository)
+	if err != nil {
+		return err
 	}
-	owner, repo := parts[0], parts[1]
 
-	path := fmt.Sprintf("/repos/%s/%s/actions/workflows/%s/enable", owner, repo, workflowName)
+	path := fmt.Sprintf("/repos/%s/%s/actions/workflows/%s/enable", owner, repo, url.PathEscape(workflowName))
 	resp, err := c.doRequest(ctx, "PUT", path, nil)
 	if err != nil {
 		return err
@@ -2041,14 +2346,12 @@ func (c *giteaClient) EnableAction(ctx context.Context, repository, workflowName
 }
 
 func (c *giteaClient) DisableAction(ctx context.Context, repository, workflowName string) error {
-	// Parse repository format "owner/repo"
-	parts := strings.Split(repository, "/")
-	if len(parts) != 2 {
-		return errors.New("repository must be in format 'owner/repo'")
+	owner, repo, err := SplitRepository(repository)
+	if err != nil {
+		return err
 	}
-	owner, repo := parts[0], parts[1]
 
-	path := fmt.Sprintf("/repos/%s/%s/actions/workflows/%s/disable", owner, repo, workflowName)
+	path := fmt.Sprintf("/repos/%s/%s/actions/workflows/%s/disable", owner, repo, url.PathEscape(workflowName))
 	resp, err := c.doRequest(ctx, "PUT", path, nil)
 	if err != nil {
 		return err
@@ -2057,95 +2360,141 @@ func (c *giteaClient) DisableAction(ctx context.Context, repository, workflowNam
 	return handleResponse(resp, nil)
 }
 
-// Runner API methods
-func (c *giteaClient) GetRunner(ctx context.Context, scope, scopeValue string, runnerID int64) (*Runner, error) {
-	var path string
-	switch scope {
-	case "repository":
-		// Parse repository format "owner/repo"
-		parts := strings.Split(scopeValue, "/")
-		if len(parts) != 2 {
-			return nil, errors.New("scopeValue must be in format 'owner/repo' for repository scope")
+// GetActionLastRun returns the most recent run of a workflow, or nil if it
+// has never run.
+func (c *giteaClient) GetActionLastRun(ctx context.Context, repository, workflowName string) (*ActionRun, error) {
+	runs, err := c.ListActionRuns(ctx, repository, workflowName, "")
+	if err != nil || len(runs) == 0 {
+		return nil, err
+	}
+	return &runs[0], nil
+}
+
+// ListActionRuns returns the most recent runs of a workflow, newest first,
+// optionally limited to runs triggered by event. Runs are matched on their
+// "<workflow>@<ref>" path. The runs of the repository are listed a page at a
+// time until a page holds runs of the workflow, so other busy workflows do
+// not hide them.
+func (c *giteaClient) ListActionRuns(ctx context.Context, repository, workflowName, event string) ([]ActionRun, error) {
+	owner, repo, err := SplitRepository(repository)
+	if err != nil {
+		return nil, err
+	}
+
+	base := fmt.Sprintf("/repos/%s/%s/actions/runs?limit=50", owner, repo)
+	if event != "" {
+		base += "&event=" + url.QueryEscape(event)
+	}
+
+	var runs []ActionRun
+	for page := 1; ; page++ {
+		resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s&page=%d", base, page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var list ActionRunList
+		if err := handleResponse(resp, &list); err != nil {
+			return nil, err
+		}
+
+		for _, run := range list.WorkflowRuns {
+			workflow, _, _ := strings.Cut(run.Path, "@")
+			if workflow == workflowName || workflow == ActionWorkflowPath(workflowName) {
+				runs = append(runs, run)
+			}
+		}
+		if len(runs) > 0 || len(list.WorkflowRuns) < 50 {
+			return runs, nil
 		}
-		owner, repo := parts[0], parts[1]
-		path = fmt.Sprintf("/repos/%s/%s/actions/runners/%d", owner, repo, runnerID)
-	case "organization":
-		path = fmt.Sprintf("/orgs/%s/actions/runners/%d", scopeValue, runnerID)
-	case "system":
-		path = fmt.Sprintf("/admin/actions/runners/%d", runnerID)
-	default:
-		return nil, errors.New("scope must be one of: repository, organization, system")
 	}
+}
 
-	resp, err := c.doRequest(ctx, "GET", path, nil)
+// GetActionRun retrieves a workflow run
+func (c *giteaClient) GetActionRun(ctx context.Context, repository string, runID int64) (*ActionRun, error) {
+	owner, repo, err := SplitRepository(repository)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, errors.New("runner not found")
+	path := fmt.Sprintf("/repos/%s/%s/actions/runs/%d", owner, repo, runID)
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	var runner Runner
-	if err := handleResponse(resp, &runner); err != nil {
+	var run ActionRun
+	if err := handleResponse(resp, &run); err != nil {
 		return nil, err
 	}
 
-	return &runner, nil
+	return &run, nil
 }
 
-func (c *giteaClient) CreateRunner(ctx context.Context, scope, scopeValue string, req *CreateRunnerRequest) (*Runner, error) {
-	var path string
-	switch scope {
-	case "repository":
-		// Parse repository format "owner/repo"
-		parts := strings.Split(scopeValue, "/")
-		if len(parts) != 2 {
-			return nil, errors.New("scopeValue must be in format 'owner/repo' for repository scope")
-		}
-		owner, repo := parts[0], parts[1]
-		path = fmt.Sprintf("/repos/%s/%s/actions/runners", owner, repo)
-	case "organization":
-		path = fmt.Sprintf("/orgs/%s/actions/runners", scopeValue)
-	case "system":
-		path = "/admin/actions/runners"
-	default:
-		return nil, errors.New("scope must be one of: repository, organization, system")
+// ListActionRunJobs returns the jobs of a workflow run
+func (c *giteaClient) ListActionRunJobs(ctx context.Context, repository string, runID int64) ([]ActionJob, error) {
+	owner, repo, err := SplitRepository(repository)
+	if err != nil {
+		return nil, err
 	}
 
-	resp, err := c.doRequest(ctx, "POST", path, req)
+	path := fmt.Sprintf("/repos/%s/%s/actions/runs/%d/jobs", owner, repo, runID)
+	resp, err := c.doRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	var runner Runner
-	if err := handleResponse(resp, &runner); err != nil {
+	var list ActionJobList
+	if err := handleResponse(resp, &list); err != nil {
 		return nil, err
 	}
 
-	return &runner, nil
+	return list.Jobs, nil
+}
+
+// DispatchWorkflow triggers a workflow_dispatch run of a workflow
+func (c *giteaClient) DispatchWorkflow(ctx context.Context, repository, workflowName string, req *DispatchWorkflowRequest) error {
+	owner, repo, err := SplitRepository(repository)
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/repos/%s/%s/actions/workflows/%s/dispatches", owner, repo, url.PathEscape(workflowName))
+	resp, err := c.doRequest(ctx, "POST", path, req)
+	if err != nil {
+		return err
+	}
+
+	return handleResponse(resp, nil)
 }
 
-func (c *giteaClient) UpdateRunner(ctx context.Context, scope, scopeValue string, runnerID int64, req *UpdateRunnerRequest) (*Runner, error) {
-	var path string
+// Runner API methods
+
+// runnersPath returns the runners endpoint for a scope
+func runnersPath(scope, scopeValue string) (string, error) {
 	switch scope {
 	case "repository":
-		// Parse repository format "owner/repo"
-		parts := strings.Split(scopeValue, "/")
-		if len(parts) != 2 {
-			return nil, errors.New("scopeValue must be in format 'owner/repo' for repository scope")
+		owner, repo, err := SplitRepository(scopeValue)
+		if err != nil {
+			return "", errors.New("scopeValue must be in format 'owner/repo' for repository scope")
 		}
-		owner, repo := parts[0], parts[1]
-		path = fmt.Sprintf("/repos/%s/%s/actions/runners/%d", owner, repo, runnerID)
+		return fmt.Sprintf("/repos/%s/%s/actions/runners", owner, repo), nil
 	case "organization":
-		path = fmt.Sprintf("/orgs/%s/actions/runners/%d", scopeValue, runnerID)
+		return fmt.Sprintf("/orgs/%s/actions/runners", scopeValue), nil
 	case "system":
-		path = fmt.Sprintf("/admin/actions/runners/%d", runnerID)
+		return "/admin/actions/runners", nil
 	default:
-		return nil, errors.New("scope must be one of: repository, organization, system")
+		return "", errors.New("scope must be one of: repository, organization, system")
 	}
+}
 
-	resp, err := c.doRequest(ctx, "PATCH", path, req)
+func (c *giteaClient) GetRunner(ctx context.Context, scope, scopeValue string, runnerID int64) (*Runner, error) {
+	base, err := runnersPath(scope, scopeValue)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/%d", base, runnerID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -2158,26 +2507,38 @@ func (c *giteaClient) UpdateRunner(ctx context.Context, scope, scopeValue string
 	return &runner, nil
 }
 
-func (c *giteaClient) DeleteRunner(ctx context.Context, scope, scopeValue string, runnerID int64) error {
-	var path string
-	switch scope {
-	case "repository":
-		// Parse repository format "owner/repo"
-		parts := strings.Split(scopeValue, "/")
-		if len(parts) != 2 {
-			return errors.New("scopeValue must be in format 'owner/repo' for repository scope")
+func (c *giteaClient) ListRunners(ctx context.Context, scope, scopeValue string) ([]Runner, error) {
+	base, err := runnersPath(scope, scopeValue)
+	if err != nil {
+		return nil, err
+	}
+
+	var runners []Runner
+	for page := 1; ; page++ {
+		resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s?page=%d&limit=50", base, page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var list RunnerList
+		if err := handleResponse(resp, &list); err != nil {
+			return nil, err
+		}
+
+		runners = append(runners, list.Runners...)
+		if len(list.Runners) == 0 || int64(len(runners)) >= list.TotalCount {
+			return runners, nil
 		}
-		owner, repo := parts[0], parts[1]
-		path = fmt.Sprintf("/repos/%s/%s/actions/runners/%d", owner, repo, runnerID)
-	case "organization":
-		path = fmt.Sprintf("/orgs/%s/actions/runners/%d", scopeValue, runnerID)
-	case "system":
-		path = fmt.Sprintf("/admin/actions/runners/%d", runnerID)
-	default:
-		return errors.New("scope must be one of: repository, organization, system")
 	}
+}
 
-	resp, err := c.doRequest(ctx, "DELETE", path, nil)
+func (c *giteaClient) DeleteRunner(ctx context.Context, scope, scopeValue string, runnerID int64) error {
+	base, err := runnersPath(scope, scopeValue)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("%s/%d", base, runnerID), nil)
 	if err != nil {
 		return err
 	}
@@ -2185,6 +2546,36 @@ func (c *giteaClient) DeleteRunner(ctx context.Context, scope, scopeValue string
 	return handleResponse(resp, nil)
 }
 
+// GetRunnerRegistrationToken returns the active registration token of a
+// scope. Gitea issues one if the scope has none.
+func (c *giteaClient) GetRunnerRegistrationToken(ctx context.Context, scope, scopeValue string) (*RunnerRegistrationToken, error) {
+	return c.runnerRegistrationToken(ctx, "GET", scope, scopeValue)
+}
+
+// CreateRunnerRegistrationToken requests a new registration token for a scope
+func (c *giteaClient) CreateRunnerRegistrationToken(ctx context.Context, scope, scopeValue string) (*RunnerRegistrationToken, error) {
+	return c.runnerRegistrationToken(ctx, "POST", scope, scopeValue)
+}
+
+func (c *giteaClient) runnerRegistrationToken(ctx context.Context, method, scope, scopeValue string) (*RunnerRegistrationToken, error) {
+	base, err := runnersPath(scope, scopeValue)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, method, base+"/registration-token", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var token RunnerRegistrationToken
+	if err := handleResponse(resp, &token); err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
 // Admin User API methods
 func (c *giteaClient) GetAdminUser(ctx context.Context, username string) (*AdminUser, error) {
 	path := fmt.Sprintf("/admin/users/%s", username)
@@ -2414,6 +2805,26 @@ func (c *giteaClient) GetReleaseByTag(ctx context.Context, owner, repo, tag stri
 	return &release, nil
 }
 
+// ListReleases lists the releases of a repository, newest first
+func (c *giteaClient) ListReleases(ctx context.Context, owner, repo string) ([]*Release, error) {
+	var releases []*Release
+	for page := 1; ; page++ {
+		resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/repos/%s/%s/releases?page=%d&limit=50", owner, repo, page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var list []*Release
+		if err := handleResponse(resp, &list); err != nil {
+			return nil, err
+		}
+		releases = append(releases, list...)
+		if len(list) < 50 {
+			return releases, nil
+		}
+	}
+}
+
 func (c *giteaClient) CreateRelease(ctx context.Context, owner, repo string, req *CreateReleaseOptions) (*Release, error) {
 	path := fmt.Sprintf("/repos/%s/%s/releases", owner, repo)
 	resp, err := c.doRequest(ctx, "POST", path, req)
@@ -2454,20 +2865,31 @@ func (c *giteaClient) DeleteRelease(ctx context.Context, owner, repo string, id
 	return handleResponse(resp, nil)
 }
 
-func (c *giteaClient) CreateReleaseAttachment(ctx context.Context, owner, repo string, releaseID int64, filename, contentType string, content []byte) (*ReleaseAttachment, error) {
-	// For simplicity, we'll implement a basic version
-	// In a full implementation, this would handle multipart file uploads
-	path := fmt.Sprintf("/repos/%s/%s/releases/%d/assets", owner, repo, releaseID)
-
-	// This is a simplified implementation - real implementation would use multipart upload
-	req := map[string]interface{}{
-		"name":         filename,
-		"content_type": contentType,
-		// Note: Real implementation would handle file upload differently
-	}
+// CreateReleaseAttachment streams content to the release as a
+// multipart/form-data upload. Uploads can outlast the client timeout, so they
+// are only bounded by ctx.
+func (c *giteaClient) CreateReleaseAttachment(ctx context.Context, owner, repo string, releaseID int64, filename, contentType string, content io.Reader) (*ReleaseAttachment, error) {
+	path := fmt.Sprintf("/repos/%s/%s/releases/%d/assets?name=%s", owner, repo, releaseID, url.QueryEscape(filename))
+
+	body, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	go func() {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="attachment"; filename="%s"`, strings.ReplaceAll(filename, `"`, `\"`)))
+		header.Set("Content-Type", contentType)
+		part, err := form.CreatePart(header)
+		if err == nil {
+			_, err = io.Copy(part, content)
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		_ = writer.CloseWithError(err)
+	}()
 
-	resp, err := c.doRequest(ctx, "POST", path, req)
+	resp, err := c.doRawRequest(withoutTimeout(ctx), "POST", path, body, form.FormDataContentType())
 	if err != nil {
+		_ = body.CloseWithError(err)
 		return nil, err
 	}
 
@@ -2533,16 +2955,56 @@ func (c *giteaClient) doRequest(ctx context.Context, method, path string, body i
 		bodyReader = bytes.NewReader(jsonBody)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bodyReader)
+	return c.doRawRequest(ctx, method, path, bodyReader, "application/json")
+}
+
+// sudoKey is the context key for the user requests are made on behalf of
+type sudoKey struct{}
+
+// withSudo makes requests using ctx run as username through the Sudo header,
+// which Gitea honours for admin tokens. It is how the /user endpoints, which
+// always act on the authenticated user, are used for other users. An empty
+// username leaves ctx acting as the authenticated user.
+func withSudo(ctx context.Context, username string) context.Context {
+	if username == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, sudoKey{}, username)
+}
+
+// unboundedKey is the context key marking requests exempt from the client
+// timeout
+type unboundedKey struct{}
+
+// withoutTimeout exempts requests using ctx from the client timeout, leaving
+// them bounded by ctx alone. It is meant for transfers whose duration depends
+// on their size.
+func withoutTimeout(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unboundedKey{}, true)
+}
+
+// doRawRequest performs an HTTP request with a body of the given content type
+func (c *giteaClient) doRawRequest(ctx context.Context, method, path string, body io.Reader, contentType string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create request")
 	}
 
 	req.Header.Set("Authorization", "token "+c.token)
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", "application/json")
+	if username, ok := ctx.Value(sudoKey{}).(string); ok {
+		req.Header.Set("Sudo", username)
+	}
+
+	httpClient := c.httpClient
+	if _, ok := ctx.Value(unboundedKey{}).(bool); ok {
+		unbounded := *c.httpClient
+		unbounded.Timeout = 0
+		httpClient = &unbounded
+	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to perform request")
 	}
@@ -19,6 +19,7 @@ package clients
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -31,6 +32,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"strings"
 	"testing"
+	"time"
 
 	v1beta1 "github.com/rossigee/provider-gitea/apis/v1beta1"
 )
@@ -191,6 +193,12 @@ func TestRepositoryOperations(t *testing.T) {
 			}`))
 		case r.Method == "DELETE" && r.URL.Path == "/api/v1/repos/testorg/testrepo":
 			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "POST" && r.URL.Path == "/api/v1/repos/testorg/testrepo/transfer":
+			var req TransferRepositoryRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = fmt.Fprintf(w, `{"id": 123, "name": "testrepo", "full_name": "%s/testrepo"}`, req.NewOwner)
 		default:
 			w.WriteHeader(http.StatusNotFound)
 		}
@@ -241,6 +249,12 @@ func TestRepositoryOperations(t *testing.T) {
 		err := c.DeleteRepository(ctx, "testorg", "testrepo")
 		require.NoError(t, err)
 	})
+
+	t.Run("TransferRepository", func(t *testing.T) {
+		repo, err := c.TransferRepository(ctx, "testorg", "testrepo", &TransferRepositoryRequest{NewOwner: "graveyard"})
+		require.NoError(t, err)
+		assert.Equal(t, "graveyard/testrepo", repo.FullName)
+	})
 }
 
 func TestWebhookOperations(t *testing.T) {
@@ -594,6 +608,1323 @@ func TestDeployKeyOperations(t *testing.T) {
 	})
 }
 
+func TestUserKeyOperations(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/users/testuser/keys":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`[
+				{"id": 1, "key": "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIA...", "title": "laptop"},
+				{"id": 2, "key": "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIB...", "title": "ci"}
+			]`))
+		case r.Method == "POST" && r.URL.Path == "/api/v1/admin/users/testuser/keys":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": 3, "key": "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIC...", "title": "new"}`))
+		case r.Method == "DELETE" && r.URL.Path == "/api/v1/admin/users/testuser/keys/2":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Create test client
+	c := &giteaClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL + "/api/v1",
+		token:      "test-token",
+	}
+
+	ctx := context.Background()
+
+	t.Run("GetUserKey", func(t *testing.T) {
+		key, err := c.GetUserKey(ctx, "testuser", 2)
+		require.NoError(t, err)
+		assert.Equal(t, "ci", key.Title)
+	})
+
+	t.Run("GetUserKeyNotFound", func(t *testing.T) {
+		_, err := c.GetUserKey(ctx, "testuser", 9)
+		require.Error(t, err)
+		assert.True(t, IsNotFound(err))
+	})
+
+	t.Run("CreateUserKey", func(t *testing.T) {
+		key, err := c.CreateUserKey(ctx, "testuser", &CreateUserKeyRequest{
+			Title: "new",
+			Key:   "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIC...",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), key.ID)
+	})
+
+	t.Run("DeleteUserKey", func(t *testing.T) {
+		require.NoError(t, c.DeleteUserKey(ctx, "testuser", 2))
+	})
+}
+
+func TestRepositoryFileOperations(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/repos/testorg/testrepo/contents/deploy/app config.yaml":
+			assert.Equal(t, "ref=main", r.URL.RawQuery)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"name": "app config.yaml", "path": "deploy/app config.yaml", "sha": "abc123", "type": "file", "encoding": "base64", "content": "aGVsbG8K"}`))
+		case r.Method == "POST" && r.URL.Path == "/api/v1/repos/testorg/testrepo/contents/README.md":
+			var body CreateFileOptions
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "main", body.Branch)
+			assert.Equal(t, "update-readme", body.NewBranch)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"content": {"path": "README.md", "sha": "def456"}, "commit": {"sha": "c1"}}`))
+		case r.Method == "PUT" && r.URL.Path == "/api/v1/repos/testorg/testrepo/contents/README.md":
+			var body UpdateFileOptions
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			if body.SHA != "def456" {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"content": {"path": "README.md", "sha": "0a1b2c"}, "commit": {"sha": "c2"}}`))
+		case r.Method == "DELETE" && r.URL.Path == "/api/v1/repos/testorg/testrepo/contents/README.md":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"commit": {"sha": "c3"}}`))
+		case r.Method == "GET" && r.URL.Path == "/api/v1/repos/testorg/testrepo/pulls/main/update-readme":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id": 10, "number": 4, "state": "open", "html_url": "https://gitea.example.com/testorg/testrepo/pulls/4"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Create test client
+	c := &giteaClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL + "/api/v1",
+		token:      "test-token",
+	}
+
+	ctx := context.Background()
+
+	t.Run("GetRepositoryFile", func(t *testing.T) {
+		file, err := c.GetRepositoryFile(ctx, "testorg", "testrepo", "/deploy/app config.yaml", "main")
+		require.NoError(t, err)
+		assert.Equal(t, "abc123", file.SHA)
+		assert.Equal(t, "aGVsbG8K", *file.Content)
+	})
+
+	t.Run("CreateRepositoryFile", func(t *testing.T) {
+		file, err := c.CreateRepositoryFile(ctx, "testorg", "testrepo", "README.md", &CreateFileOptions{
+			FileOptions: FileOptions{Message: "Add README", Branch: "main", NewBranch: "update-readme"},
+			Content:     "aGVsbG8K",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "def456", file.Content.SHA)
+	})
+
+	t.Run("UpdateRepositoryFile", func(t *testing.T) {
+		file, err := c.UpdateRepositoryFile(ctx, "testorg", "testrepo", "README.md", &UpdateFileOptions{
+			FileOptions: FileOptions{Message: "Update README"},
+			Content:     "aGVsbG8K",
+			SHA:         "def456",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "0a1b2c", file.Content.SHA)
+	})
+
+	t.Run("UpdateRepositoryFileStaleSHA", func(t *testing.T) {
+		_, err := c.UpdateRepositoryFile(ctx, "testorg", "testrepo", "README.md", &UpdateFileOptions{
+			Content: "aGVsbG8K",
+			SHA:     "stale",
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("DeleteRepositoryFile", func(t *testing.T) {
+		require.NoError(t, c.DeleteRepositoryFile(ctx, "testorg", "testrepo", "README.md", &DeleteFileOptions{SHA: "0a1b2c"}))
+	})
+
+	t.Run("GetRepositoryBranchNotFound", func(t *testing.T) {
+		_, err := c.GetRepositoryBranch(ctx, "testorg", "testrepo", "update-readme")
+		assert.Error(t, err)
+	})
+
+	t.Run("GetPullRequestByBranches", func(t *testing.T) {
+		pr, err := c.GetPullRequestByBranches(ctx, "testorg", "testrepo", "main", "update-readme")
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), pr.Number)
+		assert.Equal(t, "open", pr.State)
+	})
+}
+
+func TestActionOperations(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/repos/testorg/testrepo/contents/.gitea/workflows/ci.yml":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"path": ".gitea/workflows/ci.yml", "sha": "abc123", "encoding": "base64", "content": "b246IHB1c2gK"}`))
+		case r.Method == "POST" && r.URL.Path == "/api/v1/repos/testorg/testrepo/contents/.gitea/workflows/ci.yml":
+			var body CreateFileOptions
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "b246IHB1c2gK", body.Content)
+			assert.Equal(t, "main", body.Branch)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"content": {"path": ".gitea/workflows/ci.yml", "sha": "abc123"}}`))
+		case r.Method == "PUT" && r.URL.Path == "/api/v1/repos/testorg/testrepo/contents/.gitea/workflows/ci.yml":
+			var body UpdateFileOptions
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "abc123", body.SHA)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"content": {"path": ".gitea/workflows/ci.yml", "sha": "def456"}}`))
+		case r.Method == "DELETE" && r.URL.Path == "/api/v1/repos/testorg/testrepo/contents/.gitea/workflows/ci.yml":
+			w.WriteHeader(http.StatusOK)
+		case r.Method == "GET" && r.URL.Path == "/api/v1/repos/testorg/testrepo/actions/workflows/ci.yml":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id": "ci.yml", "name": "ci.yml", "path": ".gitea/workflows/ci.yml", "state": "disabled_manually"}`))
+		case r.Method == "PUT" && r.URL.Path == "/api/v1/repos/testorg/testrepo/actions/workflows/ci.yml/enable":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "PUT" && r.URL.Path == "/api/v1/repos/testorg/testrepo/actions/workflows/ci.yml/disable":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "GET" && r.URL.Path == "/api/v1/repos/testorg/testrepo/actions/runs":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"total_count": 2, "workflow_runs": [
+				{"id": 9, "run_number": 5, "status": "completed", "conclusion": "failure", "path": "release.yml@refs/heads/main"},
+				{"id": 8, "run_number": 4, "status": "completed", "conclusion": "success", "event": "push", "path": "ci.yml@refs/heads/main"}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Create test client
+	c := &giteaClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL + "/api/v1",
+		token:      "test-token",
+	}
+
+	ctx := context.Background()
+
+	t.Run("GetAction", func(t *testing.T) {
+		action, err := c.GetAction(ctx, "testorg/testrepo", "ci.yml", "main")
+		require.NoError(t, err)
+		assert.Equal(t, "on: push\n", action.Content)
+		assert.Equal(t, "abc123", action.SHA)
+	})
+
+	t.Run("GetActionNotFound", func(t *testing.T) {
+		_, err := c.GetAction(ctx, "testorg/testrepo", "missing.yml", "main")
+		assert.Error(t, err)
+	})
+
+	t.Run("GetActionInvalidRepository", func(t *testing.T) {
+		_, err := c.GetAction(ctx, "testrepo", "ci.yml", "main")
+		assert.Error(t, err)
+	})
+
+	t.Run("CreateAction", func(t *testing.T) {
+		action, err := c.CreateAction(ctx, "testorg/testrepo", &CreateActionRequest{
+			WorkflowName: "ci.yml",
+			Content:      "on: push\n",
+			Branch:       "main",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "abc123", action.SHA)
+	})
+
+	t.Run("UpdateAction", func(t *testing.T) {
+		action, err := c.UpdateAction(ctx, "testorg/testrepo", "ci.yml", &UpdateActionRequest{
+			Content: "on: pull_request\n",
+			SHA:     "abc123",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "def456", action.SHA)
+	})
+
+	t.Run("DeleteAction", func(t *testing.T) {
+		require.NoError(t, c.DeleteAction(ctx, "testorg/testrepo", "ci.yml", &DeleteActionRequest{SHA: "def456"}))
+	})
+
+	t.Run("GetActionWorkflow", func(t *testing.T) {
+		workflow, err := c.GetActionWorkflow(ctx, "testorg/testrepo", "ci.yml")
+		require.NoError(t, err)
+		assert.Equal(t, "disabled_manually", workflow.State)
+	})
+
+	t.Run("EnableDisableAction", func(t *testing.T) {
+		require.NoError(t, c.EnableAction(ctx, "testorg/testrepo", "ci.yml"))
+		require.NoError(t, c.DisableAction(ctx, "testorg/testrepo", "ci.yml"))
+	})
+
+	t.Run("GetActionLastRun", func(t *testing.T) {
+		run, err := c.GetActionLastRun(ctx, "testorg/testrepo", "ci.yml")
+		require.NoError(t, err)
+		require.NotNil(t, run)
+		assert.Equal(t, int64(8), run.ID)
+		assert.Equal(t, "success", run.Conclusion)
+
+		run, err = c.GetActionLastRun(ctx, "testorg/testrepo", "nightly.yml")
+		require.NoError(t, err)
+		assert.Nil(t, run)
+	})
+}
+
+func TestRunnerOperations(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/repos/testorg/testrepo/actions/runners":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			if r.URL.Query().Get("page") == "1" {
+				_, _ = w.Write([]byte(`{"total_count": 2, "runners": [{"id": 1, "name": "runner-a", "status": "idle", "labels": [{"id": 1, "name": "ubuntu-latest", "type": "custom"}]}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"total_count": 2, "runners": [{"id": 2, "name": "runner-b", "status": "offline"}]}`))
+		case r.Method == "GET" && r.URL.Path == "/api/v1/orgs/testorg/actions/runners/1":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id": 1, "name": "runner-a", "status": "active", "busy": true}`))
+		case r.Method == "DELETE" && r.URL.Path == "/api/v1/admin/actions/runners/1":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "GET" && r.URL.Path == "/api/v1/orgs/testorg/actions/runners/registration-token":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"token": "existing-token"}`))
+		case r.Method == "POST" && r.URL.Path == "/api/v1/admin/actions/runners/registration-token":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"token": "new-token"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Create test client
+	c := &giteaClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL + "/api/v1",
+		token:      "test-token",
+	}
+
+	ctx := context.Background()
+
+	t.Run("ListRunners", func(t *testing.T) {
+		runners, err := c.ListRunners(ctx, "repository", "testorg/testrepo")
+		require.NoError(t, err)
+		require.Len(t, runners, 2)
+		assert.Equal(t, "ubuntu-latest", runners[0].Labels[0].Name)
+		assert.Equal(t, "offline", runners[1].Status)
+	})
+
+	t.Run("GetRunner", func(t *testing.T) {
+		runner, err := c.GetRunner(ctx, "organization", "testorg", 1)
+		require.NoError(t, err)
+		assert.True(t, runner.Busy)
+	})
+
+	t.Run("DeleteRunner", func(t *testing.T) {
+		require.NoError(t, c.DeleteRunner(ctx, "system", "", 1))
+	})
+
+	t.Run("InvalidScope", func(t *testing.T) {
+		_, err := c.ListRunners(ctx, "repository", "testrepo")
+		assert.Error(t, err)
+		_, err = c.ListRunners(ctx, "cluster", "")
+		assert.Error(t, err)
+	})
+
+	t.Run("GetRunnerRegistrationToken", func(t *testing.T) {
+		token, err := c.GetRunnerRegistrationToken(ctx, "organization", "testorg")
+		require.NoError(t, err)
+		assert.Equal(t, "existing-token", token.Token)
+	})
+
+	t.Run("CreateRunnerRegistrationToken", func(t *testing.T) {
+		token, err := c.CreateRunnerRegistrationToken(ctx, "system", "")
+		require.NoError(t, err)
+		assert.Equal(t, "new-token", token.Token)
+	})
+}
+
+func TestListActionRunsPages(t *testing.T) {
+	var pages []string
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		runs := []ActionRun{{ID: 1, Path: "nightly.yml@refs/heads/main"}}
+		if page == "1" {
+			runs = nil
+			for i := int64(0); i < 50; i++ {
+				runs = append(runs, ActionRun{ID: 100 - i, Path: "ci.yml@refs/heads/main"})
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ActionRunList{TotalCount: 51, WorkflowRuns: runs})
+	}))
+	defer server.Close()
+
+	// Create test client
+	c := &giteaClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL + "/api/v1",
+		token:      "test-token",
+	}
+
+	runs, err := c.ListActionRuns(context.Background(), "testorg/testrepo", "nightly.yml", "")
+	require.NoError(t, err)
+	require.Len(t, runs, 1, "runs beyond the first page are found")
+	assert.Equal(t, int64(1), runs[0].ID)
+
+	pages = nil
+	runs, err = c.ListActionRuns(context.Background(), "testorg/testrepo", "ci.yml", "")
+	require.NoError(t, err)
+	assert.Len(t, runs, 50)
+	assert.Equal(t, []string{"1"}, pages, "listing stops at the first page with runs of the workflow")
+}
+
+func TestWorkflowRunOperations(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/repos/testorg/testrepo/actions/runs":
+			assert.Equal(t, "workflow_dispatch", r.URL.Query().Get("event"))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"total_count": 2, "workflow_runs": [
+				{"id": 12, "path": "migrate.yml@refs/heads/main", "status": "in_progress"},
+				{"id": 11, "path": "reindex.yml@refs/heads/main", "status": "completed"}
+			]}`))
+		case r.Method == "GET" && r.URL.Path == "/api/v1/repos/testorg/testrepo/actions/runs/12":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id": 12, "run_number": 3, "status": "completed", "conclusion": "success"}`))
+		case r.Method == "GET" && r.URL.Path == "/api/v1/repos/testorg/testrepo/actions/runs/12/jobs":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"total_count": 1, "jobs": [{"id": 30, "run_id": 12, "name": "migrate", "status": "completed", "conclusion": "success"}]}`))
+		case r.Method == "POST" && r.URL.Path == "/api/v1/repos/testorg/testrepo/actions/workflows/migrate.yml/dispatches":
+			var body DispatchWorkflowRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "main", body.Ref)
+			assert.Equal(t, "v42", body.Inputs["version"])
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Create test client
+	c := &giteaClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL + "/api/v1",
+		token:      "test-token",
+	}
+
+	ctx := context.Background()
+
+	t.Run("ListActionRuns", func(t *testing.T) {
+		runs, err := c.ListActionRuns(ctx, "testorg/testrepo", "migrate.yml", "workflow_dispatch")
+		require.NoError(t, err)
+		require.Len(t, runs, 1)
+		assert.Equal(t, int64(12), runs[0].ID)
+	})
+
+	t.Run("GetActionRun", func(t *testing.T) {
+		run, err := c.GetActionRun(ctx, "testorg/testrepo", 12)
+		require.NoError(t, err)
+		assert.Equal(t, "success", run.Conclusion)
+	})
+
+	t.Run("ListActionRunJobs", func(t *testing.T) {
+		jobs, err := c.ListActionRunJobs(ctx, "testorg/testrepo", 12)
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+		assert.Equal(t, "migrate", jobs[0].Name)
+	})
+
+	t.Run("DispatchWorkflow", func(t *testing.T) {
+		require.NoError(t, c.DispatchWorkflow(ctx, "testorg/testrepo", "migrate.yml", &DispatchWorkflowRequest{
+			Ref:    "main",
+			Inputs: map[string]string{"version": "v42"},
+		}))
+	})
+}
+
+func TestBranchProtectionOperations(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.EscapedPath() == "/api/v1/repos/testorg/testrepo/branch_protections/release%2F%2A":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"rule_name": "release/*", "priority": 2, "enable_force_push": true, "approvals_whitelist_username": ["lead"], "block_admin_merge_override": true}`))
+		case r.Method == "POST" && r.URL.Path == "/api/v1/repos/testorg/testrepo/branch_protections":
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "release/*", body["rule_name"])
+			assert.Equal(t, true, body["ignore_stale_approvals"])
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"rule_name": "release/*"}`))
+		case r.Method == "PATCH" && r.URL.EscapedPath() == "/api/v1/repos/testorg/testrepo/branch_protections/release%2F%2A":
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, []interface{}{}, body["push_whitelist_usernames"])
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"rule_name": "release/*"}`))
+		case r.Method == "DELETE" && r.URL.EscapedPath() == "/api/v1/repos/testorg/testrepo/branch_protections/release%2F%2A":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Create test client
+	c := &giteaClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL + "/api/v1",
+		token:      "test-token",
+	}
+
+	ctx := context.Background()
+
+	t.Run("GetBranchProtection", func(t *testing.T) {
+		bp, err := c.GetBranchProtection(ctx, "testorg/testrepo", "release/*")
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), bp.Priority)
+		assert.True(t, bp.EnableForcePush)
+		assert.True(t, bp.BlockAdminMergeOverride)
+		assert.Equal(t, []string{"lead"}, bp.ApprovalsWhitelistUsernames)
+	})
+
+	t.Run("GetBranchProtectionNotFound", func(t *testing.T) {
+		_, err := c.GetBranchProtection(ctx, "testorg/testrepo", "main")
+		assert.True(t, IsNotFound(err))
+	})
+
+	t.Run("CreateBranchProtection", func(t *testing.T) {
+		ignore := true
+		bp, err := c.CreateBranchProtection(ctx, "testorg/testrepo", &CreateBranchProtectionRequest{
+			RuleName:             "release/*",
+			IgnoreStaleApprovals: &ignore,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "release/*", bp.RuleName)
+	})
+
+	t.Run("UpdateBranchProtection", func(t *testing.T) {
+		_, err := c.UpdateBranchProtection(ctx, "testorg/testrepo", "release/*", &UpdateBranchProtectionRequest{
+			PushWhitelistUsernames: []string{},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("DeleteBranchProtection", func(t *testing.T) {
+		require.NoError(t, c.DeleteBranchProtection(ctx, "testorg/testrepo", "release/*"))
+	})
+}
+
+func TestTagProtectionOperations(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/repos/testorg/testrepo/tag_protections":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`[{"id": 3, "name_pattern": "v*", "whitelist_teams": ["release-team"]}]`))
+		case r.Method == "GET" && r.URL.Path == "/api/v1/repos/testorg/testrepo/tag_protections/3":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id": 3, "name_pattern": "v*", "whitelist_usernames": ["releaser"]}`))
+		case r.Method == "POST" && r.URL.Path == "/api/v1/repos/testorg/testrepo/tag_protections":
+			var body CreateTagProtectionRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "v*", body.NamePattern)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": 4, "name_pattern": "v*"}`))
+		case r.Method == "PATCH" && r.URL.Path == "/api/v1/repos/testorg/testrepo/tag_protections/3":
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, []interface{}{}, body["whitelist_teams"])
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id": 3, "name_pattern": "v*"}`))
+		case r.Method == "DELETE" && r.URL.Path == "/api/v1/repos/testorg/testrepo/tag_protections/3":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Create test client
+	c := &giteaClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL + "/api/v1",
+		token:      "test-token",
+	}
+
+	ctx := context.Background()
+
+	t.Run("ListTagProtections", func(t *testing.T) {
+		rules, err := c.ListTagProtections(ctx, "testorg/testrepo")
+		require.NoError(t, err)
+		require.Len(t, rules, 1)
+		assert.Equal(t, []string{"release-team"}, rules[0].WhitelistTeams)
+	})
+
+	t.Run("GetTagProtection", func(t *testing.T) {
+		tp, err := c.GetTagProtection(ctx, "testorg/testrepo", 3)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"releaser"}, tp.WhitelistUsernames)
+	})
+
+	t.Run("GetTagProtectionNotFound", func(t *testing.T) {
+		_, err := c.GetTagProtection(ctx, "testorg/testrepo", 9)
+		assert.True(t, IsNotFound(err))
+	})
+
+	t.Run("CreateTagProtection", func(t *testing.T) {
+		tp, err := c.CreateTagProtection(ctx, "testorg/testrepo", &CreateTagProtectionRequest{NamePattern: "v*"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), tp.ID)
+	})
+
+	t.Run("UpdateTagProtection", func(t *testing.T) {
+		_, err := c.UpdateTagProtection(ctx, "testorg/testrepo", 3, &UpdateTagProtectionRequest{
+			WhitelistUsernames: []string{"releaser"},
+			WhitelistTeams:     []string{},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("DeleteTagProtection", func(t *testing.T) {
+		require.NoError(t, c.DeleteTagProtection(ctx, "testorg/testrepo", 3))
+	})
+}
+
+func TestBranchAndTagOperations(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/repos/testorg/testrepo/branches":
+			var body CreateBranchRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "develop", body.NewBranchName)
+			assert.Equal(t, "abc123", body.OldRefName)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"name": "develop", "commit": {"id": "abc123"}}`))
+		case r.Method == "DELETE" && r.URL.EscapedPath() == "/api/v1/repos/testorg/testrepo/branches/feature%2Flogin":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "GET" && r.URL.Path == "/api/v1/repos/testorg/testrepo/tags/v1.0.0":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"name": "v1.0.0", "id": "tag123", "message": "First release", "commit": {"sha": "abc123"}}`))
+		case r.Method == "POST" && r.URL.Path == "/api/v1/repos/testorg/testrepo/tags":
+			var body CreateTagRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "v1.0.0", body.TagName)
+			assert.Equal(t, "First release", body.Message)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"name": "v1.0.0", "id": "tag123"}`))
+		case r.Method == "DELETE" && r.URL.Path == "/api/v1/repos/testorg/testrepo/tags/v1.0.0":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Create test client
+	c := &giteaClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL + "/api/v1",
+		token:      "test-token",
+	}
+
+	ctx := context.Background()
+
+	t.Run("CreateRepositoryBranch", func(t *testing.T) {
+		b, err := c.CreateRepositoryBranch(ctx, "testorg", "testrepo", &CreateBranchRequest{NewBranchName: "develop", OldRefName: "abc123"})
+		require.NoError(t, err)
+		assert.Equal(t, "abc123", b.Commit.ID)
+	})
+
+	t.Run("DeleteRepositoryBranch", func(t *testing.T) {
+		require.NoError(t, c.DeleteRepositoryBranch(ctx, "testorg", "testrepo", "feature/login"))
+	})
+
+	t.Run("GetRepositoryTag", func(t *testing.T) {
+		tag, err := c.GetRepositoryTag(ctx, "testorg", "testrepo", "v1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, "abc123", tag.Commit.SHA)
+		assert.Equal(t, "First release", tag.Message)
+	})
+
+	t.Run("GetRepositoryTagNotFound", func(t *testing.T) {
+		_, err := c.GetRepositoryTag(ctx, "testorg", "testrepo", "v9.9.9")
+		assert.True(t, IsNotFound(err))
+	})
+
+	t.Run("CreateRepositoryTag", func(t *testing.T) {
+		tag, err := c.CreateRepositoryTag(ctx, "testorg", "testrepo", &CreateTagRequest{TagName: "v1.0.0", Message: "First release"})
+		require.NoError(t, err)
+		assert.Equal(t, "tag123", tag.ID)
+	})
+
+	t.Run("DeleteRepositoryTag", func(t *testing.T) {
+		require.NoError(t, c.DeleteRepositoryTag(ctx, "testorg", "testrepo", "v1.0.0"))
+	})
+}
+
+func TestTeamMembershipOperations(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/teams/5":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "team not found"}`))
+		case r.Method == "GET" && r.URL.Path == "/api/v1/teams/1/members":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`[{"id": 2, "username": "alice"}, {"id": 3, "username": "bob"}]`))
+		case r.Method == "GET" && r.URL.Path == "/api/v1/teams/1/members/alice":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id": 2, "username": "alice"}`))
+		case (r.Method == "PUT" || r.Method == "DELETE") && r.URL.Path == "/api/v1/teams/1/members/alice":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "GET" && r.URL.Path == "/api/v1/teams/1/repos":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`[{"id": 4, "name": "app", "full_name": "testorg/app"}]`))
+		case r.Method == "GET" && r.URL.Path == "/api/v1/teams/1/repos/testorg/app":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id": 4, "name": "app", "full_name": "testorg/app"}`))
+		case (r.Method == "PUT" || r.Method == "DELETE") && r.URL.Path == "/api/v1/teams/1/repos/testorg/app":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Create test client
+	c := &giteaClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL + "/api/v1",
+		token:      "test-token",
+	}
+
+	ctx := context.Background()
+
+	t.Run("GetTeamNotFound", func(t *testing.T) {
+		_, err := c.GetTeam(ctx, 5)
+		require.Error(t, err)
+		assert.True(t, IsNotFound(err))
+	})
+
+	t.Run("ListTeamMembers", func(t *testing.T) {
+		members, err := c.ListTeamMembers(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, members, 2)
+		assert.Equal(t, "bob", members[1].Username)
+	})
+
+	t.Run("GetTeamMember", func(t *testing.T) {
+		member, err := c.GetTeamMember(ctx, 1, "alice")
+		require.NoError(t, err)
+		assert.Equal(t, "alice", member.Username)
+
+		_, err = c.GetTeamMember(ctx, 1, "mallory")
+		assert.True(t, IsNotFound(err))
+	})
+
+	t.Run("AddAndRemoveTeamMember", func(t *testing.T) {
+		require.NoError(t, c.AddTeamMember(ctx, 1, "alice"))
+		require.NoError(t, c.RemoveTeamMember(ctx, 1, "alice"))
+	})
+
+	t.Run("ListTeamRepositories", func(t *testing.T) {
+		repos, err := c.ListTeamRepositories(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, repos, 1)
+		assert.Equal(t, "app", repos[0].Name)
+	})
+
+	t.Run("GetTeamRepository", func(t *testing.T) {
+		repo, err := c.GetTeamRepository(ctx, 1, "testorg", "app")
+		require.NoError(t, err)
+		assert.Equal(t, "testorg/app", repo.FullName)
+	})
+
+	t.Run("AddAndRemoveTeamRepository", func(t *testing.T) {
+		require.NoError(t, c.AddTeamRepository(ctx, 1, "testorg", "app"))
+		require.NoError(t, c.RemoveTeamRepository(ctx, 1, "testorg", "app"))
+	})
+}
+
+func TestOrganizationMemberOperations(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/orgs/acme/members/jane":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "GET" && r.URL.Path == "/api/v1/orgs/acme/public_members/jane":
+			w.WriteHeader(http.StatusNotFound)
+		case (r.Method == "PUT" || r.Method == "DELETE") && r.URL.Path == "/api/v1/orgs/acme/public_members/jane":
+			assert.Equal(t, "jane", r.Header.Get("Sudo"), "visibility can only be changed by the member")
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "DELETE" && r.URL.Path == "/api/v1/orgs/acme/members/jane":
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/api/v1/orgs/acme/members/bob":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Create test client
+	c := &giteaClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL + "/api/v1",
+		token:      "test-token",
+	}
+
+	ctx := context.Background()
+
+	t.Run("IsOrganizationMember", func(t *testing.T) {
+		member, err := c.IsOrganizationMember(ctx, "acme", "jane")
+		require.NoError(t, err)
+		assert.True(t, member)
+
+		member, err = c.IsOrganizationMember(ctx, "acme", "mallory")
+		require.NoError(t, err)
+		assert.False(t, member)
+
+		_, err = c.IsOrganizationMember(ctx, "acme", "bob")
+		assert.Error(t, err)
+	})
+
+	t.Run("IsPublicOrganizationMember", func(t *testing.T) {
+		public, err := c.IsPublicOrganizationMember(ctx, "acme", "jane")
+		require.NoError(t, err)
+		assert.False(t, public)
+	})
+
+	t.Run("SetOrganizationMemberVisibility", func(t *testing.T) {
+		require.NoError(t, c.SetOrganizationMemberVisibility(ctx, "acme", "jane", true))
+		require.NoError(t, c.SetOrganizationMemberVisibility(ctx, "acme", "jane", false))
+	})
+
+	t.Run("RemoveOrganizationMember", func(t *testing.T) {
+		require.NoError(t, c.RemoveOrganizationMember(ctx, "acme", "jane"))
+	})
+}
+
+func TestOrganizationExtrasOperations(t *testing.T) {
+	var avatar map[string]string
+	var created CreateLabelRequest
+
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/orgs/acme/avatar":
+			_ = json.NewDecoder(r.Body).Decode(&avatar)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "DELETE" && r.URL.Path == "/api/v1/orgs/acme/avatar":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "GET" && r.URL.Path == "/api/v1/orgs/acme/labels":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"id": 1, "name": "bug", "color": "ee0701"}]`))
+		case r.Method == "POST" && r.URL.Path == "/api/v1/orgs/acme/labels":
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": 2, "name": "security", "color": "b60205"}`))
+		case r.Method == "PATCH" && r.URL.Path == "/api/v1/orgs/acme/labels/1":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id": 1, "name": "bug", "color": "d73a4a"}`))
+		case r.Method == "DELETE" && r.URL.Path == "/api/v1/orgs/acme/labels/1":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "GET" && r.URL.Path == "/api/v1/orgs/acme/blocks":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"id": 7, "login": "spammer", "username": "spammer"}]`))
+		case (r.Method == "PUT" || r.Method == "DELETE") && r.URL.Path == "/api/v1/orgs/acme/blocks/spammer":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Create test client
+	c := &giteaClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL + "/api/v1",
+		token:      "test-token",
+	}
+
+	ctx := context.Background()
+
+	t.Run("UpdateOrganizationAvatar", func(t *testing.T) {
+		require.NoError(t, c.UpdateOrganizationAvatar(ctx, "acme", []byte("png")))
+		assert.Equal(t, "cG5n", avatar["image"])
+		require.NoError(t, c.DeleteOrganizationAvatar(ctx, "acme"))
+	})
+
+	t.Run("OrganizationLabels", func(t *testing.T) {
+		labels, err := c.ListOrganizationLabels(ctx, "acme")
+		require.NoError(t, err)
+		require.Len(t, labels, 1)
+		assert.Equal(t, "bug", labels[0].Name)
+
+		label, err := c.CreateOrganizationLabel(ctx, "acme", &CreateLabelRequest{Name: "security", Color: "b60205"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), label.ID)
+		assert.Equal(t, "security", created.Name)
+
+		color := "d73a4a"
+		label, err = c.UpdateOrganizationLabel(ctx, "acme", 1, &UpdateLabelRequest{Color: &color})
+		require.NoError(t, err)
+		assert.Equal(t, color, label.Color)
+
+		require.NoError(t, c.DeleteOrganizationLabel(ctx, "acme", 1))
+	})
+
+	t.Run("OrganizationBlocks", func(t *testing.T) {
+		blocked, err := c.ListOrganizationBlocks(ctx, "acme")
+		require.NoError(t, err)
+		require.Len(t, blocked, 1)
+		assert.Equal(t, "spammer", blocked[0].Username)
+
+		require.NoError(t, c.BlockOrganizationUser(ctx, "acme", "spammer"))
+		require.NoError(t, c.UnblockOrganizationUser(ctx, "acme", "spammer"))
+		assert.Error(t, c.BlockOrganizationUser(ctx, "acme", "ghost"))
+	})
+}
+
+func TestCreateReleaseAttachment(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/repos/acme/app/releases/7/assets" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "app v1.tar.gz", r.URL.Query().Get("name"))
+
+		file, header, err := r.FormFile("attachment")
+		require.NoError(t, err)
+		defer func() {
+			_ = file.Close()
+		}()
+		content, _ := io.ReadAll(file)
+		assert.Equal(t, "app v1.tar.gz", header.Filename)
+		assert.Equal(t, "application/gzip", header.Header.Get("Content-Type"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"id": 3, "name": %q, "size": %d}`, header.Filename, len(content))
+	}))
+	defer server.Close()
+
+	// Create test client
+	c := &giteaClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL + "/api/v1",
+		token:      "test-token",
+	}
+
+	attachment, err := c.CreateReleaseAttachment(context.Background(), "acme", "app", 7, "app v1.tar.gz", "application/gzip", strings.NewReader("tarball"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), attachment.ID)
+	assert.Equal(t, int64(7), attachment.Size)
+
+	_, err = c.CreateReleaseAttachment(context.Background(), "acme", "app", 8, "app.tar.gz", "application/gzip", strings.NewReader("tarball"))
+	assert.Error(t, err)
+}
+
+func TestCreateReleaseAttachmentOutlastsTimeout(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": 3, "name": "app.tar.gz"}`))
+	}))
+	defer server.Close()
+
+	// Create test client
+	c := &giteaClient{
+		httpClient: &http.Client{Timeout: 50 * time.Millisecond},
+		baseURL:    server.URL + "/api/v1",
+		token:      "test-token",
+	}
+
+	_, err := c.CreateReleaseAttachment(context.Background(), "acme", "app", 7, "app.tar.gz", "application/gzip", strings.NewReader("tarball"))
+	require.NoError(t, err, "uploads are not bound by the client timeout")
+
+	err = c.DeleteReleaseAttachment(context.Background(), "acme", "app", 7, 3)
+	assert.Error(t, err, "other requests are")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = c.CreateReleaseAttachment(ctx, "acme", "app", 7, "app.tar.gz", "application/gzip", strings.NewReader("tarball"))
+	assert.Error(t, err, "uploads are bound by their context")
+}
+
+func TestReleaseNotesOperations(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/repos/acme/app/releases":
+			_, _ = w.Write([]byte(`[{"id": 2, "tag_name": "v1.1.0"}, {"id": 1, "tag_name": "v1.0.0"}]`))
+		case r.Method == "GET" && r.URL.Path == "/api/v1/repos/acme/app/compare/v1.0.0...v1.1.0":
+			_, _ = w.Write([]byte(`{"total_commits": 1, "commits": [{"sha": "abc123", "commit": {"message": "Fix crash"}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Create test client
+	c := &giteaClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL + "/api/v1",
+		token:      "test-token",
+	}
+
+	ctx := context.Background()
+
+	t.Run("ListReleases", func(t *testing.T) {
+		releases, err := c.ListReleases(ctx, "acme", "app")
+		require.NoError(t, err)
+		require.Len(t, releases, 2)
+		assert.Equal(t, "v1.1.0", releases[0].TagName)
+	})
+
+	t.Run("CompareCommits", func(t *testing.T) {
+		cmp, err := c.CompareCommits(ctx, "acme", "app", "v1.0.0", "v1.1.0")
+		require.NoError(t, err)
+		assert.Equal(t, 1, cmp.TotalCommits)
+		require.Len(t, cmp.Commits, 1)
+		assert.Equal(t, "Fix crash", cmp.Commits[0].Commit.Message)
+
+		_, err = c.CompareCommits(ctx, "acme", "app", "v0.1.0", "v1.1.0")
+		assert.True(t, IsNotFound(err))
+	})
+}
+
+func TestUserOffboardingOperations(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "DELETE" && r.URL.Path == "/api/v1/admin/users/jane":
+			assert.Equal(t, "true", r.URL.Query().Get("purge"))
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "GET" && r.URL.Path == "/api/v1/users/jane/repos":
+			_, _ = w.Write([]byte(`[{"id": 1, "name": "notes", "full_name": "jane/notes", "owner": {"username": "jane"}}]`))
+		case r.Method == "GET" && r.URL.Path == "/api/v1/users/jane/orgs":
+			_, _ = w.Write([]byte(`[{"id": 2, "username": "acme"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Create test client
+	c := &giteaClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL + "/api/v1",
+		token:      "test-token",
+	}
+
+	ctx := context.Background()
+
+	t.Run("PurgeUser", func(t *testing.T) {
+		require.NoError(t, c.PurgeUser(ctx, "jane"))
+	})
+
+	t.Run("ListUserRepositories", func(t *testing.T) {
+		repos, err := c.ListUserRepositories(ctx, "jane")
+		require.NoError(t, err)
+		require.Len(t, repos, 1)
+		assert.Equal(t, "jane", repos[0].Owner.Username)
+	})
+
+	t.Run("ListUserOrganizations", func(t *testing.T) {
+		orgs, err := c.ListUserOrganizations(ctx, "jane")
+		require.NoError(t, err)
+		require.Len(t, orgs, 1)
+		assert.Equal(t, "acme", orgs[0].Username)
+	})
+}
+
+func TestUserAccountOperations(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "jane", r.Header.Get("Sudo"), "user endpoints must act as the target user")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/user/emails":
+			_, _ = w.Write([]byte(`[{"email": "jane@example.com", "verified": true, "primary": true}]`))
+		case r.Method == "POST" && r.URL.Path == "/api/v1/user/emails":
+			var req UserEmailsRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, []string{"jane.doe@example.com"}, req.Emails)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`[{"email": "jane.doe@example.com"}]`))
+		case r.Method == "DELETE" && r.URL.Path == "/api/v1/user/emails":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "POST" && r.URL.Path == "/api/v1/user/gpg_keys":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": 12, "key_id": "3AA5C34371567BD2", "can_sign": true}`))
+		case r.Method == "GET" && r.URL.Path == "/api/v1/user/gpg_keys":
+			_, _ = w.Write([]byte(`[{"id": 12, "key_id": "3AA5C34371567BD2"}]`))
+		case r.Method == "GET" && r.URL.Path == "/api/v1/user/gpg_keys/12":
+			_, _ = w.Write([]byte(`{"id": 12, "key_id": "3AA5C34371567BD2"}`))
+		case r.Method == "PATCH" && r.URL.Path == "/api/v1/user/settings":
+			_, _ = w.Write([]byte(`{"theme": "gitea-dark", "diff_view_style": "split"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Create test client
+	c := &giteaClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL + "/api/v1",
+		token:      "test-token",
+	}
+
+	ctx := context.Background()
+
+	t.Run("Emails", func(t *testing.T) {
+		emails, err := c.ListUserEmails(ctx, "jane")
+		require.NoError(t, err)
+		require.Len(t, emails, 1)
+		assert.True(t, emails[0].Primary)
+
+		_, err = c.AddUserEmails(ctx, "jane", []string{"jane.doe@example.com"})
+		require.NoError(t, err)
+		require.NoError(t, c.DeleteUserEmails(ctx, "jane", []string{"jane.doe@example.com"}))
+	})
+
+	t.Run("GPGKeys", func(t *testing.T) {
+		key, err := c.CreateUserGPGKey(ctx, "jane", &CreateGPGKeyRequest{ArmoredKey: "-----BEGIN PGP PUBLIC KEY BLOCK-----"})
+		require.NoError(t, err)
+		assert.True(t, key.CanSign)
+
+		key, err = c.GetUserGPGKey(ctx, "jane", 12)
+		require.NoError(t, err)
+		assert.Equal(t, "3AA5C34371567BD2", key.KeyID)
+
+		keys, err := c.ListUserGPGKeys(ctx, "jane")
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		assert.Equal(t, int64(12), keys[0].ID)
+	})
+
+	t.Run("Settings", func(t *testing.T) {
+		theme := "gitea-dark"
+		settings, err := c.UpdateUserSettings(ctx, "jane", &UpdateUserSettingsRequest{Theme: &theme})
+		require.NoError(t, err)
+		assert.Equal(t, "split", settings.DiffViewStyle)
+	})
+}
+
+func TestOAuth2ApplicationOperations(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/user/applications/oauth2":
+			assert.Empty(t, r.Header.Get("Sudo"), "no owner acts as the token user")
+			var req OAuth2ApplicationRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.True(t, req.ConfidentialClient)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": 7, "name": "Grafana", "client_id": "abc", "client_secret": "s1"}`))
+		case r.Method == "PATCH" && r.URL.Path == "/api/v1/user/applications/oauth2/7":
+			assert.Equal(t, "grafana-bot", r.Header.Get("Sudo"))
+			_, _ = w.Write([]byte(`{"id": 7, "name": "Grafana", "client_id": "abc", "client_secret": "s2"}`))
+		case r.Method == "DELETE" && r.URL.Path == "/api/v1/user/applications/oauth2/7":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Create test client
+	c := &giteaClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL + "/api/v1",
+		token:      "test-token",
+	}
+
+	ctx := context.Background()
+	req := &OAuth2ApplicationRequest{Name: "Grafana", RedirectURIs: []string{"https://grafana.example.com/login/generic_oauth"}, ConfidentialClient: true}
+
+	app, err := c.CreateOAuth2Application(ctx, "", req)
+	require.NoError(t, err)
+	assert.Equal(t, "s1", app.ClientSecret)
+
+	app, err = c.UpdateOAuth2Application(ctx, "grafana-bot", 7, req)
+	require.NoError(t, err)
+	assert.Equal(t, "s2", app.ClientSecret)
+
+	require.NoError(t, c.DeleteOAuth2Application(ctx, "", 7))
+
+	_, err = c.GetOAuth2Application(ctx, "", 8)
+	assert.True(t, IsNotFound(err))
+}
+
+func TestAdminWebhookOperations(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/admin/hooks":
+			var req CreateWebhookRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "true", req.Config["is_system_webhook"])
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": 5, "type": "gitea", "active": true, "config": {"url": "https://audit.example.com"}}`))
+		case r.Method == "GET" && r.URL.Path == "/api/v1/admin/hooks/5":
+			_, _ = w.Write([]byte(`{"id": 5, "type": "gitea", "active": true, "config": {"url": "https://audit.example.com"}}`))
+		case r.Method == "PATCH" && r.URL.Path == "/api/v1/admin/hooks/5":
+			_, _ = w.Write([]byte(`{"id": 5, "type": "gitea", "active": false}`))
+		case r.Method == "DELETE" && r.URL.Path == "/api/v1/admin/hooks/5":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Create test client
+	c := &giteaClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL + "/api/v1",
+		token:      "test-token",
+	}
+
+	ctx := context.Background()
+
+	hook, err := c.CreateAdminWebhook(ctx, &CreateWebhookRequest{
+		Type:   "gitea",
+		Config: map[string]string{"url": "https://audit.example.com", "content_type": "json", "is_system_webhook": "true"},
+		Events: []string{"push"},
+		Active: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), hook.ID)
+
+	hook, err = c.GetAdminWebhook(ctx, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "https://audit.example.com", hook.Config["url"])
+
+	inactive := false
+	hook, err = c.UpdateAdminWebhook(ctx, 5, &UpdateWebhookRequest{Active: &inactive})
+	require.NoError(t, err)
+	assert.False(t, hook.Active)
+
+	require.NoError(t, c.DeleteAdminWebhook(ctx, 5))
+
+	_, err = c.GetAdminWebhook(ctx, 6)
+	assert.True(t, IsNotFound(err))
+}
+
+func TestRepositoryInventoryOperations(t *testing.T) {
+	// Create test server that serves a full first page of webhooks
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/repos/acme/app/hooks":
+			var hooks []map[string]interface{}
+			if r.URL.Query().Get("page") == "1" {
+				for i := 1; i <= 50; i++ {
+					hooks = append(hooks, map[string]interface{}{"id": i, "config": map[string]string{"url": fmt.Sprintf("https://hooks.example.com/%d", i)}})
+				}
+			} else {
+				hooks = append(hooks, map[string]interface{}{"id": 51, "config": map[string]string{"url": "https://hooks.example.com/51"}})
+			}
+			_ = json.NewEncoder(w).Encode(hooks)
+		case r.Method == "GET" && r.URL.Path == "/api/v1/orgs/acme/repos":
+			_, _ = w.Write([]byte(`[{"id": 1, "name": "app", "full_name": "acme/app", "archived": false}]`))
+		case r.Method == "GET" && r.URL.Path == "/api/v1/repos/acme/app/keys":
+			_, _ = w.Write([]byte(`[{"id": 3, "title": "ci", "read_only": true}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Create test client
+	c := &giteaClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL + "/api/v1",
+		token:      "test-token",
+	}
+
+	ctx := context.Background()
+
+	t.Run("ListRepositoryWebhooks", func(t *testing.T) {
+		hooks, err := c.ListRepositoryWebhooks(ctx, "acme", "app")
+		require.NoError(t, err)
+		require.Len(t, hooks, 51)
+		assert.Equal(t, "https://hooks.example.com/51", hooks[50].Config["url"])
+	})
+
+	t.Run("ListOrganizationRepositories", func(t *testing.T) {
+		repos, err := c.ListOrganizationRepositories(ctx, "acme")
+		require.NoError(t, err)
+		require.Len(t, repos, 1)
+		assert.Equal(t, "acme/app", repos[0].FullName)
+	})
+
+	t.Run("ListDeployKeys", func(t *testing.T) {
+		keys, err := c.ListDeployKeys(ctx, "acme", "app")
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		assert.Equal(t, "ci", keys[0].Title)
+	})
+}
+
 func TestOrganizationWebhookOperations(t *testing.T) {
 	// Create test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
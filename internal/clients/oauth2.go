@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"fmt"
+)
+
+// OAuth2Application represents a Gitea OAuth2 application
+type OAuth2Application struct {
+	ID                         int64    `json:"id"`
+	Name                       string   `json:"name"`
+	ClientID                   string   `json:"client_id"`
+	ClientSecret               string   `json:"client_secret"`
+	RedirectURIs               []string `json:"redirect_uris"`
+	ConfidentialClient         bool     `json:"confidential_client"`
+	SkipSecondaryAuthorization bool     `json:"skip_secondary_authorization"`
+	Created                    string   `json:"created"`
+}
+
+// OAuth2ApplicationRequest represents the request body for creating or
+// updating an OAuth2 application
+type OAuth2ApplicationRequest struct {
+	Name                       string   `json:"name"`
+	RedirectURIs               []string `json:"redirect_uris"`
+	ConfidentialClient         bool     `json:"confidential_client"`
+	SkipSecondaryAuthorization bool     `json:"skip_secondary_authorization"`
+}
+
+// GetOAuth2Application retrieves an OAuth2 application of a user, acting as
+// the user unless username is empty. The client secret is not returned.
+func (c *giteaClient) GetOAuth2Application(ctx context.Context, username string, id int64) (*OAuth2Application, error) {
+	path := fmt.Sprintf("/user/applications/oauth2/%d", id)
+
+	resp, err := c.doRequest(withSudo(ctx, username), "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var app OAuth2Application
+	if err := handleResponse(resp, &app); err != nil {
+		return nil, err
+	}
+
+	return &app, nil
+}
+
+// CreateOAuth2Application creates an OAuth2 application for a user, acting
+// as the user unless username is empty
+func (c *giteaClient) CreateOAuth2Application(ctx context.Context, username string, req *OAuth2ApplicationRequest) (*OAuth2Application, error) {
+	resp, err := c.doRequest(withSudo(ctx, username), "POST", "/user/applications/oauth2", req)
+	if err != nil {
+		return nil, err
+	}
+
+	var app OAuth2Application
+	if err := handleResponse(resp, &app); err != nil {
+		return nil, err
+	}
+
+	return &app, nil
+}
+
+// UpdateOAuth2Application updates an OAuth2 application of a user, acting as
+// the user unless username is empty. Gitea issues a new client secret on
+// every update.
+func (c *giteaClient) UpdateOAuth2Application(ctx context.Context, username string, id int64, req *OAuth2ApplicationRequest) (*OAuth2Application, error) {
+	path := fmt.Sprintf("/user/applications/oauth2/%d", id)
+
+	resp, err := c.doRequest(withSudo(ctx, username), "PATCH", path, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var app OAuth2Application
+	if err := handleResponse(resp, &app); err != nil {
+		return nil, err
+	}
+
+	return &app, nil
+}
+
+// DeleteOAuth2Application deletes an OAuth2 application of a user, acting as
+// the user unless username is empty
+func (c *giteaClient) DeleteOAuth2Application(ctx context.Context, username string, id int64) error {
+	path := fmt.Sprintf("/user/applications/oauth2/%d", id)
+
+	resp, err := c.doRequest(withSudo(ctx, username), "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	return handleResponse(resp, nil)
+}
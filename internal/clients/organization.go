@@ -18,7 +18,9 @@ package clients
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"net/url"
 )
 
 // GetOrganization retrieves an organization by name
@@ -83,3 +85,141 @@ func (c *giteaClient) DeleteOrganization(ctx context.Context, name string) error
 
 	return handleResponse(resp, nil)
 }
+
+// UpdateOrganizationAvatar uploads the organization avatar image
+func (c *giteaClient) UpdateOrganizationAvatar(ctx context.Context, org string, image []byte) error {
+	path := fmt.Sprintf("/orgs/%s/avatar", org)
+	req := map[string]string{"image": base64.StdEncoding.EncodeToString(image)}
+
+	resp, err := c.doRequest(ctx, "POST", path, req)
+	if err != nil {
+		return err
+	}
+
+	return handleResponse(resp, nil)
+}
+
+// DeleteOrganizationAvatar resets the organization avatar to the default
+func (c *giteaClient) DeleteOrganizationAvatar(ctx context.Context, org string) error {
+	path := fmt.Sprintf("/orgs/%s/avatar", org)
+
+	resp, err := c.doRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	return handleResponse(resp, nil)
+}
+
+// ListOrganizationLabels lists the issue labels shared by all repositories
+// of an organization
+func (c *giteaClient) ListOrganizationLabels(ctx context.Context, org string) ([]*Label, error) {
+	var labels []*Label
+	for page := 1; ; page++ {
+		resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/orgs/%s/labels?page=%d&limit=50", org, page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var list []*Label
+		if err := handleResponse(resp, &list); err != nil {
+			return nil, err
+		}
+
+		labels = append(labels, list...)
+		if len(list) < 50 {
+			return labels, nil
+		}
+	}
+}
+
+// CreateOrganizationLabel creates an organization label
+func (c *giteaClient) CreateOrganizationLabel(ctx context.Context, org string, req *CreateLabelRequest) (*Label, error) {
+	path := fmt.Sprintf("/orgs/%s/labels", org)
+
+	resp, err := c.doRequest(ctx, "POST", path, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var label Label
+	if err := handleResponse(resp, &label); err != nil {
+		return nil, err
+	}
+
+	return &label, nil
+}
+
+// UpdateOrganizationLabel updates an organization label
+func (c *giteaClient) UpdateOrganizationLabel(ctx context.Context, org string, labelID int64, req *UpdateLabelRequest) (*Label, error) {
+	path := fmt.Sprintf("/orgs/%s/labels/%d", org, labelID)
+
+	resp, err := c.doRequest(ctx, "PATCH", path, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var label Label
+	if err := handleResponse(resp, &label); err != nil {
+		return nil, err
+	}
+
+	return &label, nil
+}
+
+// DeleteOrganizationLabel deletes an organization label
+func (c *giteaClient) DeleteOrganizationLabel(ctx context.Context, org string, labelID int64) error {
+	path := fmt.Sprintf("/orgs/%s/labels/%d", org, labelID)
+
+	resp, err := c.doRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	return handleResponse(resp, nil)
+}
+
+// ListOrganizationBlocks lists the users blocked by an organization
+func (c *giteaClient) ListOrganizationBlocks(ctx context.Context, org string) ([]*User, error) {
+	var users []*User
+	for page := 1; ; page++ {
+		resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/orgs/%s/blocks?page=%d&limit=50", org, page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var list []*User
+		if err := handleResponse(resp, &list); err != nil {
+			return nil, err
+		}
+
+		users = append(users, list...)
+		if len(list) < 50 {
+			return users, nil
+		}
+	}
+}
+
+// BlockOrganizationUser blocks a user from an organization
+func (c *giteaClient) BlockOrganizationUser(ctx context.Context, org, username string) error {
+	path := fmt.Sprintf("/orgs/%s/blocks/%s", org, url.PathEscape(username))
+
+	resp, err := c.doRequest(ctx, "PUT", path, nil)
+	if err != nil {
+		return err
+	}
+
+	return handleResponse(resp, nil)
+}
+
+// UnblockOrganizationUser unblocks a user from an organization
+func (c *giteaClient) UnblockOrganizationUser(ctx context.Context, org, username string) error {
+	path := fmt.Sprintf("/orgs/%s/blocks/%s", org, url.PathEscape(username))
+
+	resp, err := c.doRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	return handleResponse(resp, nil)
+}
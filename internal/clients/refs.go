@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// CreateBranchRequest represents the request body for creating a branch
+type CreateBranchRequest struct {
+	NewBranchName string `json:"new_branch_name"`
+	// OldRefName is the branch, tag or commit SHA to branch from. An empty
+	// ref branches from the default branch.
+	OldRefName string `json:"old_ref_name,omitempty"`
+}
+
+// TagCommit represents the commit a tag points to
+type TagCommit struct {
+	SHA     string `json:"sha"`
+	URL     string `json:"url"`
+	Created string `json:"created"`
+}
+
+// RepositoryTag represents a tag of a repository
+type RepositoryTag struct {
+	Name       string     `json:"name"`
+	ID         string     `json:"id"`
+	Message    string     `json:"message"`
+	Commit     *TagCommit `json:"commit"`
+	ZipballURL string     `json:"zipball_url"`
+	TarballURL string     `json:"tarball_url"`
+}
+
+// CreateTagRequest represents the request body for creating a tag. A tag
+// with a message is created as an annotated tag.
+type CreateTagRequest struct {
+	TagName string `json:"tag_name"`
+	Target  string `json:"target,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// Commit represents a commit returned by a comparison
+type Commit struct {
+	SHA     string         `json:"sha"`
+	HTMLURL string         `json:"html_url"`
+	Commit  *CommitDetails `json:"commit"`
+}
+
+// CommitDetails holds the git details of a commit
+type CommitDetails struct {
+	Message string `json:"message"`
+}
+
+// Compare represents the commits between two refs
+type Compare struct {
+	TotalCommits int       `json:"total_commits"`
+	Commits      []*Commit `json:"commits"`
+}
+
+// CreateRepositoryBranch creates a branch in a repository
+func (c *giteaClient) CreateRepositoryBranch(ctx context.Context, owner, repo string, req *CreateBranchRequest) (*RepositoryBranch, error) {
+	path := fmt.Sprintf("/repos/%s/%s/branches", owner, repo)
+
+	resp, err := c.doRequest(ctx, "POST", path, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var b RepositoryBranch
+	if err := handleResponse(resp, &b); err != nil {
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+// DeleteRepositoryBranch deletes a branch from a repository
+func (c *giteaClient) DeleteRepositoryBranch(ctx context.Context, owner, repo, branch string) error {
+	path := fmt.Sprintf("/repos/%s/%s/branches/%s", owner, repo, url.PathEscape(branch))
+
+	resp, err := c.doRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	return handleResponse(resp, nil)
+}
+
+// GetRepositoryTag retrieves a tag of a repository
+func (c *giteaClient) GetRepositoryTag(ctx context.Context, owner, repo, tag string) (*RepositoryTag, error) {
+	path := fmt.Sprintf("/repos/%s/%s/tags/%s", owner, repo, url.PathEscape(tag))
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var t RepositoryTag
+	if err := handleResponse(resp, &t); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// CreateRepositoryTag creates a tag in a repository
+func (c *giteaClient) CreateRepositoryTag(ctx context.Context, owner, repo string, req *CreateTagRequest) (*RepositoryTag, error) {
+	path := fmt.Sprintf("/repos/%s/%s/tags", owner, repo)
+
+	resp, err := c.doRequest(ctx, "POST", path, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var t RepositoryTag
+	if err := handleResponse(resp, &t); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// DeleteRepositoryTag deletes a tag from a repository
+func (c *giteaClient) DeleteRepositoryTag(ctx context.Context, owner, repo, tag string) error {
+	path := fmt.Sprintf("/repos/%s/%s/tags/%s", owner, repo, url.PathEscape(tag))
+
+	resp, err := c.doRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	return handleResponse(resp, nil)
+}
+
+// CompareCommits lists the commits reachable from head but not from base
+func (c *giteaClient) CompareCommits(ctx context.Context, owner, repo, base, head string) (*Compare, error) {
+	path := fmt.Sprintf("/repos/%s/%s/compare/%s...%s", owner, repo, url.PathEscape(base), url.PathEscape(head))
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var cmp Compare
+	if err := handleResponse(resp, &cmp); err != nil {
+		return nil, err
+	}
+
+	return &cmp, nil
+}
@@ -21,6 +21,21 @@ import (
 	"fmt"
 )
 
+// RepoTransfer represents a repository transfer awaiting acceptance by the
+// new owner
+type RepoTransfer struct {
+	Doer      *User   `json:"doer"`
+	Recipient *User   `json:"recipient"`
+	Teams     []*Team `json:"teams"`
+}
+
+// TransferRepositoryRequest represents the request body for transferring a
+// repository to another owner
+type TransferRepositoryRequest struct {
+	NewOwner string  `json:"new_owner"`
+	TeamIDs  []int64 `json:"team_ids,omitempty"`
+}
+
 // GetRepository retrieves a repository by owner and name
 func (c *giteaClient) GetRepository(ctx context.Context, owner, name string) (*Repository, error) {
 	path := fmt.Sprintf("/repos/%s/%s", owner, name)
@@ -72,6 +87,27 @@ func (c *giteaClient) CreateOrganizationRepository(ctx context.Context, org stri
 	return &repository, nil
 }
 
+// ListOrganizationRepositories lists all repositories of an organization
+func (c *giteaClient) ListOrganizationRepositories(ctx context.Context, org string) ([]*Repository, error) {
+	var repos []*Repository
+	for page := 1; ; page++ {
+		resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/orgs/%s/repos?page=%d&limit=50", org, page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var list []*Repository
+		if err := handleResponse(resp, &list); err != nil {
+			return nil, err
+		}
+
+		repos = append(repos, list...)
+		if len(list) < 50 {
+			return repos, nil
+		}
+	}
+}
+
 // UpdateRepository updates an existing repository
 func (c *giteaClient) UpdateRepository(ctx context.Context, owner, name string, req *UpdateRepositoryRequest) (*Repository, error) {
 	path := fmt.Sprintf("/repos/%s/%s", owner, name)
@@ -100,3 +136,20 @@ func (c *giteaClient) DeleteRepository(ctx context.Context, owner, name string)
 
 	return handleResponse(resp, nil)
 }
+
+// TransferRepository transfers a repository to another user or organization
+func (c *giteaClient) TransferRepository(ctx context.Context, owner, name string, req *TransferRepositoryRequest) (*Repository, error) {
+	path := fmt.Sprintf("/repos/%s/%s/transfer", owner, name)
+
+	resp, err := c.doRequest(ctx, "POST", path, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var repository Repository
+	if err := handleResponse(resp, &repository); err != nil {
+		return nil, err
+	}
+
+	return &repository, nil
+}
@@ -83,3 +83,252 @@ func (c *giteaClient) DeleteUser(ctx context.Context, username string) error {
 
 	return handleResponse(resp, nil)
 }
+
+// PurgeUser deletes a user together with everything the user owns (admin only)
+func (c *giteaClient) PurgeUser(ctx context.Context, username string) error {
+	path := fmt.Sprintf("/admin/users/%s?purge=true", username)
+
+	resp, err := c.doRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	return handleResponse(resp, nil)
+}
+
+// ListUserRepositories lists the repositories owned by a user
+func (c *giteaClient) ListUserRepositories(ctx context.Context, username string) ([]*Repository, error) {
+	var repos []*Repository
+	for page := 1; ; page++ {
+		resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/users/%s/repos?page=%d&limit=50", username, page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var list []*Repository
+		if err := handleResponse(resp, &list); err != nil {
+			return nil, err
+		}
+
+		repos = append(repos, list...)
+		if len(list) < 50 {
+			return repos, nil
+		}
+	}
+}
+
+// ListUserOrganizations lists the organizations a user is a member of
+func (c *giteaClient) ListUserOrganizations(ctx context.Context, username string) ([]*Organization, error) {
+	var orgs []*Organization
+	for page := 1; ; page++ {
+		resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/users/%s/orgs?page=%d&limit=50", username, page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var list []*Organization
+		if err := handleResponse(resp, &list); err != nil {
+			return nil, err
+		}
+
+		orgs = append(orgs, list...)
+		if len(list) < 50 {
+			return orgs, nil
+		}
+	}
+}
+
+// Email is an email address of a user
+type Email struct {
+	Email    string `json:"email"`
+	Verified bool   `json:"verified"`
+	Primary  bool   `json:"primary"`
+	UserID   int64  `json:"user_id"`
+	Username string `json:"username"`
+}
+
+// UserEmailsRequest represents the request body for adding or deleting emails
+type UserEmailsRequest struct {
+	Emails []string `json:"emails"`
+}
+
+// GPGKey represents a GPG key of a user
+type GPGKey struct {
+	ID                int64          `json:"id"`
+	PrimaryKeyID      string         `json:"primary_key_id"`
+	KeyID             string         `json:"key_id"`
+	PublicKey         string         `json:"public_key"`
+	Emails            []*GPGKeyEmail `json:"emails"`
+	CanSign           bool           `json:"can_sign"`
+	CanEncryptComms   bool           `json:"can_encrypt_comms"`
+	CanEncryptStorage bool           `json:"can_encrypt_storage"`
+	CanCertify        bool           `json:"can_certify"`
+	Verified          bool           `json:"verified"`
+	CreatedAt         string         `json:"created_at"`
+	ExpiresAt         string         `json:"expires_at"`
+}
+
+// GPGKeyEmail is an email address listed in a GPG key
+type GPGKeyEmail struct {
+	Email    string `json:"email"`
+	Verified bool   `json:"verified"`
+}
+
+// CreateGPGKeyRequest represents the request body for adding a GPG key
+type CreateGPGKeyRequest struct {
+	ArmoredKey       string `json:"armored_public_key"`
+	ArmoredSignature string `json:"armored_signature,omitempty"`
+}
+
+// UserSettings represents the profile settings of a user
+type UserSettings struct {
+	FullName      string `json:"full_name"`
+	Website       string `json:"website"`
+	Description   string `json:"description"`
+	Location      string `json:"location"`
+	Language      string `json:"language"`
+	Theme         string `json:"theme"`
+	DiffViewStyle string `json:"diff_view_style"`
+	HideEmail     bool   `json:"hide_email"`
+	HideActivity  bool   `json:"hide_activity"`
+}
+
+// UpdateUserSettingsRequest represents the request body for updating user settings
+type UpdateUserSettingsRequest struct {
+	Language      *string `json:"language,omitempty"`
+	Theme         *string `json:"theme,omitempty"`
+	DiffViewStyle *string `json:"diff_view_style,omitempty"`
+	HideEmail     *bool   `json:"hide_email,omitempty"`
+	HideActivity  *bool   `json:"hide_activity,omitempty"`
+}
+
+// ListUserEmails lists the email addresses of a user, acting as the user
+func (c *giteaClient) ListUserEmails(ctx context.Context, username string) ([]*Email, error) {
+	resp, err := c.doRequest(withSudo(ctx, username), "GET", "/user/emails", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var emails []*Email
+	if err := handleResponse(resp, &emails); err != nil {
+		return nil, err
+	}
+
+	return emails, nil
+}
+
+// AddUserEmails adds email addresses to a user, acting as the user
+func (c *giteaClient) AddUserEmails(ctx context.Context, username string, emails []string) ([]*Email, error) {
+	resp, err := c.doRequest(withSudo(ctx, username), "POST", "/user/emails", &UserEmailsRequest{Emails: emails})
+	if err != nil {
+		return nil, err
+	}
+
+	var added []*Email
+	if err := handleResponse(resp, &added); err != nil {
+		return nil, err
+	}
+
+	return added, nil
+}
+
+// DeleteUserEmails removes email addresses from a user, acting as the user
+func (c *giteaClient) DeleteUserEmails(ctx context.Context, username string, emails []string) error {
+	resp, err := c.doRequest(withSudo(ctx, username), "DELETE", "/user/emails", &UserEmailsRequest{Emails: emails})
+	if err != nil {
+		return err
+	}
+
+	return handleResponse(resp, nil)
+}
+
+// ListUserGPGKeys lists the GPG keys of a user, acting as the user
+func (c *giteaClient) ListUserGPGKeys(ctx context.Context, username string) ([]*GPGKey, error) {
+	var keys []*GPGKey
+	for page := 1; ; page++ {
+		resp, err := c.doRequest(withSudo(ctx, username), "GET", fmt.Sprintf("/user/gpg_keys?page=%d&limit=50", page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var list []*GPGKey
+		if err := handleResponse(resp, &list); err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, list...)
+		if len(list) < 50 {
+			return keys, nil
+		}
+	}
+}
+
+// GetUserGPGKey retrieves a GPG key of a user, acting as the user
+func (c *giteaClient) GetUserGPGKey(ctx context.Context, username string, id int64) (*GPGKey, error) {
+	resp, err := c.doRequest(withSudo(ctx, username), "GET", fmt.Sprintf("/user/gpg_keys/%d", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var key GPGKey
+	if err := handleResponse(resp, &key); err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// CreateUserGPGKey adds a GPG key to a user, acting as the user
+func (c *giteaClient) CreateUserGPGKey(ctx context.Context, username string, req *CreateGPGKeyRequest) (*GPGKey, error) {
+	resp, err := c.doRequest(withSudo(ctx, username), "POST", "/user/gpg_keys", req)
+	if err != nil {
+		return nil, err
+	}
+
+	var key GPGKey
+	if err := handleResponse(resp, &key); err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// DeleteUserGPGKey removes a GPG key from a user, acting as the user
+func (c *giteaClient) DeleteUserGPGKey(ctx context.Context, username string, id int64) error {
+	resp, err := c.doRequest(withSudo(ctx, username), "DELETE", fmt.Sprintf("/user/gpg_keys/%d", id), nil)
+	if err != nil {
+		return err
+	}
+
+	return handleResponse(resp, nil)
+}
+
+// GetUserSettings retrieves the settings of a user, acting as the user
+func (c *giteaClient) GetUserSettings(ctx context.Context, username string) (*UserSettings, error) {
+	resp, err := c.doRequest(withSudo(ctx, username), "GET", "/user/settings", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings UserSettings
+	if err := handleResponse(resp, &settings); err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// UpdateUserSettings updates the settings of a user, acting as the user
+func (c *giteaClient) UpdateUserSettings(ctx context.Context, username string, req *UpdateUserSettingsRequest) (*UserSettings, error) {
+	resp, err := c.doRequest(withSudo(ctx, username), "PATCH", "/user/settings", req)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings UserSettings
+	if err := handleResponse(resp, &settings); err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
@@ -72,6 +72,27 @@ func (c *giteaClient) UpdateRepositoryWebhook(ctx context.Context, owner, repo s
 	return &webhook, nil
 }
 
+// ListRepositoryWebhooks lists all webhooks of a repository
+func (c *giteaClient) ListRepositoryWebhooks(ctx context.Context, owner, repo string) ([]*Webhook, error) {
+	var hooks []*Webhook
+	for page := 1; ; page++ {
+		resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/repos/%s/%s/hooks?page=%d&limit=50", owner, repo, page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var list []*Webhook
+		if err := handleResponse(resp, &list); err != nil {
+			return nil, err
+		}
+
+		hooks = append(hooks, list...)
+		if len(list) < 50 {
+			return hooks, nil
+		}
+	}
+}
+
 // DeleteRepositoryWebhook deletes a webhook
 func (c *giteaClient) DeleteRepositoryWebhook(ctx context.Context, owner, repo string, id int64) error {
 	path := fmt.Sprintf("/repos/%s/%s/hooks/%d", owner, repo, id)
@@ -146,3 +167,67 @@ func (c *giteaClient) DeleteOrganizationWebhook(ctx context.Context, org string,
 
 	return handleResponse(resp, nil)
 }
+
+// GetAdminWebhook retrieves an instance-wide system or default webhook
+func (c *giteaClient) GetAdminWebhook(ctx context.Context, id int64) (*Webhook, error) {
+	path := fmt.Sprintf("/admin/hooks/%d", id)
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhook Webhook
+	if err := handleResponse(resp, &webhook); err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// CreateAdminWebhook creates an instance-wide webhook. Setting the
+// is_system_webhook config to "true" makes it a system webhook that fires for
+// every repository; otherwise it is a default webhook copied into new
+// repositories.
+func (c *giteaClient) CreateAdminWebhook(ctx context.Context, req *CreateWebhookRequest) (*Webhook, error) {
+	resp, err := c.doRequest(ctx, "POST", "/admin/hooks", req)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhook Webhook
+	if err := handleResponse(resp, &webhook); err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// UpdateAdminWebhook updates an instance-wide webhook
+func (c *giteaClient) UpdateAdminWebhook(ctx context.Context, id int64, req *UpdateWebhookRequest) (*Webhook, error) {
+	path := fmt.Sprintf("/admin/hooks/%d", id)
+
+	resp, err := c.doRequest(ctx, "PATCH", path, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhook Webhook
+	if err := handleResponse(resp, &webhook); err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// DeleteAdminWebhook deletes an instance-wide webhook
+func (c *giteaClient) DeleteAdminWebhook(ctx context.Context, id int64) error {
+	path := fmt.Sprintf("/admin/hooks/%d", id)
+
+	resp, err := c.doRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	return handleResponse(resp, nil)
+}
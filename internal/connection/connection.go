@@ -0,0 +1,204 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package connection renders Gitea credentials into the connection secret
+// layouts expected by common GitOps tooling.
+package connection
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Supported connection secret formats.
+const (
+	// FormatDefault publishes the raw connection details of the resource.
+	FormatDefault = "Default"
+
+	// FormatArgoCD publishes an Argo CD repository (or repository
+	// credential template) Secret.
+	FormatArgoCD = "ArgoCD"
+
+	// FormatFlux publishes a Flux GitRepository authentication Secret.
+	FormatFlux = "Flux"
+
+	// FormatGitCredentials publishes a git credential-store file under the
+	// .git-credentials key.
+	FormatGitCredentials = "GitCredentials"
+)
+
+// Argo CD secret labelling.
+const (
+	ArgoCDSecretTypeLabel     = "argocd.argoproj.io/secret-type"
+	ArgoCDSecretTypeRepo      = "repository"
+	ArgoCDSecretTypeRepoCreds = "repo-creds"
+)
+
+const (
+	errGetSecret   = "failed to get connection secret"
+	errLabelSecret = "failed to label connection secret"
+)
+
+// GitCredentials describes how to reach and authenticate to a Git remote.
+type GitCredentials struct {
+	// URL of the repository, or the URL prefix when the credentials apply to
+	// more than one repository.
+	URL string
+
+	// Template marks URL as a prefix shared by many repositories rather
+	// than a single repository.
+	Template bool
+
+	// Username and Password for HTTPS authentication.
+	Username string
+	Password string
+
+	// SSHPrivateKey, SSHPublicKey and KnownHosts for SSH authentication.
+	SSHPrivateKey string
+	SSHPublicKey  string
+	KnownHosts    string
+}
+
+// FormatOrDefault dereferences a connection secret format, falling back to
+// FormatDefault when unset.
+func FormatOrDefault(f *string) string {
+	if f == nil || *f == "" {
+		return FormatDefault
+	}
+	return *f
+}
+
+// Render lays out the supplied credentials as connection details in the
+// requested format. The Default format is left to the caller, which knows
+// which raw details its resource exposes, so Render returns nil for it.
+func Render(format string, c GitCredentials) managed.ConnectionDetails {
+	switch format {
+	case FormatArgoCD:
+		return renderArgoCD(c)
+	case FormatFlux:
+		return renderFlux(c)
+	case FormatGitCredentials:
+		return renderGitCredentials(c)
+	default:
+		return nil
+	}
+}
+
+// Labels returns the labels the connection secret must carry for the
+// requested format to be picked up by its consumer.
+func Labels(format string, c GitCredentials) map[string]string {
+	if format != FormatArgoCD {
+		return nil
+	}
+	if c.Template {
+		return map[string]string{ArgoCDSecretTypeLabel: ArgoCDSecretTypeRepoCreds}
+	}
+	return map[string]string{ArgoCDSecretTypeLabel: ArgoCDSecretTypeRepo}
+}
+
+func renderArgoCD(c GitCredentials) managed.ConnectionDetails {
+	cd := managed.ConnectionDetails{
+		"type": []byte("git"),
+		"url":  []byte(c.URL),
+	}
+	if c.SSHPrivateKey != "" {
+		cd["sshPrivateKey"] = []byte(c.SSHPrivateKey)
+	}
+	if c.Password != "" {
+		cd["username"] = []byte(c.Username)
+		cd["password"] = []byte(c.Password)
+	}
+	return cd
+}
+
+func renderFlux(c GitCredentials) managed.ConnectionDetails {
+	cd := managed.ConnectionDetails{}
+	if c.SSHPrivateKey != "" {
+		cd["identity"] = []byte(c.SSHPrivateKey)
+	}
+	if c.SSHPublicKey != "" {
+		cd["identity.pub"] = []byte(c.SSHPublicKey)
+	}
+	if c.KnownHosts != "" {
+		cd["known_hosts"] = []byte(c.KnownHosts)
+	}
+	if c.Password != "" {
+		cd["username"] = []byte(c.Username)
+		cd["password"] = []byte(c.Password)
+	}
+	return cd
+}
+
+func renderGitCredentials(c GitCredentials) managed.ConnectionDetails {
+	if c.Password == "" {
+		return managed.ConnectionDetails{}
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil || u.Host == "" {
+		return managed.ConnectionDetails{}
+	}
+	// Credential-store lines match on scheme and host only.
+	line := url.URL{Scheme: u.Scheme, Host: u.Host, User: url.UserPassword(c.Username, c.Password)}
+	return managed.ConnectionDetails{
+		".git-credentials": []byte(line.String() + "\n"),
+	}
+}
+
+// EnsureLabels adds the supplied labels to the connection secret of the
+// supplied managed resource. The connection secret is written by the managed
+// reconciler after Create returns, so a missing secret is not an error; the
+// labels are applied on a later observation.
+func EnsureLabels(ctx context.Context, kube client.Client, o resource.LocalConnectionSecretOwner, labels map[string]string) error {
+	ref := o.GetWriteConnectionSecretToReference()
+	if ref == nil || len(labels) == 0 {
+		return nil
+	}
+
+	s := &corev1.Secret{}
+	if err := kube.Get(ctx, client.ObjectKey{Namespace: o.GetNamespace(), Name: ref.Name}, s); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrap(err, errGetSecret)
+	}
+
+	missing := false
+	for k, v := range labels {
+		if s.Labels[k] != v {
+			missing = true
+			break
+		}
+	}
+	if !missing {
+		return nil
+	}
+
+	patch := client.MergeFrom(s.DeepCopy())
+	if s.Labels == nil {
+		s.Labels = map[string]string{}
+	}
+	for k, v := range labels {
+		s.Labels[k] = v
+	}
+	return errors.Wrap(kube.Patch(ctx, s, patch), errLabelSecret)
+}
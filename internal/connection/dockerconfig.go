@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connection
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errParseBaseURL    = "failed to parse base URL"
+	errGetPullSecret   = "failed to get pull secret"
+	errWritePullSecret = "failed to write pull secret"
+	errNotPullSecret   = "existing secret is not of type " + string(corev1.SecretTypeDockerConfigJson)
+	errNotOwnedSecret  = "existing secret is not controlled by this resource"
+	errMarshalConfig   = "failed to marshal docker config"
+)
+
+// RegistryHost returns the container registry host served by the Gitea
+// instance at baseURL. Gitea serves its registry from the root of the web
+// host, so this is the host and port of baseURL.
+func RegistryHost(baseURL string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", errors.Wrap(err, errParseBaseURL)
+	}
+	if u.Host == "" {
+		return "", errors.Errorf("%s: %q has no host", errParseBaseURL, baseURL)
+	}
+	return u.Host, nil
+}
+
+// DockerConfigJSON renders a .dockerconfigjson document granting username
+// and password access to registry.
+func DockerConfigJSON(registry, username, password string) ([]byte, error) {
+	type auth struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Auth     string `json:"auth"`
+	}
+	cfg := struct {
+		Auths map[string]auth `json:"auths"`
+	}{
+		Auths: map[string]auth{
+			registry: {
+				Username: username,
+				Password: password,
+				Auth:     base64.StdEncoding.EncodeToString([]byte(username + ":" + password)),
+			},
+		},
+	}
+	b, err := json.Marshal(cfg)
+	return b, errors.Wrap(err, errMarshalConfig)
+}
+
+// PublishPullSecret writes a kubernetes.io/dockerconfigjson Secret named name
+// in the namespace of owner, controlled by owner. An existing Secret is only
+// overwritten if owner already controls it.
+func PublishPullSecret(ctx context.Context, kube client.Client, owner metav1.Object, gvk schema.GroupVersionKind, name string, dockerConfig []byte) error {
+	s := &corev1.Secret{}
+	err := kube.Get(ctx, client.ObjectKey{Namespace: owner.GetNamespace(), Name: name}, s)
+	if kerrors.IsNotFound(err) {
+		s = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       owner.GetNamespace(),
+				Name:            name,
+				OwnerReferences: []metav1.OwnerReference{meta.AsController(meta.TypedReferenceTo(owner, gvk))},
+			},
+			Type: corev1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{corev1.DockerConfigJsonKey: dockerConfig},
+		}
+		return errors.Wrap(kube.Create(ctx, s), errWritePullSecret)
+	}
+	if err != nil {
+		return errors.Wrap(err, errGetPullSecret)
+	}
+
+	if c := metav1.GetControllerOf(s); c == nil || c.UID != owner.GetUID() {
+		return errors.New(errNotOwnedSecret)
+	}
+	if s.Type != corev1.SecretTypeDockerConfigJson {
+		return errors.New(errNotPullSecret)
+	}
+
+	s.Data = map[string][]byte{corev1.DockerConfigJsonKey: dockerConfig}
+	return errors.Wrap(kube.Update(ctx, s), errWritePullSecret)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	accesstokenv2 "github.com/rossigee/provider-gitea/apis/accesstoken/v2"
+)
+
+func TestRegistryHost(t *testing.T) {
+	host, err := RegistryHost("https://gitea.example.com:3000/")
+	require.NoError(t, err)
+	assert.Equal(t, "gitea.example.com:3000", host)
+
+	_, err = RegistryHost("gitea.example.com")
+	assert.Error(t, err)
+}
+
+func TestDockerConfigJSON(t *testing.T) {
+	b, err := DockerConfigJSON("gitea.example.com", "bot", "s3cret")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"auths":{"gitea.example.com":{"username":"bot","password":"s3cret","auth":"Ym90OnMzY3JldA=="}}}`, string(b))
+}
+
+func TestPublishPullSecret(t *testing.T) {
+	ctx := context.Background()
+	gvk := accesstokenv2.AccessTokenGroupVersionKind
+	owner := &accesstokenv2.AccessToken{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "token", UID: "uid-1"}}
+	key := client.ObjectKey{Namespace: "default", Name: "pull"}
+
+	t.Run("creates and refreshes owned secret", func(t *testing.T) {
+		kube := fake.NewClientBuilder().Build()
+
+		require.NoError(t, PublishPullSecret(ctx, kube, owner, gvk, "pull", []byte("first")))
+		s := &corev1.Secret{}
+		require.NoError(t, kube.Get(ctx, key, s))
+		assert.Equal(t, corev1.SecretTypeDockerConfigJson, s.Type)
+		assert.Equal(t, "first", string(s.Data[corev1.DockerConfigJsonKey]))
+		require.NotNil(t, metav1.GetControllerOf(s))
+		assert.Equal(t, owner.GetUID(), metav1.GetControllerOf(s).UID)
+
+		require.NoError(t, PublishPullSecret(ctx, kube, owner, gvk, "pull", []byte("second")))
+		require.NoError(t, kube.Get(ctx, key, s))
+		assert.Equal(t, "second", string(s.Data[corev1.DockerConfigJsonKey]))
+	})
+
+	t.Run("refuses to overwrite unowned secret", func(t *testing.T) {
+		kube := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pull"},
+			Type:       corev1.SecretTypeDockerConfigJson,
+		}).Build()
+
+		assert.Error(t, PublishPullSecret(ctx, kube, owner, gvk, "pull", []byte("x")))
+	})
+}
@@ -0,0 +1,366 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accesstoken
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-gitea/apis/accesstoken/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/connection"
+	"github.com/rossigee/provider-gitea/internal/tracing"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/rossigee/provider-gitea/apis/v1beta1"
+)
+
+const (
+	errNotAccessToken    = "managed resource is not an AccessToken custom resource"
+	errGetAccessToken    = "failed to get access token"
+	errCreateAccessToken = "failed to create access token"
+	errDeleteAccessToken = "failed to delete access token"
+	errGetProviderConfig = "failed to get provider config"
+	errGetRepository     = "failed to get repository for connection details"
+	errLabelSecret       = "failed to label connection secret"
+	errPublishPullSecret = "failed to publish pull secret"
+	errGetPullSecret     = "failed to get pull secret"
+	errReplaceToken      = "failed to replace access token"
+)
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient by:
+// 1. Getting the provider config
+// 2. Creating a Gitea API client
+// 3. Returning an external client wrapping the API client
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v2.AccessToken)
+	if !ok {
+		return nil, errors.New(errNotAccessToken)
+	}
+
+	pcRef := cr.Spec.ProviderConfigReference
+	if pcRef == nil {
+		return nil, errors.New("providerConfigRef is required")
+	}
+
+	var pc v1beta1.ProviderConfig
+	if err := c.kube.Get(ctx, client.ObjectKey{
+		Namespace: cr.GetNamespace(),
+		Name:      pcRef.Name,
+	}, &pc); err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
+
+	conn, err := clients.NewClient(ctx, &pc, c.kube)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalClient{
+		client:  conn,
+		kube:    c.kube,
+		baseURL: strings.TrimSuffix(pc.Spec.BaseURL, "/"),
+	}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it matches the managed resource's desired state.
+type externalClient struct {
+	client  clients.Client
+	kube    client.Client
+	baseURL string
+}
+
+func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	_, span := tracing.StartSpan(ctx, "accesstoken.observe",
+		tracing.SpanAttrs("accesstoken", tracing.ResourceName(mg), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.AccessToken)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotAccessToken)
+	}
+
+	externalID := meta.GetExternalName(cr)
+	if externalID == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	tokenID, err := strconv.ParseInt(externalID, 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	token, err := e.client.GetAccessToken(ctx, cr.Spec.ForProvider.Username, tokenID)
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetAccessToken)
+	}
+
+	cr.Status.AtProvider = v2.AccessTokenObservation{
+		ID:             &token.ID,
+		Name:           &token.Name,
+		Scopes:         token.Scopes,
+		TokenLastEight: &token.TokenLastEight,
+		Username:       &cr.Spec.ForProvider.Username,
+	}
+
+	format := connection.FormatOrDefault(cr.Spec.ForProvider.ConnectionSecretFormat)
+	labels := connection.Labels(format, connection.GitCredentials{Template: cr.Spec.ForProvider.Repository == nil})
+	if err := connection.EnsureLabels(ctx, e.kube, cr, labels); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errLabelSecret)
+	}
+
+	published, err := e.pullSecretPublished(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	// Gitea cannot edit tokens after creation, and only reveals the token
+	// value once, so connection details are published when a token is
+	// issued. A token is only out of date when its pull secret has gone
+	// missing, which takes a new token to restore.
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: published}, nil
+}
+
+func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	_, span := tracing.StartSpan(ctx, "accesstoken.create",
+		tracing.SpanAttrs("accesstoken", tracing.ResourceName(mg), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.AccessToken)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotAccessToken)
+	}
+
+	// The token value is only revealed once, so everything it is published
+	// with is looked up before it is issued.
+	creds, err := e.credentials(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	id, cd, err := e.issue(ctx, cr, creds)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	meta.SetExternalName(cr, strconv.FormatInt(id, 10))
+	return managed.ExternalCreation{ConnectionDetails: cd}, nil
+}
+
+// Update replaces a token whose pull secret has gone missing. Gitea rejects
+// a second token with the name of an existing one, so the old token is
+// deleted before its replacement is issued. Should issuing fail, the token
+// is reported missing and issued again by Create.
+func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	_, span := tracing.StartSpan(ctx, "accesstoken.update",
+		tracing.SpanAttrs("accesstoken", tracing.ResourceName(mg), "update")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.AccessToken)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotAccessToken)
+	}
+
+	creds, err := e.credentials(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if tokenID, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64); err == nil {
+		err := e.client.DeleteAccessToken(ctx, cr.Spec.ForProvider.Username, tokenID)
+		if err != nil && !strings.Contains(err.Error(), "404") {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errReplaceToken)
+		}
+	}
+
+	id, cd, err := e.issue(ctx, cr, creds)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// The managed reconciler does not persist the external name set during
+	// Update, so it is recorded explicitly.
+	orig := cr.DeepCopy()
+	meta.SetExternalName(cr, strconv.FormatInt(id, 10))
+	if err := e.kube.Patch(ctx, cr, client.MergeFrom(orig)); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errReplaceToken)
+	}
+
+	return managed.ExternalUpdate{ConnectionDetails: cd}, nil
+}
+
+func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	_, span := tracing.StartSpan(ctx, "accesstoken.delete",
+		tracing.SpanAttrs("accesstoken", tracing.ResourceName(mg), "delete")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.AccessToken)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotAccessToken)
+	}
+
+	tokenID, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalDelete{}, nil
+	}
+
+	err = e.client.DeleteAccessToken(ctx, cr.Spec.ForProvider.Username, tokenID)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteAccessToken)
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *externalClient) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// issue creates a token for cr and publishes it to the pull secret, if one
+// was requested. It returns the token ID and the connection details carrying
+// the token with creds.
+func (e *externalClient) issue(ctx context.Context, cr *v2.AccessToken, creds connection.GitCredentials) (int64, managed.ConnectionDetails, error) {
+	token, err := e.client.CreateAccessToken(ctx, cr.Spec.ForProvider.Username, &clients.CreateAccessTokenRequest{
+		Name:   cr.Spec.ForProvider.Name,
+		Scopes: cr.Spec.ForProvider.Scopes,
+	})
+	if err != nil {
+		return 0, nil, errors.Wrap(err, errCreateAccessToken)
+	}
+
+	// A token whose pull secret cannot be written is withdrawn rather than
+	// left unusable.
+	if err := e.publishPullSecret(ctx, cr, token.Token); err != nil {
+		_ = e.client.DeleteAccessToken(ctx, cr.Spec.ForProvider.Username, token.ID)
+		return 0, nil, errors.Wrap(err, errPublishPullSecret)
+	}
+
+	creds.Password = token.Token
+	return token.ID, connectionDetails(cr, creds), nil
+}
+
+// credentials returns the credentials a token of cr is published with, less
+// the token itself. Without a repository the token is published as a
+// credential template covering every repository on the instance.
+func (e *externalClient) credentials(ctx context.Context, cr *v2.AccessToken) (connection.GitCredentials, error) {
+	creds := connection.GitCredentials{
+		URL:      e.baseURL,
+		Template: true,
+		Username: cr.Spec.ForProvider.Username,
+	}
+
+	if r := cr.Spec.ForProvider.Repository; r != nil {
+		owner, name, _ := strings.Cut(*r, "/")
+		repo, err := e.client.GetRepository(ctx, owner, name)
+		if err != nil {
+			return connection.GitCredentials{}, errors.Wrap(err, errGetRepository)
+		}
+		creds.URL = repo.CloneURL
+		creds.Template = false
+	}
+
+	return creds, nil
+}
+
+// connectionDetails renders the freshly created token, carried as the
+// password of creds, in the requested format.
+func connectionDetails(cr *v2.AccessToken, creds connection.GitCredentials) managed.ConnectionDetails {
+	format := connection.FormatOrDefault(cr.Spec.ForProvider.ConnectionSecretFormat)
+	if format != connection.FormatDefault {
+		return connection.Render(format, creds)
+	}
+
+	return managed.ConnectionDetails{
+		"token":    []byte(creds.Password),
+		"username": []byte(creds.Username),
+		"url":      []byte(creds.URL),
+	}
+}
+
+// pullSecretPublished reports whether the pull secret of cr exists, or none
+// was requested.
+func (e *externalClient) pullSecretPublished(ctx context.Context, cr *v2.AccessToken) (bool, error) {
+	ref := cr.Spec.ForProvider.WritePullSecretToRef
+	if ref == nil {
+		return true, nil
+	}
+
+	err := e.kube.Get(ctx, client.ObjectKey{Namespace: cr.GetNamespace(), Name: ref.Name}, &corev1.Secret{})
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, errors.Wrap(err, errGetPullSecret)
+}
+
+// publishPullSecret writes the token to the registry pull secret, if one was
+// requested. It runs whenever a token is issued, so the pull secret always
+// carries the current token.
+func (e *externalClient) publishPullSecret(ctx context.Context, cr *v2.AccessToken, token string) error {
+	ref := cr.Spec.ForProvider.WritePullSecretToRef
+	if ref == nil {
+		return nil
+	}
+
+	registry, err := connection.RegistryHost(e.baseURL)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := connection.DockerConfigJSON(registry, cr.Spec.ForProvider.Username, token)
+	if err != nil {
+		return err
+	}
+
+	return connection.PublishPullSecret(ctx, e.kube, cr, v2.AccessTokenGroupVersionKind, ref.Name, cfg)
+}
+
+// Setup adds a controller that reconciles AccessToken managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v2.AccessTokenKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v2.AccessTokenGroupVersionKind),
+		managed.WithExternalConnector(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v2.AccessToken{}).
+		Complete(r)
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accesstoken
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/rossigee/provider-gitea/apis/accesstoken/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/connection"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+)
+
+type mockTokenClient struct {
+	testutil.NoopClient
+	repoErr error
+	created int
+	deleted []int64
+}
+
+func (m *mockTokenClient) GetRepository(ctx context.Context, owner, name string) (*clients.Repository, error) {
+	if m.repoErr != nil {
+		return nil, m.repoErr
+	}
+	return &clients.Repository{CloneURL: "https://gitea.example.com/" + owner + "/" + name + ".git"}, nil
+}
+
+func (m *mockTokenClient) CreateAccessToken(ctx context.Context, username string, req *clients.CreateAccessTokenRequest) (*clients.AccessToken, error) {
+	m.created++
+	return &clients.AccessToken{ID: 9, Name: req.Name, Token: "s3cr3t"}, nil
+}
+
+func (m *mockTokenClient) GetAccessToken(ctx context.Context, username string, id int64) (*clients.AccessToken, error) {
+	return &clients.AccessToken{ID: id, Name: "ci"}, nil
+}
+
+func (m *mockTokenClient) DeleteAccessToken(ctx context.Context, username string, id int64) error {
+	m.deleted = append(m.deleted, id)
+	return nil
+}
+
+func newToken() *v2.AccessToken {
+	return &v2.AccessToken{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ci"},
+		Spec: v2.AccessTokenSpec{ForProvider: v2.AccessTokenParameters{
+			Username: "ci-bot",
+			Name:     "ci",
+			Scopes:   []string{"read:repository"},
+		}},
+	}
+}
+
+func TestCreateConnectionDetails(t *testing.T) {
+	repo := "acme/app"
+	argo := connection.FormatArgoCD
+
+	cases := map[string]struct {
+		mutate func(*v2.AccessToken)
+		want   map[string]string
+	}{
+		"InstanceWide": {
+			mutate: func(*v2.AccessToken) {},
+			want:   map[string]string{"token": "s3cr3t", "username": "ci-bot", "url": "https://gitea.example.com"},
+		},
+		"Repository": {
+			mutate: func(cr *v2.AccessToken) { cr.Spec.ForProvider.Repository = &repo },
+			want:   map[string]string{"token": "s3cr3t", "username": "ci-bot", "url": "https://gitea.example.com/acme/app.git"},
+		},
+		"ArgoCD": {
+			mutate: func(cr *v2.AccessToken) {
+				cr.Spec.ForProvider.Repository = &repo
+				cr.Spec.ForProvider.ConnectionSecretFormat = &argo
+			},
+			want: map[string]string{"type": "git", "username": "ci-bot", "password": "s3cr3t", "url": "https://gitea.example.com/acme/app.git"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := newToken()
+			tc.mutate(cr)
+			ec := &externalClient{client: &mockTokenClient{}, baseURL: "https://gitea.example.com"}
+
+			cre, err := ec.Create(context.Background(), cr)
+			require.NoError(t, err)
+			assert.Equal(t, "9", meta.GetExternalName(cr))
+
+			got := map[string]string{}
+			for k, v := range cre.ConnectionDetails {
+				got[k] = string(v)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestCreateLooksUpBeforeIssuing(t *testing.T) {
+	repo := "acme/app"
+	cr := newToken()
+	cr.Spec.ForProvider.Repository = &repo
+	m := &mockTokenClient{repoErr: errors.New("API request failed with status 500")}
+	ec := &externalClient{client: m, baseURL: "https://gitea.example.com"}
+
+	_, err := ec.Create(context.Background(), cr)
+	require.Error(t, err)
+	assert.Zero(t, m.created, "no token may be issued when its connection details cannot be built")
+	assert.Empty(t, meta.GetExternalName(cr))
+}
+
+func TestPullSecretRestored(t *testing.T) {
+	ctx := context.Background()
+	cr := newToken()
+	cr.Spec.ForProvider.WritePullSecretToRef = &xpv1.LocalSecretReference{Name: "ci-pull"}
+	meta.SetExternalName(cr, "7")
+	kube := fake.NewClientBuilder().WithScheme(testutil.Scheme(t)).WithObjects(cr.DeepCopy()).Build()
+	m := &mockTokenClient{}
+	ec := &externalClient{client: m, kube: kube, baseURL: "https://gitea.example.com"}
+
+	obs, err := ec.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+	assert.False(t, obs.ResourceUpToDate, "a missing pull secret takes a new token")
+
+	upd, err := ec.Update(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{7}, m.deleted)
+	assert.Equal(t, "s3cr3t", string(upd.ConnectionDetails["token"]))
+
+	s := &corev1.Secret{}
+	require.NoError(t, kube.Get(ctx, client.ObjectKey{Namespace: "default", Name: "ci-pull"}, s))
+	assert.Contains(t, string(s.Data[corev1.DockerConfigJsonKey]), "gitea.example.com")
+
+	got := &v2.AccessToken{}
+	require.NoError(t, kube.Get(ctx, client.ObjectKeyFromObject(cr), got))
+	assert.Equal(t, "9", meta.GetExternalName(got))
+
+	obs, err = ec.Observe(ctx, got)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+}
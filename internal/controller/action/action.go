@@ -0,0 +1,303 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"context"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-gitea/apis/action/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/tracing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/rossigee/provider-gitea/apis/v1beta1"
+)
+
+const (
+	errNotAction         = "managed resource is not an Action custom resource"
+	errGetAction         = "failed to get action workflow"
+	errGetWorkflowState  = "failed to get action workflow state"
+	errGetLastRun        = "failed to get last action workflow run"
+	errCreateAction      = "failed to create action workflow"
+	errUpdateAction      = "failed to update action workflow"
+	errEnableAction      = "failed to enable action workflow"
+	errDisableAction     = "failed to disable action workflow"
+	errDeleteAction      = "failed to delete action workflow"
+	errGetProviderConfig = "failed to get provider config"
+
+	defaultCommitMessage = "Update workflow via Crossplane"
+
+	// stateActive is the workflow state Gitea reports for enabled workflows.
+	stateActive = "active"
+)
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient by:
+// 1. Getting the provider config
+// 2. Creating a Gitea API client
+// 3. Returning an external client wrapping the API client
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v2.Action)
+	if !ok {
+		return nil, errors.New(errNotAction)
+	}
+
+	pcRef := cr.Spec.ProviderConfigReference
+	if pcRef == nil {
+		return nil, errors.New("providerConfigRef is required")
+	}
+
+	var pc v1beta1.ProviderConfig
+	if err := c.kube.Get(ctx, client.ObjectKey{
+		Namespace: cr.GetNamespace(),
+		Name:      pcRef.Name,
+	}, &pc); err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
+
+	conn, err := clients.NewClient(ctx, &pc, c.kube)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalClient{client: conn}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it matches the managed resource's desired state.
+type externalClient struct {
+	client clients.Client
+}
+
+func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	_, span := tracing.StartSpan(ctx, "action.observe",
+		tracing.SpanAttrs("action", tracing.ResourceName(mg), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.Action)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotAction)
+	}
+
+	p := cr.Spec.ForProvider
+	action, err := e.client.GetAction(ctx, p.Repository, p.WorkflowName, branch(cr))
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetAction)
+	}
+
+	cr.Status.AtProvider = v2.ActionObservation{
+		WorkflowName: &action.WorkflowName,
+		Path:         &action.Path,
+		SHA:          &action.SHA,
+		HTMLURL:      &action.HTMLURL,
+		Repository:   &p.Repository,
+		Branch:       p.Branch,
+	}
+
+	upToDate := action.Content == p.Content
+
+	// Gitea only reports workflows it has indexed, so a workflow that is
+	// not yet known to the Actions API has no state to reconcile.
+	workflow, err := e.client.GetActionWorkflow(ctx, p.Repository, p.WorkflowName)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetWorkflowState)
+	}
+	if err == nil && workflow != nil {
+		cr.Status.AtProvider.ID = &workflow.ID
+		cr.Status.AtProvider.State = &workflow.State
+		cr.Status.AtProvider.CreatedAt = &workflow.CreatedAt
+		cr.Status.AtProvider.UpdatedAt = &workflow.UpdatedAt
+		cr.Status.AtProvider.URL = &workflow.URL
+		cr.Status.AtProvider.BadgeURL = &workflow.BadgeURL
+		if workflow.HTMLURL != "" {
+			cr.Status.AtProvider.HTMLURL = &workflow.HTMLURL
+		}
+		upToDate = upToDate && (workflow.State == stateActive) == enabled(cr)
+	}
+
+	run, err := e.client.GetActionLastRun(ctx, p.Repository, p.WorkflowName)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetLastRun)
+	}
+	if err == nil && run != nil {
+		cr.Status.AtProvider.LastRun = &v2.ActionLastRun{
+			ID:         &run.ID,
+			Status:     &run.Status,
+			Conclusion: &run.Conclusion,
+			CreatedAt:  &run.StartedAt,
+			UpdatedAt:  &run.CompletedAt,
+			RunNumber:  &run.RunNumber,
+			Event:      &run.Event,
+			HTMLURL:    &run.HTMLURL,
+		}
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: upToDate}, nil
+}
+
+func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	_, span := tracing.StartSpan(ctx, "action.create",
+		tracing.SpanAttrs("action", tracing.ResourceName(mg), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.Action)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotAction)
+	}
+
+	// A disabled workflow is committed first and disabled by the next
+	// Update, once Gitea reports the new workflow.
+	p := cr.Spec.ForProvider
+	_, err := e.client.CreateAction(ctx, p.Repository, &clients.CreateActionRequest{
+		WorkflowName: p.WorkflowName,
+		Content:      p.Content,
+		Message:      commitMessage(cr),
+		Branch:       branch(cr),
+	})
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateAction)
+}
+
+func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	_, span := tracing.StartSpan(ctx, "action.update",
+		tracing.SpanAttrs("action", tracing.ResourceName(mg), "update")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.Action)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotAction)
+	}
+
+	p := cr.Spec.ForProvider
+	action, err := e.client.GetAction(ctx, p.Repository, p.WorkflowName, branch(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetAction)
+	}
+
+	if action.Content != p.Content {
+		// The SHA recorded by Observe guards against overwriting a change
+		// made since the workflow was last observed.
+		sha := action.SHA
+		if cr.Status.AtProvider.SHA != nil {
+			sha = *cr.Status.AtProvider.SHA
+		}
+		if _, err := e.client.UpdateAction(ctx, p.Repository, p.WorkflowName, &clients.UpdateActionRequest{
+			Content: p.Content,
+			SHA:     sha,
+			Message: commitMessage(cr),
+			Branch:  branch(cr),
+		}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateAction)
+		}
+	}
+
+	if s := cr.Status.AtProvider.State; s != nil && (*s == stateActive) != enabled(cr) {
+		if enabled(cr) {
+			err = errors.Wrap(e.client.EnableAction(ctx, p.Repository, p.WorkflowName), errEnableAction)
+		} else {
+			err = errors.Wrap(e.client.DisableAction(ctx, p.Repository, p.WorkflowName), errDisableAction)
+		}
+	}
+
+	return managed.ExternalUpdate{}, err
+}
+
+func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	_, span := tracing.StartSpan(ctx, "action.delete",
+		tracing.SpanAttrs("action", tracing.ResourceName(mg), "delete")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.Action)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotAction)
+	}
+
+	p := cr.Spec.ForProvider
+	action, err := e.client.GetAction(ctx, p.Repository, p.WorkflowName, branch(cr))
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return managed.ExternalDelete{}, nil
+		}
+		return managed.ExternalDelete{}, errors.Wrap(err, errGetAction)
+	}
+
+	err = e.client.DeleteAction(ctx, p.Repository, p.WorkflowName, &clients.DeleteActionRequest{
+		SHA:     action.SHA,
+		Message: "Delete " + action.Path,
+		Branch:  branch(cr),
+	})
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteAction)
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *externalClient) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// branch returns the branch the workflow is committed to. An empty branch
+// selects the repository default branch.
+func branch(cr *v2.Action) string {
+	if cr.Spec.ForProvider.Branch != nil {
+		return *cr.Spec.ForProvider.Branch
+	}
+	return ""
+}
+
+func commitMessage(cr *v2.Action) string {
+	if cr.Spec.ForProvider.CommitMessage != nil {
+		return *cr.Spec.ForProvider.CommitMessage
+	}
+	return defaultCommitMessage
+}
+
+func enabled(cr *v2.Action) bool {
+	return cr.Spec.ForProvider.Enabled == nil || *cr.Spec.ForProvider.Enabled
+}
+
+// Setup adds a controller that reconciles Action managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v2.ActionKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v2.ActionGroupVersionKind),
+		managed.WithExternalConnector(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v2.Action{}).
+		Complete(r)
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/rossigee/provider-gitea/apis/action/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type mockActionClient struct {
+	testutil.NoopClient
+	action   *clients.Action
+	workflow *clients.ActionWorkflow
+	run      *clients.ActionRun
+	updated  *clients.UpdateActionRequest
+	enabled  bool
+	disabled bool
+}
+
+func (m *mockActionClient) GetAction(ctx context.Context, repository, workflowName, branch string) (*clients.Action, error) {
+	if m.action == nil {
+		return nil, fmt.Errorf("API request failed with status 404: not found")
+	}
+	return m.action, nil
+}
+
+func (m *mockActionClient) UpdateAction(ctx context.Context, repository, workflowName string, req *clients.UpdateActionRequest) (*clients.Action, error) {
+	m.updated = req
+	return m.action, nil
+}
+
+func (m *mockActionClient) GetActionWorkflow(ctx context.Context, repository, workflowName string) (*clients.ActionWorkflow, error) {
+	if m.workflow == nil {
+		return nil, fmt.Errorf("API request failed with status 404: not found")
+	}
+	return m.workflow, nil
+}
+
+func (m *mockActionClient) EnableAction(ctx context.Context, repository, workflowName string) error {
+	m.enabled = true
+	return nil
+}
+
+func (m *mockActionClient) DisableAction(ctx context.Context, repository, workflowName string) error {
+	m.disabled = true
+	return nil
+}
+
+func (m *mockActionClient) GetActionLastRun(ctx context.Context, repository, workflowName string) (*clients.ActionRun, error) {
+	return m.run, nil
+}
+
+func newAction(content string, enabled bool) *v2.Action {
+	branch := "main"
+	return &v2.Action{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ci"},
+		Spec: v2.ActionSpec{
+			ForProvider: v2.ActionParameters{
+				Repository:   "testorg/testrepo",
+				WorkflowName: "ci.yml",
+				Content:      content,
+				Branch:       &branch,
+				Enabled:      &enabled,
+			},
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	t.Run("missing workflow file does not exist", func(t *testing.T) {
+		ec := &externalClient{client: &mockActionClient{}}
+
+		obs, err := ec.Observe(context.Background(), newAction("on: push\n", true))
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+
+	t.Run("matching workflow is up to date", func(t *testing.T) {
+		ec := &externalClient{client: &mockActionClient{
+			action:   &clients.Action{WorkflowName: "ci.yml", Path: ".gitea/workflows/ci.yml", SHA: "abc123", Content: "on: push\n"},
+			workflow: &clients.ActionWorkflow{ID: "ci.yml", State: "active"},
+			run:      &clients.ActionRun{ID: 8, RunNumber: 4, Status: "completed", Conclusion: "success"},
+		}}
+		cr := newAction("on: push\n", true)
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.Equal(t, "abc123", *cr.Status.AtProvider.SHA)
+		assert.Equal(t, "active", *cr.Status.AtProvider.State)
+		require.NotNil(t, cr.Status.AtProvider.LastRun)
+		assert.Equal(t, "success", *cr.Status.AtProvider.LastRun.Conclusion)
+	})
+
+	t.Run("changed content is not up to date", func(t *testing.T) {
+		ec := &externalClient{client: &mockActionClient{
+			action: &clients.Action{WorkflowName: "ci.yml", Content: "on: pull_request\n"},
+		}}
+
+		obs, err := ec.Observe(context.Background(), newAction("on: push\n", true))
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate)
+	})
+
+	t.Run("disabled workflow is not up to date when enabled", func(t *testing.T) {
+		ec := &externalClient{client: &mockActionClient{
+			action:   &clients.Action{WorkflowName: "ci.yml", Content: "on: push\n"},
+			workflow: &clients.ActionWorkflow{ID: "ci.yml", State: "disabled_manually"},
+		}}
+
+		obs, err := ec.Observe(context.Background(), newAction("on: push\n", true))
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("commits content with observed SHA", func(t *testing.T) {
+		m := &mockActionClient{action: &clients.Action{WorkflowName: "ci.yml", SHA: "current", Content: "on: pull_request\n"}}
+		ec := &externalClient{client: m}
+		cr := newAction("on: push\n", true)
+		sha := "observed"
+		cr.Status.AtProvider.SHA = &sha
+
+		_, err := ec.Update(context.Background(), cr)
+		require.NoError(t, err)
+		require.NotNil(t, m.updated)
+		assert.Equal(t, "observed", m.updated.SHA)
+		assert.Equal(t, "main", m.updated.Branch)
+		assert.False(t, m.enabled)
+		assert.False(t, m.disabled)
+	})
+
+	t.Run("disables active workflow", func(t *testing.T) {
+		m := &mockActionClient{action: &clients.Action{WorkflowName: "ci.yml", Content: "on: push\n"}}
+		ec := &externalClient{client: m}
+		cr := newAction("on: push\n", false)
+		state := "active"
+		cr.Status.AtProvider.State = &state
+
+		_, err := ec.Update(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Nil(t, m.updated)
+		assert.True(t, m.disabled)
+	})
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package branch
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-gitea/apis/branch/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type mockBranchClient struct {
+	testutil.NoopClient
+	branch  *clients.RepositoryBranch
+	created *clients.CreateBranchRequest
+	deleted bool
+}
+
+func (m *mockBranchClient) GetRepositoryBranch(ctx context.Context, owner, repo, branch string) (*clients.RepositoryBranch, error) {
+	if m.branch == nil {
+		return nil, fmt.Errorf("API request failed with status 404: not found")
+	}
+	return m.branch, nil
+}
+
+func (m *mockBranchClient) CreateRepositoryBranch(ctx context.Context, owner, repo string, req *clients.CreateBranchRequest) (*clients.RepositoryBranch, error) {
+	m.created = req
+	return &clients.RepositoryBranch{Name: req.NewBranchName}, nil
+}
+
+func (m *mockBranchClient) DeleteRepositoryBranch(ctx context.Context, owner, repo, branch string) error {
+	m.deleted = true
+	return nil
+}
+
+func newBranch(p v2.BranchParameters) *v2.Branch {
+	p.Repository = "testorg/testrepo"
+	p.Name = "develop"
+	return &v2.Branch{Spec: v2.BranchSpec{ForProvider: p}}
+}
+
+func TestObserve(t *testing.T) {
+	t.Run("missing branch does not exist", func(t *testing.T) {
+		ec := &externalClient{client: &mockBranchClient{}}
+
+		obs, err := ec.Observe(context.Background(), newBranch(v2.BranchParameters{}))
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+
+	t.Run("existing branch is available", func(t *testing.T) {
+		ec := &externalClient{client: &mockBranchClient{branch: &clients.RepositoryBranch{
+			Name:   "develop",
+			Commit: &clients.BranchCommit{ID: "abc123"},
+		}}}
+		cr := newBranch(v2.BranchParameters{})
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.Equal(t, "abc123", *cr.Status.AtProvider.CommitSHA)
+		assert.Equal(t, xpv1.ReasonAvailable, cr.GetCondition(xpv1.TypeReady).Reason)
+	})
+
+	t.Run("kept branch is released on deletion", func(t *testing.T) {
+		ec := &externalClient{client: &mockBranchClient{branch: &clients.RepositoryBranch{Name: "develop"}}}
+		cr := newBranch(v2.BranchParameters{})
+		now := metav1.Now()
+		cr.SetDeletionTimestamp(&now)
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+
+	t.Run("deleted branch is observed until removed", func(t *testing.T) {
+		ec := &externalClient{client: &mockBranchClient{branch: &clients.RepositoryBranch{Name: "develop"}}}
+		remove := true
+		cr := newBranch(v2.BranchParameters{DeleteOnRemoval: &remove})
+		now := metav1.Now()
+		cr.SetDeletionTimestamp(&now)
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	m := &mockBranchClient{}
+	ec := &externalClient{client: m}
+	from := "v1.0.0"
+
+	_, err := ec.Create(context.Background(), newBranch(v2.BranchParameters{From: &from}))
+	require.NoError(t, err)
+	assert.Equal(t, "develop", m.created.NewBranchName)
+	assert.Equal(t, "v1.0.0", m.created.OldRefName)
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("branch is kept by default", func(t *testing.T) {
+		m := &mockBranchClient{}
+		ec := &externalClient{client: m}
+
+		_, err := ec.Delete(context.Background(), newBranch(v2.BranchParameters{}))
+		require.NoError(t, err)
+		assert.False(t, m.deleted)
+	})
+
+	t.Run("branch is deleted on removal when requested", func(t *testing.T) {
+		m := &mockBranchClient{}
+		ec := &externalClient{client: m}
+		deleteOnRemoval := true
+
+		_, err := ec.Delete(context.Background(), newBranch(v2.BranchParameters{DeleteOnRemoval: &deleteOnRemoval}))
+		require.NoError(t, err)
+		assert.True(t, m.deleted)
+	})
+}
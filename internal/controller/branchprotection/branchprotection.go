@@ -0,0 +1,351 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package branchprotection
+
+import (
+	"context"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-gitea/apis/branchprotection/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/lists"
+	"github.com/rossigee/provider-gitea/internal/tracing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/rossigee/provider-gitea/apis/v1beta1"
+)
+
+const (
+	errNotBranchProtection    = "managed resource is not a BranchProtection custom resource"
+	errGetBranchProtection    = "failed to get branch protection"
+	errCreateBranchProtection = "failed to create branch protection"
+	errUpdateBranchProtection = "failed to update branch protection"
+	errDeleteBranchProtection = "failed to delete branch protection"
+	errGetProviderConfig      = "failed to get provider config"
+)
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient by:
+// 1. Getting the provider config
+// 2. Creating a Gitea API client
+// 3. Returning an external client wrapping the API client
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v2.BranchProtection)
+	if !ok {
+		return nil, errors.New(errNotBranchProtection)
+	}
+
+	pcRef := cr.Spec.ProviderConfigReference
+	if pcRef == nil {
+		return nil, errors.New("providerConfigRef is required")
+	}
+
+	var pc v1beta1.ProviderConfig
+	if err := c.kube.Get(ctx, client.ObjectKey{
+		Namespace: cr.GetNamespace(),
+		Name:      pcRef.Name,
+	}, &pc); err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
+
+	conn, err := clients.NewClient(ctx, &pc, c.kube)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalClient{client: conn}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it matches the managed resource's desired state.
+type externalClient struct {
+	client clients.Client
+}
+
+func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	_, span := tracing.StartSpan(ctx, "branchprotection.observe",
+		tracing.SpanAttrs("branchprotection", tracing.ResourceName(mg), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.BranchProtection)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotBranchProtection)
+	}
+
+	p := cr.Spec.ForProvider
+	bp, err := e.client.GetBranchProtection(ctx, p.Repository, p.RuleName)
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetBranchProtection)
+	}
+
+	cr.Status.AtProvider = v2.BranchProtectionObservation{
+		RuleName:        &bp.RuleName,
+		Priority:        &bp.Priority,
+		CreatedAt:       &bp.CreatedAt,
+		UpdatedAt:       &bp.UpdatedAt,
+		AppliedSettings: appliedSettings(bp),
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	drift := diff(p, bp)
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: len(drift) == 0,
+		Diff:             strings.Join(drift, ", "),
+	}, nil
+}
+
+func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	_, span := tracing.StartSpan(ctx, "branchprotection.create",
+		tracing.SpanAttrs("branchprotection", tracing.ResourceName(mg), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.BranchProtection)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotBranchProtection)
+	}
+
+	_, err := e.client.CreateBranchProtection(ctx, cr.Spec.ForProvider.Repository, createRequest(cr.Spec.ForProvider))
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateBranchProtection)
+}
+
+func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	_, span := tracing.StartSpan(ctx, "branchprotection.update",
+		tracing.SpanAttrs("branchprotection", tracing.ResourceName(mg), "update")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.BranchProtection)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotBranchProtection)
+	}
+
+	p := cr.Spec.ForProvider
+	_, err := e.client.UpdateBranchProtection(ctx, p.Repository, p.RuleName, updateRequest(p))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateBranchProtection)
+}
+
+func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	_, span := tracing.StartSpan(ctx, "branchprotection.delete",
+		tracing.SpanAttrs("branchprotection", tracing.ResourceName(mg), "delete")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.BranchProtection)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotBranchProtection)
+	}
+
+	p := cr.Spec.ForProvider
+	err := e.client.DeleteBranchProtection(ctx, p.Repository, p.RuleName)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteBranchProtection)
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *externalClient) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+func createRequest(p v2.BranchProtectionParameters) *clients.CreateBranchProtectionRequest {
+	return &clients.CreateBranchProtectionRequest{
+		RuleName:                      p.RuleName,
+		Priority:                      p.Priority,
+		EnablePush:                    p.EnablePush,
+		EnablePushWhitelist:           p.EnablePushWhitelist,
+		PushWhitelistUsernames:        p.PushWhitelistUsernames,
+		PushWhitelistTeams:            p.PushWhitelistTeams,
+		PushWhitelistDeployKeys:       p.PushWhitelistDeployKeys,
+		EnableForcePush:               p.EnableForcePush,
+		EnableForcePushAllowlist:      p.EnableForcePushAllowlist,
+		ForcePushAllowlistUsernames:   p.ForcePushAllowlistUsernames,
+		ForcePushAllowlistTeams:       p.ForcePushAllowlistTeams,
+		ForcePushAllowlistDeployKeys:  p.ForcePushAllowlistDeployKeys,
+		EnableMergeWhitelist:          p.EnableMergeWhitelist,
+		MergeWhitelistUsernames:       p.MergeWhitelistUsernames,
+		MergeWhitelistTeams:           p.MergeWhitelistTeams,
+		EnableStatusCheck:             p.EnableStatusCheck,
+		StatusCheckContexts:           p.StatusCheckContexts,
+		RequiredApprovals:             p.RequiredApprovals,
+		EnableApprovalsWhitelist:      p.EnableApprovalsWhitelist,
+		ApprovalsWhitelistUsernames:   p.ApprovalsWhitelistUsernames,
+		ApprovalsWhitelistTeams:       p.ApprovalsWhitelistTeams,
+		BlockOnRejectedReviews:        p.BlockOnRejectedReviews,
+		BlockOnOfficialReviewRequests: p.BlockOnOfficialReviewRequests,
+		BlockOnOutdatedBranch:         p.BlockOnOutdatedBranch,
+		BlockAdminMergeOverride:       p.BlockAdminMergeOverride,
+		DismissStaleApprovals:         p.DismissStaleApprovals,
+		IgnoreStaleApprovals:          p.IgnoreStaleApprovals,
+		RequireSignedCommits:          p.RequireSignedCommits,
+		ProtectedFilePatterns:         p.ProtectedFilePatterns,
+		UnprotectedFilePatterns:       p.UnprotectedFilePatterns,
+	}
+}
+
+func updateRequest(p v2.BranchProtectionParameters) *clients.UpdateBranchProtectionRequest {
+	return &clients.UpdateBranchProtectionRequest{
+		Priority:                      p.Priority,
+		EnablePush:                    p.EnablePush,
+		EnablePushWhitelist:           p.EnablePushWhitelist,
+		PushWhitelistUsernames:        lists.OrEmpty(p.PushWhitelistUsernames),
+		PushWhitelistTeams:            lists.OrEmpty(p.PushWhitelistTeams),
+		PushWhitelistDeployKeys:       p.PushWhitelistDeployKeys,
+		EnableForcePush:               p.EnableForcePush,
+		EnableForcePushAllowlist:      p.EnableForcePushAllowlist,
+		ForcePushAllowlistUsernames:   lists.OrEmpty(p.ForcePushAllowlistUsernames),
+		ForcePushAllowlistTeams:       lists.OrEmpty(p.ForcePushAllowlistTeams),
+		ForcePushAllowlistDeployKeys:  p.ForcePushAllowlistDeployKeys,
+		EnableMergeWhitelist:          p.EnableMergeWhitelist,
+		MergeWhitelistUsernames:       lists.OrEmpty(p.MergeWhitelistUsernames),
+		MergeWhitelistTeams:           lists.OrEmpty(p.MergeWhitelistTeams),
+		EnableStatusCheck:             p.EnableStatusCheck,
+		StatusCheckContexts:           lists.OrEmpty(p.StatusCheckContexts),
+		RequiredApprovals:             p.RequiredApprovals,
+		EnableApprovalsWhitelist:      p.EnableApprovalsWhitelist,
+		ApprovalsWhitelistUsernames:   lists.OrEmpty(p.ApprovalsWhitelistUsernames),
+		ApprovalsWhitelistTeams:       lists.OrEmpty(p.ApprovalsWhitelistTeams),
+		BlockOnRejectedReviews:        p.BlockOnRejectedReviews,
+		BlockOnOfficialReviewRequests: p.BlockOnOfficialReviewRequests,
+		BlockOnOutdatedBranch:         p.BlockOnOutdatedBranch,
+		BlockAdminMergeOverride:       p.BlockAdminMergeOverride,
+		DismissStaleApprovals:         p.DismissStaleApprovals,
+		IgnoreStaleApprovals:          p.IgnoreStaleApprovals,
+		RequireSignedCommits:          p.RequireSignedCommits,
+		ProtectedFilePatterns:         p.ProtectedFilePatterns,
+		UnprotectedFilePatterns:       p.UnprotectedFilePatterns,
+	}
+}
+
+func appliedSettings(bp *clients.BranchProtection) *v2.BranchProtectionAppliedSettings {
+	return &v2.BranchProtectionAppliedSettings{
+		EnablePush:                    &bp.EnablePush,
+		EnablePushWhitelist:           &bp.EnablePushWhitelist,
+		PushWhitelistUsernames:        bp.PushWhitelistUsernames,
+		PushWhitelistTeams:            bp.PushWhitelistTeams,
+		PushWhitelistDeployKeys:       &bp.PushWhitelistDeployKeys,
+		EnableForcePush:               &bp.EnableForcePush,
+		EnableForcePushAllowlist:      &bp.EnableForcePushAllowlist,
+		ForcePushAllowlistUsernames:   bp.ForcePushAllowlistUsernames,
+		ForcePushAllowlistTeams:       bp.ForcePushAllowlistTeams,
+		ForcePushAllowlistDeployKeys:  &bp.ForcePushAllowlistDeployKeys,
+		EnableMergeWhitelist:          &bp.EnableMergeWhitelist,
+		MergeWhitelistUsernames:       bp.MergeWhitelistUsernames,
+		MergeWhitelistTeams:           bp.MergeWhitelistTeams,
+		EnableStatusCheck:             &bp.EnableStatusCheck,
+		RequiredStatusChecks:          bp.StatusCheckContexts,
+		RequiredApprovals:             &bp.RequiredApprovals,
+		EnableApprovalsWhitelist:      &bp.EnableApprovalsWhitelist,
+		ApprovalsWhitelistUsernames:   bp.ApprovalsWhitelistUsernames,
+		ApprovalsWhitelistTeams:       bp.ApprovalsWhitelistTeams,
+		BlockOnRejectedReviews:        &bp.BlockOnRejectedReviews,
+		BlockOnOfficialReviewRequests: &bp.BlockOnOfficialReviewRequests,
+		BlockOnOutdatedBranch:         &bp.BlockOnOutdatedBranch,
+		BlockAdminMergeOverride:       &bp.BlockAdminMergeOverride,
+		DismissStaleApprovals:         &bp.DismissStaleApprovals,
+		IgnoreStaleApprovals:          &bp.IgnoreStaleApprovals,
+		RequireSignedCommits:          &bp.RequireSignedCommits,
+		ProtectedFilePatterns:         &bp.ProtectedFilePatterns,
+		UnprotectedFilePatterns:       &bp.UnprotectedFilePatterns,
+	}
+}
+
+// diff returns the spec fields whose value differs from the rule in Gitea.
+// Unset scalar fields are left to Gitea; lists are compared as sets, with
+// an unset list meaning an empty one.
+func diff(p v2.BranchProtectionParameters, bp *clients.BranchProtection) []string {
+	var d []string
+	flag := func(field string, want *bool, got bool) {
+		if want != nil && *want != got {
+			d = append(d, field)
+		}
+	}
+	set := func(field string, want, got []string) {
+		if !lists.SameSet(want, got) {
+			d = append(d, field)
+		}
+	}
+	text := func(field string, want *string, got string) {
+		if want != nil && *want != got {
+			d = append(d, field)
+		}
+	}
+
+	if p.Priority != nil && *p.Priority != bp.Priority {
+		d = append(d, "priority")
+	}
+	flag("enablePush", p.EnablePush, bp.EnablePush)
+	flag("enablePushWhitelist", p.EnablePushWhitelist, bp.EnablePushWhitelist)
+	set("pushWhitelistUsernames", p.PushWhitelistUsernames, bp.PushWhitelistUsernames)
+	set("pushWhitelistTeams", p.PushWhitelistTeams, bp.PushWhitelistTeams)
+	flag("pushWhitelistDeployKeys", p.PushWhitelistDeployKeys, bp.PushWhitelistDeployKeys)
+	flag("enableForcePush", p.EnableForcePush, bp.EnableForcePush)
+	flag("enableForcePushAllowlist", p.EnableForcePushAllowlist, bp.EnableForcePushAllowlist)
+	set("forcePushAllowlistUsernames", p.ForcePushAllowlistUsernames, bp.ForcePushAllowlistUsernames)
+	set("forcePushAllowlistTeams", p.ForcePushAllowlistTeams, bp.ForcePushAllowlistTeams)
+	flag("forcePushAllowlistDeployKeys", p.ForcePushAllowlistDeployKeys, bp.ForcePushAllowlistDeployKeys)
+	flag("enableMergeWhitelist", p.EnableMergeWhitelist, bp.EnableMergeWhitelist)
+	set("mergeWhitelistUsernames", p.MergeWhitelistUsernames, bp.MergeWhitelistUsernames)
+	set("mergeWhitelistTeams", p.MergeWhitelistTeams, bp.MergeWhitelistTeams)
+	flag("enableStatusCheck", p.EnableStatusCheck, bp.EnableStatusCheck)
+	set("statusCheckContexts", p.StatusCheckContexts, bp.StatusCheckContexts)
+	if p.RequiredApprovals != nil && *p.RequiredApprovals != bp.RequiredApprovals {
+		d = append(d, "requiredApprovals")
+	}
+	flag("enableApprovalsWhitelist", p.EnableApprovalsWhitelist, bp.EnableApprovalsWhitelist)
+	set("approvalsWhitelistUsernames", p.ApprovalsWhitelistUsernames, bp.ApprovalsWhitelistUsernames)
+	set("approvalsWhitelistTeams", p.ApprovalsWhitelistTeams, bp.ApprovalsWhitelistTeams)
+	flag("blockOnRejectedReviews", p.BlockOnRejectedReviews, bp.BlockOnRejectedReviews)
+	flag("blockOnOfficialReviewRequests", p.BlockOnOfficialReviewRequests, bp.BlockOnOfficialReviewRequests)
+	flag("blockOnOutdatedBranch", p.BlockOnOutdatedBranch, bp.BlockOnOutdatedBranch)
+	flag("blockAdminMergeOverride", p.BlockAdminMergeOverride, bp.BlockAdminMergeOverride)
+	flag("dismissStaleApprovals", p.DismissStaleApprovals, bp.DismissStaleApprovals)
+	flag("ignoreStaleApprovals", p.IgnoreStaleApprovals, bp.IgnoreStaleApprovals)
+	flag("requireSignedCommits", p.RequireSignedCommits, bp.RequireSignedCommits)
+	text("protectedFilePatterns", p.ProtectedFilePatterns, bp.ProtectedFilePatterns)
+	text("unprotectedFilePatterns", p.UnprotectedFilePatterns, bp.UnprotectedFilePatterns)
+	return d
+}
+
+// Setup adds a controller that reconciles BranchProtection managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v2.BranchProtectionKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v2.BranchProtectionGroupVersionKind),
+		managed.WithExternalConnector(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v2.BranchProtection{}).
+		Complete(r)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package branchprotection
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-gitea/apis/branchprotection/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockBranchProtectionClient struct {
+	testutil.NoopClient
+	rule    *clients.BranchProtection
+	updated *clients.UpdateBranchProtectionRequest
+}
+
+func (m *mockBranchProtectionClient) GetBranchProtection(ctx context.Context, repository, ruleName string) (*clients.BranchProtection, error) {
+	if m.rule == nil || m.rule.RuleName != ruleName {
+		return nil, fmt.Errorf("API request failed with status 404: not found")
+	}
+	return m.rule, nil
+}
+
+func (m *mockBranchProtectionClient) UpdateBranchProtection(ctx context.Context, repository, ruleName string, req *clients.UpdateBranchProtectionRequest) (*clients.BranchProtection, error) {
+	m.updated = req
+	return m.rule, nil
+}
+
+func boolPtr(b bool) *bool { return &b }
+
+func newBranchProtection(p v2.BranchProtectionParameters) *v2.BranchProtection {
+	p.Repository = "testorg/testrepo"
+	p.RuleName = "release/*"
+	return &v2.BranchProtection{Spec: v2.BranchProtectionSpec{ForProvider: p}}
+}
+
+func TestObserve(t *testing.T) {
+	t.Run("missing rule does not exist", func(t *testing.T) {
+		ec := &externalClient{client: &mockBranchProtectionClient{}}
+
+		obs, err := ec.Observe(context.Background(), newBranchProtection(v2.BranchProtectionParameters{}))
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+
+	t.Run("matching rule is up to date", func(t *testing.T) {
+		ec := &externalClient{client: &mockBranchProtectionClient{rule: &clients.BranchProtection{
+			RuleName:               "release/*",
+			EnableForcePush:        true,
+			PushWhitelistUsernames: []string{"bob", "alice"},
+		}}}
+		cr := newBranchProtection(v2.BranchProtectionParameters{
+			EnableForcePush:        boolPtr(true),
+			PushWhitelistUsernames: []string{"alice", "bob"},
+		})
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.Equal(t, xpv1.ReasonAvailable, cr.GetCondition(xpv1.TypeReady).Reason)
+		assert.True(t, *cr.Status.AtProvider.AppliedSettings.EnableForcePush)
+	})
+
+	t.Run("drift is reported per field", func(t *testing.T) {
+		priority := int64(1)
+		ec := &externalClient{client: &mockBranchProtectionClient{rule: &clients.BranchProtection{
+			RuleName:                "release/*",
+			Priority:                3,
+			BlockAdminMergeOverride: true,
+			MergeWhitelistTeams:     []string{"maintainers"},
+		}}}
+		cr := newBranchProtection(v2.BranchProtectionParameters{
+			Priority:                &priority,
+			BlockAdminMergeOverride: boolPtr(true),
+		})
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate)
+		assert.Equal(t, "priority, mergeWhitelistTeams", obs.Diff)
+	})
+}
+
+func TestUpdateClearsLists(t *testing.T) {
+	m := &mockBranchProtectionClient{rule: &clients.BranchProtection{RuleName: "release/*"}}
+	ec := &externalClient{client: m}
+
+	_, err := ec.Update(context.Background(), newBranchProtection(v2.BranchProtectionParameters{
+		IgnoreStaleApprovals: boolPtr(true),
+	}))
+	require.NoError(t, err)
+	require.NotNil(t, m.updated)
+	assert.NotNil(t, m.updated.MergeWhitelistTeams)
+	assert.Empty(t, m.updated.MergeWhitelistTeams)
+	assert.True(t, *m.updated.IgnoreStaleApprovals)
+}
@@ -18,11 +18,34 @@ package controller
 
 import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/rossigee/provider-gitea/internal/controller/accesstoken"
+	"github.com/rossigee/provider-gitea/internal/controller/action"
+	"github.com/rossigee/provider-gitea/internal/controller/branch"
+	"github.com/rossigee/provider-gitea/internal/controller/branchprotection"
+	"github.com/rossigee/provider-gitea/internal/controller/deploykey"
+	"github.com/rossigee/provider-gitea/internal/controller/labelset"
+	"github.com/rossigee/provider-gitea/internal/controller/oauth2application"
 	"github.com/rossigee/provider-gitea/internal/controller/organization"
+	"github.com/rossigee/provider-gitea/internal/controller/organizationmember"
+	"github.com/rossigee/provider-gitea/internal/controller/organizationsettings"
 	"github.com/rossigee/provider-gitea/internal/controller/providerconfig"
+	"github.com/rossigee/provider-gitea/internal/controller/release"
 	"github.com/rossigee/provider-gitea/internal/controller/repository"
+	"github.com/rossigee/provider-gitea/internal/controller/repositoryfile"
+	"github.com/rossigee/provider-gitea/internal/controller/repositorykey"
+	"github.com/rossigee/provider-gitea/internal/controller/runner"
+	"github.com/rossigee/provider-gitea/internal/controller/runnerregistrationtoken"
+	"github.com/rossigee/provider-gitea/internal/controller/tag"
+	"github.com/rossigee/provider-gitea/internal/controller/tagprotection"
+	"github.com/rossigee/provider-gitea/internal/controller/team"
+	"github.com/rossigee/provider-gitea/internal/controller/teammembership"
+	"github.com/rossigee/provider-gitea/internal/controller/teamrepository"
 	"github.com/rossigee/provider-gitea/internal/controller/user"
+	"github.com/rossigee/provider-gitea/internal/controller/useremail"
+	"github.com/rossigee/provider-gitea/internal/controller/usergpgkey"
+	"github.com/rossigee/provider-gitea/internal/controller/userkey"
 	"github.com/rossigee/provider-gitea/internal/controller/webhook"
+	"github.com/rossigee/provider-gitea/internal/controller/workflowdispatch"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -35,6 +58,29 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		organization.Setup,
 		user.Setup,
 		webhook.Setup,
+		deploykey.Setup,
+		accesstoken.Setup,
+		repositorykey.Setup,
+		userkey.Setup,
+		useremail.Setup,
+		usergpgkey.Setup,
+		oauth2application.Setup,
+		repositoryfile.Setup,
+		labelset.Setup,
+		action.Setup,
+		runnerregistrationtoken.Setup,
+		runner.Setup,
+		workflowdispatch.Setup,
+		branchprotection.Setup,
+		tagprotection.Setup,
+		branch.Setup,
+		tag.Setup,
+		release.Setup,
+		team.Setup,
+		teammembership.Setup,
+		teamrepository.Setup,
+		organizationmember.Setup,
+		organizationsettings.Setup,
 	} {
 		if err := setup(mgr, o); err != nil {
 			return err
@@ -0,0 +1,378 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploykey
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-gitea/apis/deploykey/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/connection"
+	"github.com/rossigee/provider-gitea/internal/sshkey"
+	"github.com/rossigee/provider-gitea/internal/tracing"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/rossigee/provider-gitea/apis/v1beta1"
+)
+
+const (
+	errNotDeployKey      = "managed resource is not a DeployKey custom resource"
+	errGetDeployKey      = "failed to get deploy key"
+	errCreateDeployKey   = "failed to create deploy key"
+	errDeleteDeployKey   = "failed to delete deploy key"
+	errGetProviderConfig = "failed to get provider config"
+	errGetRepository     = "failed to get repository for connection details"
+	errGetPrivateKey     = "failed to get private key secret"
+	errLabelSecret       = "failed to label connection secret"
+	errGenerateKey       = "failed to generate deploy key"
+	errReplaceDeployKey  = "failed to replace deploy key"
+	errDeleteRetiredKey  = "failed to delete retired deploy key"
+)
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient by:
+// 1. Getting the provider config
+// 2. Creating a Gitea API client
+// 3. Returning an external client wrapping the API client
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v2.DeployKey)
+	if !ok {
+		return nil, errors.New(errNotDeployKey)
+	}
+
+	pcRef := cr.Spec.ProviderConfigReference
+	if pcRef == nil {
+		return nil, errors.New("providerConfigRef is required")
+	}
+
+	var pc v1beta1.ProviderConfig
+	if err := c.kube.Get(ctx, client.ObjectKey{
+		Namespace: cr.GetNamespace(),
+		Name:      pcRef.Name,
+	}, &pc); err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
+
+	conn, err := clients.NewClient(ctx, &pc, c.kube)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalClient{client: conn, kube: c.kube}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it matches the managed resource's desired state.
+type externalClient struct {
+	client clients.Client
+	kube   client.Client
+}
+
+func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	_, span := tracing.StartSpan(ctx, "deploykey.observe",
+		tracing.SpanAttrs("deploykey", tracing.ResourceName(mg), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.DeployKey)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotDeployKey)
+	}
+
+	externalID := meta.GetExternalName(cr)
+	if externalID == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	keyID, err := strconv.ParseInt(externalID, 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	key, err := e.client.GetDeployKey(ctx, cr.Spec.ForProvider.Owner, cr.Spec.ForProvider.Repository, keyID)
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetDeployKey)
+	}
+
+	cr.Status.AtProvider = v2.DeployKeyObservation{
+		ID:          &key.ID,
+		URL:         &key.URL,
+		Fingerprint: &key.Fingerprint,
+		PublicKey:   &key.Key,
+		CreatedAt:   &key.CreatedAt,
+	}
+
+	creds, err := e.credentials(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	format := connection.FormatOrDefault(cr.Spec.ForProvider.ConnectionSecretFormat)
+	if err := connection.EnsureLabels(ctx, e.kube, cr, connection.Labels(format, creds)); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errLabelSecret)
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	// Gitea does not allow deploy keys to be edited, so an existing key is
+	// only out of date when a generated key has been asked to regenerate or
+	// the key it replaced has yet to be deleted.
+	_, retired := sshkey.Retired(cr)
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  !retired && (cr.Spec.ForProvider.GenerateKey == nil || !sshkey.Regeneration.Requested(cr)),
+		ConnectionDetails: connectionDetails(format, creds),
+	}, nil
+}
+
+func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	_, span := tracing.StartSpan(ctx, "deploykey.create",
+		tracing.SpanAttrs("deploykey", tracing.ResourceName(mg), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.DeployKey)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotDeployKey)
+	}
+
+	if cr.Spec.ForProvider.GenerateKey != nil {
+		id, cd, err := e.generate(ctx, cr, cr.Spec.ForProvider.Title)
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errCreateDeployKey)
+		}
+		meta.SetExternalName(cr, strconv.FormatInt(id, 10))
+		sshkey.Regeneration.Mark(cr)
+		return managed.ExternalCreation{ConnectionDetails: cd}, nil
+	}
+
+	key, err := e.client.CreateDeployKey(ctx, cr.Spec.ForProvider.Owner, cr.Spec.ForProvider.Repository, e.createRequest(cr, cr.Spec.ForProvider.Key))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateDeployKey)
+	}
+
+	meta.SetExternalName(cr, strconv.FormatInt(key.ID, 10))
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	_, span := tracing.StartSpan(ctx, "deploykey.update",
+		tracing.SpanAttrs("deploykey", tracing.ResourceName(mg), "update")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.DeployKey)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotDeployKey)
+	}
+
+	// A replaced key is only deleted once the reconciler has published the
+	// connection details of its replacement, so consumers are never left
+	// without a working key.
+	if err := e.deleteRetired(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// Deploy keys are immutable in Gitea, so the only other update is
+	// replacing a generated key whose regeneration was requested.
+	if cr.Spec.ForProvider.GenerateKey == nil || !sshkey.Regeneration.Requested(cr) {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	newID, cd, err := e.generate(ctx, cr, sshkey.Title(cr.Spec.ForProvider.Title, cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errReplaceDeployKey)
+	}
+
+	if err := sshkey.RecordRegeneration(ctx, e.kube, cr, strconv.FormatInt(newID, 10)); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errReplaceDeployKey)
+	}
+
+	return managed.ExternalUpdate{ConnectionDetails: cd}, nil
+}
+
+func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	_, span := tracing.StartSpan(ctx, "deploykey.delete",
+		tracing.SpanAttrs("deploykey", tracing.ResourceName(mg), "delete")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.DeployKey)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotDeployKey)
+	}
+
+	if err := e.deleteRetired(ctx, cr); err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
+	keyID, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalDelete{}, nil
+	}
+
+	err = e.client.DeleteDeployKey(ctx, cr.Spec.ForProvider.Owner, cr.Spec.ForProvider.Repository, keyID)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteDeployKey)
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *externalClient) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+func (e *externalClient) createRequest(cr *v2.DeployKey, key string) *clients.CreateDeployKeyRequest {
+	readOnly := true
+	if cr.Spec.ForProvider.ReadOnly != nil {
+		readOnly = *cr.Spec.ForProvider.ReadOnly
+	}
+
+	return &clients.CreateDeployKeyRequest{
+		Title:    cr.Spec.ForProvider.Title,
+		Key:      key,
+		ReadOnly: readOnly,
+	}
+}
+
+// deleteRetired deletes the key superseded by the current one, if any.
+func (e *externalClient) deleteRetired(ctx context.Context, cr *v2.DeployKey) error {
+	id, ok := sshkey.Retired(cr)
+	if !ok {
+		return nil
+	}
+	err := e.client.DeleteDeployKey(ctx, cr.Spec.ForProvider.Owner, cr.Spec.ForProvider.Repository, id)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return errors.Wrap(err, errDeleteRetiredKey)
+	}
+	return errors.Wrap(sshkey.ForgetRetired(ctx, e.kube, cr), errDeleteRetiredKey)
+}
+
+// generate registers a freshly generated keypair under title and returns the
+// new key ID along with connection details carrying the private key.
+func (e *externalClient) generate(ctx context.Context, cr *v2.DeployKey, title string) (int64, managed.ConnectionDetails, error) {
+	gen := cr.Spec.ForProvider.GenerateKey
+	bits := 0
+	if gen.Bits != nil {
+		bits = *gen.Bits
+	}
+
+	kp, err := sshkey.Generate(gen.Algorithm, bits, cr.Spec.ForProvider.Title)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, errGenerateKey)
+	}
+
+	creds, err := e.credentials(ctx, cr)
+	if err != nil {
+		return 0, nil, err
+	}
+	creds.SSHPrivateKey = kp.PrivateKey
+	creds.SSHPublicKey = kp.PublicKey
+
+	// known_hosts is a convenience for consumers; the key remains usable
+	// without it, so an unreachable SSH server is not an error.
+	if addr, err := sshkey.Address(creds.URL); err == nil {
+		creds.KnownHosts, _ = sshkey.KnownHosts(ctx, addr)
+	}
+
+	req := e.createRequest(cr, kp.PublicKey)
+	req.Title = title
+	key, err := e.client.CreateDeployKey(ctx, cr.Spec.ForProvider.Owner, cr.Spec.ForProvider.Repository, req)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	format := connection.FormatOrDefault(cr.Spec.ForProvider.ConnectionSecretFormat)
+	return key.ID, connectionDetails(format, creds), nil
+}
+
+// credentials assembles the SSH credentials for the repository the key
+// grants access to.
+func (e *externalClient) credentials(ctx context.Context, cr *v2.DeployKey) (connection.GitCredentials, error) {
+	repo, err := e.client.GetRepository(ctx, cr.Spec.ForProvider.Owner, cr.Spec.ForProvider.Repository)
+	if err != nil {
+		return connection.GitCredentials{}, errors.Wrap(err, errGetRepository)
+	}
+
+	creds := connection.GitCredentials{
+		URL:          repo.SSHURL,
+		SSHPublicKey: cr.Spec.ForProvider.Key,
+	}
+	if creds.SSHPublicKey == "" && cr.Status.AtProvider.PublicKey != nil {
+		creds.SSHPublicKey = *cr.Status.AtProvider.PublicKey
+	}
+
+	if ref := cr.Spec.ForProvider.PrivateKeySecretRef; ref != nil {
+		s := &corev1.Secret{}
+		if err := e.kube.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+			return connection.GitCredentials{}, errors.Wrap(err, errGetPrivateKey)
+		}
+		creds.SSHPrivateKey = string(s.Data[ref.Key])
+	}
+
+	return creds, nil
+}
+
+// connectionDetails renders the supplied credentials in the requested format.
+func connectionDetails(format string, creds connection.GitCredentials) managed.ConnectionDetails {
+	if format != connection.FormatDefault {
+		return connection.Render(format, creds)
+	}
+
+	cd := managed.ConnectionDetails{
+		"url":       []byte(creds.URL),
+		"publicKey": []byte(creds.SSHPublicKey),
+	}
+	if creds.SSHPrivateKey != "" {
+		cd["privateKey"] = []byte(creds.SSHPrivateKey)
+	}
+	if creds.KnownHosts != "" {
+		cd["knownHosts"] = []byte(creds.KnownHosts)
+	}
+	return cd
+}
+
+// Setup adds a controller that reconciles DeployKey managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v2.DeployKeyKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v2.DeployKeyGroupVersionKind),
+		managed.WithExternalConnector(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v2.DeployKey{}).
+		Complete(r)
+}
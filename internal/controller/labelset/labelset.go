@@ -0,0 +1,423 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package labelset
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/rossigee/provider-gitea/apis/labelset/v2"
+	repositoryv2 "github.com/rossigee/provider-gitea/apis/repository/v2"
+	v1beta1 "github.com/rossigee/provider-gitea/apis/v1beta1"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/labels"
+	"github.com/rossigee/provider-gitea/internal/tracing"
+)
+
+const (
+	errNotLabelSet           = "managed resource is not a LabelSet custom resource"
+	errGetProviderConfig     = "failed to get provider config"
+	errListRepositories      = "failed to list organization repositories"
+	errListRepositoryObjects = "failed to list Repository resources"
+	errInvalidSelector       = "invalid repository selector"
+	errListLabels            = "failed to list labels"
+	errCreateLabel           = "failed to create label %s"
+	errUpdateLabel           = "failed to update label %s"
+	errDeleteLabel           = "failed to prune label %s"
+	errSyncRepositories      = "failed to sync labels of %s"
+)
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient by:
+// 1. Getting the provider config
+// 2. Creating a Gitea API client
+// 3. Returning an external client wrapping the API client
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v2.LabelSet)
+	if !ok {
+		return nil, errors.New(errNotLabelSet)
+	}
+
+	pcRef := cr.Spec.ProviderConfigReference
+	if pcRef == nil {
+		return nil, errors.New("providerConfigRef is required")
+	}
+
+	var pc v1beta1.ProviderConfig
+	if err := c.kube.Get(ctx, client.ObjectKey{
+		Namespace: cr.GetNamespace(),
+		Name:      pcRef.Name,
+	}, &pc); err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
+
+	conn, err := clients.NewClient(ctx, &pc, c.kube)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalClient{client: conn, kube: c.kube}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it matches the managed resource's desired state.
+type externalClient struct {
+	client clients.Client
+	kube   client.Client
+}
+
+func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	_, span := tracing.StartSpan(ctx, "labelset.observe",
+		tracing.SpanAttrs("labelset", tracing.ResourceName(mg), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.LabelSet)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotLabelSet)
+	}
+
+	// A label set has nothing of its own in Gitea. Deleting it leaves the
+	// labels in place, so it is gone as soon as it is deleted.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	catalog, err := labels.Catalog(ctx, e.kube, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	targets, err := e.targets(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	statuses := make([]v2.LabelSetRepositoryStatus, 0, len(targets))
+	for _, repo := range targets {
+		s := v2.LabelSetRepositoryStatus{Repository: repo}
+		p, err := e.plan(ctx, cr, catalog, repo)
+		if err != nil {
+			s.Message = err.Error()
+		} else {
+			s.Synced = p.empty()
+			s.Message = p.String()
+		}
+		statuses = append(statuses, s)
+	}
+	setStatus(cr, catalog, statuses)
+
+	cr.SetConditions(xpv1.Available())
+
+	synced := cr.Status.AtProvider.SyncedRepositories
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: *synced == int64(len(targets)),
+	}, nil
+}
+
+func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	_, span := tracing.StartSpan(ctx, "labelset.create",
+		tracing.SpanAttrs("labelset", tracing.ResourceName(mg), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.LabelSet)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotLabelSet)
+	}
+
+	return managed.ExternalCreation{}, e.sync(ctx, cr)
+}
+
+func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	_, span := tracing.StartSpan(ctx, "labelset.update",
+		tracing.SpanAttrs("labelset", tracing.ResourceName(mg), "update")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.LabelSet)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotLabelSet)
+	}
+
+	return managed.ExternalUpdate{}, e.sync(ctx, cr)
+}
+
+func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	// Deleting the resource leaves the labels in the repositories.
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *externalClient) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// sync applies the catalog to every targeted repository. A repository that
+// fails does not stop the others; its error is recorded in its status.
+func (e *externalClient) sync(ctx context.Context, cr *v2.LabelSet) error {
+	catalog, err := labels.Catalog(ctx, e.kube, cr)
+	if err != nil {
+		return err
+	}
+	targets, err := e.targets(ctx, cr)
+	if err != nil {
+		return err
+	}
+
+	statuses := make([]v2.LabelSetRepositoryStatus, 0, len(targets))
+	var failed []string
+	for _, repo := range targets {
+		s := v2.LabelSetRepositoryStatus{Repository: repo, Synced: true}
+		if err := e.apply(ctx, cr, catalog, repo); err != nil {
+			s.Synced = false
+			s.Message = err.Error()
+			failed = append(failed, repo)
+		}
+		statuses = append(statuses, s)
+	}
+	setStatus(cr, catalog, statuses)
+
+	if len(failed) > 0 {
+		return errors.Errorf(errSyncRepositories, strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// apply creates, updates and, when pruning, deletes the labels of a
+// repository to match the catalog.
+func (e *externalClient) apply(ctx context.Context, cr *v2.LabelSet, catalog []v2.LabelDefinition, repo string) error {
+	p, err := e.plan(ctx, cr, catalog, repo)
+	if err != nil {
+		return err
+	}
+	owner, name := split(repo)
+
+	for _, c := range p.changes {
+		if c.Existing == nil {
+			_, err := e.client.CreateLabel(ctx, owner, name, &clients.CreateLabelRequest{
+				Name:        c.Want.Name,
+				Color:       c.Want.Color,
+				Description: deref(c.Want.Description),
+				Exclusive:   c.Want.Exclusive != nil && *c.Want.Exclusive,
+			})
+			if err != nil {
+				return errors.Wrapf(err, errCreateLabel, c.Want.Name)
+			}
+			continue
+		}
+		_, err := e.client.UpdateLabel(ctx, owner, name, c.Existing.ID, &clients.UpdateLabelRequest{
+			Color:       &c.Want.Color,
+			Description: c.Want.Description,
+			Exclusive:   c.Want.Exclusive,
+		})
+		if err != nil {
+			return errors.Wrapf(err, errUpdateLabel, c.Want.Name)
+		}
+	}
+
+	for _, l := range p.prune {
+		if err := e.client.DeleteLabel(ctx, owner, name, l.ID); err != nil {
+			return errors.Wrapf(err, errDeleteLabel, l.Name)
+		}
+	}
+	return nil
+}
+
+// plan is the work needed to bring the labels of a repository in line with
+// the catalog.
+type plan struct {
+	changes []labels.Change
+	prune   []*clients.Label
+}
+
+func (p *plan) empty() bool {
+	return len(p.changes) == 0 && len(p.prune) == 0
+}
+
+// String summarises the pending changes, such as "labels pending: 2 to
+// create, 1 to update".
+func (p *plan) String() string {
+	var create, update int
+	for _, c := range p.changes {
+		if c.Existing == nil {
+			create++
+		} else {
+			update++
+		}
+	}
+	var parts []string
+	if create > 0 {
+		parts = append(parts, fmt.Sprintf("%d to create", create))
+	}
+	if update > 0 {
+		parts = append(parts, fmt.Sprintf("%d to update", update))
+	}
+	if len(p.prune) > 0 {
+		parts = append(parts, fmt.Sprintf("%d to prune", len(p.prune)))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "labels pending: " + strings.Join(parts, ", ")
+}
+
+func (e *externalClient) plan(ctx context.Context, cr *v2.LabelSet, catalog []v2.LabelDefinition, repo string) (*plan, error) {
+	owner, name := split(repo)
+	have, err := e.client.ListRepositoryLabels(ctx, owner, name)
+	if err != nil {
+		return nil, errors.Wrap(err, errListLabels)
+	}
+
+	want := make([]labels.Definition, 0, len(catalog))
+	for _, l := range catalog {
+		want = append(want, labels.Definition(l))
+	}
+	p := &plan{changes: labels.Changes(want, have)}
+	if cr.Spec.ForProvider.Prune != nil && *cr.Spec.ForProvider.Prune {
+		for _, h := range have {
+			if !inCatalog(catalog, h.Name) {
+				p.prune = append(p.prune, h)
+			}
+		}
+	}
+	return p, nil
+}
+
+func inCatalog(catalog []v2.LabelDefinition, name string) bool {
+	for _, l := range catalog {
+		if l.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// targets returns the repositories the set applies to, in owner/name format
+// and sorted. Archived repositories of an organization or selected by label
+// are skipped, as Gitea does not allow changing their labels.
+func (e *externalClient) targets(ctx context.Context, cr *v2.LabelSet) ([]string, error) {
+	p := cr.Spec.ForProvider
+	seen := map[string]bool{}
+	var targets []string
+	add := func(repo string) {
+		if k := strings.ToLower(repo); !seen[k] {
+			seen[k] = true
+			targets = append(targets, repo)
+		}
+	}
+
+	for _, r := range p.Repositories {
+		add(r)
+	}
+
+	if p.Organization != nil {
+		repos, err := e.client.ListOrganizationRepositories(ctx, *p.Organization)
+		if err != nil {
+			return nil, errors.Wrap(err, errListRepositories)
+		}
+		for _, r := range repos {
+			if !r.Archived {
+				add(r.FullName)
+			}
+		}
+	}
+
+	if p.RepositorySelector != nil {
+		sel, err := metav1.LabelSelectorAsSelector(p.RepositorySelector)
+		if err != nil {
+			return nil, errors.Wrap(err, errInvalidSelector)
+		}
+		list := &repositoryv2.RepositoryList{}
+		if err := e.kube.List(ctx, list, client.InNamespace(cr.GetNamespace()), client.MatchingLabelsSelector{Selector: sel}); err != nil {
+			return nil, errors.Wrap(err, errListRepositoryObjects)
+		}
+		for _, r := range list.Items {
+			if r.GetDeletionTimestamp() != nil || (r.Spec.ForProvider.Archived != nil && *r.Spec.ForProvider.Archived) {
+				continue
+			}
+			// Repositories that have not been created yet are picked up
+			// once they have an owner/name external name.
+			if ext := meta.GetExternalName(&r); strings.Count(ext, "/") == 1 {
+				add(ext)
+			}
+		}
+	}
+
+	sort.Slice(targets, func(i, j int) bool { return strings.ToLower(targets[i]) < strings.ToLower(targets[j]) })
+	return targets, nil
+}
+
+// setStatus records the catalog size and the sync status of each targeted
+// repository.
+func setStatus(cr *v2.LabelSet, catalog []v2.LabelDefinition, statuses []v2.LabelSetRepositoryStatus) {
+	size := int64(len(catalog))
+	total := int64(len(statuses))
+	var synced int64
+	for _, s := range statuses {
+		if s.Synced {
+			synced++
+		}
+	}
+	cr.Status.AtProvider = v2.LabelSetObservation{
+		Labels:             &size,
+		SyncedRepositories: &synced,
+		TotalRepositories:  &total,
+		Repositories:       statuses,
+	}
+}
+
+func split(repo string) (string, string) {
+	owner, name, _ := strings.Cut(repo, "/")
+	return owner, name
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// Setup adds a controller that reconciles LabelSet managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v2.LabelSetKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v2.LabelSetGroupVersionKind),
+		managed.WithExternalConnector(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v2.LabelSet{}).
+		Complete(r)
+}
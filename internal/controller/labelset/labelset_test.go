@@ -0,0 +1,232 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package labelset
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/rossigee/provider-gitea/apis/labelset/v2"
+	repositoryv2 "github.com/rossigee/provider-gitea/apis/repository/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// mockLabelClient serves labels keyed by repository and records changes.
+type mockLabelClient struct {
+	testutil.NoopClient
+	labels  map[string][]*clients.Label
+	orgs    map[string][]*clients.Repository
+	failing map[string]bool
+	changes []string
+}
+
+func (m *mockLabelClient) ListRepositoryLabels(ctx context.Context, owner, repo string) ([]*clients.Label, error) {
+	return m.labels[owner+"/"+repo], nil
+}
+
+func (m *mockLabelClient) ListOrganizationRepositories(ctx context.Context, org string) ([]*clients.Repository, error) {
+	return m.orgs[org], nil
+}
+
+func (m *mockLabelClient) CreateLabel(ctx context.Context, owner, repo string, req *clients.CreateLabelRequest) (*clients.Label, error) {
+	if m.failing[owner+"/"+repo] {
+		return nil, fmt.Errorf("API request failed with status 423: repository is archived")
+	}
+	m.changes = append(m.changes, fmt.Sprintf("create %s/%s %s", owner, repo, req.Name))
+	return &clients.Label{Name: req.Name}, nil
+}
+
+func (m *mockLabelClient) UpdateLabel(ctx context.Context, owner, repo string, id int64, req *clients.UpdateLabelRequest) (*clients.Label, error) {
+	m.changes = append(m.changes, fmt.Sprintf("update %s/%s %d", owner, repo, id))
+	return &clients.Label{ID: id}, nil
+}
+
+func (m *mockLabelClient) DeleteLabel(ctx context.Context, owner, repo string, id int64) error {
+	m.changes = append(m.changes, fmt.Sprintf("delete %s/%s %d", owner, repo, id))
+	return nil
+}
+
+func newLabelSet(p v2.LabelSetParameters) *v2.LabelSet {
+	return &v2.LabelSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "taxonomy"},
+		Spec:       v2.LabelSetSpec{ForProvider: p},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	high := "High priority"
+	exclusive := true
+	catalog := []v2.LabelDefinition{
+		{Name: "priority/high", Color: "#e11d21", Description: &high, Exclusive: &exclusive},
+		{Name: "type/bug", Color: "ee0701"},
+	}
+
+	m := &mockLabelClient{labels: map[string][]*clients.Label{
+		"acme/api": {
+			{ID: 1, Name: "priority/high", Color: "E11D21", Description: "High priority", Exclusive: true},
+			{ID: 2, Name: "type/bug", Color: "ee0701"},
+		},
+		"acme/web": {
+			{ID: 3, Name: "type/bug", Color: "000000"},
+			{ID: 4, Name: "wontfix", Color: "ffffff"},
+		},
+	}}
+	e := &externalClient{client: m, kube: fake.NewClientBuilder().WithScheme(testutil.Scheme(t)).Build()}
+
+	t.Run("reports per repository sync status", func(t *testing.T) {
+		cr := newLabelSet(v2.LabelSetParameters{Labels: catalog, Repositories: []string{"acme/web", "acme/api"}})
+
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.False(t, obs.ResourceUpToDate)
+
+		at := cr.Status.AtProvider
+		assert.Equal(t, int64(2), *at.Labels)
+		assert.Equal(t, int64(1), *at.SyncedRepositories)
+		assert.Equal(t, int64(2), *at.TotalRepositories)
+		assert.Equal(t, []v2.LabelSetRepositoryStatus{
+			{Repository: "acme/api", Synced: true},
+			{Repository: "acme/web", Message: "labels pending: 1 to create, 1 to update"},
+		}, at.Repositories)
+	})
+
+	t.Run("prune counts labels outside the catalog", func(t *testing.T) {
+		prune := true
+		cr := newLabelSet(v2.LabelSetParameters{Labels: catalog, Repositories: []string{"acme/web"}, Prune: &prune})
+
+		_, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, "labels pending: 1 to create, 1 to update, 1 to prune", cr.Status.AtProvider.Repositories[0].Message)
+	})
+
+	t.Run("a deleted set no longer exists", func(t *testing.T) {
+		cr := newLabelSet(v2.LabelSetParameters{Labels: catalog, Repositories: []string{"acme/web"}})
+		now := metav1.Now()
+		cr.SetDeletionTimestamp(&now)
+
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	prune := true
+	catalog := []v2.LabelDefinition{{Name: "type/bug", Color: "#ee0701"}}
+
+	t.Run("applies the catalog and prunes", func(t *testing.T) {
+		m := &mockLabelClient{labels: map[string][]*clients.Label{
+			"acme/api": {{ID: 1, Name: "type/bug", Color: "000000"}, {ID: 2, Name: "legacy", Color: "ffffff"}},
+		}}
+		e := &externalClient{client: m, kube: fake.NewClientBuilder().WithScheme(testutil.Scheme(t)).Build()}
+		cr := newLabelSet(v2.LabelSetParameters{Labels: catalog, Repositories: []string{"acme/api", "acme/web"}, Prune: &prune})
+
+		_, err := e.Update(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"update acme/api 1", "delete acme/api 2", "create acme/web type/bug"}, m.changes)
+		assert.Equal(t, int64(2), *cr.Status.AtProvider.SyncedRepositories)
+	})
+
+	t.Run("a failing repository does not stop the others", func(t *testing.T) {
+		m := &mockLabelClient{failing: map[string]bool{"acme/api": true}}
+		e := &externalClient{client: m, kube: fake.NewClientBuilder().WithScheme(testutil.Scheme(t)).Build()}
+		cr := newLabelSet(v2.LabelSetParameters{Labels: catalog, Repositories: []string{"acme/api", "acme/web"}})
+
+		_, err := e.Update(context.Background(), cr)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "acme/api")
+		assert.Equal(t, []string{"create acme/web type/bug"}, m.changes)
+		require.Len(t, cr.Status.AtProvider.Repositories, 2)
+		assert.False(t, cr.Status.AtProvider.Repositories[0].Synced)
+		assert.Contains(t, cr.Status.AtProvider.Repositories[0].Message, "status 423")
+		assert.True(t, cr.Status.AtProvider.Repositories[1].Synced)
+	})
+}
+
+func TestTargets(t *testing.T) {
+	org := "acme"
+	selected := &repositoryv2.Repository{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tools", Labels: map[string]string{"labels": "standard"}},
+	}
+	meta.SetExternalName(selected, "platform/tools")
+	pending := &repositoryv2.Repository{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pending", Labels: map[string]string{"labels": "standard"}},
+	}
+	other := &repositoryv2.Repository{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other"},
+	}
+	meta.SetExternalName(other, "platform/other")
+
+	m := &mockLabelClient{orgs: map[string][]*clients.Repository{
+		"acme": {{FullName: "acme/api"}, {FullName: "acme/old", Archived: true}},
+	}}
+	e := &externalClient{
+		client: m,
+		kube:   fake.NewClientBuilder().WithScheme(testutil.Scheme(t)).WithObjects(selected, pending, other).Build(),
+	}
+	cr := newLabelSet(v2.LabelSetParameters{
+		Repositories:       []string{"ACME/api", "acme/web"},
+		Organization:       &org,
+		RepositorySelector: &metav1.LabelSelector{MatchLabels: map[string]string{"labels": "standard"}},
+	})
+
+	targets, err := e.targets(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ACME/api", "acme/web", "platform/tools"}, targets)
+}
+
+func TestCatalog(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "labels"},
+		Data: map[string]string{
+			"labels.yaml": "- name: priority/high\n  color: \"#e11d21\"\n  exclusive: true\n- name: type/bug\n  color: ee0701\n",
+			"invalid":     "- name: type/bug\n  colour: ee0701\n",
+		},
+	}
+	e := &externalClient{client: &mockLabelClient{}, kube: fake.NewClientBuilder().WithScheme(testutil.Scheme(t)).WithObjects(cm).Build()}
+
+	t.Run("reads the catalog from a ConfigMap", func(t *testing.T) {
+		cr := newLabelSet(v2.LabelSetParameters{
+			LabelsFrom:   &v2.LabelSource{ConfigMapKeyRef: v2.ConfigMapKeySelector{Name: "labels", Key: "labels.yaml"}},
+			Repositories: []string{"acme/api"},
+		})
+
+		_, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), *cr.Status.AtProvider.Labels)
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		cr := newLabelSet(v2.LabelSetParameters{
+			LabelsFrom:   &v2.LabelSource{ConfigMapKeyRef: v2.ConfigMapKeySelector{Name: "labels", Key: "invalid"}},
+			Repositories: []string{"acme/api"},
+		})
+
+		_, err := e.Observe(context.Background(), cr)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse label catalog")
+	})
+}
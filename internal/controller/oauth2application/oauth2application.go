@@ -0,0 +1,300 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oauth2application
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-gitea/apis/oauth2application/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/regeneration"
+	"github.com/rossigee/provider-gitea/internal/tracing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/rossigee/provider-gitea/apis/v1beta1"
+)
+
+const (
+	errNotOAuth2Application = "managed resource is not an OAuth2Application custom resource"
+	errGetApplication       = "failed to get OAuth2 application"
+	errCreateApplication    = "failed to create OAuth2 application"
+	errUpdateApplication    = "failed to update OAuth2 application"
+	errDeleteApplication    = "failed to delete OAuth2 application"
+	errRecordRegeneration   = "failed to record OAuth2 client secret regeneration"
+	errGetProviderConfig    = "failed to get provider config"
+
+	// AnnotationRegenerate requests a new client secret whenever its value
+	// changes.
+	AnnotationRegenerate = "gitea.m.crossplane.io/regenerate-secret"
+
+	// AnnotationRegenerated records the AnnotationRegenerate value the
+	// current client secret was issued for.
+	AnnotationRegenerated = "gitea.m.crossplane.io/regenerated-secret"
+
+	// Connection secret keys read by OAuth2 clients.
+	keyClientID     = "clientId"
+	keyClientSecret = "clientSecret"
+	keyAuthorizeURL = "authorizeUrl"
+	keyTokenURL     = "tokenUrl"
+	keyUserinfoURL  = "userinfoUrl"
+)
+
+// secretRegeneration tracks client secret regeneration requests.
+var secretRegeneration = regeneration.Annotations{Request: AnnotationRegenerate, Issued: AnnotationRegenerated}
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient by:
+// 1. Getting the provider config
+// 2. Creating a Gitea API client
+// 3. Returning an external client wrapping the API client
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v2.OAuth2Application)
+	if !ok {
+		return nil, errors.New(errNotOAuth2Application)
+	}
+
+	pcRef := cr.Spec.ProviderConfigReference
+	if pcRef == nil {
+		return nil, errors.New("providerConfigRef is required")
+	}
+
+	var pc v1beta1.ProviderConfig
+	if err := c.kube.Get(ctx, client.ObjectKey{
+		Namespace: cr.GetNamespace(),
+		Name:      pcRef.Name,
+	}, &pc); err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
+
+	conn, err := clients.NewClient(ctx, &pc, c.kube)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalClient{client: conn, kube: c.kube, instanceURL: strings.TrimSuffix(pc.Spec.BaseURL, "/")}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it matches the managed resource's desired state.
+type externalClient struct {
+	client      clients.Client
+	kube        client.Client
+	instanceURL string
+}
+
+func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	_, span := tracing.StartSpan(ctx, "oauth2application.observe",
+		tracing.SpanAttrs("oauth2application", tracing.ResourceName(mg), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.OAuth2Application)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotOAuth2Application)
+	}
+
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	app, err := e.client.GetOAuth2Application(ctx, username(cr), id)
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetApplication)
+	}
+
+	cr.Status.AtProvider = v2.OAuth2ApplicationObservation{
+		ID:       &app.ID,
+		ClientID: &app.ClientID,
+		Created:  &app.Created,
+	}
+	cr.SetConditions(xpv1.Available())
+
+	// Gitea only returns the client secret when it is issued, so the secret
+	// already in the connection Secret is left as it is.
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  isUpToDate(cr, app) && !secretRegeneration.Requested(cr),
+		ConnectionDetails: e.connectionDetails(app),
+	}, nil
+}
+
+func isUpToDate(cr *v2.OAuth2Application, app *clients.OAuth2Application) bool {
+	p := cr.Spec.ForProvider
+	req := request(cr)
+	switch {
+	case app.Name != p.Name:
+		return false
+	case !slices.Equal(app.RedirectURIs, p.RedirectURIs):
+		return false
+	case app.ConfidentialClient != req.ConfidentialClient:
+		return false
+	case p.SkipSecondaryAuthorization != nil && app.SkipSecondaryAuthorization != *p.SkipSecondaryAuthorization:
+		return false
+	}
+	return true
+}
+
+func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	_, span := tracing.StartSpan(ctx, "oauth2application.create",
+		tracing.SpanAttrs("oauth2application", tracing.ResourceName(mg), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.OAuth2Application)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotOAuth2Application)
+	}
+
+	app, err := e.client.CreateOAuth2Application(ctx, username(cr), request(cr))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateApplication)
+	}
+
+	meta.SetExternalName(cr, strconv.FormatInt(app.ID, 10))
+	secretRegeneration.Mark(cr)
+	return managed.ExternalCreation{ConnectionDetails: e.connectionDetails(app)}, nil
+}
+
+func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	_, span := tracing.StartSpan(ctx, "oauth2application.update",
+		tracing.SpanAttrs("oauth2application", tracing.ResourceName(mg), "update")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.OAuth2Application)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotOAuth2Application)
+	}
+
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateApplication)
+	}
+
+	// Gitea issues a new client secret on every update, which is also how a
+	// regeneration is carried out.
+	app, err := e.client.UpdateOAuth2Application(ctx, username(cr), id, request(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateApplication)
+	}
+
+	// The managed reconciler does not persist annotations set during
+	// Update, so the regeneration is recorded explicitly.
+	if secretRegeneration.Requested(cr) {
+		if err := secretRegeneration.Record(ctx, e.kube, cr); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errRecordRegeneration)
+		}
+	}
+
+	return managed.ExternalUpdate{ConnectionDetails: e.connectionDetails(app)}, nil
+}
+
+func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	_, span := tracing.StartSpan(ctx, "oauth2application.delete",
+		tracing.SpanAttrs("oauth2application", tracing.ResourceName(mg), "delete")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.OAuth2Application)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotOAuth2Application)
+	}
+
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalDelete{}, nil
+	}
+
+	err = e.client.DeleteOAuth2Application(ctx, username(cr), id)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteApplication)
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *externalClient) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// connectionDetails returns the client credentials and Gitea's OAuth2
+// endpoints. The client secret is only included when Gitea returned it.
+func (e *externalClient) connectionDetails(app *clients.OAuth2Application) managed.ConnectionDetails {
+	cd := managed.ConnectionDetails{
+		keyClientID:     []byte(app.ClientID),
+		keyAuthorizeURL: []byte(e.instanceURL + "/login/oauth/authorize"),
+		keyTokenURL:     []byte(e.instanceURL + "/login/oauth/access_token"),
+		keyUserinfoURL:  []byte(e.instanceURL + "/login/oauth/userinfo"),
+	}
+	if app.ClientSecret != "" {
+		cd[keyClientSecret] = []byte(app.ClientSecret)
+	}
+	return cd
+}
+
+func request(cr *v2.OAuth2Application) *clients.OAuth2ApplicationRequest {
+	p := cr.Spec.ForProvider
+	req := &clients.OAuth2ApplicationRequest{
+		Name:               p.Name,
+		RedirectURIs:       p.RedirectURIs,
+		ConfidentialClient: true,
+	}
+	if p.ConfidentialClient != nil {
+		req.ConfidentialClient = *p.ConfidentialClient
+	}
+	if p.SkipSecondaryAuthorization != nil {
+		req.SkipSecondaryAuthorization = *p.SkipSecondaryAuthorization
+	}
+	return req
+}
+
+func username(cr *v2.OAuth2Application) string {
+	if cr.Spec.ForProvider.Username != nil {
+		return *cr.Spec.ForProvider.Username
+	}
+	return ""
+}
+
+// Setup adds a controller that reconciles OAuth2Application managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v2.OAuth2ApplicationKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v2.OAuth2ApplicationGroupVersionKind),
+		managed.WithExternalConnector(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v2.OAuth2Application{}).
+		Complete(r)
+}
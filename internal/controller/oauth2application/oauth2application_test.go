@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oauth2application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/rossigee/provider-gitea/apis/oauth2application/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type mockAppClient struct {
+	testutil.NoopClient
+	app     *clients.OAuth2Application
+	sudo    string
+	updated *clients.OAuth2ApplicationRequest
+}
+
+func (m *mockAppClient) GetOAuth2Application(ctx context.Context, username string, id int64) (*clients.OAuth2Application, error) {
+	m.sudo = username
+	app := *m.app
+	app.ClientSecret = ""
+	return &app, nil
+}
+
+func (m *mockAppClient) CreateOAuth2Application(ctx context.Context, username string, req *clients.OAuth2ApplicationRequest) (*clients.OAuth2Application, error) {
+	m.sudo = username
+	return &clients.OAuth2Application{ID: 7, ClientID: "client-id", ClientSecret: "secret-1"}, nil
+}
+
+func (m *mockAppClient) UpdateOAuth2Application(ctx context.Context, username string, id int64, req *clients.OAuth2ApplicationRequest) (*clients.OAuth2Application, error) {
+	m.updated = req
+	return &clients.OAuth2Application{ID: id, ClientID: "client-id", ClientSecret: "secret-2"}, nil
+}
+
+func newApp() *v2.OAuth2Application {
+	owner := "grafana-bot"
+	cr := &v2.OAuth2Application{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "grafana"},
+		Spec: v2.OAuth2ApplicationSpec{ForProvider: v2.OAuth2ApplicationParameters{
+			Username:     &owner,
+			Name:         "Grafana",
+			RedirectURIs: []string{"https://grafana.example.com/login/generic_oauth"},
+		}},
+	}
+	meta.SetExternalName(cr, "7")
+	return cr
+}
+
+func TestObserve(t *testing.T) {
+	m := &mockAppClient{app: &clients.OAuth2Application{
+		ID:                 7,
+		Name:               "Grafana",
+		ClientID:           "client-id",
+		RedirectURIs:       []string{"https://grafana.example.com/login/generic_oauth"},
+		ConfidentialClient: true,
+	}}
+	ec := &externalClient{client: m, instanceURL: "https://gitea.example.com"}
+	cr := newApp()
+
+	obs, err := ec.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.Equal(t, "grafana-bot", m.sudo)
+	assert.Equal(t, "client-id", string(obs.ConnectionDetails[keyClientID]))
+	assert.Equal(t, "https://gitea.example.com/login/oauth/authorize", string(obs.ConnectionDetails[keyAuthorizeURL]))
+	assert.Equal(t, "https://gitea.example.com/login/oauth/access_token", string(obs.ConnectionDetails[keyTokenURL]))
+	assert.Equal(t, "https://gitea.example.com/login/oauth/userinfo", string(obs.ConnectionDetails[keyUserinfoURL]))
+	assert.NotContains(t, obs.ConnectionDetails, keyClientSecret, "the published secret must not be overwritten")
+
+	cr.Spec.ForProvider.RedirectURIs = append(cr.Spec.ForProvider.RedirectURIs, "https://grafana.internal/login/generic_oauth")
+	obs, err = ec.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate)
+
+	cr = newApp()
+	meta.AddAnnotations(cr, map[string]string{AnnotationRegenerate: "1"})
+	obs, err = ec.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate)
+}
+
+func TestCreate(t *testing.T) {
+	m := &mockAppClient{}
+	ec := &externalClient{client: m, instanceURL: "https://gitea.example.com"}
+	cr := newApp()
+	meta.SetExternalName(cr, "grafana")
+
+	cre, err := ec.Create(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, "7", meta.GetExternalName(cr))
+	assert.Equal(t, "secret-1", string(cre.ConnectionDetails[keyClientSecret]))
+}
+
+func TestUpdateRegenerates(t *testing.T) {
+	ctx := context.Background()
+
+	cr := newApp()
+	meta.AddAnnotations(cr, map[string]string{AnnotationRegenerate: "1"})
+	kube := fake.NewClientBuilder().WithScheme(testutil.Scheme(t)).WithObjects(cr.DeepCopy()).Build()
+	m := &mockAppClient{}
+	ec := &externalClient{client: m, kube: kube}
+
+	upd, err := ec.Update(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-2", string(upd.ConnectionDetails[keyClientSecret]))
+	assert.True(t, m.updated.ConfidentialClient, "clients are confidential by default")
+
+	got := &v2.OAuth2Application{}
+	require.NoError(t, kube.Get(ctx, client.ObjectKeyFromObject(cr), got))
+	assert.False(t, secretRegeneration.Requested(got))
+}
@@ -18,6 +18,8 @@ package organization
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"strings"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
@@ -28,7 +30,9 @@ import (
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/rossigee/provider-gitea/apis/organization/v2"
 	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/labels"
 	"github.com/rossigee/provider-gitea/internal/tracing"
+	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -43,6 +47,17 @@ const (
 	errDeleteOrganization  = "failed to delete organization"
 	errGetProviderConfig   = "failed to get provider config"
 	errInvalidExternalName = "invalid external-name, expected organization name"
+	errGetAvatar           = "failed to get avatar image"
+	errMissingAvatarKey    = "avatar source has no key %q"
+	errUpdateAvatar        = "failed to update organization avatar"
+	errDeleteAvatar        = "failed to reset organization avatar"
+	errListLabels          = "failed to list organization labels"
+	errCreateLabel         = "failed to create organization label"
+	errUpdateLabel         = "failed to update organization label"
+	errDeleteLabel         = "failed to delete organization label"
+	errListBlocks          = "failed to list blocked users"
+	errBlockUser           = "failed to block user"
+	errUnblockUser         = "failed to unblock user"
 )
 
 // A connector is expected to produce an ExternalClient when its Connect method is called.
@@ -78,13 +93,14 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, err
 	}
 
-	return &externalClient{client: conn}, nil
+	return &externalClient{client: conn, kube: c.kube}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it matches the managed resource's desired state.
 type externalClient struct {
 	client clients.Client
+	kube   client.Client
 }
 
 func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -115,6 +131,9 @@ func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (mana
 	privateRepos := org.NumPrivateRepos
 	members := org.NumMembers
 	teams := org.NumTeams
+	avatarHash := cr.Status.AtProvider.AvatarHash
+	managedLabels := cr.Status.AtProvider.ManagedLabels
+	managedBlocks := cr.Status.AtProvider.ManagedBlockedUsers
 
 	cr.Status.AtProvider = v2.OrganizationObservation{
 		ID:                     &org.ID,
@@ -125,11 +144,23 @@ func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (mana
 		PrivateRepos:           &privateRepos,
 		Members:                &members,
 		Teams:                  &teams,
+		AvatarHash:             avatarHash,
+		ManagedLabels:          managedLabels,
+		ManagedBlockedUsers:    managedBlocks,
+	}
+
+	drift, err := e.drift(ctx, cr, externalName)
+	if err != nil {
+		return managed.ExternalObservation{}, err
 	}
 
 	cr.SetConditions(xpv1.Available())
 
-	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: isOrganizationUpToDate(cr, org)}, nil
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: isOrganizationUpToDate(cr, org) && len(drift) == 0,
+		Diff:             strings.Join(drift, ", "),
+	}, nil
 }
 
 func isOrganizationUpToDate(cr *v2.Organization, org *clients.Organization) bool {
@@ -258,6 +289,9 @@ func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (manag
 	privateRepos := org.NumPrivateRepos
 	members := org.NumMembers
 	teams := org.NumTeams
+	avatarHash := cr.Status.AtProvider.AvatarHash
+	managedLabels := cr.Status.AtProvider.ManagedLabels
+	managedBlocks := cr.Status.AtProvider.ManagedBlockedUsers
 
 	cr.Status.AtProvider = v2.OrganizationObservation{
 		ID:                     &org.ID,
@@ -268,9 +302,12 @@ func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (manag
 		PrivateRepos:           &privateRepos,
 		Members:                &members,
 		Teams:                  &teams,
+		AvatarHash:             avatarHash,
+		ManagedLabels:          managedLabels,
+		ManagedBlockedUsers:    managedBlocks,
 	}
 
-	return managed.ExternalUpdate{}, nil
+	return managed.ExternalUpdate{}, e.apply(ctx, cr, externalName)
 }
 
 func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
@@ -306,6 +343,245 @@ func (e *externalClient) Disconnect(ctx context.Context) error {
 	return nil
 }
 
+// avatar returns the desired avatar image and its SHA-256.
+func (e *externalClient) avatar(ctx context.Context, cr *v2.Organization) ([]byte, string, error) {
+	src := cr.Spec.ForProvider.Avatar
+	var image []byte
+	var ok bool
+
+	if ref := src.ConfigMapKeyRef; ref != nil {
+		cm := &corev1.ConfigMap{}
+		if err := e.kube.Get(ctx, client.ObjectKey{Namespace: cr.GetNamespace(), Name: ref.Name}, cm); err != nil {
+			return nil, "", errors.Wrap(err, errGetAvatar)
+		}
+		if image, ok = cm.BinaryData[ref.Key]; !ok {
+			var v string
+			v, ok = cm.Data[ref.Key]
+			image = []byte(v)
+		}
+		if !ok {
+			return nil, "", errors.Errorf(errMissingAvatarKey, ref.Key)
+		}
+	} else if ref := src.SecretKeyRef; ref != nil {
+		secret := &corev1.Secret{}
+		if err := e.kube.Get(ctx, client.ObjectKey{Namespace: cr.GetNamespace(), Name: ref.Name}, secret); err != nil {
+			return nil, "", errors.Wrap(err, errGetAvatar)
+		}
+		if image, ok = secret.Data[ref.Key]; !ok {
+			return nil, "", errors.Errorf(errMissingAvatarKey, ref.Key)
+		}
+	}
+
+	sum := sha256.Sum256(image)
+	return image, hex.EncodeToString(sum[:]), nil
+}
+
+// drift returns the avatar, labels and blocked users that differ from
+// Gitea, and reports the organization's labels and blocks in status.
+func (e *externalClient) drift(ctx context.Context, cr *v2.Organization, org string) ([]string, error) {
+	p := cr.Spec.ForProvider
+	managed := cr.Status.AtProvider
+	var d []string
+
+	// Gitea does not expose the avatar image, so the hash of the last
+	// uploaded image is compared instead.
+	if p.Avatar != nil {
+		_, hash, err := e.avatar(ctx, cr)
+		if err != nil {
+			return nil, err
+		}
+		if h := managed.AvatarHash; h == nil || *h != hash {
+			d = append(d, "avatar")
+		}
+	} else if managed.AvatarHash != nil {
+		d = append(d, "avatar")
+	}
+
+	if len(p.Labels) > 0 || len(managed.ManagedLabels) > 0 {
+		have, err := e.client.ListOrganizationLabels(ctx, org)
+		if err != nil {
+			return nil, errors.Wrap(err, errListLabels)
+		}
+		for _, l := range have {
+			cr.Status.AtProvider.Labels = append(cr.Status.AtProvider.Labels, l.Name)
+		}
+		if len(labels.Changes(definitions(p.Labels), have)) > 0 || len(removedLabels(p.Labels, managed.ManagedLabels, have)) > 0 {
+			d = append(d, "labels")
+		}
+	}
+
+	if len(p.BlockedUsers) > 0 || len(managed.ManagedBlockedUsers) > 0 {
+		blocked, err := e.client.ListOrganizationBlocks(ctx, org)
+		if err != nil {
+			return nil, errors.Wrap(err, errListBlocks)
+		}
+		for _, u := range blocked {
+			cr.Status.AtProvider.BlockedUsers = append(cr.Status.AtProvider.BlockedUsers, u.Username)
+		}
+		if len(missingBlocks(p.BlockedUsers, blocked)) > 0 || len(removedBlocks(p.BlockedUsers, managed.ManagedBlockedUsers, blocked)) > 0 {
+			d = append(d, "blockedUsers")
+		}
+	}
+
+	return d, nil
+}
+
+// apply uploads the avatar if it changed, creates or updates labels and
+// blocks users that are not blocked yet. The avatar, labels and blocks the
+// provider applied are reset, deleted and lifted once removed from the spec.
+func (e *externalClient) apply(ctx context.Context, cr *v2.Organization, org string) error {
+	p := cr.Spec.ForProvider
+	status := &cr.Status.AtProvider
+
+	if p.Avatar != nil {
+		image, hash, err := e.avatar(ctx, cr)
+		if err != nil {
+			return err
+		}
+		if h := status.AvatarHash; h == nil || *h != hash {
+			if err := e.client.UpdateOrganizationAvatar(ctx, org, image); err != nil {
+				return errors.Wrap(err, errUpdateAvatar)
+			}
+			status.AvatarHash = &hash
+		}
+	} else if status.AvatarHash != nil {
+		if err := e.client.DeleteOrganizationAvatar(ctx, org); err != nil {
+			return errors.Wrap(err, errDeleteAvatar)
+		}
+		status.AvatarHash = nil
+	}
+
+	if len(p.Labels) > 0 || len(status.ManagedLabels) > 0 {
+		have, err := e.client.ListOrganizationLabels(ctx, org)
+		if err != nil {
+			return errors.Wrap(err, errListLabels)
+		}
+		for _, l := range removedLabels(p.Labels, status.ManagedLabels, have) {
+			if err := e.client.DeleteOrganizationLabel(ctx, org, l.ID); err != nil && !clients.IsNotFound(err) {
+				return errors.Wrapf(err, "%s %s", errDeleteLabel, l.Name)
+			}
+		}
+		for _, c := range labels.Changes(definitions(p.Labels), have) {
+			if c.Existing == nil {
+				_, err = e.client.CreateOrganizationLabel(ctx, org, &clients.CreateLabelRequest{
+					Name:        c.Want.Name,
+					Color:       c.Want.Color,
+					Description: stringValue(c.Want.Description),
+					Exclusive:   c.Want.Exclusive != nil && *c.Want.Exclusive,
+				})
+				if err != nil {
+					return errors.Wrapf(err, "%s %s", errCreateLabel, c.Want.Name)
+				}
+				continue
+			}
+			_, err = e.client.UpdateOrganizationLabel(ctx, org, c.Existing.ID, &clients.UpdateLabelRequest{
+				Color:       &c.Want.Color,
+				Description: c.Want.Description,
+				Exclusive:   c.Want.Exclusive,
+			})
+			if err != nil {
+				return errors.Wrapf(err, "%s %s", errUpdateLabel, c.Want.Name)
+			}
+		}
+		status.ManagedLabels = nil
+		for _, l := range p.Labels {
+			status.ManagedLabels = append(status.ManagedLabels, l.Name)
+		}
+	}
+
+	if len(p.BlockedUsers) > 0 || len(status.ManagedBlockedUsers) > 0 {
+		blocked, err := e.client.ListOrganizationBlocks(ctx, org)
+		if err != nil {
+			return errors.Wrap(err, errListBlocks)
+		}
+		for _, u := range removedBlocks(p.BlockedUsers, status.ManagedBlockedUsers, blocked) {
+			if err := e.client.UnblockOrganizationUser(ctx, org, u); err != nil && !clients.IsNotFound(err) {
+				return errors.Wrapf(err, "%s %s", errUnblockUser, u)
+			}
+		}
+		for _, u := range missingBlocks(p.BlockedUsers, blocked) {
+			if err := e.client.BlockOrganizationUser(ctx, org, u); err != nil {
+				return errors.Wrapf(err, "%s %s", errBlockUser, u)
+			}
+		}
+		status.ManagedBlockedUsers = append([]string(nil), p.BlockedUsers...)
+	}
+
+	return nil
+}
+
+// removedLabels returns the labels in Gitea that the provider applied and
+// that are no longer declared.
+func removedLabels(declared []v2.OrganizationLabel, managed []string, have []*clients.Label) []*clients.Label {
+	undeclared := map[string]bool{}
+	for _, m := range managed {
+		undeclared[m] = true
+	}
+	for _, l := range declared {
+		delete(undeclared, l.Name)
+	}
+
+	var removed []*clients.Label
+	for _, h := range have {
+		if undeclared[h.Name] {
+			removed = append(removed, h)
+		}
+	}
+	return removed
+}
+
+// removedBlocks returns the blocked users that the provider blocked and
+// that are no longer declared.
+func removedBlocks(declared, managed []string, blocked []*clients.User) []string {
+	undeclared := map[string]bool{}
+	for _, m := range managed {
+		undeclared[strings.ToLower(m)] = true
+	}
+	for _, d := range declared {
+		delete(undeclared, strings.ToLower(d))
+	}
+
+	var removed []string
+	for _, u := range blocked {
+		if undeclared[strings.ToLower(u.Username)] {
+			removed = append(removed, u.Username)
+		}
+	}
+	return removed
+}
+
+func definitions(declared []v2.OrganizationLabel) []labels.Definition {
+	defs := make([]labels.Definition, 0, len(declared))
+	for _, l := range declared {
+		defs = append(defs, labels.Definition(l))
+	}
+	return defs
+}
+
+func missingBlocks(want []string, blocked []*clients.User) []string {
+	var missing []string
+	for _, w := range want {
+		found := false
+		for _, u := range blocked {
+			if strings.EqualFold(u.Username, w) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, w)
+		}
+	}
+	return missing
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
 // Setup adds a controller that reconciles Organization managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(v2.OrganizationKind)
@@ -18,6 +18,8 @@ package organization
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"testing"
 
@@ -27,7 +29,9 @@ import (
 	"github.com/rossigee/provider-gitea/internal/controller/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 type mockOrgClient struct {
@@ -457,3 +461,205 @@ func TestDelete(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+type mockExtrasClient struct {
+	mockOrgClient
+	labels  []*clients.Label
+	blocked []*clients.User
+	avatar  []byte
+	created []string
+	updated []int64
+	blocks  []string
+	deleted []int64
+	unblock []string
+	reset   bool
+}
+
+func (m *mockExtrasClient) ListOrganizationLabels(ctx context.Context, org string) ([]*clients.Label, error) {
+	return m.labels, nil
+}
+func (m *mockExtrasClient) CreateOrganizationLabel(ctx context.Context, org string, req *clients.CreateLabelRequest) (*clients.Label, error) {
+	m.created = append(m.created, req.Name)
+	return &clients.Label{Name: req.Name}, nil
+}
+func (m *mockExtrasClient) UpdateOrganizationLabel(ctx context.Context, org string, labelID int64, req *clients.UpdateLabelRequest) (*clients.Label, error) {
+	m.updated = append(m.updated, labelID)
+	return &clients.Label{ID: labelID}, nil
+}
+func (m *mockExtrasClient) ListOrganizationBlocks(ctx context.Context, org string) ([]*clients.User, error) {
+	return m.blocked, nil
+}
+func (m *mockExtrasClient) BlockOrganizationUser(ctx context.Context, org, username string) error {
+	m.blocks = append(m.blocks, username)
+	return nil
+}
+func (m *mockExtrasClient) DeleteOrganizationLabel(ctx context.Context, org string, labelID int64) error {
+	m.deleted = append(m.deleted, labelID)
+	return nil
+}
+func (m *mockExtrasClient) UnblockOrganizationUser(ctx context.Context, org, username string) error {
+	m.unblock = append(m.unblock, username)
+	return nil
+}
+func (m *mockExtrasClient) DeleteOrganizationAvatar(ctx context.Context, org string) error {
+	m.reset = true
+	return nil
+}
+func (m *mockExtrasClient) UpdateOrganizationAvatar(ctx context.Context, org string, image []byte) error {
+	m.avatar = image
+	return nil
+}
+
+func extrasOrganization() *v2.Organization {
+	cr := &v2.Organization{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-org"},
+		Spec: v2.OrganizationSpec{
+			ForProvider: v2.OrganizationParameters{
+				Username: "test-org",
+				Avatar: &v2.AvatarSource{
+					ConfigMapKeyRef: &v2.KeySelector{Name: "branding", Key: "avatar.png"},
+				},
+				Labels: []v2.OrganizationLabel{
+					{Name: "bug", Color: "#EE0701"},
+					{Name: "security", Color: "b60205"},
+				},
+				BlockedUsers: []string{"spammer", "Troll"},
+			},
+		},
+	}
+	meta.SetExternalName(cr, "test-org")
+	return cr
+}
+
+func TestOrganizationExtras(t *testing.T) {
+	image := []byte("png")
+	sum := sha256.Sum256(image)
+	hash := hex.EncodeToString(sum[:])
+	branding := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "branding"},
+		BinaryData: map[string][]byte{"avatar.png": image},
+	}
+
+	newClient := func() *mockExtrasClient {
+		return &mockExtrasClient{
+			mockOrgClient: mockOrgClient{
+				getOrgFn: func(ctx context.Context, name string) (*clients.Organization, error) {
+					return &clients.Organization{Username: "test-org"}, nil
+				},
+				updateOrgFn: func(ctx context.Context, name string, req *clients.UpdateOrganizationRequest) (*clients.Organization, error) {
+					return &clients.Organization{Username: "test-org"}, nil
+				},
+			},
+			labels: []*clients.Label{
+				{ID: 1, Name: "bug", Color: "ee0701"},
+				{ID: 2, Name: "security", Color: "e11d21"},
+			},
+			blocked: []*clients.User{{Username: "troll"}},
+		}
+	}
+
+	t.Run("observe reports drift", func(t *testing.T) {
+		ec := &externalClient{client: newClient(), kube: fake.NewClientBuilder().WithObjects(branding).Build()}
+		cr := extrasOrganization()
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate)
+		assert.Equal(t, "avatar, labels, blockedUsers", obs.Diff)
+		assert.Equal(t, []string{"bug", "security"}, cr.Status.AtProvider.Labels)
+		assert.Equal(t, []string{"troll"}, cr.Status.AtProvider.BlockedUsers)
+	})
+
+	t.Run("observe is up to date once applied", func(t *testing.T) {
+		c := newClient()
+		c.labels[1].Color = "B60205"
+		c.blocked = append(c.blocked, &clients.User{Username: "spammer"})
+		ec := &externalClient{client: c, kube: fake.NewClientBuilder().WithObjects(branding).Build()}
+		cr := extrasOrganization()
+		cr.Status.AtProvider.AvatarHash = &hash
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+		require.NotNil(t, cr.Status.AtProvider.AvatarHash)
+		assert.Equal(t, hash, *cr.Status.AtProvider.AvatarHash)
+	})
+
+	t.Run("update applies avatar, labels and blocks", func(t *testing.T) {
+		c := newClient()
+		c.labels = c.labels[1:]
+		ec := &externalClient{client: c, kube: fake.NewClientBuilder().WithObjects(branding).Build()}
+		cr := extrasOrganization()
+
+		_, err := ec.Update(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, image, c.avatar)
+		require.NotNil(t, cr.Status.AtProvider.AvatarHash)
+		assert.Equal(t, hash, *cr.Status.AtProvider.AvatarHash)
+		assert.Equal(t, []string{"bug"}, c.created)
+		assert.Equal(t, []int64{2}, c.updated)
+		assert.Equal(t, []string{"spammer"}, c.blocks)
+	})
+
+	t.Run("update records the applied labels and blocks", func(t *testing.T) {
+		c := newClient()
+		ec := &externalClient{client: c, kube: fake.NewClientBuilder().WithObjects(branding).Build()}
+		cr := extrasOrganization()
+
+		_, err := ec.Update(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bug", "security"}, cr.Status.AtProvider.ManagedLabels)
+		assert.Equal(t, []string{"spammer", "Troll"}, cr.Status.AtProvider.ManagedBlockedUsers)
+	})
+
+	t.Run("observe reports removed avatar, labels and blocks", func(t *testing.T) {
+		c := newClient()
+		c.labels[1].Color = "b60205"
+		ec := &externalClient{client: c, kube: fake.NewClientBuilder().Build()}
+		cr := extrasOrganization()
+		cr.Spec.ForProvider.Avatar = nil
+		cr.Spec.ForProvider.Labels = cr.Spec.ForProvider.Labels[1:]
+		cr.Spec.ForProvider.BlockedUsers = []string{"spammer"}
+		cr.Status.AtProvider.AvatarHash = &hash
+		cr.Status.AtProvider.ManagedLabels = []string{"bug", "security"}
+		cr.Status.AtProvider.ManagedBlockedUsers = []string{"spammer", "Troll"}
+		c.blocked = append(c.blocked, &clients.User{Username: "spammer"})
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, "avatar, labels, blockedUsers", obs.Diff)
+		assert.Equal(t, []string{"bug", "security"}, cr.Status.AtProvider.ManagedLabels)
+	})
+
+	t.Run("update removes what was dropped from the spec", func(t *testing.T) {
+		c := newClient()
+		c.labels = append(c.labels, &clients.Label{ID: 3, Name: "unmanaged", Color: "000000"})
+		c.blocked = append(c.blocked, &clients.User{Username: "other"})
+		ec := &externalClient{client: c, kube: fake.NewClientBuilder().Build()}
+		cr := extrasOrganization()
+		cr.Spec.ForProvider.Avatar = nil
+		cr.Spec.ForProvider.Labels = cr.Spec.ForProvider.Labels[1:]
+		cr.Spec.ForProvider.BlockedUsers = nil
+		cr.Status.AtProvider.AvatarHash = &hash
+		cr.Status.AtProvider.ManagedLabels = []string{"bug", "security"}
+		cr.Status.AtProvider.ManagedBlockedUsers = []string{"spammer", "Troll"}
+
+		_, err := ec.Update(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, c.reset)
+		assert.Nil(t, cr.Status.AtProvider.AvatarHash)
+		assert.Equal(t, []int64{1}, c.deleted)
+		assert.Equal(t, []string{"troll"}, c.unblock)
+		assert.Equal(t, []string{"security"}, cr.Status.AtProvider.ManagedLabels)
+		assert.Empty(t, cr.Status.AtProvider.ManagedBlockedUsers)
+	})
+
+	t.Run("missing avatar key returns error", func(t *testing.T) {
+		ec := &externalClient{client: newClient(), kube: fake.NewClientBuilder().WithObjects(branding).Build()}
+		cr := extrasOrganization()
+		cr.Spec.ForProvider.Avatar.ConfigMapKeyRef.Key = "logo.png"
+
+		_, err := ec.Observe(context.Background(), cr)
+		require.Error(t, err)
+	})
+}
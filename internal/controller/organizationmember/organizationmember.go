@@ -0,0 +1,346 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizationmember
+
+import (
+	"context"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-gitea/apis/organizationmember/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/teams"
+	"github.com/rossigee/provider-gitea/internal/tracing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/rossigee/provider-gitea/apis/v1beta1"
+)
+
+const (
+	errNotOrganizationMember = "managed resource is not a OrganizationMember custom resource"
+	errGetMembership         = "failed to get organization membership"
+	errListTeams             = "failed to list organization teams"
+	errGetTeamMember         = "failed to get team member"
+	errGetVisibility         = "failed to get membership visibility"
+	errResolveTeam           = "failed to resolve team"
+	errAddMember             = "failed to add user to team"
+	errRemoveOwner           = "failed to remove user from the Owners team"
+	errSetVisibility         = "failed to set membership visibility"
+	errRemoveMember          = "failed to remove organization member"
+	errGetProviderConfig     = "failed to get provider config"
+)
+
+// ownersTeam is the team Gitea creates with every organization; its members
+// own the organization.
+const ownersTeam = "Owners"
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient by:
+// 1. Getting the provider config
+// 2. Creating a Gitea API client
+// 3. Returning an external client wrapping the API client
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v2.OrganizationMember)
+	if !ok {
+		return nil, errors.New(errNotOrganizationMember)
+	}
+
+	pcRef := cr.Spec.ProviderConfigReference
+	if pcRef == nil {
+		return nil, errors.New("providerConfigRef is required")
+	}
+
+	var pc v1beta1.ProviderConfig
+	if err := c.kube.Get(ctx, client.ObjectKey{
+		Namespace: cr.GetNamespace(),
+		Name:      pcRef.Name,
+	}, &pc); err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
+
+	conn, err := clients.NewClient(ctx, &pc, c.kube)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalClient{client: conn}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it matches the managed resource's desired state.
+type externalClient struct {
+	client clients.Client
+}
+
+// membership is a user's place in an organization, derived from the teams
+// they belong to.
+type membership struct {
+	user   *clients.User
+	teams  []string
+	owner  bool
+	public bool
+}
+
+func (m *membership) inTeam(name string) bool {
+	for _, t := range m.teams {
+		if strings.EqualFold(t, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	_, span := tracing.StartSpan(ctx, "organizationmember.observe",
+		tracing.SpanAttrs("organizationmember", tracing.ResourceName(mg), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.OrganizationMember)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotOrganizationMember)
+	}
+
+	p := cr.Spec.ForProvider
+	isMember, err := e.client.IsOrganizationMember(ctx, p.Organization, p.Username)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetMembership)
+	}
+	if !isMember {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	m, err := e.membership(ctx, p)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	role, visibility := "member", "private"
+	if m.owner {
+		role = "owner"
+	}
+	if m.public {
+		visibility = "public"
+	}
+	cr.Status.AtProvider = v2.OrganizationMemberObservation{
+		Username:     &p.Username,
+		Organization: &p.Organization,
+		Role:         &role,
+		Visibility:   &visibility,
+		Teams:        m.teams,
+	}
+	if m.user != nil {
+		cr.Status.AtProvider.UserInfo = &v2.OrganizationMemberUserInfo{
+			ID:        &m.user.ID,
+			Email:     &m.user.Email,
+			FullName:  &m.user.FullName,
+			AvatarURL: &m.user.AvatarURL,
+		}
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	var drift []string
+	if p.Role == "owner" && !m.owner || p.Role != "owner" && (m.owner || !m.inTeam(defaultTeam(p))) {
+		drift = append(drift, "role")
+	}
+	if p.Visibility != nil && *p.Visibility != visibility {
+		drift = append(drift, "visibility")
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: len(drift) == 0,
+		Diff:             strings.Join(drift, ", "),
+	}, nil
+}
+
+func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	_, span := tracing.StartSpan(ctx, "organizationmember.create",
+		tracing.SpanAttrs("organizationmember", tracing.ResourceName(mg), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.OrganizationMember)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotOrganizationMember)
+	}
+
+	p := cr.Spec.ForProvider
+	if err := e.addToTeam(ctx, p, roleTeam(p)); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	public, err := e.client.IsPublicOrganizationMember(ctx, p.Organization, p.Username)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errGetVisibility)
+	}
+	return managed.ExternalCreation{}, e.setVisibility(ctx, p, public)
+}
+
+func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	_, span := tracing.StartSpan(ctx, "organizationmember.update",
+		tracing.SpanAttrs("organizationmember", tracing.ResourceName(mg), "update")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.OrganizationMember)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotOrganizationMember)
+	}
+
+	p := cr.Spec.ForProvider
+	m, err := e.membership(ctx, p)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// The user joins the team for their role before leaving Owners, so a
+	// demoted owner stays in the organization.
+	if team := roleTeam(p); !m.inTeam(team) {
+		if err := e.addToTeam(ctx, p, team); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+	if p.Role != "owner" && m.owner {
+		t, err := e.team(ctx, p, ownersTeam)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		if err := e.client.RemoveTeamMember(ctx, t.ID, p.Username); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errRemoveOwner)
+		}
+	}
+
+	return managed.ExternalUpdate{}, e.setVisibility(ctx, p, m.public)
+}
+
+func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	_, span := tracing.StartSpan(ctx, "organizationmember.delete",
+		tracing.SpanAttrs("organizationmember", tracing.ResourceName(mg), "delete")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.OrganizationMember)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotOrganizationMember)
+	}
+
+	// Removing the user from the organization removes them from every team.
+	err := e.client.RemoveOrganizationMember(ctx, cr.Spec.ForProvider.Organization, cr.Spec.ForProvider.Username)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return managed.ExternalDelete{}, errors.Wrap(err, errRemoveMember)
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *externalClient) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// membership returns the teams of the organization the user belongs to and
+// whether their membership is public.
+func (e *externalClient) membership(ctx context.Context, p v2.OrganizationMemberParameters) (*membership, error) {
+	list, err := e.client.ListOrganizationTeams(ctx, p.Organization)
+	if err != nil {
+		return nil, errors.Wrap(err, errListTeams)
+	}
+
+	m := &membership{}
+	for _, t := range list {
+		u, err := e.client.GetTeamMember(ctx, t.ID, p.Username)
+		if err != nil {
+			if strings.Contains(err.Error(), "404") {
+				continue
+			}
+			return nil, errors.Wrap(err, errGetTeamMember)
+		}
+		m.user = u
+		m.teams = append(m.teams, t.Name)
+		if strings.EqualFold(t.Name, ownersTeam) {
+			m.owner = true
+		}
+	}
+
+	m.public, err = e.client.IsPublicOrganizationMember(ctx, p.Organization, p.Username)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetVisibility)
+	}
+	return m, nil
+}
+
+func (e *externalClient) team(ctx context.Context, p v2.OrganizationMemberParameters, name string) (*clients.Team, error) {
+	t, err := teams.Resolve(ctx, nil, e.client, "", nil, &p.Organization, &name)
+	return t, errors.Wrap(err, errResolveTeam)
+}
+
+func (e *externalClient) addToTeam(ctx context.Context, p v2.OrganizationMemberParameters, name string) error {
+	t, err := e.team(ctx, p, name)
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(e.client.AddTeamMember(ctx, t.ID, p.Username), errAddMember)
+}
+
+// setVisibility publicizes or conceals the membership if it is not already
+// as desired. Gitea only lets members change their own visibility, so the
+// request is made as the user, which fails unless the provider token belongs
+// to an admin.
+func (e *externalClient) setVisibility(ctx context.Context, p v2.OrganizationMemberParameters, public bool) error {
+	if p.Visibility == nil || (*p.Visibility == "public") == public {
+		return nil
+	}
+	err := e.client.SetOrganizationMemberVisibility(ctx, p.Organization, p.Username, !public)
+	return errors.Wrap(err, errSetVisibility)
+}
+
+// roleTeam returns the team that grants the user their role.
+func roleTeam(p v2.OrganizationMemberParameters) string {
+	if p.Role == "owner" {
+		return ownersTeam
+	}
+	return defaultTeam(p)
+}
+
+func defaultTeam(p v2.OrganizationMemberParameters) string {
+	if p.DefaultTeam == nil {
+		return ""
+	}
+	return *p.DefaultTeam
+}
+
+// Setup adds a controller that reconciles OrganizationMember managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v2.OrganizationMemberKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v2.OrganizationMemberGroupVersionKind),
+		managed.WithExternalConnector(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v2.OrganizationMember{}).
+		Complete(r)
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizationmember
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-gitea/apis/organizationmember/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type mockOrganizationMemberClient struct {
+	testutil.NoopClient
+	// teams maps team IDs to their members
+	teams   map[int64]map[string]bool
+	public  bool
+	visible *bool
+}
+
+func newMock() *mockOrganizationMemberClient {
+	return &mockOrganizationMemberClient{teams: map[int64]map[string]bool{1: {}, 2: {}}}
+}
+
+func (m *mockOrganizationMemberClient) IsOrganizationMember(ctx context.Context, org, username string) (bool, error) {
+	for _, members := range m.teams {
+		if members[username] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockOrganizationMemberClient) IsPublicOrganizationMember(ctx context.Context, org, username string) (bool, error) {
+	return m.public, nil
+}
+
+func (m *mockOrganizationMemberClient) SetOrganizationMemberVisibility(ctx context.Context, org, username string, public bool) error {
+	m.visible = &public
+	return nil
+}
+
+func (m *mockOrganizationMemberClient) ListOrganizationTeams(ctx context.Context, org string) ([]*clients.Team, error) {
+	return []*clients.Team{{ID: 1, Name: "Owners"}, {ID: 2, Name: "Developers"}}, nil
+}
+
+func (m *mockOrganizationMemberClient) GetTeamMember(ctx context.Context, teamID int64, username string) (*clients.User, error) {
+	if !m.teams[teamID][username] {
+		return nil, fmt.Errorf("API request failed with status 404: not found")
+	}
+	return &clients.User{ID: 3, Username: username, FullName: "Jane Developer"}, nil
+}
+
+func (m *mockOrganizationMemberClient) AddTeamMember(ctx context.Context, teamID int64, username string) error {
+	m.teams[teamID][username] = true
+	return nil
+}
+
+func (m *mockOrganizationMemberClient) RemoveTeamMember(ctx context.Context, teamID int64, username string) error {
+	delete(m.teams[teamID], username)
+	return nil
+}
+
+func newMember(role, visibility string) *v2.OrganizationMember {
+	team := "developers"
+	return &v2.OrganizationMember{
+		ObjectMeta: metav1.ObjectMeta{Name: "jane"},
+		Spec: v2.OrganizationMemberSpec{
+			ForProvider: v2.OrganizationMemberParameters{
+				Organization: "acme",
+				Username:     "jane",
+				Role:         role,
+				DefaultTeam:  &team,
+				Visibility:   &visibility,
+			},
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	t.Run("non member does not exist", func(t *testing.T) {
+		e := &externalClient{client: newMock()}
+		obs, err := e.Observe(context.Background(), newMember("member", "private"))
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+
+	t.Run("reports teams and role", func(t *testing.T) {
+		m := newMock()
+		m.teams[2]["jane"] = true
+		cr := newMember("member", "private")
+		e := &externalClient{client: m}
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.Equal(t, []string{"Developers"}, cr.Status.AtProvider.Teams)
+		assert.Equal(t, "member", *cr.Status.AtProvider.Role)
+		assert.Equal(t, "Jane Developer", *cr.Status.AtProvider.UserInfo.FullName)
+		assert.Equal(t, xpv1.ReasonAvailable, cr.GetCondition(xpv1.TypeReady).Reason)
+	})
+
+	t.Run("owner and visibility drift", func(t *testing.T) {
+		m := newMock()
+		m.teams[1]["jane"] = true
+		m.teams[2]["jane"] = true
+		e := &externalClient{client: m}
+		obs, err := e.Observe(context.Background(), newMember("member", "public"))
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate)
+		assert.Equal(t, "role, visibility", obs.Diff)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	m := newMock()
+	e := &externalClient{client: m}
+	_, err := e.Create(context.Background(), newMember("owner", "public"))
+	require.NoError(t, err)
+	assert.True(t, m.teams[1]["jane"])
+	require.NotNil(t, m.visible)
+	assert.True(t, *m.visible)
+}
+
+func TestUpdateDemotesOwner(t *testing.T) {
+	m := newMock()
+	m.teams[1]["jane"] = true
+	e := &externalClient{client: m}
+	_, err := e.Update(context.Background(), newMember("member", "private"))
+	require.NoError(t, err)
+	assert.False(t, m.teams[1]["jane"])
+	assert.True(t, m.teams[2]["jane"])
+	assert.Nil(t, m.visible, "visibility already matches")
+}
+
+func TestUpdateConcealsMembership(t *testing.T) {
+	m := newMock()
+	m.teams[2]["jane"] = true
+	m.public = true
+	e := &externalClient{client: m}
+	_, err := e.Update(context.Background(), newMember("member", "private"))
+	require.NoError(t, err)
+	require.NotNil(t, m.visible)
+	assert.False(t, *m.visible)
+}
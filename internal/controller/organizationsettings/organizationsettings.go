@@ -0,0 +1,307 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizationsettings
+
+import (
+	"context"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-gitea/apis/organizationsettings/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/teams"
+	"github.com/rossigee/provider-gitea/internal/tracing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/rossigee/provider-gitea/apis/v1beta1"
+)
+
+const (
+	errNotOrganizationSettings = "managed resource is not a OrganizationSettings custom resource"
+	errUnsupported             = "Gitea does not support these settings, remove them from the spec"
+	errGetOrganization         = "failed to get organization"
+	errUpdateOrganization      = "failed to update organization"
+	errResolveDefaultTeam      = "failed to resolve default team"
+	errUpdateDefaultTeam       = "failed to update default team"
+	errSetMaxRepoCreation      = "failed to set maximum repository creation"
+	errGetProviderConfig       = "failed to get provider config"
+)
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient by:
+// 1. Getting the provider config
+// 2. Creating a Gitea API client
+// 3. Returning an external client wrapping the API client
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v2.OrganizationSettings)
+	if !ok {
+		return nil, errors.New(errNotOrganizationSettings)
+	}
+
+	pcRef := cr.Spec.ProviderConfigReference
+	if pcRef == nil {
+		return nil, errors.New("providerConfigRef is required")
+	}
+
+	var pc v1beta1.ProviderConfig
+	if err := c.kube.Get(ctx, client.ObjectKey{
+		Namespace: cr.GetNamespace(),
+		Name:      pcRef.Name,
+	}, &pc); err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
+
+	conn, err := clients.NewClient(ctx, &pc, c.kube)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalClient{client: conn}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it matches the managed resource's desired state.
+type externalClient struct {
+	client clients.Client
+}
+
+func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	_, span := tracing.StartSpan(ctx, "organizationsettings.observe",
+		tracing.SpanAttrs("organizationsettings", tracing.ResourceName(mg), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.OrganizationSettings)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotOrganizationSettings)
+	}
+
+	// Settings Gitea has no equivalent for fail the reconcile, so the Synced
+	// condition shows they are not enforced.
+	p := cr.Spec.ForProvider
+	if u := unsupported(p); len(u) > 0 {
+		return managed.ExternalObservation{}, errors.Errorf("%s: %s", errUnsupported, strings.Join(u, ", "))
+	}
+
+	org, err := e.client.GetOrganization(ctx, p.Organization)
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetOrganization)
+	}
+
+	// Gitea does not report the repository limit, so the last applied
+	// value is kept.
+	var maxRepoCreation *int64
+	if cr.Status.AtProvider.AppliedSettings != nil {
+		maxRepoCreation = cr.Status.AtProvider.AppliedSettings.MaxRepoCreation
+	}
+
+	applied := &v2.AppliedOrganizationSettings{
+		Description:               &org.Description,
+		Website:                   &org.Website,
+		Location:                  &org.Location,
+		Visibility:                &org.Visibility,
+		RepoAdminChangeTeamAccess: &org.RepoAdminChangeTeamAccess,
+		MaxRepoCreation:           maxRepoCreation,
+	}
+
+	var drift []string
+	if p.Visibility != nil && *p.Visibility != org.Visibility {
+		drift = append(drift, "visibility")
+	}
+	if p.RepoAdminChangeTeamAccess != nil && *p.RepoAdminChangeTeamAccess != org.RepoAdminChangeTeamAccess {
+		drift = append(drift, "repoAdminChangeTeamAccess")
+	}
+	if p.MaxRepoCreation != nil && (maxRepoCreation == nil || *p.MaxRepoCreation != *maxRepoCreation) {
+		drift = append(drift, "maxRepoCreation")
+	}
+
+	if p.DefaultTeam != nil {
+		t, err := teams.Resolve(ctx, nil, e.client, "", nil, &p.Organization, p.DefaultTeam)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errResolveDefaultTeam)
+		}
+		applied.DefaultRepoPermission = &t.Permission
+		applied.MembersCanCreateRepos = &t.CanCreateOrgRepo
+		if p.DefaultRepoPermission != nil && (*p.DefaultRepoPermission != t.Permission || !t.IncludesAllRepositories) {
+			drift = append(drift, "defaultRepoPermission")
+		}
+		if p.MembersCanCreateRepos != nil && *p.MembersCanCreateRepos != t.CanCreateOrgRepo {
+			drift = append(drift, "membersCanCreateRepos")
+		}
+	}
+
+	cr.Status.AtProvider = v2.OrganizationSettingsObservation{
+		OrganizationID:  &org.ID,
+		LastUpdated:     &org.Updated,
+		AppliedSettings: applied,
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: len(drift) == 0,
+		Diff:             strings.Join(drift, ", "),
+	}, nil
+}
+
+func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	_, span := tracing.StartSpan(ctx, "organizationsettings.create",
+		tracing.SpanAttrs("organizationsettings", tracing.ResourceName(mg), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.OrganizationSettings)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotOrganizationSettings)
+	}
+
+	// The organization itself is managed elsewhere; applying the settings
+	// fails until it exists.
+	return managed.ExternalCreation{}, e.apply(ctx, cr)
+}
+
+func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	_, span := tracing.StartSpan(ctx, "organizationsettings.update",
+		tracing.SpanAttrs("organizationsettings", tracing.ResourceName(mg), "update")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.OrganizationSettings)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotOrganizationSettings)
+	}
+
+	return managed.ExternalUpdate{}, e.apply(ctx, cr)
+}
+
+func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	// Deleting the resource leaves the organization's settings as they are.
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *externalClient) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// apply sets every setting in the spec.
+func (e *externalClient) apply(ctx context.Context, cr *v2.OrganizationSettings) error {
+	p := cr.Spec.ForProvider
+
+	if p.Visibility != nil || p.RepoAdminChangeTeamAccess != nil {
+		_, err := e.client.UpdateOrganization(ctx, p.Organization, &clients.UpdateOrganizationRequest{
+			Visibility:                p.Visibility,
+			RepoAdminChangeTeamAccess: p.RepoAdminChangeTeamAccess,
+		})
+		if err != nil {
+			return errors.Wrap(err, errUpdateOrganization)
+		}
+	}
+
+	if p.DefaultTeam != nil && (p.DefaultRepoPermission != nil || p.MembersCanCreateRepos != nil) {
+		t, err := teams.Resolve(ctx, nil, e.client, "", nil, &p.Organization, p.DefaultTeam)
+		if err != nil {
+			return errors.Wrap(err, errResolveDefaultTeam)
+		}
+		req := &clients.UpdateTeamRequest{
+			Name:             &t.Name,
+			Permission:       p.DefaultRepoPermission,
+			CanCreateOrgRepo: p.MembersCanCreateRepos,
+		}
+		if p.DefaultRepoPermission != nil {
+			all := true
+			req.IncludesAllRepositories = &all
+		}
+		if _, err := e.client.UpdateTeam(ctx, t.ID, req); err != nil {
+			return errors.Wrap(err, errUpdateDefaultTeam)
+		}
+	}
+
+	if p.MaxRepoCreation != nil {
+		_, err := e.client.UpdateUser(ctx, p.Organization, &clients.UpdateUserRequest{MaxRepoCreation: p.MaxRepoCreation})
+		if err != nil {
+			return errors.Wrap(err, errSetMaxRepoCreation)
+		}
+		if cr.Status.AtProvider.AppliedSettings == nil {
+			cr.Status.AtProvider.AppliedSettings = &v2.AppliedOrganizationSettings{}
+		}
+		limit := *p.MaxRepoCreation
+		cr.Status.AtProvider.AppliedSettings.MaxRepoCreation = &limit
+	}
+
+	return nil
+}
+
+// unsupported returns the spec fields that have no Gitea equivalent. Earlier
+// versions of the CRD defaulted these fields, so objects created then have
+// the defaults persisted; a field still at its old default is treated as
+// unset.
+func unsupported(p v2.OrganizationSettingsParameters) []string {
+	var u []string
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{
+		{"membersCanCreatePrivate", boolChanged(p.MembersCanCreatePrivate, true)},
+		{"membersCanCreateInternal", boolChanged(p.MembersCanCreateInternal, true)},
+		{"membersCanDeleteRepos", boolChanged(p.MembersCanDeleteRepos, false)},
+		{"membersCanFork", boolChanged(p.MembersCanFork, true)},
+		{"membersCanCreatePages", boolChanged(p.MembersCanCreatePages, true)},
+		{"defaultRepoVisibility", p.DefaultRepoVisibility != nil && *p.DefaultRepoVisibility != "public"},
+		{"requireSignedCommits", boolChanged(p.RequireSignedCommits, false)},
+		{"enableDependencyGraph", boolChanged(p.EnableDependencyGraph, false)},
+		{"allowGitHooks", boolChanged(p.AllowGitHooks, false)},
+		{"allowCustomGitHooks", boolChanged(p.AllowCustomGitHooks, false)},
+	} {
+		if f.set {
+			u = append(u, f.name)
+		}
+	}
+	return u
+}
+
+// boolChanged reports whether b is set to something other than the old
+// default.
+func boolChanged(b *bool, old bool) bool {
+	return b != nil && *b != old
+}
+
+// Setup adds a controller that reconciles OrganizationSettings managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v2.OrganizationSettingsKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v2.OrganizationSettingsGroupVersionKind),
+		managed.WithExternalConnector(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v2.OrganizationSettings{}).
+		Complete(r)
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizationsettings
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-gitea/apis/organizationsettings/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type mockOrganizationSettingsClient struct {
+	testutil.NoopClient
+	org         *clients.Organization
+	team        *clients.Team
+	orgUpdate   *clients.UpdateOrganizationRequest
+	teamUpdate  *clients.UpdateTeamRequest
+	userUpdate  *clients.UpdateUserRequest
+	apiRequests int
+}
+
+func (m *mockOrganizationSettingsClient) GetOrganization(ctx context.Context, name string) (*clients.Organization, error) {
+	m.apiRequests++
+	return m.org, nil
+}
+
+func (m *mockOrganizationSettingsClient) UpdateOrganization(ctx context.Context, name string, req *clients.UpdateOrganizationRequest) (*clients.Organization, error) {
+	m.orgUpdate = req
+	return m.org, nil
+}
+
+func (m *mockOrganizationSettingsClient) ListOrganizationTeams(ctx context.Context, org string) ([]*clients.Team, error) {
+	return []*clients.Team{m.team}, nil
+}
+
+func (m *mockOrganizationSettingsClient) UpdateTeam(ctx context.Context, teamID int64, req *clients.UpdateTeamRequest) (*clients.Team, error) {
+	m.teamUpdate = req
+	return m.team, nil
+}
+
+func (m *mockOrganizationSettingsClient) UpdateUser(ctx context.Context, username string, req *clients.UpdateUserRequest) (*clients.User, error) {
+	m.userUpdate = req
+	return &clients.User{}, nil
+}
+
+func newMock() *mockOrganizationSettingsClient {
+	return &mockOrganizationSettingsClient{
+		org:  &clients.Organization{ID: 1, Username: "acme", Visibility: "public"},
+		team: &clients.Team{ID: 2, Name: "members", Permission: "read", IncludesAllRepositories: true},
+	}
+}
+
+func newSettings(p v2.OrganizationSettingsParameters) *v2.OrganizationSettings {
+	p.Organization = "acme"
+	return &v2.OrganizationSettings{
+		ObjectMeta: metav1.ObjectMeta{Name: "acme-settings"},
+		Spec:       v2.OrganizationSettingsSpec{ForProvider: p},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	t.Run("unsupported settings are rejected", func(t *testing.T) {
+		fork := false
+		m := newMock()
+		e := &externalClient{client: m}
+		_, err := e.Observe(context.Background(), newSettings(v2.OrganizationSettingsParameters{MembersCanFork: &fork}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "membersCanFork")
+		assert.Zero(t, m.apiRequests)
+	})
+
+	t.Run("old defaults of unsupported settings are ignored", func(t *testing.T) {
+		yes, no, public, read := true, false, "public", "read"
+		cr := newSettings(v2.OrganizationSettingsParameters{
+			DefaultRepoPermission:    &read,
+			MembersCanCreateRepos:    &yes,
+			MembersCanCreatePrivate:  &yes,
+			MembersCanCreateInternal: &yes,
+			MembersCanDeleteRepos:    &no,
+			MembersCanFork:           &yes,
+			MembersCanCreatePages:    &yes,
+			DefaultRepoVisibility:    &public,
+			RequireSignedCommits:     &no,
+			EnableDependencyGraph:    &no,
+			AllowGitHooks:            &no,
+			AllowCustomGitHooks:      &no,
+		})
+		m := newMock()
+		e := &externalClient{client: m}
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+
+		_, err = e.Update(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Nil(t, m.teamUpdate)
+	})
+
+	t.Run("supported settings are compared", func(t *testing.T) {
+		private, team, write := "private", "Members", "write"
+		cr := newSettings(v2.OrganizationSettingsParameters{
+			Visibility:            &private,
+			DefaultTeam:           &team,
+			DefaultRepoPermission: &write,
+		})
+		e := &externalClient{client: newMock()}
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.False(t, obs.ResourceUpToDate)
+		assert.Equal(t, "visibility, defaultRepoPermission", obs.Diff)
+		assert.Equal(t, "read", *cr.Status.AtProvider.AppliedSettings.DefaultRepoPermission)
+		assert.Equal(t, xpv1.ReasonAvailable, cr.GetCondition(xpv1.TypeReady).Reason)
+	})
+
+	t.Run("repository limit is compared with the last applied value", func(t *testing.T) {
+		limit := int64(10)
+		cr := newSettings(v2.OrganizationSettingsParameters{MaxRepoCreation: &limit})
+		e := &externalClient{client: newMock()}
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, "maxRepoCreation", obs.Diff)
+
+		_, err = e.Update(context.Background(), cr)
+		require.NoError(t, err)
+		obs, err = e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	private, team, write, create := "private", "members", "write", true
+	limit := int64(-1)
+	cr := newSettings(v2.OrganizationSettingsParameters{
+		Visibility:            &private,
+		DefaultTeam:           &team,
+		DefaultRepoPermission: &write,
+		MembersCanCreateRepos: &create,
+		MaxRepoCreation:       &limit,
+	})
+
+	m := newMock()
+	e := &externalClient{client: m}
+	_, err := e.Update(context.Background(), cr)
+	require.NoError(t, err)
+
+	require.NotNil(t, m.orgUpdate)
+	assert.Equal(t, "private", *m.orgUpdate.Visibility)
+	require.NotNil(t, m.teamUpdate)
+	assert.Equal(t, "members", *m.teamUpdate.Name)
+	assert.Equal(t, "write", *m.teamUpdate.Permission)
+	assert.True(t, *m.teamUpdate.IncludesAllRepositories)
+	assert.True(t, *m.teamUpdate.CanCreateOrgRepo)
+	require.NotNil(t, m.userUpdate)
+	assert.Equal(t, int64(-1), *m.userUpdate.MaxRepoCreation)
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-gitea/apis/release/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errGetAssetSource  = "failed to get source of asset %s"
+	errMissingAssetKey = "source of asset %s has no key %q"
+	errDecodeAsset     = "failed to decode content of asset %s"
+	errFetchAsset      = "failed to download asset %s"
+	errUploadAsset     = "failed to upload asset %s"
+	errDeleteAsset     = "failed to delete asset %s"
+
+	defaultAssetContentType = "application/octet-stream"
+)
+
+// fetchClient downloads assets declared by URL.
+var fetchClient = &http.Client{Timeout: 10 * time.Minute}
+
+// desiredAsset is a declared asset with its content resolved. URL sources
+// have no content; they are streamed from the URL when uploaded.
+type desiredAsset struct {
+	v2.ReleaseAsset
+	content []byte
+	sha256  string
+}
+
+// resolveAssets reads the content of each declared asset from the spec or
+// from the referenced ConfigMap or Secret.
+func resolveAssets(ctx context.Context, kube client.Client, namespace string, assets []v2.ReleaseAsset) ([]desiredAsset, error) {
+	desired := make([]desiredAsset, 0, len(assets))
+	for _, a := range assets {
+		d := desiredAsset{ReleaseAsset: a}
+		if a.URL == nil {
+			content, err := assetContent(ctx, kube, namespace, a)
+			if err != nil {
+				return nil, err
+			}
+			sum := sha256.Sum256(content)
+			d.content = content
+			d.sha256 = hex.EncodeToString(sum[:])
+		}
+		desired = append(desired, d)
+	}
+	return desired, nil
+}
+
+func assetContent(ctx context.Context, kube client.Client, namespace string, a v2.ReleaseAsset) ([]byte, error) {
+	switch {
+	case a.Content != nil:
+		content, err := base64.StdEncoding.DecodeString(*a.Content)
+		return content, errors.Wrapf(err, errDecodeAsset, a.Name)
+	case a.ConfigMapKeyRef != nil:
+		cm := &corev1.ConfigMap{}
+		if err := kube.Get(ctx, client.ObjectKey{Namespace: namespace, Name: a.ConfigMapKeyRef.Name}, cm); err != nil {
+			return nil, errors.Wrapf(err, errGetAssetSource, a.Name)
+		}
+		if content, ok := cm.BinaryData[a.ConfigMapKeyRef.Key]; ok {
+			return content, nil
+		}
+		if content, ok := cm.Data[a.ConfigMapKeyRef.Key]; ok {
+			return []byte(content), nil
+		}
+		return nil, errors.Errorf(errMissingAssetKey, a.Name, a.ConfigMapKeyRef.Key)
+	case a.SecretKeyRef != nil:
+		secret := &corev1.Secret{}
+		if err := kube.Get(ctx, client.ObjectKey{Namespace: namespace, Name: a.SecretKeyRef.Name}, secret); err != nil {
+			return nil, errors.Wrapf(err, errGetAssetSource, a.Name)
+		}
+		if content, ok := secret.Data[a.SecretKeyRef.Key]; ok {
+			return content, nil
+		}
+		return nil, errors.Errorf(errMissingAssetKey, a.Name, a.SecretKeyRef.Key)
+	}
+	return nil, nil
+}
+
+// observeAssets converts the release's attachments, carrying over the
+// checksum and source URL recorded when the provider uploaded them.
+func observeAssets(attachments []clients.ReleaseAttachment, previous []v2.ReleaseAssetObservation) []v2.ReleaseAssetObservation {
+	observed := make([]v2.ReleaseAssetObservation, 0, len(attachments))
+	for _, a := range attachments {
+		o := assetObservation(&a)
+		for _, p := range previous {
+			if p.ID == a.ID {
+				o.SHA256 = p.SHA256
+				o.SourceURL = p.SourceURL
+				break
+			}
+		}
+		observed = append(observed, o)
+	}
+	return observed
+}
+
+func assetObservation(a *clients.ReleaseAttachment) v2.ReleaseAssetObservation {
+	return v2.ReleaseAssetObservation{
+		ID:                 a.ID,
+		Name:               a.Name,
+		Size:               a.Size,
+		DownloadCount:      a.DownloadCount,
+		ContentType:        a.ContentType,
+		BrowserDownloadURL: a.BrowserDownloadURL,
+		CreatedAt:          a.CreatedAt,
+		UpdatedAt:          a.UpdatedAt,
+	}
+}
+
+// assetChanges returns the declared assets to upload, because they are
+// missing or their content changed, and the attached assets to delete,
+// because they are being replaced or the provider uploaded them and they are
+// no longer declared. Assets attached by other means are left alone.
+func assetChanges(desired []desiredAsset, observed []v2.ReleaseAssetObservation) ([]desiredAsset, []v2.ReleaseAssetObservation) {
+	var upload []desiredAsset
+	var remove []v2.ReleaseAssetObservation
+
+	// Replacements are uploaded alongside the assets they replace, so an
+	// asset already uploaded but whose predecessor is yet to be deleted is
+	// not uploaded again.
+	for _, d := range desired {
+		current := false
+		for _, o := range observed {
+			if o.Name != d.Name {
+				continue
+			}
+			if assetChanged(d, o) {
+				remove = append(remove, o)
+			} else {
+				current = true
+			}
+		}
+		if !current {
+			upload = append(upload, d)
+		}
+	}
+
+	for _, o := range observed {
+		if o.SHA256 == "" {
+			continue
+		}
+		declared := false
+		for _, d := range desired {
+			if d.Name == o.Name {
+				declared = true
+				break
+			}
+		}
+		if !declared {
+			remove = append(remove, o)
+		}
+	}
+
+	return upload, remove
+}
+
+// assetChanged compares URL sources by URL, so they are not downloaded on
+// every poll, and other sources by checksum and size.
+func assetChanged(d desiredAsset, o v2.ReleaseAssetObservation) bool {
+	if d.URL != nil {
+		return o.SourceURL != *d.URL
+	}
+	return o.SHA256 != d.sha256 || o.Size != int64(len(d.content))
+}
+
+// syncAssets uploads new and changed assets, then deletes the assets they
+// replace and undeclared ones, so a failed upload leaves the previous asset
+// in place. It returns the release's assets afterwards, also when it fails
+// part way, so that the checksums of the assets it uploaded are recorded.
+func syncAssets(ctx context.Context, gitea clients.Client, owner, repo string, releaseID int64, desired []desiredAsset, observed []v2.ReleaseAssetObservation) ([]v2.ReleaseAssetObservation, error) {
+	upload, remove := assetChanges(desired, observed)
+
+	result := append(make([]v2.ReleaseAssetObservation, 0, len(observed)+len(upload)), observed...)
+	for _, d := range upload {
+		o, err := uploadAsset(ctx, gitea, owner, repo, releaseID, d)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, o)
+	}
+
+	for _, r := range remove {
+		if err := gitea.DeleteReleaseAttachment(ctx, owner, repo, releaseID, r.ID); err != nil && !clients.IsNotFound(err) {
+			return result, errors.Wrapf(err, errDeleteAsset, r.Name)
+		}
+		for i, o := range result {
+			if o.ID == r.ID {
+				result = append(result[:i], result[i+1:]...)
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// uploadAsset uploads one asset. URL sources are streamed from the URL to
+// Gitea and hashed on the way through.
+func uploadAsset(ctx context.Context, gitea clients.Client, owner, repo string, releaseID int64, d desiredAsset) (v2.ReleaseAssetObservation, error) {
+	contentType := defaultAssetContentType
+	if d.ContentType != nil {
+		contentType = *d.ContentType
+	}
+
+	var body io.Reader = bytes.NewReader(d.content)
+	hash := sha256.New()
+	if d.URL != nil {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, *d.URL, nil)
+		if err != nil {
+			return v2.ReleaseAssetObservation{}, errors.Wrapf(err, errFetchAsset, d.Name)
+		}
+		resp, err := fetchClient.Do(req)
+		if err != nil {
+			return v2.ReleaseAssetObservation{}, errors.Wrapf(err, errFetchAsset, d.Name)
+		}
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		if resp.StatusCode >= 400 {
+			return v2.ReleaseAssetObservation{}, errors.Errorf(errFetchAsset+": status %d", d.Name, resp.StatusCode)
+		}
+		body = io.TeeReader(resp.Body, hash)
+	}
+
+	attachment, err := gitea.CreateReleaseAttachment(ctx, owner, repo, releaseID, d.Name, contentType, body)
+	if err != nil {
+		return v2.ReleaseAssetObservation{}, errors.Wrapf(err, errUploadAsset, d.Name)
+	}
+
+	o := assetObservation(attachment)
+	o.SHA256 = d.sha256
+	if d.URL != nil {
+		o.SHA256 = hex.EncodeToString(hash.Sum(nil))
+		o.SourceURL = *d.URL
+	}
+	return o, nil
+}
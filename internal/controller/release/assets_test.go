@@ -0,0 +1,223 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rossigee/provider-gitea/apis/release/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type mockAssetClient struct {
+	testutil.NoopClient
+	nextID     int64
+	uploaded   map[string]string
+	deleted    []int64
+	failUpload bool
+}
+
+func (m *mockAssetClient) CreateReleaseAttachment(ctx context.Context, owner, repo string, releaseID int64, filename, contentType string, content io.Reader) (*clients.ReleaseAttachment, error) {
+	if m.failUpload {
+		return nil, fmt.Errorf("API request failed with status 500")
+	}
+	body, err := io.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+	if m.uploaded == nil {
+		m.uploaded = map[string]string{}
+	}
+	m.uploaded[filename] = string(body)
+	m.nextID++
+	return &clients.ReleaseAttachment{ID: 100 + m.nextID, Name: filename, Size: int64(len(body)), ContentType: contentType}, nil
+}
+
+func (m *mockAssetClient) DeleteReleaseAttachment(ctx context.Context, owner, repo string, releaseID, attachmentID int64) error {
+	m.deleted = append(m.deleted, attachmentID)
+	if attachmentID == 404 {
+		return fmt.Errorf("API request failed with status 404: not found")
+	}
+	return nil
+}
+
+func checksum(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestResolveAssets(t *testing.T) {
+	kube := fake.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dist"},
+			BinaryData: map[string][]byte{"app.tar.gz": []byte("tarball")},
+			Data:       map[string]string{"NOTES.txt": "notes"},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "signing"},
+			Data:       map[string][]byte{"app.sig": []byte("signature")},
+		},
+	).Build()
+	content := "aW5saW5l"
+	url := "https://example.com/app.zip"
+
+	desired, err := resolveAssets(context.Background(), kube, "default", []v2.ReleaseAsset{
+		{Name: "inline.txt", Content: &content},
+		{Name: "app.tar.gz", ConfigMapKeyRef: &v2.KeySelector{Name: "dist", Key: "app.tar.gz"}},
+		{Name: "NOTES.txt", ConfigMapKeyRef: &v2.KeySelector{Name: "dist", Key: "NOTES.txt"}},
+		{Name: "app.sig", SecretKeyRef: &v2.KeySelector{Name: "signing", Key: "app.sig"}},
+		{Name: "app.zip", URL: &url},
+	})
+	require.NoError(t, err)
+	require.Len(t, desired, 5)
+	assert.Equal(t, "inline", string(desired[0].content))
+	assert.Equal(t, checksum("inline"), desired[0].sha256)
+	assert.Equal(t, "tarball", string(desired[1].content))
+	assert.Equal(t, "notes", string(desired[2].content))
+	assert.Equal(t, "signature", string(desired[3].content))
+	assert.Nil(t, desired[4].content)
+
+	_, err = resolveAssets(context.Background(), kube, "default", []v2.ReleaseAsset{
+		{Name: "missing", ConfigMapKeyRef: &v2.KeySelector{Name: "dist", Key: "missing"}},
+	})
+	assert.Error(t, err)
+}
+
+func TestObserveAssets(t *testing.T) {
+	observed := observeAssets(
+		[]clients.ReleaseAttachment{{ID: 1, Name: "a", Size: 3}, {ID: 2, Name: "b"}},
+		[]v2.ReleaseAssetObservation{{ID: 1, Name: "a", SHA256: "abc", SourceURL: "https://example.com/a"}},
+	)
+	require.Len(t, observed, 2)
+	assert.Equal(t, "abc", observed[0].SHA256)
+	assert.Equal(t, "https://example.com/a", observed[0].SourceURL)
+	assert.Empty(t, observed[1].SHA256)
+}
+
+func TestAssetChanges(t *testing.T) {
+	url := "https://example.com/new.zip"
+	desired := []desiredAsset{
+		{ReleaseAsset: v2.ReleaseAsset{Name: "same"}, content: []byte("same"), sha256: checksum("same")},
+		{ReleaseAsset: v2.ReleaseAsset{Name: "changed"}, content: []byte("new"), sha256: checksum("new")},
+		{ReleaseAsset: v2.ReleaseAsset{Name: "new"}, content: []byte("new"), sha256: checksum("new")},
+		{ReleaseAsset: v2.ReleaseAsset{Name: "app.zip", URL: &url}},
+	}
+	observed := []v2.ReleaseAssetObservation{
+		{ID: 1, Name: "same", Size: 4, SHA256: checksum("same")},
+		{ID: 2, Name: "changed", Size: 3, SHA256: checksum("old")},
+		{ID: 3, Name: "app.zip", SourceURL: "https://example.com/old.zip"},
+		{ID: 4, Name: "removed", SHA256: checksum("removed")},
+		{ID: 5, Name: "unmanaged"},
+	}
+
+	upload, remove := assetChanges(desired, observed)
+	var uploadNames []string
+	for _, u := range upload {
+		uploadNames = append(uploadNames, u.Name)
+	}
+	var removeIDs []int64
+	for _, r := range remove {
+		removeIDs = append(removeIDs, r.ID)
+	}
+	assert.Equal(t, []string{"changed", "new", "app.zip"}, uploadNames)
+	assert.Equal(t, []int64{2, 3, 4}, removeIDs)
+
+	observed[2].SourceURL = url
+	upload, _ = assetChanges(desired[3:], observed[2:3])
+	assert.Empty(t, upload, "URL sources are not re-downloaded while the URL is unchanged")
+
+	upload, remove = assetChanges(desired[1:2], []v2.ReleaseAssetObservation{
+		{ID: 2, Name: "changed", Size: 3, SHA256: checksum("old")},
+		{ID: 6, Name: "changed", Size: 3, SHA256: checksum("new")},
+	})
+	assert.Empty(t, upload, "an uploaded replacement is not uploaded again")
+	require.Len(t, remove, 1)
+	assert.Equal(t, int64(2), remove[0].ID)
+}
+
+func TestSyncAssets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/app.zip":
+			_, _ = w.Write([]byte("zip"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	url := server.URL + "/app.zip"
+	gitea := &mockAssetClient{}
+	desired := []desiredAsset{
+		{ReleaseAsset: v2.ReleaseAsset{Name: "same"}, content: []byte("same"), sha256: checksum("same")},
+		{ReleaseAsset: v2.ReleaseAsset{Name: "changed"}, content: []byte("new"), sha256: checksum("new")},
+		{ReleaseAsset: v2.ReleaseAsset{Name: "app.zip", URL: &url}},
+	}
+	observed := []v2.ReleaseAssetObservation{
+		{ID: 1, Name: "same", Size: 4, SHA256: checksum("same")},
+		{ID: 2, Name: "changed", Size: 3, SHA256: checksum("old")},
+		{ID: 404, Name: "gone", SHA256: checksum("gone")},
+	}
+
+	result, err := syncAssets(context.Background(), gitea, "acme", "app", 7, desired, observed)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 404}, gitea.deleted)
+	assert.Equal(t, map[string]string{"changed": "new", "app.zip": "zip"}, gitea.uploaded)
+
+	require.Len(t, result, 3)
+	assert.Equal(t, int64(1), result[0].ID)
+	assert.Equal(t, "changed", result[1].Name)
+	assert.Equal(t, checksum("new"), result[1].SHA256)
+	assert.Equal(t, defaultAssetContentType, result[1].ContentType)
+	assert.Equal(t, "app.zip", result[2].Name)
+	assert.Equal(t, checksum("zip"), result[2].SHA256)
+	assert.Equal(t, url, result[2].SourceURL)
+
+	missing := server.URL + "/missing.zip"
+	_, err = syncAssets(context.Background(), gitea, "acme", "app", 7, []desiredAsset{
+		{ReleaseAsset: v2.ReleaseAsset{Name: "missing.zip", URL: &missing}},
+	}, nil)
+	assert.Error(t, err)
+}
+
+func TestSyncAssetsKeepsReplacedAsset(t *testing.T) {
+	gitea := &mockAssetClient{failUpload: true}
+	desired := []desiredAsset{
+		{ReleaseAsset: v2.ReleaseAsset{Name: "changed"}, content: []byte("new"), sha256: checksum("new")},
+	}
+	observed := []v2.ReleaseAssetObservation{
+		{ID: 2, Name: "changed", Size: 3, SHA256: checksum("old")},
+	}
+
+	result, err := syncAssets(context.Background(), gitea, "acme", "app", 7, desired, observed)
+	require.Error(t, err)
+	assert.Empty(t, gitea.deleted, "the asset is only deleted once its replacement is uploaded")
+	assert.Equal(t, observed, result)
+}
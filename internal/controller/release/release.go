@@ -0,0 +1,400 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-gitea/apis/release/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/tracing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/rossigee/provider-gitea/apis/v1beta1"
+)
+
+const (
+	errNotRelease        = "managed resource is not a Release custom resource"
+	errGetRelease        = "failed to get release"
+	errCreateRelease     = "failed to create release"
+	errUpdateRelease     = "failed to update release"
+	errDeleteRelease     = "failed to delete release"
+	errGetTag            = "failed to get release tag"
+	errCreateTag         = "failed to create release tag"
+	errDeleteTag         = "failed to delete release tag"
+	errGenerateNotes     = "failed to generate release notes"
+	errGetProviderConfig = "failed to get provider config"
+
+	deletionModeReleaseAndTag = "ReleaseAndTag"
+)
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient by:
+// 1. Getting the provider config
+// 2. Creating a Gitea API client
+// 3. Returning an external client wrapping the API client
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v2.Release)
+	if !ok {
+		return nil, errors.New(errNotRelease)
+	}
+
+	pcRef := cr.Spec.ProviderConfigReference
+	if pcRef == nil {
+		return nil, errors.New("providerConfigRef is required")
+	}
+
+	var pc v1beta1.ProviderConfig
+	if err := c.kube.Get(ctx, client.ObjectKey{
+		Namespace: cr.GetNamespace(),
+		Name:      pcRef.Name,
+	}, &pc); err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
+
+	conn, err := clients.NewClient(ctx, &pc, c.kube)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalClient{client: conn, kube: c.kube}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it matches the managed resource's desired state.
+type externalClient struct {
+	client clients.Client
+	kube   client.Client
+}
+
+// Observe looks the release up by the ID in its external name. A
+// non-numeric external name adopts the release for spec.forProvider.tagName.
+func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	_, span := tracing.StartSpan(ctx, "release.observe",
+		tracing.SpanAttrs("release", tracing.ResourceName(mg), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.Release)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRelease)
+	}
+	p := cr.Spec.ForProvider
+
+	var rel *clients.Release
+	adopted := false
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		rel, err = e.client.GetReleaseByTag(ctx, p.Owner, p.Repository, p.TagName)
+		adopted = true
+	} else {
+		rel, err = e.client.GetRelease(ctx, p.Owner, p.Repository, id)
+	}
+	if err != nil {
+		if clients.IsNotFound(err) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetRelease)
+	}
+	if adopted {
+		meta.SetExternalName(cr, strconv.FormatInt(rel.ID, 10))
+	}
+
+	desired, err := resolveAssets(ctx, e.kube, cr.GetNamespace(), p.Assets)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	cr.Status.AtProvider = observation(rel, observeAssets(rel.Assets, cr.Status.AtProvider.Assets))
+
+	drift := releaseDrift(p, rel)
+	if upload, remove := assetChanges(desired, cr.Status.AtProvider.Assets); len(upload) > 0 || len(remove) > 0 {
+		drift = append(drift, "assets")
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        len(drift) == 0,
+		ResourceLateInitialized: adopted,
+		Diff:                    strings.Join(drift, ", "),
+	}, nil
+}
+
+// Create creates the tag from targetCommitish if it does not exist yet,
+// then the release.
+func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	_, span := tracing.StartSpan(ctx, "release.create",
+		tracing.SpanAttrs("release", tracing.ResourceName(mg), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.Release)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRelease)
+	}
+	p := cr.Spec.ForProvider
+
+	if _, err := e.client.GetRepositoryTag(ctx, p.Owner, p.Repository, p.TagName); err != nil {
+		if !clients.IsNotFound(err) {
+			return managed.ExternalCreation{}, errors.Wrap(err, errGetTag)
+		}
+		req := &clients.CreateTagRequest{TagName: p.TagName}
+		if p.TargetCommitish != nil {
+			req.Target = *p.TargetCommitish
+		}
+		if _, err := e.client.CreateRepositoryTag(ctx, p.Owner, p.Repository, req); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errCreateTag)
+		}
+	}
+
+	req := &clients.CreateReleaseOptions{
+		TagName:    p.TagName,
+		Name:       stringValue(p.Name),
+		Body:       stringValue(p.Body),
+		Draft:      boolValue(p.Draft),
+		Prerelease: boolValue(p.Prerelease),
+	}
+	if p.TargetCommitish != nil {
+		req.TargetCommitish = *p.TargetCommitish
+	}
+	if boolValue(p.GenerateNotes) {
+		notes, err := e.releaseNotes(ctx, p.Owner, p.Repository, p.TagName)
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errGenerateNotes)
+		}
+		req.Body = strings.TrimSpace(req.Body + "\n\n" + notes)
+	}
+
+	rel, err := e.client.CreateRelease(ctx, p.Owner, p.Repository, req)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateRelease)
+	}
+	meta.SetExternalName(cr, strconv.FormatInt(rel.ID, 10))
+	cr.Status.AtProvider = observation(rel, nil)
+
+	// Assets are uploaded by the update that follows the next observation.
+	// Their checksums are recorded in the status, which does not survive the
+	// external name update that follows creation.
+	return managed.ExternalCreation{}, nil
+}
+
+// Update applies the name, notes and draft and prerelease flags, which
+// promotes a draft or prerelease once the flags are cleared, and syncs the
+// assets.
+func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	_, span := tracing.StartSpan(ctx, "release.update",
+		tracing.SpanAttrs("release", tracing.ResourceName(mg), "update")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.Release)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRelease)
+	}
+	p := cr.Spec.ForProvider
+
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateRelease)
+	}
+
+	desired, err := resolveAssets(ctx, e.kube, cr.GetNamespace(), p.Assets)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	req := &clients.UpdateReleaseOptions{
+		Name:       p.Name,
+		Draft:      p.Draft,
+		Prerelease: p.Prerelease,
+	}
+	if !boolValue(p.GenerateNotes) {
+		req.Body = p.Body
+	}
+
+	rel, err := e.client.UpdateRelease(ctx, p.Owner, p.Repository, id, req)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateRelease)
+	}
+
+	// The status is updated even if syncing fails, so that assets uploaded
+	// before the failure are recognised on the next reconcile.
+	assets, err := syncAssets(ctx, e.client, p.Owner, p.Repository, rel.ID, desired, observeAssets(rel.Assets, cr.Status.AtProvider.Assets))
+	cr.Status.AtProvider = observation(rel, assets)
+
+	return managed.ExternalUpdate{}, err
+}
+
+// Delete deletes the release, and its tag when deletionMode is
+// ReleaseAndTag. Gitea refuses to delete a tag that still has a release, so
+// the release goes first.
+func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	_, span := tracing.StartSpan(ctx, "release.delete",
+		tracing.SpanAttrs("release", tracing.ResourceName(mg), "delete")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.Release)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotRelease)
+	}
+	p := cr.Spec.ForProvider
+
+	if id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64); err == nil {
+		err = e.client.DeleteRelease(ctx, p.Owner, p.Repository, id)
+		if err != nil && !clients.IsNotFound(err) {
+			return managed.ExternalDelete{}, errors.Wrap(err, errDeleteRelease)
+		}
+	}
+
+	if p.DeletionMode != nil && *p.DeletionMode == deletionModeReleaseAndTag {
+		err := e.client.DeleteRepositoryTag(ctx, p.Owner, p.Repository, p.TagName)
+		if err != nil && !clients.IsNotFound(err) {
+			return managed.ExternalDelete{}, errors.Wrap(err, errDeleteTag)
+		}
+	}
+
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *externalClient) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// releaseNotes lists the commits between the newest published release with
+// another tag and this release's tag.
+func (e *externalClient) releaseNotes(ctx context.Context, owner, repo, tag string) (string, error) {
+	releases, err := e.client.ListReleases(ctx, owner, repo)
+	if err != nil {
+		return "", err
+	}
+
+	previous := ""
+	for _, r := range releases {
+		if !r.Draft && r.TagName != tag {
+			previous = r.TagName
+			break
+		}
+	}
+	if previous == "" {
+		return "Initial release.", nil
+	}
+
+	cmp, err := e.client.CompareCommits(ctx, owner, repo, previous, tag)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("## What's Changed\n\n")
+	for _, c := range cmp.Commits {
+		subject := ""
+		if c.Commit != nil {
+			subject, _, _ = strings.Cut(c.Commit.Message, "\n")
+		}
+		sha := c.SHA
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		fmt.Fprintf(&b, "* %s (%s)\n", subject, sha)
+	}
+	fmt.Fprintf(&b, "\n**Full Changelog**: %s...%s", previous, tag)
+
+	return b.String(), nil
+}
+
+// releaseDrift returns the fields that differ from the release. Generated
+// notes are only written on creation, so the body is not compared for them.
+func releaseDrift(p v2.ReleaseParameters, rel *clients.Release) []string {
+	var drift []string
+	if p.Name != nil && *p.Name != rel.Name {
+		drift = append(drift, "name")
+	}
+	if p.Body != nil && !boolValue(p.GenerateNotes) && *p.Body != rel.Body {
+		drift = append(drift, "body")
+	}
+	if p.Draft != nil && *p.Draft != rel.Draft {
+		drift = append(drift, "draft")
+	}
+	if p.Prerelease != nil && *p.Prerelease != rel.Prerelease {
+		drift = append(drift, "prerelease")
+	}
+	return drift
+}
+
+func observation(rel *clients.Release, assets []v2.ReleaseAssetObservation) v2.ReleaseObservation {
+	o := v2.ReleaseObservation{
+		ID:              rel.ID,
+		TagName:         rel.TagName,
+		TargetCommitish: rel.TargetCommitish,
+		Name:            rel.Name,
+		Body:            rel.Body,
+		URL:             rel.URL,
+		HTMLURL:         rel.HTMLURL,
+		TarballURL:      rel.TarballURL,
+		ZipballURL:      rel.ZipballURL,
+		UploadURL:       rel.UploadURL,
+		Draft:           rel.Draft,
+		Prerelease:      rel.Prerelease,
+		CreatedAt:       rel.CreatedAt,
+		PublishedAt:     rel.PublishedAt,
+		Assets:          assets,
+	}
+	if rel.Author != nil {
+		o.Author = rel.Author.Username
+	}
+	return o
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
+
+// Setup adds a controller that reconciles Release managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v2.ReleaseKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v2.ReleaseGroupVersionKind),
+		managed.WithExternalConnector(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v2.Release{}).
+		Complete(r)
+}
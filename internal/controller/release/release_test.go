@@ -0,0 +1,293 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-gitea/apis/release/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var errNotFound = fmt.Errorf("API request failed with status 404: not found")
+
+type mockReleaseClient struct {
+	testutil.NoopClient
+	releases    map[int64]*clients.Release
+	tags        map[string]bool
+	createdTag  *clients.CreateTagRequest
+	created     *clients.CreateReleaseOptions
+	updated     *clients.UpdateReleaseOptions
+	deletedRel  []int64
+	deletedTags []string
+	list        []*clients.Release
+	compare     *clients.Compare
+	uploaded    []string
+}
+
+func (m *mockReleaseClient) GetRelease(ctx context.Context, owner, repo string, id int64) (*clients.Release, error) {
+	if r, ok := m.releases[id]; ok {
+		return r, nil
+	}
+	return nil, errNotFound
+}
+
+func (m *mockReleaseClient) GetReleaseByTag(ctx context.Context, owner, repo, tag string) (*clients.Release, error) {
+	for _, r := range m.releases {
+		if r.TagName == tag {
+			return r, nil
+		}
+	}
+	return nil, errNotFound
+}
+
+func (m *mockReleaseClient) ListReleases(ctx context.Context, owner, repo string) ([]*clients.Release, error) {
+	return m.list, nil
+}
+
+func (m *mockReleaseClient) CompareCommits(ctx context.Context, owner, repo, base, head string) (*clients.Compare, error) {
+	return m.compare, nil
+}
+
+func (m *mockReleaseClient) GetRepositoryTag(ctx context.Context, owner, repo, tag string) (*clients.RepositoryTag, error) {
+	if m.tags[tag] {
+		return &clients.RepositoryTag{Name: tag}, nil
+	}
+	return nil, errNotFound
+}
+
+func (m *mockReleaseClient) CreateRepositoryTag(ctx context.Context, owner, repo string, req *clients.CreateTagRequest) (*clients.RepositoryTag, error) {
+	m.createdTag = req
+	return &clients.RepositoryTag{Name: req.TagName}, nil
+}
+
+func (m *mockReleaseClient) DeleteRepositoryTag(ctx context.Context, owner, repo, tag string) error {
+	m.deletedTags = append(m.deletedTags, tag)
+	return nil
+}
+
+func (m *mockReleaseClient) CreateRelease(ctx context.Context, owner, repo string, req *clients.CreateReleaseOptions) (*clients.Release, error) {
+	m.created = req
+	return &clients.Release{ID: 9, TagName: req.TagName, Body: req.Body, Draft: req.Draft}, nil
+}
+
+func (m *mockReleaseClient) UpdateRelease(ctx context.Context, owner, repo string, id int64, req *clients.UpdateReleaseOptions) (*clients.Release, error) {
+	m.updated = req
+	r := *m.releases[id]
+	if req.Draft != nil {
+		r.Draft = *req.Draft
+	}
+	if req.Prerelease != nil {
+		r.Prerelease = *req.Prerelease
+	}
+	return &r, nil
+}
+
+func (m *mockReleaseClient) CreateReleaseAttachment(ctx context.Context, owner, repo string, releaseID int64, filename, contentType string, content io.Reader) (*clients.ReleaseAttachment, error) {
+	m.uploaded = append(m.uploaded, filename)
+	return &clients.ReleaseAttachment{ID: int64(len(m.uploaded)), Name: filename}, nil
+}
+
+func (m *mockReleaseClient) DeleteRelease(ctx context.Context, owner, repo string, id int64) error {
+	m.deletedRel = append(m.deletedRel, id)
+	if _, ok := m.releases[id]; !ok {
+		return errNotFound
+	}
+	return nil
+}
+
+func newRelease(externalName string) *v2.Release {
+	draft, prerelease := false, false
+	cr := &v2.Release{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app-v1"},
+		Spec: v2.ReleaseSpec{
+			ForProvider: v2.ReleaseParameters{
+				Owner:      "acme",
+				Repository: "app",
+				TagName:    "v1.0.0",
+				Draft:      &draft,
+				Prerelease: &prerelease,
+			},
+		},
+	}
+	meta.SetExternalName(cr, externalName)
+	return cr
+}
+
+func newExternal(m *mockReleaseClient) *externalClient {
+	return &externalClient{client: m, kube: fake.NewClientBuilder().Build()}
+}
+
+func TestObserve(t *testing.T) {
+	t.Run("adopts the release for the tag", func(t *testing.T) {
+		e := newExternal(&mockReleaseClient{releases: map[int64]*clients.Release{7: {ID: 7, TagName: "v1.0.0"}}})
+		cr := newRelease("app-v1")
+
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.True(t, obs.ResourceLateInitialized)
+		assert.Equal(t, "7", meta.GetExternalName(cr))
+		assert.Equal(t, xpv1.ReasonAvailable, cr.GetCondition(xpv1.TypeReady).Reason)
+	})
+
+	t.Run("missing release does not exist", func(t *testing.T) {
+		e := newExternal(&mockReleaseClient{})
+
+		obs, err := e.Observe(context.Background(), newRelease("7"))
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+
+	t.Run("prerelease awaiting promotion is not up to date", func(t *testing.T) {
+		e := newExternal(&mockReleaseClient{releases: map[int64]*clients.Release{7: {ID: 7, TagName: "v1.0.0", Prerelease: true}}})
+
+		obs, err := e.Observe(context.Background(), newRelease("7"))
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate)
+		assert.Equal(t, "prerelease", obs.Diff)
+	})
+
+	t.Run("generated notes are not compared", func(t *testing.T) {
+		e := newExternal(&mockReleaseClient{releases: map[int64]*clients.Release{7: {ID: 7, TagName: "v1.0.0", Body: "Highlights\n\n## What's Changed"}}})
+		cr := newRelease("7")
+		body, generate := "Highlights", true
+		cr.Spec.ForProvider.Body = &body
+		cr.Spec.ForProvider.GenerateNotes = &generate
+
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("creates the missing tag and generates notes", func(t *testing.T) {
+		m := &mockReleaseClient{
+			list: []*clients.Release{{TagName: "v1.0.0-rc.1", Draft: true}, {TagName: "v0.9.0"}},
+			compare: &clients.Compare{Commits: []*clients.Commit{
+				{SHA: "abcdef1234567", Commit: &clients.CommitDetails{Message: "Add login\n\nDetails"}},
+			}},
+		}
+		e := newExternal(m)
+		cr := newRelease("app-v1")
+		target, body, generate := "main", "Highlights", true
+		cr.Spec.ForProvider.TargetCommitish = &target
+		cr.Spec.ForProvider.Body = &body
+		cr.Spec.ForProvider.GenerateNotes = &generate
+
+		_, err := e.Create(context.Background(), cr)
+		require.NoError(t, err)
+		require.NotNil(t, m.createdTag)
+		assert.Equal(t, "v1.0.0", m.createdTag.TagName)
+		assert.Equal(t, "main", m.createdTag.Target)
+		assert.Equal(t, "Highlights\n\n## What's Changed\n\n* Add login (abcdef1)\n\n**Full Changelog**: v0.9.0...v1.0.0", m.created.Body)
+		assert.Equal(t, "9", meta.GetExternalName(cr))
+		assert.Equal(t, int64(9), cr.Status.AtProvider.ID)
+	})
+
+	t.Run("uses an existing tag", func(t *testing.T) {
+		m := &mockReleaseClient{tags: map[string]bool{"v1.0.0": true}}
+		e := newExternal(m)
+
+		_, err := e.Create(context.Background(), newRelease("app-v1"))
+		require.NoError(t, err)
+		assert.Nil(t, m.createdTag)
+		assert.Equal(t, "", m.created.Body)
+	})
+
+	t.Run("first release notes", func(t *testing.T) {
+		m := &mockReleaseClient{tags: map[string]bool{"v1.0.0": true}}
+		e := newExternal(m)
+		cr := newRelease("app-v1")
+		generate := true
+		cr.Spec.ForProvider.GenerateNotes = &generate
+
+		_, err := e.Create(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, "Initial release.", m.created.Body)
+	})
+
+	t.Run("leaves assets to the update", func(t *testing.T) {
+		m := &mockReleaseClient{tags: map[string]bool{"v1.0.0": true}}
+		e := newExternal(m)
+		cr := newRelease("app-v1")
+		content := "aGVsbG8="
+		cr.Spec.ForProvider.Assets = []v2.ReleaseAsset{{Name: "hello.txt", Content: &content}}
+
+		_, err := e.Create(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Empty(t, m.uploaded)
+		assert.Empty(t, cr.Status.AtProvider.Assets)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	m := &mockReleaseClient{releases: map[int64]*clients.Release{7: {ID: 7, TagName: "v1.0.0", Draft: true, Prerelease: true}}}
+	e := newExternal(m)
+	cr := newRelease("7")
+	content := "aGVsbG8="
+	cr.Spec.ForProvider.Assets = []v2.ReleaseAsset{{Name: "hello.txt", Content: &content}}
+
+	_, err := e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hello.txt"}, m.uploaded)
+	require.Len(t, cr.Status.AtProvider.Assets, 1)
+	assert.NotEmpty(t, cr.Status.AtProvider.Assets[0].SHA256)
+	require.NotNil(t, m.updated.Draft)
+	assert.False(t, *m.updated.Draft)
+	require.NotNil(t, m.updated.Prerelease)
+	assert.False(t, *m.updated.Prerelease)
+	assert.False(t, cr.Status.AtProvider.Draft)
+	assert.False(t, cr.Status.AtProvider.Prerelease)
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("deletes only the release by default", func(t *testing.T) {
+		m := &mockReleaseClient{releases: map[int64]*clients.Release{7: {ID: 7}}}
+		e := newExternal(m)
+
+		_, err := e.Delete(context.Background(), newRelease("7"))
+		require.NoError(t, err)
+		assert.Equal(t, []int64{7}, m.deletedRel)
+		assert.Empty(t, m.deletedTags)
+	})
+
+	t.Run("deletes the release and its tag", func(t *testing.T) {
+		m := &mockReleaseClient{}
+		e := newExternal(m)
+		cr := newRelease("7")
+		mode := deletionModeReleaseAndTag
+		cr.Spec.ForProvider.DeletionMode = &mode
+
+		_, err := e.Delete(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{7}, m.deletedRel)
+		assert.Equal(t, []string{"v1.0.0"}, m.deletedTags)
+	})
+}
@@ -0,0 +1,276 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	deploykeyv2 "github.com/rossigee/provider-gitea/apis/deploykey/v2"
+	labelv2 "github.com/rossigee/provider-gitea/apis/label/v2"
+	labelsetv2 "github.com/rossigee/provider-gitea/apis/labelset/v2"
+	"github.com/rossigee/provider-gitea/apis/repository/v2"
+	collaboratorv2 "github.com/rossigee/provider-gitea/apis/repositorycollaborator/v2"
+	repositorykeyv2 "github.com/rossigee/provider-gitea/apis/repositorykey/v2"
+	webhookv2 "github.com/rossigee/provider-gitea/apis/webhook/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/labels"
+)
+
+const (
+	modeEnforce = "Enforce"
+
+	errListUnmanaged = "failed to list repository %s"
+	errListManaging  = "failed to list %s resources"
+	errPrune         = "failed to remove unmanaged %s %q"
+)
+
+// unmanaged holds the entries of a repository that no resource in the
+// namespace of the Repository manages.
+type unmanaged struct {
+	labels        []*clients.Label
+	collaborators []*clients.RepositoryCollaborator
+	webhooks      []*clients.Webhook
+	deployKeys    []*clients.DeployKey
+}
+
+func (u *unmanaged) empty() bool {
+	return len(u.labels) == 0 && len(u.collaborators) == 0 && len(u.webhooks) == 0 && len(u.deployKeys) == 0
+}
+
+// observation returns the status listing of the entries, or nil when there
+// are none.
+func (u *unmanaged) observation() *v2.UnmanagedEntries {
+	if u.empty() {
+		return nil
+	}
+	o := &v2.UnmanagedEntries{}
+	for _, l := range u.labels {
+		o.Labels = append(o.Labels, l.Name)
+	}
+	for _, c := range u.collaborators {
+		o.Collaborators = append(o.Collaborators, c.Username)
+	}
+	for _, h := range u.webhooks {
+		o.Webhooks = append(o.Webhooks, fmt.Sprintf("%s (#%d)", hookURL(h), h.ID))
+	}
+	for _, k := range u.deployKeys {
+		o.DeployKeys = append(o.DeployKeys, fmt.Sprintf("%s (#%d)", k.Title, k.ID))
+	}
+	return o
+}
+
+// enforced reports whether unmanaged entries are removed rather than only
+// reported.
+func enforced(cr *v2.Repository) bool {
+	x := cr.Spec.ForProvider.Exclusive
+	return x != nil && x.Mode != nil && *x.Mode == modeEnforce
+}
+
+// findUnmanaged lists the entries selected by the exclusive settings that no
+// Label, LabelSet, RepositoryCollaborator, Webhook, DeployKey or
+// RepositoryKey in the namespace of the Repository accounts for. Resources
+// being deleted do not count, so orphaned entries are reported once their
+// resource is gone.
+func (e *externalClient) findUnmanaged(ctx context.Context, cr *v2.Repository, owner, name string) (*unmanaged, error) {
+	u := &unmanaged{}
+	x := cr.Spec.ForProvider.Exclusive
+	if x == nil {
+		return u, nil
+	}
+	ns := client.InNamespace(cr.GetNamespace())
+
+	if isTrue(x.Labels) {
+		have, err := e.client.ListRepositoryLabels(ctx, owner, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, errListUnmanaged, "labels")
+		}
+		list := &labelv2.LabelList{}
+		if err := e.kube.List(ctx, list, ns); err != nil {
+			return nil, errors.Wrapf(err, errListManaging, "Label")
+		}
+		managed := map[string]bool{}
+		for _, l := range list.Items {
+			if l.GetDeletionTimestamp() == nil && sameRepository(l.Spec.ForProvider.Repository, owner, name) {
+				managed[strings.ToLower(l.Spec.ForProvider.Name)] = true
+			}
+		}
+		sets := &labelsetv2.LabelSetList{}
+		if err := e.kube.List(ctx, sets, ns); err != nil {
+			return nil, errors.Wrapf(err, errListManaging, "LabelSet")
+		}
+		for _, ls := range sets.Items {
+			if ls.GetDeletionTimestamp() != nil || !labels.Targets(&ls, owner+"/"+name) {
+				continue
+			}
+			catalog, err := labels.Catalog(ctx, e.kube, &ls)
+			if err != nil {
+				return nil, err
+			}
+			for _, l := range catalog {
+				managed[strings.ToLower(l.Name)] = true
+			}
+		}
+		for _, l := range have {
+			if !managed[strings.ToLower(l.Name)] {
+				u.labels = append(u.labels, l)
+			}
+		}
+	}
+
+	if isTrue(x.Collaborators) {
+		collaborators, err := e.client.ListRepositoryCollaborators(ctx, owner, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, errListUnmanaged, "collaborators")
+		}
+		list := &collaboratorv2.RepositoryCollaboratorList{}
+		if err := e.kube.List(ctx, list, ns); err != nil {
+			return nil, errors.Wrapf(err, errListManaging, "RepositoryCollaborator")
+		}
+		managed := map[string]bool{}
+		for _, c := range list.Items {
+			if c.GetDeletionTimestamp() == nil && sameRepository(c.Spec.ForProvider.Repository, owner, name) {
+				managed[strings.ToLower(c.Spec.ForProvider.Username)] = true
+			}
+		}
+		for _, c := range collaborators {
+			if !managed[strings.ToLower(c.Username)] {
+				u.collaborators = append(u.collaborators, c)
+			}
+		}
+	}
+
+	if isTrue(x.Webhooks) {
+		hooks, err := e.client.ListRepositoryWebhooks(ctx, owner, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, errListUnmanaged, "webhooks")
+		}
+		list := &webhookv2.WebhookList{}
+		if err := e.kube.List(ctx, list, ns); err != nil {
+			return nil, errors.Wrapf(err, errListManaging, "Webhook")
+		}
+		ids, urls := map[int64]bool{}, map[string]bool{}
+		for _, w := range list.Items {
+			p := w.Spec.ForProvider
+			if w.GetDeletionTimestamp() != nil || p.Owner == nil || p.Repository == nil || !sameRepository(*p.Owner+"/"+*p.Repository, owner, name) {
+				continue
+			}
+			// The external name of a repository webhook is owner/repo/id.
+			ext := meta.GetExternalName(&w)
+			if id, err := strconv.ParseInt(ext[strings.LastIndex(ext, "/")+1:], 10, 64); err == nil {
+				ids[id] = true
+			}
+			urls[p.URL] = true
+		}
+		for _, h := range hooks {
+			if !ids[h.ID] && !urls[hookURL(h)] {
+				u.webhooks = append(u.webhooks, h)
+			}
+		}
+	}
+
+	if isTrue(x.DeployKeys) {
+		keys, err := e.client.ListDeployKeys(ctx, owner, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, errListUnmanaged, "deploy keys")
+		}
+		ids, titles := map[int64]bool{}, map[string]bool{}
+		add := func(ext, title string) {
+			if id, err := strconv.ParseInt(ext, 10, 64); err == nil {
+				ids[id] = true
+			}
+			titles[title] = true
+		}
+		deployKeys := &deploykeyv2.DeployKeyList{}
+		if err := e.kube.List(ctx, deployKeys, ns); err != nil {
+			return nil, errors.Wrapf(err, errListManaging, "DeployKey")
+		}
+		for _, k := range deployKeys.Items {
+			p := k.Spec.ForProvider
+			if k.GetDeletionTimestamp() == nil && sameRepository(p.Owner+"/"+p.Repository, owner, name) {
+				add(meta.GetExternalName(&k), p.Title)
+			}
+		}
+		repositoryKeys := &repositorykeyv2.RepositoryKeyList{}
+		if err := e.kube.List(ctx, repositoryKeys, ns); err != nil {
+			return nil, errors.Wrapf(err, errListManaging, "RepositoryKey")
+		}
+		for _, k := range repositoryKeys.Items {
+			p := k.Spec.ForProvider
+			if k.GetDeletionTimestamp() == nil && sameRepository(p.Repository, owner, name) {
+				add(meta.GetExternalName(&k), p.Title)
+			}
+		}
+		for _, k := range keys {
+			if !ids[k.ID] && !titles[k.Title] {
+				u.deployKeys = append(u.deployKeys, k)
+			}
+		}
+	}
+
+	return u, nil
+}
+
+// prune removes the unmanaged entries from the repository.
+func (e *externalClient) prune(ctx context.Context, owner, name string, u *unmanaged) error {
+	for _, l := range u.labels {
+		if err := e.client.DeleteLabel(ctx, owner, name, l.ID); err != nil {
+			return errors.Wrapf(err, errPrune, "label", l.Name)
+		}
+	}
+	for _, c := range u.collaborators {
+		if err := e.client.RemoveRepositoryCollaborator(ctx, owner, name, c.Username); err != nil {
+			return errors.Wrapf(err, errPrune, "collaborator", c.Username)
+		}
+	}
+	for _, h := range u.webhooks {
+		if err := e.client.DeleteRepositoryWebhook(ctx, owner, name, h.ID); err != nil {
+			return errors.Wrapf(err, errPrune, "webhook", hookURL(h))
+		}
+	}
+	for _, k := range u.deployKeys {
+		if err := e.client.DeleteDeployKey(ctx, owner, name, k.ID); err != nil {
+			return errors.Wrapf(err, errPrune, "deploy key", k.Title)
+		}
+	}
+	return nil
+}
+
+// sameRepository reports whether ref, in owner/name format, names the
+// repository.
+func sameRepository(ref, owner, name string) bool {
+	return strings.EqualFold(ref, owner+"/"+name)
+}
+
+// hookURL returns the payload URL of a webhook, which Gitea returns in its
+// config.
+func hookURL(h *clients.Webhook) string {
+	if u := h.Config["url"]; u != "" {
+		return u
+	}
+	return h.URL
+}
+
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
@@ -31,18 +32,44 @@ import (
 	"github.com/rossigee/provider-gitea/apis/repository/v2"
 
 	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/connection"
 	"github.com/rossigee/provider-gitea/internal/tracing"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
-	errNotRepository     = "managed resource is not a Repository custom resource"
-	errGetRepository     = "failed to get repository"
-	errCreateRepository  = "failed to create repository"
-	errUpdateRepository  = "failed to update repository"
-	errDeleteRepository  = "failed to delete repository"
-	errGetProviderConfig = "failed to get provider config"
+	errNotRepository      = "managed resource is not a Repository custom resource"
+	errGetRepository      = "failed to get repository"
+	errCreateRepository   = "failed to create repository"
+	errUpdateRepository   = "failed to update repository"
+	errDeleteRepository   = "failed to delete repository"
+	errGetProviderConfig  = "failed to get provider config"
+	errLabelSecret        = "failed to label connection secret"
+	errGetDefaultBranch   = "failed to get default branch"
+	errArchiveRepository  = "failed to archive repository"
+	errTransferRepository = "failed to transfer repository"
+	errProtected          = "repository %s is protected by the %s annotation and is not empty"
+	errDeletionDeferred   = "deletion of repository %s is deferred by deletionGracePeriod until %s"
+	errResolveTeams       = "failed to resolve transfer teams"
+	errTeamNotFound       = "team %q not found in organization %s"
+	errRecordLocation     = "failed to record the new repository location"
+
+	// AnnotationProtect set to "true" blocks deleting the repository while
+	// it has content. Archive and Transfer deletion modes are not blocked.
+	AnnotationProtect = "gitea.m.crossplane.io/protect"
+
+	deletionModeArchive  = "Archive"
+	deletionModeTransfer = "Transfer"
+
+	// TypeTransferPending is true while an ownership transfer awaits
+	// acceptance by the new owner.
+	TypeTransferPending xpv1.ConditionType = "TransferPending"
+
+	reasonAwaitingAcceptance xpv1.ConditionReason = "AwaitingAcceptance"
+	reasonTransferred        xpv1.ConditionReason = "Transferred"
 )
 
 // A connector is expected to produce an ExternalClient when its Connect method is called.
@@ -79,13 +106,14 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, err
 	}
 
-	return &externalClient{client: conn}, nil
+	return &externalClient{client: conn, kube: c.kube}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it matches the managed resource's desired state.
 type externalClient struct {
 	client clients.Client
+	kube   client.Client
 }
 
 func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -111,6 +139,16 @@ func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (mana
 	owner, name := parts[0], parts[1]
 
 	repo, err := e.client.GetRepository(ctx, owner, name)
+	if err != nil && strings.Contains(err.Error(), "404") {
+		// A transfer awaiting acceptance moves the repository once the new
+		// owner accepts it. Follow it there, but only if it is still the
+		// same repository.
+		if to, id := cr.Status.AtProvider.PendingTransferTo, cr.Status.AtProvider.ID; to != nil && id != nil {
+			if moved, terr := e.client.GetRepository(ctx, *to, name); terr == nil && moved.ID == *id {
+				repo, err = moved, nil
+			}
+		}
+	}
 	if err != nil {
 		if strings.Contains(err.Error(), "404") {
 			return managed.ExternalObservation{ResourceExists: false}, nil
@@ -118,37 +156,137 @@ func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (mana
 		return managed.ExternalObservation{}, errors.Wrap(err, errGetRepository)
 	}
 
-	// Update observed state
-	cr.Status.AtProvider = v2.RepositoryObservation{
-		ID:       &repo.ID,
-		FullName: &repo.FullName,
-		HTMLURL:  &repo.HTMLURL,
-		SSHURL:   &repo.SSHURL,
-		CloneURL: &repo.CloneURL,
-		Language: &repo.Language,
+	// Archiving and transferring keep the repository, which Gitea keeps
+	// serving under its old name, so it is released once it is retired.
+	if retired(cr, repo) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	moved := repo.FullName != "" && repo.FullName != externalID
+	if moved {
+		meta.SetExternalName(cr, repo.FullName)
 	}
 
 	// Update observed state
 	cr.Status.AtProvider = v2.RepositoryObservation{
-		ID:       &repo.ID,
-		FullName: &repo.FullName,
-		HTMLURL:  &repo.HTMLURL,
-		SSHURL:   &repo.SSHURL,
-		CloneURL: &repo.CloneURL,
-		Language: &repo.Language,
+		ID:            &repo.ID,
+		FullName:      &repo.FullName,
+		HTMLURL:       &repo.HTMLURL,
+		SSHURL:        &repo.SSHURL,
+		CloneURL:      &repo.CloneURL,
+		DefaultBranch: &repo.DefaultBranch,
+		Language:      &repo.Language,
+	}
+
+	uo := isRepositoryUpToDate(cr, repo) && !needsRename(cr, repo.Name) && !needsTransfer(cr, repo.Owner, repo.RepoTransfer)
+	observeTransfer(cr, repo.RepoTransfer)
+
+	if cr.Spec.ForProvider.Exclusive != nil {
+		if parts := strings.Split(meta.GetExternalName(cr), "/"); len(parts) == 2 {
+			owner, name = parts[0], parts[1]
+		}
+		u, err := e.findUnmanaged(ctx, cr, owner, name)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		cr.Status.AtProvider.Unmanaged = u.observation()
+		uo = uo && (u.empty() || !enforced(cr))
 	}
 
-	uo := isRepositoryUpToDate(cr, repo)
+	format := connection.FormatOrDefault(cr.Spec.ForProvider.ConnectionSecretFormat)
+	creds := connection.GitCredentials{URL: repo.CloneURL}
+	if err := connection.EnsureLabels(ctx, e.kube, cr, connection.Labels(format, creds)); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errLabelSecret)
+	}
 
 	// Only set Available() - Crossplane runtime handles Synced condition automatically
 	cr.SetConditions(xpv1.Available())
 
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: uo,
+		ResourceExists:          true,
+		ResourceUpToDate:        uo,
+		ResourceLateInitialized: moved,
+		ConnectionDetails:       connectionDetails(format, repo),
 	}, nil
 }
 
+// retired reports whether a repository being deleted with the Archive or
+// Transfer mode has reached its final state, so that the resource can be
+// released.
+func retired(cr *v2.Repository, repo *clients.Repository) bool {
+	if !meta.WasDeleted(cr) || cr.Spec.ForProvider.DeletionMode == nil {
+		return false
+	}
+	switch *cr.Spec.ForProvider.DeletionMode {
+	case deletionModeArchive:
+		return repo.Archived && strings.Contains(repo.Name, "-deleted-")
+	case deletionModeTransfer:
+		graveyard := cr.Spec.ForProvider.GraveyardOrganization
+		if graveyard == nil {
+			return false
+		}
+		if repo.Owner != nil && strings.EqualFold(repo.Owner.Username, *graveyard) {
+			return true
+		}
+		t := repo.RepoTransfer
+		return t != nil && t.Recipient != nil && strings.EqualFold(t.Recipient.Username, *graveyard)
+	}
+	return false
+}
+
+func needsRename(cr *v2.Repository, name string) bool {
+	return cr.Spec.ForProvider.Name != "" && cr.Spec.ForProvider.Name != name
+}
+
+// needsTransfer reports whether the repository belongs to another owner than
+// the spec's and no transfer to that owner is awaiting acceptance.
+func needsTransfer(cr *v2.Repository, owner *clients.User, pending *clients.RepoTransfer) bool {
+	want := cr.Spec.ForProvider.Owner
+	if want == nil || *want == "" || owner == nil || strings.EqualFold(*want, owner.Username) {
+		return false
+	}
+	return pending == nil || pending.Recipient == nil || !strings.EqualFold(*want, pending.Recipient.Username)
+}
+
+// observeTransfer records a transfer awaiting acceptance in the status and
+// the TransferPending condition, and clears them once it is resolved.
+func observeTransfer(cr *v2.Repository, pending *clients.RepoTransfer) {
+	if pending != nil && pending.Recipient != nil {
+		recipient := pending.Recipient.Username
+		cr.Status.AtProvider.PendingTransferTo = &recipient
+		cr.SetConditions(xpv1.Condition{
+			Type:               TypeTransferPending,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+			Reason:             reasonAwaitingAcceptance,
+			Message:            fmt.Sprintf("transfer to %s awaits acceptance by the new owner", recipient),
+		})
+		return
+	}
+	if cr.GetCondition(TypeTransferPending).Status == corev1.ConditionTrue {
+		cr.SetConditions(xpv1.Condition{
+			Type:               TypeTransferPending,
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Reason:             reasonTransferred,
+		})
+	}
+}
+
+// connectionDetails publishes the repository endpoints. A repository carries
+// no credentials of its own; pair it with a DeployKey or AccessToken for
+// authenticated access.
+func connectionDetails(format string, repo *clients.Repository) managed.ConnectionDetails {
+	if format != connection.FormatDefault {
+		return connection.Render(format, connection.GitCredentials{URL: repo.CloneURL})
+	}
+	return managed.ConnectionDetails{
+		"url":     []byte(repo.CloneURL),
+		"sshUrl":  []byte(repo.SSHURL),
+		"htmlUrl": []byte(repo.HTMLURL),
+	}
+}
+
 func isRepositoryUpToDate(cr *v2.Repository, repo *clients.Repository) bool {
 	if cr.Spec.ForProvider.Description != nil && *cr.Spec.ForProvider.Description != repo.Description {
 		return false
@@ -283,6 +421,16 @@ func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (manag
 
 	owner, name := parts[0], parts[1]
 
+	if enforced(cr) {
+		u, err := e.findUnmanaged(ctx, cr, owner, name)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		if err := e.prune(ctx, owner, name, u); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+
 	// Build update request from spec
 	updateReq := &clients.UpdateRepositoryRequest{}
 
@@ -299,14 +447,127 @@ func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (manag
 		updateReq.DefaultBranch = cr.Spec.ForProvider.DefaultBranch
 	}
 
-	_, err := e.client.UpdateRepository(ctx, owner, name, updateReq)
+	// Gitea rejects a default branch that does not exist. A branch created
+	// by a Branch resource may not exist yet, so the rest of the spec is
+	// applied and the switch is retried until the branch appears.
+	missing, err := e.defaultBranchMissing(ctx, cr, owner, name)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetDefaultBranch)
+	}
+	if missing {
+		updateReq.DefaultBranch = nil
+	}
+
+	if needsRename(cr, name) {
+		updateReq.Name = &cr.Spec.ForProvider.Name
+	}
+
+	_, err = e.client.UpdateRepository(ctx, owner, name, updateReq)
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateRepository)
 	}
+	if updateReq.Name != nil {
+		name = *updateReq.Name
+		if err := e.recordLocation(ctx, cr, owner, name); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+
+	current := &clients.User{Username: owner}
+	if needsTransfer(cr, current, pendingTransfer(cr)) {
+		if err := e.transfer(ctx, cr, owner, name); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+
+	if missing {
+		return managed.ExternalUpdate{}, errors.Errorf("default branch %q does not exist yet", *cr.Spec.ForProvider.DefaultBranch)
+	}
 
 	return managed.ExternalUpdate{}, nil
 }
 
+// pendingTransfer returns the transfer awaiting acceptance recorded by
+// Observe, if any.
+func pendingTransfer(cr *v2.Repository) *clients.RepoTransfer {
+	if to := cr.Status.AtProvider.PendingTransferTo; to != nil {
+		return &clients.RepoTransfer{Recipient: &clients.User{Username: *to}}
+	}
+	return nil
+}
+
+// transfer moves the repository to the spec's owner, giving the listed
+// teams of an organization owner access. Transfers to owners the
+// credentials cannot create repositories for wait for acceptance.
+func (e *externalClient) transfer(ctx context.Context, cr *v2.Repository, owner, name string) error {
+	newOwner := *cr.Spec.ForProvider.Owner
+	req := &clients.TransferRepositoryRequest{NewOwner: newOwner}
+
+	if len(cr.Spec.ForProvider.TransferTeams) > 0 {
+		teams, err := e.client.ListOrganizationTeams(ctx, newOwner)
+		if err != nil {
+			return errors.Wrap(err, errResolveTeams)
+		}
+		for _, want := range cr.Spec.ForProvider.TransferTeams {
+			found := false
+			for _, t := range teams {
+				if strings.EqualFold(t.Name, want) {
+					req.TeamIDs = append(req.TeamIDs, t.ID)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return errors.Errorf(errTeamNotFound, want, newOwner)
+			}
+		}
+	}
+
+	repo, err := e.client.TransferRepository(ctx, owner, name, req)
+	if err != nil {
+		return errors.Wrap(err, errTransferRepository)
+	}
+	if repo != nil && repo.RepoTransfer != nil {
+		observeTransfer(cr, repo.RepoTransfer)
+		return nil
+	}
+	return e.recordLocation(ctx, cr, newOwner, name)
+}
+
+// recordLocation persists the external name of a renamed or transferred
+// repository. The managed reconciler does not persist annotations set
+// during Update, and the old location no longer resolves.
+func (e *externalClient) recordLocation(ctx context.Context, cr *v2.Repository, owner, name string) error {
+	orig := cr.DeepCopy()
+	meta.SetExternalName(cr, owner+"/"+name)
+	return errors.Wrap(e.kube.Patch(ctx, cr, client.MergeFrom(orig)), errRecordLocation)
+}
+
+// defaultBranchMissing reports whether the repository is being switched to
+// a default branch that does not exist. Empty repositories have no branches
+// yet, and Gitea records their default branch for the first push.
+func (e *externalClient) defaultBranchMissing(ctx context.Context, cr *v2.Repository, owner, name string) (bool, error) {
+	want := cr.Spec.ForProvider.DefaultBranch
+	if want == nil {
+		return false, nil
+	}
+	if current := cr.Status.AtProvider.DefaultBranch; current != nil && *current == *want {
+		return false, nil
+	}
+	_, err := e.client.GetRepositoryBranch(ctx, owner, name, *want)
+	if err == nil {
+		return false, nil
+	}
+	if !strings.Contains(err.Error(), "404") {
+		return false, err
+	}
+	repo, err := e.client.GetRepository(ctx, owner, name)
+	if err != nil {
+		return false, err
+	}
+	return repo == nil || !repo.Empty, nil
+}
+
 func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
 	_, span := tracing.StartSpan(ctx, "repository.delete",
 		tracing.SpanAttrs("repository", tracing.ResourceName(mg), "delete")...)
@@ -326,12 +587,47 @@ func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (manag
 	owner, name := parts[0], parts[1]
 
 	// Gracefully handle already-deleted external resource.
-	if _, err := e.client.GetRepository(ctx, owner, name); err == nil {
-		if err := e.client.DeleteRepository(ctx, owner, name); err != nil {
-			return managed.ExternalDelete{}, errors.Wrap(err, errDeleteRepository)
+	repo, err := e.client.GetRepository(ctx, owner, name)
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return managed.ExternalDelete{}, nil
 		}
-		return managed.ExternalDelete{}, nil
-	} else if !strings.Contains(err.Error(), "404") {
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteRepository)
+	}
+
+	mode := ""
+	if cr.Spec.ForProvider.DeletionMode != nil {
+		mode = *cr.Spec.ForProvider.DeletionMode
+	}
+
+	if mode != deletionModeArchive && mode != deletionModeTransfer && cr.GetAnnotations()[AnnotationProtect] == "true" && repo != nil && !repo.Empty {
+		return managed.ExternalDelete{}, errors.Errorf(errProtected, externalID, AnnotationProtect)
+	}
+
+	// The grace period runs from the resource's deletion. Until it ends the
+	// repository is left alone, and the deletion fails with an error saying
+	// when it will go ahead, so it is retried with backoff rather than
+	// reported as requested.
+	if gp := cr.Spec.ForProvider.DeletionGracePeriod; gp != nil && cr.GetDeletionTimestamp() != nil {
+		at := metav1.NewTime(cr.GetDeletionTimestamp().Add(gp.Duration))
+		cr.Status.AtProvider.DeletionScheduledAt = &at
+		if time.Now().Before(at.Time) {
+			return managed.ExternalDelete{}, errors.Errorf(errDeletionDeferred, externalID, at.UTC().Format(time.RFC3339))
+		}
+	}
+
+	switch mode {
+	case deletionModeArchive:
+		archived := true
+		tombstone := fmt.Sprintf("%s-deleted-%s", name, time.Now().UTC().Format("20060102150405"))
+		_, err = e.client.UpdateRepository(ctx, owner, name, &clients.UpdateRepositoryRequest{Name: &tombstone, Archived: &archived})
+		return managed.ExternalDelete{}, errors.Wrap(err, errArchiveRepository)
+	case deletionModeTransfer:
+		_, err = e.client.TransferRepository(ctx, owner, name, &clients.TransferRepositoryRequest{NewOwner: *cr.Spec.ForProvider.GraveyardOrganization})
+		return managed.ExternalDelete{}, errors.Wrap(err, errTransferRepository)
+	}
+
+	if err := e.client.DeleteRepository(ctx, owner, name); err != nil {
 		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteRepository)
 	}
 	return managed.ExternalDelete{}, nil
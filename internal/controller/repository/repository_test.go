@@ -19,15 +19,23 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	labelv2 "github.com/rossigee/provider-gitea/apis/label/v2"
+	labelsetv2 "github.com/rossigee/provider-gitea/apis/labelset/v2"
 	"github.com/rossigee/provider-gitea/apis/repository/v2"
+	webhookv2 "github.com/rossigee/provider-gitea/apis/webhook/v2"
 	"github.com/rossigee/provider-gitea/internal/clients"
 	"github.com/rossigee/provider-gitea/internal/controller/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -38,6 +46,51 @@ type mockRepoClient struct {
 	createOrgFn  func(ctx context.Context, org string, req *clients.CreateRepositoryRequest) (*clients.Repository, error)
 	updateRepoFn func(ctx context.Context, owner, name string, req *clients.UpdateRepositoryRequest) (*clients.Repository, error)
 	deleteRepoFn func(ctx context.Context, owner, name string) error
+	getBranchFn  func(ctx context.Context, owner, repo, branch string) (*clients.RepositoryBranch, error)
+	transferFn   func(ctx context.Context, owner, name string, req *clients.TransferRepositoryRequest) (*clients.Repository, error)
+	listTeamsFn  func(ctx context.Context, org string) ([]*clients.Team, error)
+
+	labels        []*clients.Label
+	collaborators []*clients.RepositoryCollaborator
+	webhooks      []*clients.Webhook
+	deployKeys    []*clients.DeployKey
+	removed       []string
+}
+
+func (m *mockRepoClient) ListRepositoryLabels(ctx context.Context, owner, repo string) ([]*clients.Label, error) {
+	return m.labels, nil
+}
+
+func (m *mockRepoClient) ListRepositoryCollaborators(ctx context.Context, owner, repo string) ([]*clients.RepositoryCollaborator, error) {
+	return m.collaborators, nil
+}
+
+func (m *mockRepoClient) ListRepositoryWebhooks(ctx context.Context, owner, repo string) ([]*clients.Webhook, error) {
+	return m.webhooks, nil
+}
+
+func (m *mockRepoClient) ListDeployKeys(ctx context.Context, owner, repo string) ([]*clients.DeployKey, error) {
+	return m.deployKeys, nil
+}
+
+func (m *mockRepoClient) DeleteLabel(ctx context.Context, owner, repo string, id int64) error {
+	m.removed = append(m.removed, fmt.Sprintf("label:%d", id))
+	return nil
+}
+
+func (m *mockRepoClient) RemoveRepositoryCollaborator(ctx context.Context, owner, repo, username string) error {
+	m.removed = append(m.removed, "collaborator:"+username)
+	return nil
+}
+
+func (m *mockRepoClient) DeleteRepositoryWebhook(ctx context.Context, owner, repo string, id int64) error {
+	m.removed = append(m.removed, fmt.Sprintf("webhook:%d", id))
+	return nil
+}
+
+func (m *mockRepoClient) DeleteDeployKey(ctx context.Context, owner, repo string, id int64) error {
+	m.removed = append(m.removed, fmt.Sprintf("deploykey:%d", id))
+	return nil
 }
 
 func (m *mockRepoClient) GetRepository(ctx context.Context, owner, name string) (*clients.Repository, error) {
@@ -71,6 +124,27 @@ func (m *mockRepoClient) DeleteRepository(ctx context.Context, owner, name strin
 	return nil
 }
 
+func (m *mockRepoClient) TransferRepository(ctx context.Context, owner, name string, req *clients.TransferRepositoryRequest) (*clients.Repository, error) {
+	if m.transferFn != nil {
+		return m.transferFn(ctx, owner, name, req)
+	}
+	return nil, nil
+}
+
+func (m *mockRepoClient) ListOrganizationTeams(ctx context.Context, org string) ([]*clients.Team, error) {
+	if m.listTeamsFn != nil {
+		return m.listTeamsFn(ctx, org)
+	}
+	return nil, nil
+}
+
+func (m *mockRepoClient) GetRepositoryBranch(ctx context.Context, owner, repo, branch string) (*clients.RepositoryBranch, error) {
+	if m.getBranchFn != nil {
+		return m.getBranchFn(ctx, owner, repo, branch)
+	}
+	return &clients.RepositoryBranch{Name: branch}, nil
+}
+
 func TestObserve(t *testing.T) {
 	t.Run("resource not found returns not exists", func(t *testing.T) {
 		ec := &externalClient{
@@ -292,6 +366,111 @@ func TestUpdate(t *testing.T) {
 		_, err := ec.Update(context.Background(), cr)
 		require.Error(t, err)
 	})
+
+	t.Run("waits for missing default branch", func(t *testing.T) {
+		var got *clients.UpdateRepositoryRequest
+		ec := &externalClient{
+			client: &mockRepoClient{
+				getBranchFn: func(ctx context.Context, owner, repo, branch string) (*clients.RepositoryBranch, error) {
+					assert.Equal(t, "develop", branch)
+					return nil, fmt.Errorf("API request failed with status 404: not found")
+				},
+				updateRepoFn: func(ctx context.Context, owner, name string, req *clients.UpdateRepositoryRequest) (*clients.Repository, error) {
+					got = req
+					return &clients.Repository{}, nil
+				},
+			},
+		}
+
+		desc, branch := "Updated description", "develop"
+		cr := &v2.Repository{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-repo",
+			},
+			Spec: v2.RepositorySpec{
+				ForProvider: v2.RepositoryParameters{
+					Description:   &desc,
+					DefaultBranch: &branch,
+				},
+			},
+		}
+		meta.SetExternalName(cr, "owner/test-repo")
+
+		_, err := ec.Update(context.Background(), cr)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "develop")
+		require.NotNil(t, got)
+		assert.Equal(t, &desc, got.Description)
+		assert.Nil(t, got.DefaultBranch)
+	})
+
+	t.Run("sets the default branch of an empty repository", func(t *testing.T) {
+		var got *clients.UpdateRepositoryRequest
+		ec := &externalClient{
+			client: &mockRepoClient{
+				getRepoFn: func(ctx context.Context, owner, name string) (*clients.Repository, error) {
+					return &clients.Repository{Name: name, Empty: true}, nil
+				},
+				getBranchFn: func(ctx context.Context, owner, repo, branch string) (*clients.RepositoryBranch, error) {
+					return nil, fmt.Errorf("API request failed with status 404: not found")
+				},
+				updateRepoFn: func(ctx context.Context, owner, name string, req *clients.UpdateRepositoryRequest) (*clients.Repository, error) {
+					got = req
+					return &clients.Repository{}, nil
+				},
+			},
+		}
+
+		branch := "develop"
+		cr := &v2.Repository{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-repo",
+			},
+			Spec: v2.RepositorySpec{
+				ForProvider: v2.RepositoryParameters{
+					DefaultBranch: &branch,
+				},
+			},
+		}
+		meta.SetExternalName(cr, "owner/test-repo")
+
+		_, err := ec.Update(context.Background(), cr)
+		require.NoError(t, err)
+		require.NotNil(t, got.DefaultBranch)
+		assert.Equal(t, "develop", *got.DefaultBranch)
+	})
+
+	t.Run("switches to existing default branch", func(t *testing.T) {
+		var got *clients.UpdateRepositoryRequest
+		ec := &externalClient{
+			client: &mockRepoClient{
+				updateRepoFn: func(ctx context.Context, owner, name string, req *clients.UpdateRepositoryRequest) (*clients.Repository, error) {
+					got = req
+					return &clients.Repository{}, nil
+				},
+			},
+		}
+
+		branch := "develop"
+		cr := &v2.Repository{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-repo",
+			},
+			Spec: v2.RepositorySpec{
+				ForProvider: v2.RepositoryParameters{
+					DefaultBranch: &branch,
+				},
+			},
+		}
+		meta.SetExternalName(cr, "owner/test-repo")
+
+		_, err := ec.Update(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, "develop", *got.DefaultBranch)
+	})
 }
 
 func TestDelete(t *testing.T) {
@@ -356,6 +535,216 @@ func TestDelete(t *testing.T) {
 		_, err := ec.Delete(context.Background(), cr)
 		require.Error(t, err)
 	})
+
+	t.Run("protection annotation blocks deleting a non-empty repository", func(t *testing.T) {
+		ec := &externalClient{
+			client: &mockRepoClient{
+				getRepoFn: func(ctx context.Context, owner, name string) (*clients.Repository, error) {
+					return &clients.Repository{Name: name}, nil
+				},
+				deleteRepoFn: func(ctx context.Context, owner, name string) error {
+					t.Fatal("protected repository was deleted")
+					return nil
+				},
+			},
+		}
+
+		cr := &v2.Repository{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "test-repo",
+				Annotations: map[string]string{AnnotationProtect: "true"},
+			},
+		}
+		meta.SetExternalName(cr, "owner/test-repo")
+
+		_, err := ec.Delete(context.Background(), cr)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), AnnotationProtect)
+	})
+
+	t.Run("protection annotation allows deleting an empty repository", func(t *testing.T) {
+		deleted := false
+		ec := &externalClient{
+			client: &mockRepoClient{
+				getRepoFn: func(ctx context.Context, owner, name string) (*clients.Repository, error) {
+					return &clients.Repository{Name: name, Empty: true}, nil
+				},
+				deleteRepoFn: func(ctx context.Context, owner, name string) error {
+					deleted = true
+					return nil
+				},
+			},
+		}
+
+		cr := &v2.Repository{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "test-repo",
+				Annotations: map[string]string{AnnotationProtect: "true"},
+			},
+		}
+		meta.SetExternalName(cr, "owner/test-repo")
+
+		_, err := ec.Delete(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, deleted)
+	})
+
+	t.Run("archive mode archives and renames", func(t *testing.T) {
+		var update *clients.UpdateRepositoryRequest
+		ec := &externalClient{
+			client: &mockRepoClient{
+				getRepoFn: func(ctx context.Context, owner, name string) (*clients.Repository, error) {
+					return &clients.Repository{Name: name}, nil
+				},
+				updateRepoFn: func(ctx context.Context, owner, name string, req *clients.UpdateRepositoryRequest) (*clients.Repository, error) {
+					update = req
+					return &clients.Repository{}, nil
+				},
+				deleteRepoFn: func(ctx context.Context, owner, name string) error {
+					t.Fatal("archived repository was deleted")
+					return nil
+				},
+			},
+		}
+
+		mode := "Archive"
+		cr := &v2.Repository{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "test-repo",
+				Annotations: map[string]string{AnnotationProtect: "true"},
+			},
+			Spec: v2.RepositorySpec{ForProvider: v2.RepositoryParameters{DeletionMode: &mode}},
+		}
+		meta.SetExternalName(cr, "owner/test-repo")
+
+		_, err := ec.Delete(context.Background(), cr)
+		require.NoError(t, err)
+		require.NotNil(t, update)
+		require.NotNil(t, update.Archived)
+		assert.True(t, *update.Archived)
+		require.NotNil(t, update.Name)
+		assert.True(t, strings.HasPrefix(*update.Name, "test-repo-deleted-"))
+	})
+
+	t.Run("transfer mode moves to the graveyard", func(t *testing.T) {
+		var transfer *clients.TransferRepositoryRequest
+		ec := &externalClient{
+			client: &mockRepoClient{
+				getRepoFn: func(ctx context.Context, owner, name string) (*clients.Repository, error) {
+					return &clients.Repository{Name: name}, nil
+				},
+				transferFn: func(ctx context.Context, owner, name string, req *clients.TransferRepositoryRequest) (*clients.Repository, error) {
+					transfer = req
+					return &clients.Repository{}, nil
+				},
+			},
+		}
+
+		mode, graveyard := "Transfer", "graveyard"
+		cr := &v2.Repository{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-repo"},
+			Spec: v2.RepositorySpec{ForProvider: v2.RepositoryParameters{
+				DeletionMode:          &mode,
+				GraveyardOrganization: &graveyard,
+			}},
+		}
+		meta.SetExternalName(cr, "owner/test-repo")
+
+		_, err := ec.Delete(context.Background(), cr)
+		require.NoError(t, err)
+		require.NotNil(t, transfer)
+		assert.Equal(t, "graveyard", transfer.NewOwner)
+	})
+
+	t.Run("retired repositories are released", func(t *testing.T) {
+		for _, mode := range []string{"Archive", "Transfer"} {
+			t.Run(mode, func(t *testing.T) {
+				// Gitea redirects the old name, so the repository is always
+				// found, in whatever state the deletion left it.
+				repo := &clients.Repository{ID: 1, Name: "test-repo", FullName: "owner/test-repo", Owner: &clients.User{Username: "owner"}}
+				updates, transfers := 0, 0
+				ec := &externalClient{
+					client: &mockRepoClient{
+						getRepoFn: func(ctx context.Context, owner, name string) (*clients.Repository, error) {
+							r := *repo
+							return &r, nil
+						},
+						updateRepoFn: func(ctx context.Context, owner, name string, req *clients.UpdateRepositoryRequest) (*clients.Repository, error) {
+							updates++
+							repo.Name, repo.Archived = *req.Name, *req.Archived
+							repo.FullName = repo.Owner.Username + "/" + repo.Name
+							return repo, nil
+						},
+						transferFn: func(ctx context.Context, owner, name string, req *clients.TransferRepositoryRequest) (*clients.Repository, error) {
+							transfers++
+							repo.Owner = &clients.User{Username: req.NewOwner}
+							repo.FullName = req.NewOwner + "/" + repo.Name
+							return repo, nil
+						},
+					},
+				}
+
+				graveyard := "graveyard"
+				deletedAt := metav1.Now()
+				cr := &v2.Repository{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-repo", DeletionTimestamp: &deletedAt},
+					Spec: v2.RepositorySpec{ForProvider: v2.RepositoryParameters{
+						DeletionMode:          &mode,
+						GraveyardOrganization: &graveyard,
+					}},
+				}
+				meta.SetExternalName(cr, "owner/test-repo")
+
+				_, err := ec.Delete(context.Background(), cr)
+				require.NoError(t, err)
+
+				obs, err := ec.Observe(context.Background(), cr)
+				require.NoError(t, err)
+				assert.False(t, obs.ResourceExists, "a retired repository must release the resource")
+				assert.Equal(t, 1, updates+transfers)
+			})
+		}
+	})
+
+	t.Run("grace period delays deletion", func(t *testing.T) {
+		deleted := false
+		ec := &externalClient{
+			client: &mockRepoClient{
+				getRepoFn: func(ctx context.Context, owner, name string) (*clients.Repository, error) {
+					return &clients.Repository{Name: name}, nil
+				},
+				deleteRepoFn: func(ctx context.Context, owner, name string) error {
+					deleted = true
+					return nil
+				},
+			},
+		}
+
+		deletedAt := metav1.NewTime(time.Now())
+		cr := &v2.Repository{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-repo", DeletionTimestamp: &deletedAt},
+			Spec: v2.RepositorySpec{ForProvider: v2.RepositoryParameters{
+				DeletionGracePeriod: &metav1.Duration{Duration: time.Hour},
+			}},
+		}
+		meta.SetExternalName(cr, "owner/test-repo")
+
+		_, err := ec.Delete(context.Background(), cr)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "deferred")
+		assert.False(t, deleted)
+		require.NotNil(t, cr.Status.AtProvider.DeletionScheduledAt)
+		assert.Equal(t, deletedAt.Add(time.Hour), cr.Status.AtProvider.DeletionScheduledAt.Time)
+
+		past := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+		cr.DeletionTimestamp = &past
+		_, err = ec.Delete(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, deleted)
+	})
 }
 
 func TestIsRepositoryUpToDate(t *testing.T) {
@@ -458,3 +847,358 @@ func TestConnector(t *testing.T) {
 		assert.Contains(t, err.Error(), "providerConfigRef is required")
 	})
 }
+
+func TestRenameAndTransfer(t *testing.T) {
+	newRepo := func(name, owner string) *v2.Repository {
+		cr := &v2.Repository{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-repo"},
+			Spec: v2.RepositorySpec{ForProvider: v2.RepositoryParameters{
+				Name:  name,
+				Owner: &owner,
+			}},
+		}
+		meta.SetExternalName(cr, "acme/old-name")
+		return cr
+	}
+
+	t.Run("observe reports a rename and a transfer", func(t *testing.T) {
+		ec := &externalClient{
+			client: &mockRepoClient{
+				getRepoFn: func(ctx context.Context, owner, name string) (*clients.Repository, error) {
+					return &clients.Repository{Name: name, FullName: owner + "/" + name, Owner: &clients.User{Username: owner}}, nil
+				},
+			},
+		}
+		cr := newRepo("new-name", "platform")
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.False(t, obs.ResourceUpToDate)
+		assert.Equal(t, "acme/old-name", meta.GetExternalName(cr))
+	})
+
+	t.Run("observe follows an accepted transfer", func(t *testing.T) {
+		ec := &externalClient{
+			client: &mockRepoClient{
+				getRepoFn: func(ctx context.Context, owner, name string) (*clients.Repository, error) {
+					if owner != "platform" || name != "old-name" {
+						return nil, fmt.Errorf("API request failed with status 404: not found")
+					}
+					return &clients.Repository{ID: 42, Name: name, FullName: "platform/old-name", Owner: &clients.User{Username: owner}}, nil
+				},
+			},
+		}
+		cr := newRepo("old-name", "platform")
+		id, pending := int64(42), "platform"
+		cr.Status.AtProvider.ID = &id
+		cr.Status.AtProvider.PendingTransferTo = &pending
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.True(t, obs.ResourceLateInitialized)
+		assert.Equal(t, "platform/old-name", meta.GetExternalName(cr))
+	})
+
+	t.Run("observe does not adopt another repository", func(t *testing.T) {
+		ec := &externalClient{
+			client: &mockRepoClient{
+				getRepoFn: func(ctx context.Context, owner, name string) (*clients.Repository, error) {
+					if owner != "platform" {
+						return nil, fmt.Errorf("API request failed with status 404: not found")
+					}
+					return &clients.Repository{ID: 7, Name: name, FullName: owner + "/" + name, Owner: &clients.User{Username: owner}}, nil
+				},
+			},
+		}
+		cr := newRepo("new-name", "platform")
+		id, pending := int64(42), "platform"
+		cr.Status.AtProvider.ID = &id
+		cr.Status.AtProvider.PendingTransferTo = &pending
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+		assert.Equal(t, "acme/old-name", meta.GetExternalName(cr))
+	})
+
+	t.Run("observe surfaces a pending transfer", func(t *testing.T) {
+		ec := &externalClient{
+			client: &mockRepoClient{
+				getRepoFn: func(ctx context.Context, owner, name string) (*clients.Repository, error) {
+					return &clients.Repository{
+						Name:         name,
+						FullName:     owner + "/" + name,
+						Owner:        &clients.User{Username: owner},
+						RepoTransfer: &clients.RepoTransfer{Recipient: &clients.User{Username: "platform"}},
+					}, nil
+				},
+			},
+		}
+		cr := newRepo("old-name", "platform")
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+		require.NotNil(t, cr.Status.AtProvider.PendingTransferTo)
+		assert.Equal(t, "platform", *cr.Status.AtProvider.PendingTransferTo)
+		c := cr.GetCondition(TypeTransferPending)
+		assert.Equal(t, corev1.ConditionTrue, c.Status)
+		assert.Equal(t, reasonAwaitingAcceptance, c.Reason)
+	})
+
+	t.Run("observe clears an accepted transfer", func(t *testing.T) {
+		ec := &externalClient{
+			client: &mockRepoClient{
+				getRepoFn: func(ctx context.Context, owner, name string) (*clients.Repository, error) {
+					return &clients.Repository{Name: name, FullName: owner + "/" + name, Owner: &clients.User{Username: owner}}, nil
+				},
+			},
+		}
+		cr := newRepo("old-name", "acme")
+		cr.SetConditions(xpv1.Condition{Type: TypeTransferPending, Status: corev1.ConditionTrue, Reason: reasonAwaitingAcceptance})
+
+		_, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(TypeTransferPending).Status)
+	})
+
+	t.Run("update renames then transfers with teams", func(t *testing.T) {
+		var renamed *string
+		var transferredFrom string
+		var transfer *clients.TransferRepositoryRequest
+		ec := &externalClient{
+			client: &mockRepoClient{
+				updateRepoFn: func(ctx context.Context, owner, name string, req *clients.UpdateRepositoryRequest) (*clients.Repository, error) {
+					assert.Equal(t, "old-name", name)
+					renamed = req.Name
+					return &clients.Repository{}, nil
+				},
+				listTeamsFn: func(ctx context.Context, org string) ([]*clients.Team, error) {
+					assert.Equal(t, "platform", org)
+					return []*clients.Team{{ID: 3, Name: "Owners"}, {ID: 7, Name: "Developers"}}, nil
+				},
+				transferFn: func(ctx context.Context, owner, name string, req *clients.TransferRepositoryRequest) (*clients.Repository, error) {
+					transferredFrom = owner + "/" + name
+					transfer = req
+					return &clients.Repository{
+						RepoTransfer: &clients.RepoTransfer{Recipient: &clients.User{Username: "platform"}},
+					}, nil
+				},
+			},
+		}
+		cr := newRepo("new-name", "platform")
+		cr.Spec.ForProvider.TransferTeams = []string{"developers"}
+		ec.kube = fake.NewClientBuilder().WithScheme(testutil.Scheme(t)).WithObjects(cr.DeepCopy()).Build()
+
+		_, err := ec.Update(context.Background(), cr)
+		require.NoError(t, err)
+		require.NotNil(t, renamed)
+		assert.Equal(t, "new-name", *renamed)
+		assert.Equal(t, "acme/new-name", transferredFrom)
+		require.NotNil(t, transfer)
+		assert.Equal(t, "platform", transfer.NewOwner)
+		assert.Equal(t, []int64{7}, transfer.TeamIDs)
+		assert.Equal(t, corev1.ConditionTrue, cr.GetCondition(TypeTransferPending).Status)
+
+		// The transfer awaits acceptance, so the repository stays renamed
+		// under its old owner.
+		persisted := &v2.Repository{}
+		require.NoError(t, ec.kube.Get(context.Background(), client.ObjectKeyFromObject(cr), persisted))
+		assert.Equal(t, "acme/new-name", meta.GetExternalName(persisted))
+	})
+
+	t.Run("update records an immediate transfer", func(t *testing.T) {
+		ec := &externalClient{
+			client: &mockRepoClient{
+				updateRepoFn: func(ctx context.Context, owner, name string, req *clients.UpdateRepositoryRequest) (*clients.Repository, error) {
+					return &clients.Repository{}, nil
+				},
+				transferFn: func(ctx context.Context, owner, name string, req *clients.TransferRepositoryRequest) (*clients.Repository, error) {
+					return &clients.Repository{Name: name, FullName: "platform/" + name, Owner: &clients.User{Username: "platform"}}, nil
+				},
+			},
+		}
+		cr := newRepo("old-name", "platform")
+		ec.kube = fake.NewClientBuilder().WithScheme(testutil.Scheme(t)).WithObjects(cr.DeepCopy()).Build()
+
+		_, err := ec.Update(context.Background(), cr)
+		require.NoError(t, err)
+		persisted := &v2.Repository{}
+		require.NoError(t, ec.kube.Get(context.Background(), client.ObjectKeyFromObject(cr), persisted))
+		assert.Equal(t, "platform/old-name", meta.GetExternalName(persisted))
+	})
+
+	t.Run("update does not repeat a pending transfer", func(t *testing.T) {
+		ec := &externalClient{
+			client: &mockRepoClient{
+				updateRepoFn: func(ctx context.Context, owner, name string, req *clients.UpdateRepositoryRequest) (*clients.Repository, error) {
+					return &clients.Repository{}, nil
+				},
+				transferFn: func(ctx context.Context, owner, name string, req *clients.TransferRepositoryRequest) (*clients.Repository, error) {
+					t.Fatal("pending transfer was requested again")
+					return nil, nil
+				},
+			},
+		}
+		cr := newRepo("old-name", "platform")
+		pending := "platform"
+		cr.Status.AtProvider.PendingTransferTo = &pending
+
+		_, err := ec.Update(context.Background(), cr)
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown transfer team returns error", func(t *testing.T) {
+		ec := &externalClient{
+			client: &mockRepoClient{
+				updateRepoFn: func(ctx context.Context, owner, name string, req *clients.UpdateRepositoryRequest) (*clients.Repository, error) {
+					return &clients.Repository{}, nil
+				},
+				listTeamsFn: func(ctx context.Context, org string) ([]*clients.Team, error) {
+					return []*clients.Team{{ID: 3, Name: "Owners"}}, nil
+				},
+			},
+		}
+		cr := newRepo("old-name", "platform")
+		cr.Spec.ForProvider.TransferTeams = []string{"missing"}
+
+		_, err := ec.Update(context.Background(), cr)
+		require.Error(t, err)
+	})
+}
+
+func TestExclusive(t *testing.T) {
+	on := true
+	newRepo := func(mode string) *v2.Repository {
+		cr := &v2.Repository{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app"},
+			Spec: v2.RepositorySpec{ForProvider: v2.RepositoryParameters{
+				Name: "app",
+				Exclusive: &v2.RepositoryExclusivity{
+					Labels:        &on,
+					Collaborators: &on,
+					Webhooks:      &on,
+					DeployKeys:    &on,
+					Mode:          &mode,
+				},
+			}},
+		}
+		meta.SetExternalName(cr, "acme/app")
+		return cr
+	}
+	newClient := func() *mockRepoClient {
+		return &mockRepoClient{
+			getRepoFn: func(ctx context.Context, owner, name string) (*clients.Repository, error) {
+				return &clients.Repository{Name: name, FullName: owner + "/" + name, Owner: &clients.User{Username: owner}}, nil
+			},
+			labels: []*clients.Label{{ID: 1, Name: "bug"}, {ID: 2, Name: "wontfix"}},
+			webhooks: []*clients.Webhook{
+				{ID: 7, Config: map[string]string{"url": "https://ci.example.com/hook"}},
+				{ID: 8, Config: map[string]string{"url": "https://old.example.com/hook"}},
+			},
+			deployKeys: []*clients.DeployKey{{ID: 3, Title: "legacy"}},
+		}
+	}
+
+	owner, repo := "acme", "app"
+	label := &labelv2.Label{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "bug"},
+		Spec: labelv2.LabelSpec{ForProvider: labelv2.LabelParameters{
+			Name:       "Bug",
+			Repository: "acme/app",
+		}},
+	}
+	otherLabel := &labelv2.Label{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "wontfix"},
+		Spec: labelv2.LabelSpec{ForProvider: labelv2.LabelParameters{
+			Name:       "wontfix",
+			Repository: "acme/other",
+		}},
+	}
+	hook := &webhookv2.Webhook{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ci"},
+		Spec: webhookv2.WebhookSpec{ForProvider: webhookv2.WebhookParameters{
+			Owner:      &owner,
+			Repository: &repo,
+			URL:        "https://ci.example.com/hook",
+		}},
+	}
+	meta.SetExternalName(hook, "acme/app/7")
+	kube := func() *fake.ClientBuilder {
+		return fake.NewClientBuilder().WithScheme(testutil.Scheme(t)).WithObjects(label, otherLabel, hook)
+	}
+
+	t.Run("dry run reports unmanaged entries", func(t *testing.T) {
+		m := newClient()
+		ec := &externalClient{client: m, kube: kube().Build()}
+		cr := newRepo("DryRun")
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+		require.NotNil(t, cr.Status.AtProvider.Unmanaged)
+		assert.Equal(t, &v2.UnmanagedEntries{
+			Labels:     []string{"wontfix"},
+			Webhooks:   []string{"https://old.example.com/hook (#8)"},
+			DeployKeys: []string{"legacy (#3)"},
+		}, cr.Status.AtProvider.Unmanaged)
+
+		_, err = ec.Update(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Empty(t, m.removed)
+	})
+
+	t.Run("enforce prunes unmanaged entries", func(t *testing.T) {
+		m := newClient()
+		ec := &externalClient{client: m, kube: kube().Build()}
+		cr := newRepo("Enforce")
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate)
+
+		_, err = ec.Update(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"label:2", "webhook:8", "deploykey:3"}, m.removed)
+	})
+
+	t.Run("labels of a label set syncing the repository are managed", func(t *testing.T) {
+		set := &labelsetv2.LabelSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "taxonomy"},
+			Spec: labelsetv2.LabelSetSpec{ForProvider: labelsetv2.LabelSetParameters{
+				Labels: []labelsetv2.LabelDefinition{{Name: "wontfix", Color: "ffffff"}},
+			}},
+			Status: labelsetv2.LabelSetStatus{AtProvider: labelsetv2.LabelSetObservation{
+				Repositories: []labelsetv2.LabelSetRepositoryStatus{{Repository: "acme/app", Synced: true}},
+			}},
+		}
+		m := newClient()
+		ec := &externalClient{client: m, kube: kube().WithObjects(set).Build()}
+		cr := newRepo("DryRun")
+
+		_, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		require.NotNil(t, cr.Status.AtProvider.Unmanaged)
+		assert.Empty(t, cr.Status.AtProvider.Unmanaged.Labels)
+	})
+
+	t.Run("nothing unmanaged clears the status", func(t *testing.T) {
+		m := &mockRepoClient{
+			getRepoFn: func(ctx context.Context, owner, name string) (*clients.Repository, error) {
+				return &clients.Repository{Name: name, FullName: owner + "/" + name, Owner: &clients.User{Username: owner}}, nil
+			},
+			labels: []*clients.Label{{ID: 1, Name: "bug"}},
+		}
+		ec := &externalClient{client: m, kube: kube().Build()}
+		cr := newRepo("Enforce")
+		cr.Status.AtProvider.Unmanaged = &v2.UnmanagedEntries{Labels: []string{"bug"}}
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.Nil(t, cr.Status.AtProvider.Unmanaged)
+	})
+}
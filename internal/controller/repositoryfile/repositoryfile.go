@@ -0,0 +1,494 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositoryfile
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-gitea/apis/repositoryfile/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/tracing"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/rossigee/provider-gitea/apis/v1beta1"
+)
+
+const (
+	errNotRepositoryFile    = "managed resource is not a RepositoryFile custom resource"
+	errGetRepositoryFile    = "failed to get repository file"
+	errWriteRepositoryFile  = "failed to write repository file"
+	errDeleteRepositoryFile = "failed to delete repository file"
+	errGetProviderConfig    = "failed to get provider config"
+	errGetRepository        = "failed to get repository"
+	errGetConfigMap         = "failed to get content ConfigMap"
+	errMissingConfigMapKey  = "content ConfigMap has no key %q"
+	errDecodeContent        = "failed to decode repository file content"
+	errGetHeadBranch        = "failed to get pull request head branch"
+	errGetPullRequest       = "failed to get pull request"
+	errCreatePullRequest    = "failed to create pull request"
+)
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient by:
+// 1. Getting the provider config
+// 2. Creating a Gitea API client
+// 3. Returning an external client wrapping the API client
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v2.RepositoryFile)
+	if !ok {
+		return nil, errors.New(errNotRepositoryFile)
+	}
+
+	pcRef := cr.Spec.ProviderConfigReference
+	if pcRef == nil {
+		return nil, errors.New("providerConfigRef is required")
+	}
+
+	var pc v1beta1.ProviderConfig
+	if err := c.kube.Get(ctx, client.ObjectKey{
+		Namespace: cr.GetNamespace(),
+		Name:      pcRef.Name,
+	}, &pc); err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
+
+	conn, err := clients.NewClient(ctx, &pc, c.kube)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalClient{client: conn, kube: c.kube}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it matches the managed resource's desired state.
+type externalClient struct {
+	client clients.Client
+	kube   client.Client
+}
+
+func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	_, span := tracing.StartSpan(ctx, "repositoryfile.observe",
+		tracing.SpanAttrs("repositoryfile", tracing.ResourceName(mg), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.RepositoryFile)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRepositoryFile)
+	}
+
+	base, err := e.baseBranch(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	want, err := e.desiredContent(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	p := cr.Spec.ForProvider
+	file, err := e.client.GetRepositoryFile(ctx, p.Owner, p.Repository, p.Path, base)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetRepositoryFile)
+	}
+
+	cr.Status.AtProvider = v2.RepositoryFileObservation{Branch: &base}
+	upToDate := false
+	if file != nil && err == nil {
+		cr.Status.AtProvider.SHA = &file.SHA
+		cr.Status.AtProvider.LastCommitSHA = &file.LastCommitSHA
+		cr.Status.AtProvider.HTMLURL = &file.HTMLURL
+
+		got, err := decodeContent(file)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		upToDate = bytes.Equal(got, want)
+	} else {
+		file = nil
+	}
+
+	// A change awaiting review in a pull request is as far as the provider
+	// can take it, so it is reported as up to date until the pull request
+	// is merged or closed.
+	if !upToDate && p.PullRequest != nil {
+		pr, err := e.pendingPullRequest(ctx, cr, base, want)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if pr != nil {
+			if file == nil {
+				cr.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf("awaiting merge of pull request #%d", pr.Number)))
+			} else {
+				cr.SetConditions(xpv1.Available())
+			}
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+		}
+	}
+
+	if file == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: upToDate}, nil
+}
+
+func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	_, span := tracing.StartSpan(ctx, "repositoryfile.create",
+		tracing.SpanAttrs("repositoryfile", tracing.ResourceName(mg), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.RepositoryFile)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRepositoryFile)
+	}
+
+	return managed.ExternalCreation{}, errors.Wrap(e.write(ctx, cr), errWriteRepositoryFile)
+}
+
+func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	_, span := tracing.StartSpan(ctx, "repositoryfile.update",
+		tracing.SpanAttrs("repositoryfile", tracing.ResourceName(mg), "update")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.RepositoryFile)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRepositoryFile)
+	}
+
+	return managed.ExternalUpdate{}, errors.Wrap(e.write(ctx, cr), errWriteRepositoryFile)
+}
+
+func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	_, span := tracing.StartSpan(ctx, "repositoryfile.delete",
+		tracing.SpanAttrs("repositoryfile", tracing.ResourceName(mg), "delete")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.RepositoryFile)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotRepositoryFile)
+	}
+
+	base, err := e.baseBranch(ctx, cr)
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return managed.ExternalDelete{}, nil
+		}
+		return managed.ExternalDelete{}, err
+	}
+
+	p := cr.Spec.ForProvider
+	file, err := e.client.GetRepositoryFile(ctx, p.Owner, p.Repository, p.Path, base)
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return managed.ExternalDelete{}, nil
+		}
+		return managed.ExternalDelete{}, errors.Wrap(err, errGetRepositoryFile)
+	}
+
+	opts := fileOptions(cr, fmt.Sprintf("Delete %s", p.Path))
+	if p.PullRequest != nil {
+		return managed.ExternalDelete{}, errors.Wrap(e.proposeDeletion(ctx, cr, base, opts), errDeleteRepositoryFile)
+	}
+
+	opts.Branch = base
+	err = e.client.DeleteRepositoryFile(ctx, p.Owner, p.Repository, p.Path, &clients.DeleteFileOptions{
+		FileOptions: opts,
+		SHA:         file.SHA,
+	})
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteRepositoryFile)
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *externalClient) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// baseBranch returns the branch the file is managed on.
+func (e *externalClient) baseBranch(ctx context.Context, cr *v2.RepositoryFile) (string, error) {
+	if b := cr.Spec.ForProvider.Branch; b != nil && *b != "" {
+		return *b, nil
+	}
+	repo, err := e.client.GetRepository(ctx, cr.Spec.ForProvider.Owner, cr.Spec.ForProvider.Repository)
+	if err != nil {
+		return "", errors.Wrap(err, errGetRepository)
+	}
+	return repo.DefaultBranch, nil
+}
+
+// desiredContent returns the content the file should hold.
+func (e *externalClient) desiredContent(ctx context.Context, cr *v2.RepositoryFile) ([]byte, error) {
+	if c := cr.Spec.ForProvider.Content; c != nil {
+		return []byte(*c), nil
+	}
+
+	ref := cr.Spec.ForProvider.ContentFrom.ConfigMapKeyRef
+	cm := &corev1.ConfigMap{}
+	if err := e.kube.Get(ctx, client.ObjectKey{Namespace: cr.GetNamespace(), Name: ref.Name}, cm); err != nil {
+		return nil, errors.Wrap(err, errGetConfigMap)
+	}
+	if v, ok := cm.Data[ref.Key]; ok {
+		return []byte(v), nil
+	}
+	if v, ok := cm.BinaryData[ref.Key]; ok {
+		return v, nil
+	}
+	return nil, errors.Errorf(errMissingConfigMapKey, ref.Key)
+}
+
+// write commits the desired content, either directly to the base branch or
+// to the pull request head branch.
+func (e *externalClient) write(ctx context.Context, cr *v2.RepositoryFile) error {
+	base, err := e.baseBranch(ctx, cr)
+	if err != nil {
+		return err
+	}
+
+	want, err := e.desiredContent(ctx, cr)
+	if err != nil {
+		return err
+	}
+
+	p := cr.Spec.ForProvider
+	opts := fileOptions(cr, fmt.Sprintf("Update %s", p.Path))
+
+	if p.PullRequest == nil {
+		// The SHA recorded by Observe guards against overwriting a change
+		// made since the file was last observed.
+		opts.Branch = base
+		return e.commit(ctx, cr, opts, cr.Status.AtProvider.SHA, want)
+	}
+
+	readRef, err := e.onHeadBranch(ctx, cr, base, &opts)
+	if err != nil {
+		return err
+	}
+
+	var sha *string
+	current, err := e.client.GetRepositoryFile(ctx, p.Owner, p.Repository, p.Path, readRef)
+	switch {
+	case err == nil:
+		sha = &current.SHA
+	case !strings.Contains(err.Error(), "404"):
+		return errors.Wrap(err, errGetRepositoryFile)
+	}
+
+	if err := e.commit(ctx, cr, opts, sha, want); err != nil {
+		return err
+	}
+
+	return e.ensurePullRequest(ctx, cr, base, opts.Message)
+}
+
+// proposeDeletion deletes the file on the pull request head branch and opens
+// a pull request for it, so that deletion is reviewed like any other change.
+// The file stays on the base branch, and the resource with it, until the pull
+// request is merged.
+func (e *externalClient) proposeDeletion(ctx context.Context, cr *v2.RepositoryFile, base string, opts clients.FileOptions) error {
+	readRef, err := e.onHeadBranch(ctx, cr, base, &opts)
+	if err != nil {
+		return err
+	}
+
+	// A file already missing from the head branch was deleted there by an
+	// earlier attempt, which only lacks its pull request.
+	p := cr.Spec.ForProvider
+	file, err := e.client.GetRepositoryFile(ctx, p.Owner, p.Repository, p.Path, readRef)
+	switch {
+	case err == nil:
+		if err := e.client.DeleteRepositoryFile(ctx, p.Owner, p.Repository, p.Path, &clients.DeleteFileOptions{
+			FileOptions: opts,
+			SHA:         file.SHA,
+		}); err != nil {
+			return err
+		}
+	case !strings.Contains(err.Error(), "404"):
+		return errors.Wrap(err, errGetRepositoryFile)
+	}
+
+	return e.ensurePullRequest(ctx, cr, base, opts.Message)
+}
+
+// onHeadBranch points opts at the pull request head branch, creating it from
+// the base branch with the commit if it does not exist yet. It returns the
+// ref the current blob of the file is read from.
+func (e *externalClient) onHeadBranch(ctx context.Context, cr *v2.RepositoryFile, base string, opts *clients.FileOptions) (string, error) {
+	p := cr.Spec.ForProvider
+	head := p.PullRequest.HeadBranch
+
+	if _, err := e.client.GetRepositoryBranch(ctx, p.Owner, p.Repository, head); err != nil {
+		if !strings.Contains(err.Error(), "404") {
+			return "", errors.Wrap(err, errGetHeadBranch)
+		}
+		opts.Branch = base
+		opts.NewBranch = head
+		return base, nil
+	}
+
+	opts.Branch = head
+	return head, nil
+}
+
+// commit creates the file, or replaces the blob identified by sha.
+func (e *externalClient) commit(ctx context.Context, cr *v2.RepositoryFile, opts clients.FileOptions, sha *string, content []byte) error {
+	p := cr.Spec.ForProvider
+	encoded := base64.StdEncoding.EncodeToString(content)
+
+	if sha == nil {
+		_, err := e.client.CreateRepositoryFile(ctx, p.Owner, p.Repository, p.Path, &clients.CreateFileOptions{
+			FileOptions: opts,
+			Content:     encoded,
+		})
+		return err
+	}
+
+	_, err := e.client.UpdateRepositoryFile(ctx, p.Owner, p.Repository, p.Path, &clients.UpdateFileOptions{
+		FileOptions: opts,
+		Content:     encoded,
+		SHA:         *sha,
+	})
+	return err
+}
+
+// ensurePullRequest opens a pull request from the head branch unless one is
+// already open.
+func (e *externalClient) ensurePullRequest(ctx context.Context, cr *v2.RepositoryFile, base, message string) error {
+	p := cr.Spec.ForProvider
+	head := p.PullRequest.HeadBranch
+
+	pr, err := e.client.GetPullRequestByBranches(ctx, p.Owner, p.Repository, base, head)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return errors.Wrap(err, errGetPullRequest)
+	}
+	if err == nil && pr.State == "open" {
+		return nil
+	}
+
+	title := message
+	if p.PullRequest.Title != nil {
+		title = *p.PullRequest.Title
+	}
+	_, err = e.client.CreatePullRequest(ctx, p.Owner, p.Repository, &clients.CreatePullRequestOptions{
+		Title: title,
+		Body:  p.PullRequest.Body,
+		Head:  head,
+		Base:  base,
+	})
+	return errors.Wrap(err, errCreatePullRequest)
+}
+
+// pendingPullRequest returns the open pull request proposing the desired
+// content, if any, and records it in the status of cr.
+func (e *externalClient) pendingPullRequest(ctx context.Context, cr *v2.RepositoryFile, base string, want []byte) (*clients.PullRequest, error) {
+	p := cr.Spec.ForProvider
+	head := p.PullRequest.HeadBranch
+
+	pr, err := e.client.GetPullRequestByBranches(ctx, p.Owner, p.Repository, base, head)
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, errGetPullRequest)
+	}
+	if pr.State != "open" {
+		return nil, nil
+	}
+
+	file, err := e.client.GetRepositoryFile(ctx, p.Owner, p.Repository, p.Path, head)
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, errGetRepositoryFile)
+	}
+	got, err := decodeContent(file)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(got, want) {
+		return nil, nil
+	}
+
+	cr.Status.AtProvider.PullRequestNumber = &pr.Number
+	cr.Status.AtProvider.PullRequestURL = &pr.HTMLURL
+	return pr, nil
+}
+
+// fileOptions returns the commit options shared by all writes.
+func fileOptions(cr *v2.RepositoryFile, defaultMessage string) clients.FileOptions {
+	p := cr.Spec.ForProvider
+	opts := clients.FileOptions{Message: defaultMessage}
+	if p.CommitMessage != nil {
+		opts.Message = *p.CommitMessage
+	}
+	if p.Author != nil {
+		opts.Author = &clients.Identity{Name: p.Author.Name, Email: p.Author.Email}
+	}
+	if p.Committer != nil {
+		opts.Committer = &clients.Identity{Name: p.Committer.Name, Email: p.Committer.Email}
+	}
+	return opts
+}
+
+// decodeContent returns the raw content of a file returned by the contents API.
+func decodeContent(file *clients.ContentsResponse) ([]byte, error) {
+	if file.Content == nil {
+		return nil, nil
+	}
+	if file.Encoding == nil || *file.Encoding != "base64" {
+		return []byte(*file.Content), nil
+	}
+	b, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(*file.Content, "\n", ""))
+	return b, errors.Wrap(err, errDecodeContent)
+}
+
+// Setup adds a controller that reconciles RepositoryFile managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v2.RepositoryFileKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v2.RepositoryFileGroupVersionKind),
+		managed.WithExternalConnector(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v2.RepositoryFile{}).
+		Complete(r)
+}
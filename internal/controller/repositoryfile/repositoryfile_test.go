@@ -0,0 +1,280 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositoryfile
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-gitea/apis/repositoryfile/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var errNotFound = fmt.Errorf("API request failed with status 404: not found")
+
+// mockContentsClient serves files keyed by branch and records writes.
+type mockContentsClient struct {
+	testutil.NoopClient
+	files    map[string]string
+	branches map[string]bool
+	pr       *clients.PullRequest
+	created  []*clients.CreateFileOptions
+	updated  []*clients.UpdateFileOptions
+	deleted  []*clients.DeleteFileOptions
+	prs      []*clients.CreatePullRequestOptions
+}
+
+func (m *mockContentsClient) GetRepository(ctx context.Context, owner, name string) (*clients.Repository, error) {
+	return &clients.Repository{DefaultBranch: "main"}, nil
+}
+
+func (m *mockContentsClient) GetRepositoryFile(ctx context.Context, owner, repo, filepath, ref string) (*clients.ContentsResponse, error) {
+	content, ok := m.files[ref]
+	if !ok {
+		return nil, errNotFound
+	}
+	encoded := base64.StdEncoding.EncodeToString([]byte(content))
+	encoding := "base64"
+	return &clients.ContentsResponse{Path: filepath, SHA: "sha-" + ref, Encoding: &encoding, Content: &encoded}, nil
+}
+
+func (m *mockContentsClient) CreateRepositoryFile(ctx context.Context, owner, repo, filepath string, req *clients.CreateFileOptions) (*clients.FileResponse, error) {
+	m.created = append(m.created, req)
+	return &clients.FileResponse{}, nil
+}
+
+func (m *mockContentsClient) UpdateRepositoryFile(ctx context.Context, owner, repo, filepath string, req *clients.UpdateFileOptions) (*clients.FileResponse, error) {
+	m.updated = append(m.updated, req)
+	return &clients.FileResponse{}, nil
+}
+
+func (m *mockContentsClient) DeleteRepositoryFile(ctx context.Context, owner, repo, filepath string, req *clients.DeleteFileOptions) error {
+	m.deleted = append(m.deleted, req)
+	return nil
+}
+
+func (m *mockContentsClient) GetRepositoryBranch(ctx context.Context, owner, repo, branch string) (*clients.RepositoryBranch, error) {
+	if !m.branches[branch] {
+		return nil, errNotFound
+	}
+	return &clients.RepositoryBranch{Name: branch}, nil
+}
+
+func (m *mockContentsClient) GetPullRequestByBranches(ctx context.Context, owner, repo, base, head string) (*clients.PullRequest, error) {
+	if m.pr == nil {
+		return nil, errNotFound
+	}
+	return m.pr, nil
+}
+
+func (m *mockContentsClient) CreatePullRequest(ctx context.Context, owner, repo string, req *clients.CreatePullRequestOptions) (*clients.PullRequest, error) {
+	m.prs = append(m.prs, req)
+	return &clients.PullRequest{Number: 1, State: "open"}, nil
+}
+
+func newFile(content string, pr *v2.PullRequestOptions) *v2.RepositoryFile {
+	return &v2.RepositoryFile{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "readme"},
+		Spec: v2.RepositoryFileSpec{
+			ForProvider: v2.RepositoryFileParameters{
+				Owner:       "testorg",
+				Repository:  "testrepo",
+				Path:        "README.md",
+				Content:     &content,
+				PullRequest: pr,
+			},
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	t.Run("missing file does not exist", func(t *testing.T) {
+		ec := &externalClient{client: &mockContentsClient{}}
+
+		obs, err := ec.Observe(context.Background(), newFile("hello", nil))
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+
+	t.Run("matching content is up to date", func(t *testing.T) {
+		ec := &externalClient{client: &mockContentsClient{files: map[string]string{"main": "hello"}}}
+		cr := newFile("hello", nil)
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.Equal(t, "sha-main", *cr.Status.AtProvider.SHA)
+		assert.Equal(t, "main", *cr.Status.AtProvider.Branch)
+	})
+
+	t.Run("changed content is not up to date", func(t *testing.T) {
+		ec := &externalClient{client: &mockContentsClient{files: map[string]string{"main": "goodbye"}}}
+
+		obs, err := ec.Observe(context.Background(), newFile("hello", nil))
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.False(t, obs.ResourceUpToDate)
+	})
+
+	t.Run("content from ConfigMap", func(t *testing.T) {
+		kube := fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "docs"},
+			Data:       map[string]string{"README.md": "hello"},
+		}).Build()
+		ec := &externalClient{client: &mockContentsClient{files: map[string]string{"main": "hello"}}, kube: kube}
+		cr := newFile("", nil)
+		cr.Spec.ForProvider.Content = nil
+		cr.Spec.ForProvider.ContentFrom = &v2.ContentSource{
+			ConfigMapKeyRef: v2.ConfigMapKeySelector{Name: "docs", Key: "README.md"},
+		}
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+	})
+
+	t.Run("open pull request with desired content is pending", func(t *testing.T) {
+		ec := &externalClient{client: &mockContentsClient{
+			files: map[string]string{"update-readme": "hello"},
+			pr:    &clients.PullRequest{Number: 7, State: "open", HTMLURL: "https://gitea.example.com/testorg/testrepo/pulls/7"},
+		}}
+		cr := newFile("hello", &v2.PullRequestOptions{HeadBranch: "update-readme"})
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.Equal(t, int64(7), *cr.Status.AtProvider.PullRequestNumber)
+		assert.Equal(t, xpv1.ReasonUnavailable, cr.GetCondition(xpv1.TypeReady).Reason)
+	})
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("direct update uses observed SHA", func(t *testing.T) {
+		m := &mockContentsClient{}
+		ec := &externalClient{client: m}
+		cr := newFile("hello", nil)
+		sha := "observed"
+		cr.Status.AtProvider.SHA = &sha
+
+		_, err := ec.Update(context.Background(), cr)
+		require.NoError(t, err)
+		require.Len(t, m.updated, 1)
+		assert.Equal(t, "observed", m.updated[0].SHA)
+		assert.Equal(t, "main", m.updated[0].Branch)
+		assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("hello")), m.updated[0].Content)
+	})
+
+	t.Run("pull request mode creates head branch and pull request", func(t *testing.T) {
+		m := &mockContentsClient{files: map[string]string{"main": "goodbye"}}
+		ec := &externalClient{client: m}
+		cr := newFile("hello", &v2.PullRequestOptions{HeadBranch: "update-readme"})
+
+		_, err := ec.Update(context.Background(), cr)
+		require.NoError(t, err)
+		require.Len(t, m.updated, 1)
+		assert.Equal(t, "main", m.updated[0].Branch)
+		assert.Equal(t, "update-readme", m.updated[0].NewBranch)
+		assert.Equal(t, "sha-main", m.updated[0].SHA)
+		require.Len(t, m.prs, 1)
+		assert.Equal(t, "update-readme", m.prs[0].Head)
+		assert.Equal(t, "main", m.prs[0].Base)
+		assert.Equal(t, "Update README.md", m.prs[0].Title)
+	})
+
+	t.Run("pull request mode creates file on existing head branch", func(t *testing.T) {
+		m := &mockContentsClient{branches: map[string]bool{"update-readme": true}}
+		ec := &externalClient{client: m}
+		cr := newFile("hello", &v2.PullRequestOptions{HeadBranch: "update-readme"})
+
+		_, err := ec.Create(context.Background(), cr)
+		require.NoError(t, err)
+		require.Len(t, m.created, 1)
+		assert.Equal(t, "update-readme", m.created[0].Branch)
+		assert.Empty(t, m.created[0].NewBranch)
+		require.Len(t, m.prs, 1)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("direct delete commits to the base branch", func(t *testing.T) {
+		m := &mockContentsClient{files: map[string]string{"main": "hello"}}
+		ec := &externalClient{client: m}
+
+		_, err := ec.Delete(context.Background(), newFile("hello", nil))
+		require.NoError(t, err)
+		require.Len(t, m.deleted, 1)
+		assert.Equal(t, "main", m.deleted[0].Branch)
+		assert.Equal(t, "sha-main", m.deleted[0].SHA)
+		assert.Empty(t, m.prs)
+	})
+
+	t.Run("pull request mode deletes on a new head branch", func(t *testing.T) {
+		m := &mockContentsClient{files: map[string]string{"main": "hello"}}
+		ec := &externalClient{client: m}
+		cr := newFile("hello", &v2.PullRequestOptions{HeadBranch: "update-readme"})
+
+		_, err := ec.Delete(context.Background(), cr)
+		require.NoError(t, err)
+		require.Len(t, m.deleted, 1)
+		assert.Equal(t, "main", m.deleted[0].Branch)
+		assert.Equal(t, "update-readme", m.deleted[0].NewBranch)
+		require.Len(t, m.prs, 1)
+		assert.Equal(t, "update-readme", m.prs[0].Head)
+		assert.Equal(t, "main", m.prs[0].Base)
+		assert.Equal(t, "Delete README.md", m.prs[0].Title)
+	})
+
+	t.Run("pull request mode only opens the pull request once deleted on head", func(t *testing.T) {
+		m := &mockContentsClient{
+			files:    map[string]string{"main": "hello"},
+			branches: map[string]bool{"update-readme": true},
+		}
+		ec := &externalClient{client: m}
+		cr := newFile("hello", &v2.PullRequestOptions{HeadBranch: "update-readme"})
+
+		_, err := ec.Delete(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Empty(t, m.deleted)
+		require.Len(t, m.prs, 1)
+	})
+
+	t.Run("pull request mode leaves an open pull request alone", func(t *testing.T) {
+		m := &mockContentsClient{
+			files:    map[string]string{"main": "hello"},
+			branches: map[string]bool{"update-readme": true},
+			pr:       &clients.PullRequest{Number: 7, State: "open"},
+		}
+		ec := &externalClient{client: m}
+		cr := newFile("hello", &v2.PullRequestOptions{HeadBranch: "update-readme"})
+
+		_, err := ec.Delete(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Empty(t, m.deleted)
+		assert.Empty(t, m.prs)
+	})
+}
@@ -25,10 +25,12 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/pkg/errors"
 	"github.com/rossigee/provider-gitea/apis/repositorykey/v2"
 
 	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/sshkey"
 	"github.com/rossigee/provider-gitea/internal/tracing"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -43,6 +45,10 @@ const (
 	errUpdateRepositoryKey = "failed to update repositorykey"
 	errDeleteRepositoryKey = "failed to delete repositorykey"
 	errGetProviderConfig   = "failed to get provider config"
+	errGetRepository       = "failed to get repository for connection details"
+	errGenerateKey         = "failed to generate repositorykey"
+	errReplaceKey          = "failed to replace repositorykey"
+	errDeleteRetiredKey    = "failed to delete retired repositorykey"
 )
 
 type connector struct {
@@ -73,11 +79,12 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, err
 	}
 
-	return &externalClient{client: conn}, nil
+	return &externalClient{client: conn, kube: c.kube}, nil
 }
 
 type externalClient struct {
 	client clients.Client
+	kube   client.Client
 }
 
 func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -97,7 +104,7 @@ func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (mana
 
 	keyID, err := strconv.ParseInt(externalID, 10, 64)
 	if err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(err, "failed to parse key ID")
+		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
 
 	key, err := e.client.GetRepositoryKey(ctx, cr.Spec.ForProvider.Repository, keyID)
@@ -109,10 +116,24 @@ func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (mana
 	}
 
 	cr.Status.AtProvider = v2.RepositoryKeyObservation{
-		ID: &key.ID,
+		ID:          &key.ID,
+		Title:       &key.Title,
+		Fingerprint: &key.Fingerprint,
+		PublicKey:   &key.Key,
+		ReadOnly:    &key.ReadOnly,
+		CreatedAt:   &key.CreatedAt,
+		URL:         &key.URL,
+		Repository:  &cr.Spec.ForProvider.Repository,
 	}
 
-	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	cr.SetConditions(xpv1.Available())
+
+	// A generated key is out of date once its regeneration has been requested,
+	// and until the key it replaced has been deleted.
+	_, retired := sshkey.Retired(cr)
+	upToDate := !retired && (cr.Spec.ForProvider.GenerateKey == nil || !sshkey.Regeneration.Requested(cr))
+
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: upToDate}, nil
 }
 
 func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
@@ -125,12 +146,17 @@ func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (manag
 		return managed.ExternalCreation{}, errors.New(errNotRepositoryKey)
 	}
 
-	createReq := &clients.CreateRepositoryKeyRequest{
-		Title: cr.Spec.ForProvider.Title,
-		Key:   cr.Spec.ForProvider.Key,
+	if cr.Spec.ForProvider.GenerateKey != nil {
+		id, cd, err := e.generate(ctx, cr, cr.Spec.ForProvider.Title)
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errCreateRepositoryKey)
+		}
+		meta.SetExternalName(cr, strconv.FormatInt(id, 10))
+		sshkey.Regeneration.Mark(cr)
+		return managed.ExternalCreation{ConnectionDetails: cd}, nil
 	}
 
-	key, err := e.client.CreateRepositoryKey(ctx, cr.Spec.ForProvider.Repository, createReq)
+	key, err := e.client.CreateRepositoryKey(ctx, cr.Spec.ForProvider.Repository, createRequest(cr, cr.Spec.ForProvider.Key))
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errCreateRepositoryKey)
 	}
@@ -155,6 +181,25 @@ func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (manag
 		return managed.ExternalUpdate{}, errors.Wrap(err, "failed to parse key ID")
 	}
 
+	// A replaced key is only deleted once the reconciler has published the
+	// connection details of its replacement, so consumers are never left
+	// without a working key.
+	_, retired := sshkey.Retired(cr)
+	if err := e.deleteRetired(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if cr.Spec.ForProvider.GenerateKey != nil && sshkey.Regeneration.Requested(cr) {
+		cd, err := e.regenerate(ctx, cr)
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errReplaceKey)
+		}
+		return managed.ExternalUpdate{ConnectionDetails: cd}, nil
+	}
+	if retired {
+		return managed.ExternalUpdate{}, nil
+	}
+
 	title := cr.Spec.ForProvider.Title
 	updateReq := &clients.UpdateRepositoryKeyRequest{
 		Title: &title,
@@ -178,6 +223,10 @@ func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (manag
 		return managed.ExternalDelete{}, errors.New(errNotRepositoryKey)
 	}
 
+	if err := e.deleteRetired(ctx, cr); err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
 	externalID := meta.GetExternalName(cr)
 	keyID, err := strconv.ParseInt(externalID, 10, 64)
 	if err != nil {
@@ -185,13 +234,98 @@ func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (manag
 	}
 
 	err = e.client.DeleteRepositoryKey(ctx, cr.Spec.ForProvider.Repository, keyID)
-	return managed.ExternalDelete{}, errors.Wrap(err, errDeleteRepositoryKey)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteRepositoryKey)
+	}
+	return managed.ExternalDelete{}, nil
 }
 
 func (e *externalClient) Disconnect(ctx context.Context) error {
 	return nil
 }
 
+func createRequest(cr *v2.RepositoryKey, key string) *clients.CreateRepositoryKeyRequest {
+	return &clients.CreateRepositoryKeyRequest{
+		Title:    cr.Spec.ForProvider.Title,
+		Key:      key,
+		ReadOnly: cr.Spec.ForProvider.ReadOnly,
+	}
+}
+
+// generate registers a freshly generated keypair under title and returns the
+// new key ID along with connection details carrying the private key.
+func (e *externalClient) generate(ctx context.Context, cr *v2.RepositoryKey, title string) (int64, managed.ConnectionDetails, error) {
+	gen := cr.Spec.ForProvider.GenerateKey
+	bits := 0
+	if gen.Bits != nil {
+		bits = *gen.Bits
+	}
+
+	kp, err := sshkey.Generate(gen.Algorithm, bits, cr.Spec.ForProvider.Title)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, errGenerateKey)
+	}
+
+	owner, name, _ := strings.Cut(cr.Spec.ForProvider.Repository, "/")
+	repo, err := e.client.GetRepository(ctx, owner, name)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, errGetRepository)
+	}
+
+	req := createRequest(cr, kp.PublicKey)
+	req.Title = title
+	key, err := e.client.CreateRepositoryKey(ctx, cr.Spec.ForProvider.Repository, req)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	cd := managed.ConnectionDetails{
+		"url":        []byte(repo.SSHURL),
+		"privateKey": []byte(kp.PrivateKey),
+		"publicKey":  []byte(kp.PublicKey),
+	}
+
+	// known_hosts is a convenience for consumers; the key remains usable
+	// without it, so an unreachable SSH server is not an error.
+	if addr, err := sshkey.Address(repo.SSHURL); err == nil {
+		if kh, err := sshkey.KnownHosts(ctx, addr); err == nil {
+			cd["knownHosts"] = []byte(kh)
+		}
+	}
+
+	return key.ID, cd, nil
+}
+
+// regenerate registers a freshly generated replacement for the current key.
+// Gitea rejects a second key with the title of an existing one, so the
+// replacement is registered under a distinct title, and the current key is
+// recorded as retired rather than deleted.
+func (e *externalClient) regenerate(ctx context.Context, cr *v2.RepositoryKey) (managed.ConnectionDetails, error) {
+	newID, cd, err := e.generate(ctx, cr, sshkey.Title(cr.Spec.ForProvider.Title, cr))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sshkey.RecordRegeneration(ctx, e.kube, cr, strconv.FormatInt(newID, 10)); err != nil {
+		return nil, err
+	}
+
+	return cd, nil
+}
+
+// deleteRetired deletes the key superseded by the current one, if any.
+func (e *externalClient) deleteRetired(ctx context.Context, cr *v2.RepositoryKey) error {
+	id, ok := sshkey.Retired(cr)
+	if !ok {
+		return nil
+	}
+	err := e.client.DeleteRepositoryKey(ctx, cr.Spec.ForProvider.Repository, id)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return errors.Wrap(err, errDeleteRetiredKey)
+	}
+	return errors.Wrap(sshkey.ForgetRetired(ctx, e.kube, cr), errDeleteRetiredKey)
+}
+
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(v2.RepositoryKeyKind)
 
@@ -199,6 +333,7 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		resource.ManagedKind(v2.RepositoryKeyGroupVersionKind),
 		managed.WithExternalConnector(&connector{kube: mgr.GetClient()}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
 	)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositorykey
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/rossigee/provider-gitea/apis/repositorykey/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+	"github.com/rossigee/provider-gitea/internal/sshkey"
+)
+
+const oldKey = "ssh-ed25519 AAAAold"
+
+// mockKeyClient holds repository keys the way Gitea does, rejecting a second key
+// with the title of an existing one.
+type mockKeyClient struct {
+	testutil.NoopClient
+	keys      map[int64]*clients.RepositoryKey
+	nextID    int64
+	rejectNew bool
+}
+
+func (m *mockKeyClient) GetRepository(ctx context.Context, owner, name string) (*clients.Repository, error) {
+	return &clients.Repository{}, nil
+}
+
+func (m *mockKeyClient) CreateRepositoryKey(ctx context.Context, repository string, req *clients.CreateRepositoryKeyRequest) (*clients.RepositoryKey, error) {
+	for _, k := range m.keys {
+		if k.Title == req.Title {
+			return nil, errors.New("API request failed with status 422: key title has been used")
+		}
+	}
+	if m.rejectNew && req.Key != oldKey {
+		return nil, errors.New("API request failed with status 500")
+	}
+	m.nextID++
+	k := &clients.RepositoryKey{ID: m.nextID, Title: req.Title, Key: req.Key}
+	m.keys[k.ID] = k
+	return k, nil
+}
+
+func (m *mockKeyClient) DeleteRepositoryKey(ctx context.Context, repository string, keyID int64) error {
+	delete(m.keys, keyID)
+	return nil
+}
+
+func newRegeneratingKey() *v2.RepositoryKey {
+	old := oldKey
+	cr := &v2.RepositoryKey{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ci"},
+		Spec: v2.RepositoryKeySpec{ForProvider: v2.RepositoryKeyParameters{
+			Repository:  "acme/app",
+			Title:       "ci",
+			GenerateKey: &v2.KeyGeneration{Algorithm: sshkey.AlgorithmED25519},
+		}},
+		Status: v2.RepositoryKeyStatus{AtProvider: v2.RepositoryKeyObservation{PublicKey: &old}},
+	}
+	meta.SetExternalName(cr, "1")
+	meta.AddAnnotations(cr, map[string]string{sshkey.AnnotationRegenerate: "1"})
+	return cr
+}
+
+func TestUpdateRegenerates(t *testing.T) {
+	ctx := context.Background()
+	cr := newRegeneratingKey()
+	kube := fake.NewClientBuilder().WithScheme(testutil.Scheme(t)).WithObjects(cr.DeepCopy()).Build()
+	m := &mockKeyClient{keys: map[int64]*clients.RepositoryKey{1: {ID: 1, Title: "ci", Key: oldKey}}, nextID: 1}
+	ec := &externalClient{client: m, kube: kube}
+
+	upd, err := ec.Update(ctx, cr)
+	require.NoError(t, err)
+	assert.NotEmpty(t, upd.ConnectionDetails["privateKey"])
+	require.Len(t, m.keys, 2, "the old key outlives the publication of its replacement")
+	assert.Equal(t, "ci-1", m.keys[2].Title)
+
+	got := &v2.RepositoryKey{}
+	require.NoError(t, kube.Get(ctx, client.ObjectKeyFromObject(cr), got))
+	assert.Equal(t, "2", meta.GetExternalName(got))
+	assert.False(t, sshkey.Regeneration.Requested(got))
+	id, retired := sshkey.Retired(got)
+	require.True(t, retired)
+	assert.Equal(t, int64(1), id)
+
+	_, err = ec.Update(ctx, cr)
+	require.NoError(t, err)
+	require.Len(t, m.keys, 1)
+	assert.Contains(t, m.keys, int64(2), "the old key is deleted once its replacement is published")
+
+	require.NoError(t, kube.Get(ctx, client.ObjectKeyFromObject(cr), got))
+	_, retired = sshkey.Retired(got)
+	assert.False(t, retired)
+}
+
+func TestUpdateKeepsOldKey(t *testing.T) {
+	ctx := context.Background()
+	cr := newRegeneratingKey()
+	kube := fake.NewClientBuilder().WithScheme(testutil.Scheme(t)).WithObjects(cr.DeepCopy()).Build()
+	m := &mockKeyClient{keys: map[int64]*clients.RepositoryKey{1: {ID: 1, Title: "ci", Key: oldKey}}, nextID: 1, rejectNew: true}
+	ec := &externalClient{client: m, kube: kube}
+
+	_, err := ec.Update(ctx, cr)
+	require.Error(t, err)
+	require.Len(t, m.keys, 1)
+	assert.Equal(t, oldKey, m.keys[1].Key, "the old key is untouched")
+
+	got := &v2.RepositoryKey{}
+	require.NoError(t, kube.Get(ctx, client.ObjectKeyFromObject(cr), got))
+	assert.Equal(t, "1", meta.GetExternalName(got))
+	assert.True(t, sshkey.Regeneration.Requested(got), "the regeneration is retried")
+}
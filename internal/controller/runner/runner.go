@@ -0,0 +1,257 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-gitea/apis/runner/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/tracing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/rossigee/provider-gitea/apis/v1beta1"
+)
+
+const (
+	errNotRunner         = "managed resource is not a Runner custom resource"
+	errGetRunner         = "failed to get runner"
+	errListRunners       = "failed to list runners"
+	errDeleteRunner      = "failed to delete runner"
+	errGetProviderConfig = "failed to get provider config"
+	errNotRegistered     = "no registered runner matches name and labels; runners register through act_runner using a RunnerRegistrationToken"
+
+	statusOffline = "offline"
+)
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient by:
+// 1. Getting the provider config
+// 2. Creating a Gitea API client
+// 3. Returning an external client wrapping the API client
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v2.Runner)
+	if !ok {
+		return nil, errors.New(errNotRunner)
+	}
+
+	pcRef := cr.Spec.ProviderConfigReference
+	if pcRef == nil {
+		return nil, errors.New("providerConfigRef is required")
+	}
+
+	var pc v1beta1.ProviderConfig
+	if err := c.kube.Get(ctx, client.ObjectKey{
+		Namespace: cr.GetNamespace(),
+		Name:      pcRef.Name,
+	}, &pc); err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
+
+	conn, err := clients.NewClient(ctx, &pc, c.kube)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalClient{client: conn}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it matches the managed resource's desired state.
+type externalClient struct {
+	client clients.Client
+}
+
+func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	_, span := tracing.StartSpan(ctx, "runner.observe",
+		tracing.SpanAttrs("runner", tracing.ResourceName(mg), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.Runner)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRunner)
+	}
+
+	p := cr.Spec.ForProvider
+	var runner *clients.Runner
+
+	// A runner that re-registers gets a new ID, so an adopted runner that
+	// has gone or no longer matches is looked up again by name and labels.
+	if id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64); err == nil {
+		r, err := e.client.GetRunner(ctx, p.Scope, scopeValue(cr), id)
+		if err != nil && !strings.Contains(err.Error(), "404") {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetRunner)
+		}
+		if err == nil && matches(r, p) {
+			runner = r
+		}
+	}
+
+	lateInitialized := false
+	if runner == nil {
+		runners, err := e.client.ListRunners(ctx, p.Scope, scopeValue(cr))
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errListRunners)
+		}
+		for i := range runners {
+			// Prefer the most recent registration of a reused name.
+			if matches(&runners[i], p) && (runner == nil || runners[i].ID > runner.ID) {
+				runner = &runners[i]
+			}
+		}
+		if runner == nil {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		meta.SetExternalName(cr, strconv.FormatInt(runner.ID, 10))
+		lateInitialized = true
+	}
+
+	online := runner.Status != statusOffline
+	cr.Status.AtProvider = v2.RunnerObservation{
+		ID:         &runner.ID,
+		Name:       &runner.Name,
+		Status:     &runner.Status,
+		Online:     &online,
+		Busy:       &runner.Busy,
+		Ephemeral:  &runner.Ephemeral,
+		Labels:     labelNames(runner),
+		Scope:      &p.Scope,
+		ScopeValue: p.ScopeValue,
+	}
+	if runner.Version != "" {
+		cr.Status.AtProvider.Version = &runner.Version
+	}
+
+	if online {
+		cr.SetConditions(xpv1.Available())
+	} else {
+		cr.SetConditions(xpv1.Unavailable().WithMessage("runner is offline"))
+	}
+
+	// Gitea has no API to change a registered runner, so there is never
+	// anything to update.
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        true,
+		ResourceLateInitialized: lateInitialized,
+	}, nil
+}
+
+// Create reports that no runner has registered yet. Runners can only be
+// registered by act_runner itself.
+func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	_, span := tracing.StartSpan(ctx, "runner.create",
+		tracing.SpanAttrs("runner", tracing.ResourceName(mg), "create")...)
+	defer span.End()
+
+	if _, ok := mg.(*v2.Runner); !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRunner)
+	}
+	return managed.ExternalCreation{}, errors.New(errNotRegistered)
+}
+
+func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	_, span := tracing.StartSpan(ctx, "runner.delete",
+		tracing.SpanAttrs("runner", tracing.ResourceName(mg), "delete")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.Runner)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotRunner)
+	}
+
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalDelete{}, nil
+	}
+
+	err = e.client.DeleteRunner(ctx, cr.Spec.ForProvider.Scope, scopeValue(cr), id)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteRunner)
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *externalClient) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// matches returns true if runner has the name and all labels of p.
+func matches(runner *clients.Runner, p v2.RunnerParameters) bool {
+	if runner.Name != p.Name {
+		return false
+	}
+	have := make(map[string]bool, len(runner.Labels))
+	for _, l := range runner.Labels {
+		have[l.Name] = true
+	}
+	for _, l := range p.Labels {
+		if !have[l] {
+			return false
+		}
+	}
+	return true
+}
+
+func labelNames(runner *clients.Runner) []string {
+	names := make([]string, 0, len(runner.Labels))
+	for _, l := range runner.Labels {
+		names = append(names, l.Name)
+	}
+	return names
+}
+
+func scopeValue(cr *v2.Runner) string {
+	if cr.Spec.ForProvider.ScopeValue != nil {
+		return *cr.Spec.ForProvider.ScopeValue
+	}
+	return ""
+}
+
+// Setup adds a controller that reconciles Runner managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v2.RunnerKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v2.RunnerGroupVersionKind),
+		managed.WithExternalConnector(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v2.Runner{}).
+		Complete(r)
+}
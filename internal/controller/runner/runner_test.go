@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-gitea/apis/runner/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type mockRunnerClient struct {
+	testutil.NoopClient
+	runners []clients.Runner
+}
+
+func (m *mockRunnerClient) GetRunner(ctx context.Context, scope, scopeValue string, runnerID int64) (*clients.Runner, error) {
+	for i := range m.runners {
+		if m.runners[i].ID == runnerID {
+			return &m.runners[i], nil
+		}
+	}
+	return nil, fmt.Errorf("API request failed with status 404: not found")
+}
+
+func (m *mockRunnerClient) ListRunners(ctx context.Context, scope, scopeValue string) ([]clients.Runner, error) {
+	return m.runners, nil
+}
+
+func labels(names ...string) []clients.RunnerLabel {
+	l := make([]clients.RunnerLabel, 0, len(names))
+	for _, n := range names {
+		l = append(l, clients.RunnerLabel{Name: n})
+	}
+	return l
+}
+
+func newRunner() *v2.Runner {
+	cr := &v2.Runner{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "build"},
+		Spec: v2.RunnerSpec{
+			ForProvider: v2.RunnerParameters{
+				Scope:  "system",
+				Name:   "build-runner",
+				Labels: []string{"ubuntu-latest"},
+			},
+		},
+	}
+	meta.SetExternalName(cr, "build")
+	return cr
+}
+
+func TestObserve(t *testing.T) {
+	t.Run("no matching runner does not exist", func(t *testing.T) {
+		ec := &externalClient{client: &mockRunnerClient{runners: []clients.Runner{
+			{ID: 1, Name: "build-runner", Status: "idle", Labels: labels("windows")},
+		}}}
+
+		obs, err := ec.Observe(context.Background(), newRunner())
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+
+	t.Run("adopts most recent matching runner", func(t *testing.T) {
+		ec := &externalClient{client: &mockRunnerClient{runners: []clients.Runner{
+			{ID: 1, Name: "build-runner", Status: "offline", Labels: labels("ubuntu-latest")},
+			{ID: 4, Name: "build-runner", Status: "idle", Labels: labels("ubuntu-latest", "docker")},
+			{ID: 5, Name: "other-runner", Status: "idle", Labels: labels("ubuntu-latest")},
+		}}}
+		cr := newRunner()
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.True(t, obs.ResourceLateInitialized)
+		assert.Equal(t, "4", meta.GetExternalName(cr))
+		assert.True(t, *cr.Status.AtProvider.Online)
+		assert.Equal(t, []string{"ubuntu-latest", "docker"}, cr.Status.AtProvider.Labels)
+	})
+
+	t.Run("offline adopted runner is unavailable", func(t *testing.T) {
+		ec := &externalClient{client: &mockRunnerClient{runners: []clients.Runner{
+			{ID: 1, Name: "build-runner", Status: "offline", Labels: labels("ubuntu-latest")},
+		}}}
+		cr := newRunner()
+		meta.SetExternalName(cr, "1")
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.False(t, obs.ResourceLateInitialized)
+		assert.Equal(t, xpv1.ReasonUnavailable, cr.GetCondition(xpv1.TypeReady).Reason)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	ec := &externalClient{client: &mockRunnerClient{}}
+
+	_, err := ec.Create(context.Background(), newRunner())
+	assert.EqualError(t, err, errNotRegistered)
+}
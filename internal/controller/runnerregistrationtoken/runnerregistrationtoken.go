@@ -0,0 +1,235 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runnerregistrationtoken
+
+import (
+	"context"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-gitea/apis/runnerregistrationtoken/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/regeneration"
+	"github.com/rossigee/provider-gitea/internal/tracing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/rossigee/provider-gitea/apis/v1beta1"
+)
+
+const (
+	errNotRunnerRegistrationToken = "managed resource is not a RunnerRegistrationToken custom resource"
+	errGetToken                   = "failed to get runner registration token"
+	errCreateToken                = "failed to create runner registration token"
+	errRecordRegeneration         = "failed to record runner registration token regeneration"
+	errGetProviderConfig          = "failed to get provider config"
+
+	// AnnotationRegenerate requests a new registration token whenever its
+	// value changes.
+	AnnotationRegenerate = "gitea.m.crossplane.io/regenerate-token"
+
+	// AnnotationRegenerated records the AnnotationRegenerate value the
+	// current token was issued for.
+	AnnotationRegenerated = "gitea.m.crossplane.io/regenerated-token"
+
+	// Connection secret keys read by act_runner Deployments.
+	keyToken       = "token"
+	keyInstanceURL = "instanceUrl"
+)
+
+// tokenRegeneration tracks registration token regeneration requests.
+var tokenRegeneration = regeneration.Annotations{Request: AnnotationRegenerate, Issued: AnnotationRegenerated}
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient by:
+// 1. Getting the provider config
+// 2. Creating a Gitea API client
+// 3. Returning an external client wrapping the API client
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v2.RunnerRegistrationToken)
+	if !ok {
+		return nil, errors.New(errNotRunnerRegistrationToken)
+	}
+
+	pcRef := cr.Spec.ProviderConfigReference
+	if pcRef == nil {
+		return nil, errors.New("providerConfigRef is required")
+	}
+
+	var pc v1beta1.ProviderConfig
+	if err := c.kube.Get(ctx, client.ObjectKey{
+		Namespace: cr.GetNamespace(),
+		Name:      pcRef.Name,
+	}, &pc); err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
+
+	conn, err := clients.NewClient(ctx, &pc, c.kube)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalClient{client: conn, kube: c.kube, instanceURL: strings.TrimSuffix(pc.Spec.BaseURL, "/")}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it matches the managed resource's desired state.
+type externalClient struct {
+	client      clients.Client
+	kube        client.Client
+	instanceURL string
+}
+
+func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	_, span := tracing.StartSpan(ctx, "runnerregistrationtoken.observe",
+		tracing.SpanAttrs("runnerregistrationtoken", tracing.ResourceName(mg), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.RunnerRegistrationToken)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRunnerRegistrationToken)
+	}
+
+	// Tokens cannot be revoked, so there is nothing left to wait for once
+	// the resource is deleted.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	// Gitea issues a token for the scope if none is active, so the token
+	// only fails to exist if the scope itself does not.
+	token, err := e.client.GetRunnerRegistrationToken(ctx, cr.Spec.ForProvider.Scope, scopeValue(cr))
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetToken)
+	}
+
+	cr.Status.AtProvider = v2.RunnerRegistrationTokenObservation{
+		Scope:       &cr.Spec.ForProvider.Scope,
+		ScopeValue:  cr.Spec.ForProvider.ScopeValue,
+		InstanceURL: &e.instanceURL,
+	}
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  !tokenRegeneration.Requested(cr),
+		ConnectionDetails: e.connectionDetails(token),
+	}, nil
+}
+
+func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	_, span := tracing.StartSpan(ctx, "runnerregistrationtoken.create",
+		tracing.SpanAttrs("runnerregistrationtoken", tracing.ResourceName(mg), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.RunnerRegistrationToken)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRunnerRegistrationToken)
+	}
+
+	token, err := e.client.CreateRunnerRegistrationToken(ctx, cr.Spec.ForProvider.Scope, scopeValue(cr))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateToken)
+	}
+
+	tokenRegeneration.Mark(cr)
+	return managed.ExternalCreation{ConnectionDetails: e.connectionDetails(token)}, nil
+}
+
+func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	_, span := tracing.StartSpan(ctx, "runnerregistrationtoken.update",
+		tracing.SpanAttrs("runnerregistrationtoken", tracing.ResourceName(mg), "update")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.RunnerRegistrationToken)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRunnerRegistrationToken)
+	}
+
+	token, err := e.client.CreateRunnerRegistrationToken(ctx, cr.Spec.ForProvider.Scope, scopeValue(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errCreateToken)
+	}
+
+	// The managed reconciler does not persist annotations set during
+	// Update, so the regeneration is recorded explicitly.
+	if err := tokenRegeneration.Record(ctx, e.kube, cr); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errRecordRegeneration)
+	}
+
+	return managed.ExternalUpdate{ConnectionDetails: e.connectionDetails(token)}, nil
+}
+
+// Delete is a no-op. Gitea has no API to revoke a registration token; it is
+// superseded the next time a token is issued for the scope.
+func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	_, span := tracing.StartSpan(ctx, "runnerregistrationtoken.delete",
+		tracing.SpanAttrs("runnerregistrationtoken", tracing.ResourceName(mg), "delete")...)
+	defer span.End()
+
+	if _, ok := mg.(*v2.RunnerRegistrationToken); !ok {
+		return managed.ExternalDelete{}, errors.New(errNotRunnerRegistrationToken)
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *externalClient) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+func (e *externalClient) connectionDetails(token *clients.RunnerRegistrationToken) managed.ConnectionDetails {
+	return managed.ConnectionDetails{
+		keyToken:       []byte(token.Token),
+		keyInstanceURL: []byte(e.instanceURL),
+	}
+}
+
+func scopeValue(cr *v2.RunnerRegistrationToken) string {
+	if cr.Spec.ForProvider.ScopeValue != nil {
+		return *cr.Spec.ForProvider.ScopeValue
+	}
+	return ""
+}
+
+// Setup adds a controller that reconciles RunnerRegistrationToken managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v2.RunnerRegistrationTokenKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v2.RunnerRegistrationTokenGroupVersionKind),
+		managed.WithExternalConnector(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v2.RunnerRegistrationToken{}).
+		Complete(r)
+}
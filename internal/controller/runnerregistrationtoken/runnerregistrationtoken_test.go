@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runnerregistrationtoken
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/rossigee/provider-gitea/apis/runnerregistrationtoken/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type mockTokenClient struct {
+	testutil.NoopClient
+	token string
+}
+
+func (m *mockTokenClient) GetRunnerRegistrationToken(ctx context.Context, scope, scopeValue string) (*clients.RunnerRegistrationToken, error) {
+	return &clients.RunnerRegistrationToken{Token: m.token}, nil
+}
+
+func (m *mockTokenClient) CreateRunnerRegistrationToken(ctx context.Context, scope, scopeValue string) (*clients.RunnerRegistrationToken, error) {
+	m.token = "regenerated"
+	return &clients.RunnerRegistrationToken{Token: m.token}, nil
+}
+
+func newToken() *v2.RunnerRegistrationToken {
+	org := "testorg"
+	return &v2.RunnerRegistrationToken{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "runners"},
+		Spec: v2.RunnerRegistrationTokenSpec{
+			ForProvider: v2.RunnerRegistrationTokenParameters{Scope: "organization", ScopeValue: &org},
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	ec := &externalClient{client: &mockTokenClient{token: "current"}, instanceURL: "https://gitea.example.com"}
+	cr := newToken()
+
+	obs, err := ec.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.Equal(t, "current", string(obs.ConnectionDetails[keyToken]))
+	assert.Equal(t, "https://gitea.example.com", string(obs.ConnectionDetails[keyInstanceURL]))
+
+	meta.AddAnnotations(cr, map[string]string{AnnotationRegenerate: "1"})
+	obs, err = ec.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate)
+}
+
+func TestObserveDeleted(t *testing.T) {
+	ec := &externalClient{client: &mockTokenClient{token: "current"}}
+	cr := newToken()
+	now := metav1.Now()
+	cr.SetDeletionTimestamp(&now)
+
+	obs, err := ec.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceExists)
+}
+
+func TestUpdateRegenerates(t *testing.T) {
+	ctx := context.Background()
+
+	cr := newToken()
+	meta.AddAnnotations(cr, map[string]string{AnnotationRegenerate: "1"})
+	kube := fake.NewClientBuilder().WithScheme(testutil.Scheme(t)).WithObjects(cr.DeepCopy()).Build()
+	ec := &externalClient{client: &mockTokenClient{token: "current"}, kube: kube}
+
+	upd, err := ec.Update(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, "regenerated", string(upd.ConnectionDetails[keyToken]))
+
+	got := &v2.RunnerRegistrationToken{}
+	require.NoError(t, kube.Get(ctx, client.ObjectKeyFromObject(cr), got))
+	assert.False(t, tokenRegeneration.Requested(got))
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tag
+
+import (
+	"context"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-gitea/apis/tag/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/tracing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/rossigee/provider-gitea/apis/v1beta1"
+)
+
+const (
+	errNotTag            = "managed resource is not a Tag custom resource"
+	errGetTag            = "failed to get tag"
+	errCreateTag         = "failed to create tag"
+	errDeleteTag         = "failed to delete tag"
+	errGetProviderConfig = "failed to get provider config"
+)
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient by:
+// 1. Getting the provider config
+// 2. Creating a Gitea API client
+// 3. Returning an external client wrapping the API client
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v2.Tag)
+	if !ok {
+		return nil, errors.New(errNotTag)
+	}
+
+	pcRef := cr.Spec.ProviderConfigReference
+	if pcRef == nil {
+		return nil, errors.New("providerConfigRef is required")
+	}
+
+	var pc v1beta1.ProviderConfig
+	if err := c.kube.Get(ctx, client.ObjectKey{
+		Namespace: cr.GetNamespace(),
+		Name:      pcRef.Name,
+	}, &pc); err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
+
+	conn, err := clients.NewClient(ctx, &pc, c.kube)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalClient{client: conn}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it matches the managed resource's desired state.
+type externalClient struct {
+	client clients.Client
+}
+
+func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	_, span := tracing.StartSpan(ctx, "tag.observe",
+		tracing.SpanAttrs("tag", tracing.ResourceName(mg), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.Tag)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTag)
+	}
+
+	owner, repo, err := clients.SplitRepository(cr.Spec.ForProvider.Repository)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetTag)
+	}
+
+	t, err := e.client.GetRepositoryTag(ctx, owner, repo, cr.Spec.ForProvider.Name)
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetTag)
+	}
+
+	cr.Status.AtProvider = v2.TagObservation{
+		Name:       &t.Name,
+		ID:         &t.ID,
+		Message:    &t.Message,
+		ZipballURL: &t.ZipballURL,
+		TarballURL: &t.TarballURL,
+	}
+	if t.Commit != nil {
+		cr.Status.AtProvider.CommitSHA = &t.Commit.SHA
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	// Gitea cannot edit tags, and the target and message are immutable in
+	// the spec, so an existing tag is always up to date.
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	_, span := tracing.StartSpan(ctx, "tag.create",
+		tracing.SpanAttrs("tag", tracing.ResourceName(mg), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.Tag)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTag)
+	}
+
+	owner, repo, err := clients.SplitRepository(cr.Spec.ForProvider.Repository)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateTag)
+	}
+
+	req := &clients.CreateTagRequest{TagName: cr.Spec.ForProvider.Name}
+	if cr.Spec.ForProvider.Target != nil {
+		req.Target = *cr.Spec.ForProvider.Target
+	}
+	if cr.Spec.ForProvider.Message != nil {
+		req.Message = *cr.Spec.ForProvider.Message
+	}
+
+	_, err = e.client.CreateRepositoryTag(ctx, owner, repo, req)
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateTag)
+}
+
+func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	_, span := tracing.StartSpan(ctx, "tag.delete",
+		tracing.SpanAttrs("tag", tracing.ResourceName(mg), "delete")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.Tag)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotTag)
+	}
+
+	owner, repo, err := clients.SplitRepository(cr.Spec.ForProvider.Repository)
+	if err != nil {
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteTag)
+	}
+
+	err = e.client.DeleteRepositoryTag(ctx, owner, repo, cr.Spec.ForProvider.Name)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteTag)
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *externalClient) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// Setup adds a controller that reconciles Tag managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v2.TagKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v2.TagGroupVersionKind),
+		managed.WithExternalConnector(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v2.Tag{}).
+		Complete(r)
+}
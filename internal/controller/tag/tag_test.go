@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tag
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-gitea/apis/tag/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTagClient struct {
+	testutil.NoopClient
+	tag     *clients.RepositoryTag
+	created *clients.CreateTagRequest
+}
+
+func (m *mockTagClient) GetRepositoryTag(ctx context.Context, owner, repo, tag string) (*clients.RepositoryTag, error) {
+	if m.tag == nil {
+		return nil, fmt.Errorf("API request failed with status 404: not found")
+	}
+	return m.tag, nil
+}
+
+func (m *mockTagClient) CreateRepositoryTag(ctx context.Context, owner, repo string, req *clients.CreateTagRequest) (*clients.RepositoryTag, error) {
+	m.created = req
+	return &clients.RepositoryTag{Name: req.TagName}, nil
+}
+
+func newTag(p v2.TagParameters) *v2.Tag {
+	p.Repository = "testorg/testrepo"
+	p.Name = "v1.0.0"
+	return &v2.Tag{Spec: v2.TagSpec{ForProvider: p}}
+}
+
+func TestObserve(t *testing.T) {
+	t.Run("missing tag does not exist", func(t *testing.T) {
+		ec := &externalClient{client: &mockTagClient{}}
+
+		obs, err := ec.Observe(context.Background(), newTag(v2.TagParameters{}))
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+
+	t.Run("existing tag is available", func(t *testing.T) {
+		ec := &externalClient{client: &mockTagClient{tag: &clients.RepositoryTag{
+			Name:    "v1.0.0",
+			ID:      "tag123",
+			Message: "First release",
+			Commit:  &clients.TagCommit{SHA: "abc123"},
+		}}}
+		cr := newTag(v2.TagParameters{})
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.Equal(t, "abc123", *cr.Status.AtProvider.CommitSHA)
+		assert.Equal(t, xpv1.ReasonAvailable, cr.GetCondition(xpv1.TypeReady).Reason)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("annotated tag", func(t *testing.T) {
+		m := &mockTagClient{}
+		ec := &externalClient{client: m}
+		target, message := "main", "First release"
+
+		_, err := ec.Create(context.Background(), newTag(v2.TagParameters{Target: &target, Message: &message}))
+		require.NoError(t, err)
+		assert.Equal(t, "v1.0.0", m.created.TagName)
+		assert.Equal(t, "main", m.created.Target)
+		assert.Equal(t, "First release", m.created.Message)
+	})
+
+	t.Run("lightweight tag", func(t *testing.T) {
+		m := &mockTagClient{}
+		ec := &externalClient{client: m}
+
+		_, err := ec.Create(context.Background(), newTag(v2.TagParameters{}))
+		require.NoError(t, err)
+		assert.Empty(t, m.created.Message)
+	})
+}
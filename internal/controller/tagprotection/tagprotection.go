@@ -0,0 +1,261 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tagprotection
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-gitea/apis/tagprotection/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/lists"
+	"github.com/rossigee/provider-gitea/internal/tracing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/rossigee/provider-gitea/apis/v1beta1"
+)
+
+const (
+	errNotTagProtection    = "managed resource is not a TagProtection custom resource"
+	errGetTagProtection    = "failed to get tag protection"
+	errListTagProtections  = "failed to list tag protections"
+	errCreateTagProtection = "failed to create tag protection"
+	errUpdateTagProtection = "failed to update tag protection"
+	errDeleteTagProtection = "failed to delete tag protection"
+	errGetProviderConfig   = "failed to get provider config"
+)
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient by:
+// 1. Getting the provider config
+// 2. Creating a Gitea API client
+// 3. Returning an external client wrapping the API client
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v2.TagProtection)
+	if !ok {
+		return nil, errors.New(errNotTagProtection)
+	}
+
+	pcRef := cr.Spec.ProviderConfigReference
+	if pcRef == nil {
+		return nil, errors.New("providerConfigRef is required")
+	}
+
+	var pc v1beta1.ProviderConfig
+	if err := c.kube.Get(ctx, client.ObjectKey{
+		Namespace: cr.GetNamespace(),
+		Name:      pcRef.Name,
+	}, &pc); err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
+
+	conn, err := clients.NewClient(ctx, &pc, c.kube)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalClient{client: conn}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it matches the managed resource's desired state.
+type externalClient struct {
+	client clients.Client
+}
+
+func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	_, span := tracing.StartSpan(ctx, "tagprotection.observe",
+		tracing.SpanAttrs("tagprotection", tracing.ResourceName(mg), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.TagProtection)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTagProtection)
+	}
+
+	p := cr.Spec.ForProvider
+	var tp *clients.TagProtection
+	adopted := false
+
+	// Gitea identifies tag protections by ID. Until the resource has one, an
+	// existing rule with the same pattern is adopted rather than duplicated.
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		rules, err := e.client.ListTagProtections(ctx, p.Repository)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errListTagProtections)
+		}
+		for i := range rules {
+			if rules[i].NamePattern == p.NamePattern {
+				tp = &rules[i]
+				break
+			}
+		}
+		if tp == nil {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		meta.SetExternalName(cr, strconv.FormatInt(tp.ID, 10))
+		adopted = true
+	} else {
+		tp, err = e.client.GetTagProtection(ctx, p.Repository, id)
+		if err != nil {
+			if strings.Contains(err.Error(), "404") {
+				return managed.ExternalObservation{ResourceExists: false}, nil
+			}
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetTagProtection)
+		}
+	}
+
+	cr.Status.AtProvider = v2.TagProtectionObservation{
+		ID:                 &tp.ID,
+		NamePattern:        &tp.NamePattern,
+		WhitelistUsernames: tp.WhitelistUsernames,
+		WhitelistTeams:     tp.WhitelistTeams,
+		CreatedAt:          &tp.CreatedAt,
+		UpdatedAt:          &tp.UpdatedAt,
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	drift := diff(p, tp)
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        len(drift) == 0,
+		ResourceLateInitialized: adopted,
+		Diff:                    strings.Join(drift, ", "),
+	}, nil
+}
+
+func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	_, span := tracing.StartSpan(ctx, "tagprotection.create",
+		tracing.SpanAttrs("tagprotection", tracing.ResourceName(mg), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.TagProtection)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTagProtection)
+	}
+
+	p := cr.Spec.ForProvider
+	tp, err := e.client.CreateTagProtection(ctx, p.Repository, &clients.CreateTagProtectionRequest{
+		NamePattern:        p.NamePattern,
+		WhitelistUsernames: p.WhitelistUsernames,
+		WhitelistTeams:     p.WhitelistTeams,
+	})
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateTagProtection)
+	}
+
+	meta.SetExternalName(cr, strconv.FormatInt(tp.ID, 10))
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	_, span := tracing.StartSpan(ctx, "tagprotection.update",
+		tracing.SpanAttrs("tagprotection", tracing.ResourceName(mg), "update")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.TagProtection)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTagProtection)
+	}
+
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateTagProtection)
+	}
+
+	p := cr.Spec.ForProvider
+	_, err = e.client.UpdateTagProtection(ctx, p.Repository, id, &clients.UpdateTagProtectionRequest{
+		NamePattern:        &p.NamePattern,
+		WhitelistUsernames: lists.OrEmpty(p.WhitelistUsernames),
+		WhitelistTeams:     lists.OrEmpty(p.WhitelistTeams),
+	})
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateTagProtection)
+}
+
+func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	_, span := tracing.StartSpan(ctx, "tagprotection.delete",
+		tracing.SpanAttrs("tagprotection", tracing.ResourceName(mg), "delete")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.TagProtection)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotTagProtection)
+	}
+
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalDelete{}, nil
+	}
+
+	err = e.client.DeleteTagProtection(ctx, cr.Spec.ForProvider.Repository, id)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteTagProtection)
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *externalClient) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// diff returns the spec fields whose value differs from the rule in Gitea.
+// Whitelists are compared as sets, with an unset list meaning an empty one.
+func diff(p v2.TagProtectionParameters, tp *clients.TagProtection) []string {
+	var d []string
+	if p.NamePattern != tp.NamePattern {
+		d = append(d, "namePattern")
+	}
+	if !lists.SameSet(p.WhitelistUsernames, tp.WhitelistUsernames) {
+		d = append(d, "whitelistUsernames")
+	}
+	if !lists.SameSet(p.WhitelistTeams, tp.WhitelistTeams) {
+		d = append(d, "whitelistTeams")
+	}
+	return d
+}
+
+// Setup adds a controller that reconciles TagProtection managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v2.TagProtectionKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v2.TagProtectionGroupVersionKind),
+		managed.WithExternalConnector(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v2.TagProtection{}).
+		Complete(r)
+}
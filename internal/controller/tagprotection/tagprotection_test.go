@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tagprotection
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-gitea/apis/tagprotection/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type mockTagProtectionClient struct {
+	testutil.NoopClient
+	rules   []clients.TagProtection
+	created *clients.CreateTagProtectionRequest
+	updated *clients.UpdateTagProtectionRequest
+}
+
+func (m *mockTagProtectionClient) ListTagProtections(ctx context.Context, repository string) ([]clients.TagProtection, error) {
+	return m.rules, nil
+}
+
+func (m *mockTagProtectionClient) GetTagProtection(ctx context.Context, repository string, id int64) (*clients.TagProtection, error) {
+	for i := range m.rules {
+		if m.rules[i].ID == id {
+			return &m.rules[i], nil
+		}
+	}
+	return nil, fmt.Errorf("API request failed with status 404: not found")
+}
+
+func (m *mockTagProtectionClient) CreateTagProtection(ctx context.Context, repository string, req *clients.CreateTagProtectionRequest) (*clients.TagProtection, error) {
+	m.created = req
+	return &clients.TagProtection{ID: 7, NamePattern: req.NamePattern}, nil
+}
+
+func (m *mockTagProtectionClient) UpdateTagProtection(ctx context.Context, repository string, id int64, req *clients.UpdateTagProtectionRequest) (*clients.TagProtection, error) {
+	m.updated = req
+	return &clients.TagProtection{ID: id}, nil
+}
+
+func newTagProtection(externalName string, teams ...string) *v2.TagProtection {
+	cr := &v2.TagProtection{
+		ObjectMeta: metav1.ObjectMeta{Name: "release-tags"},
+		Spec: v2.TagProtectionSpec{
+			ForProvider: v2.TagProtectionParameters{
+				Repository:     "testorg/testrepo",
+				NamePattern:    "v*",
+				WhitelistTeams: teams,
+			},
+		},
+	}
+	meta.SetExternalName(cr, externalName)
+	return cr
+}
+
+func TestObserve(t *testing.T) {
+	t.Run("no matching rule does not exist", func(t *testing.T) {
+		ec := &externalClient{client: &mockTagProtectionClient{
+			rules: []clients.TagProtection{{ID: 1, NamePattern: "release-*"}},
+		}}
+
+		obs, err := ec.Observe(context.Background(), newTagProtection("release-tags"))
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+
+	t.Run("adopts rule with same pattern", func(t *testing.T) {
+		ec := &externalClient{client: &mockTagProtectionClient{
+			rules: []clients.TagProtection{{ID: 3, NamePattern: "v*", WhitelistTeams: []string{"release-team"}}},
+		}}
+		cr := newTagProtection("release-tags", "release-team")
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.True(t, obs.ResourceLateInitialized)
+		assert.Equal(t, "3", meta.GetExternalName(cr))
+		assert.Equal(t, xpv1.ReasonAvailable, cr.GetCondition(xpv1.TypeReady).Reason)
+	})
+
+	t.Run("whitelist drift", func(t *testing.T) {
+		ec := &externalClient{client: &mockTagProtectionClient{
+			rules: []clients.TagProtection{{ID: 3, NamePattern: "v*", WhitelistUsernames: []string{"bob"}}},
+		}}
+
+		obs, err := ec.Observe(context.Background(), newTagProtection("3", "release-team"))
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate)
+		assert.Equal(t, "whitelistUsernames, whitelistTeams", obs.Diff)
+	})
+
+	t.Run("deleted rule does not exist", func(t *testing.T) {
+		ec := &externalClient{client: &mockTagProtectionClient{}}
+
+		obs, err := ec.Observe(context.Background(), newTagProtection("3"))
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	m := &mockTagProtectionClient{}
+	ec := &externalClient{client: m}
+	cr := newTagProtection("release-tags", "release-team")
+
+	_, err := ec.Create(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, "v*", m.created.NamePattern)
+	assert.Equal(t, "7", meta.GetExternalName(cr))
+}
+
+func TestUpdateClearsWhitelists(t *testing.T) {
+	m := &mockTagProtectionClient{}
+	ec := &externalClient{client: m}
+
+	_, err := ec.Update(context.Background(), newTagProtection("3", "release-team"))
+	require.NoError(t, err)
+	assert.NotNil(t, m.updated.WhitelistUsernames)
+	assert.Empty(t, m.updated.WhitelistUsernames)
+	assert.Equal(t, []string{"release-team"}, m.updated.WhitelistTeams)
+}
@@ -0,0 +1,498 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package team
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-gitea/apis/team/v2"
+	teammembershipv2 "github.com/rossigee/provider-gitea/apis/teammembership/v2"
+	teamrepositoryv2 "github.com/rossigee/provider-gitea/apis/teamrepository/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/lists"
+	"github.com/rossigee/provider-gitea/internal/teams"
+	"github.com/rossigee/provider-gitea/internal/tracing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/rossigee/provider-gitea/apis/v1beta1"
+)
+
+const (
+	errNotTeam              = "managed resource is not a Team custom resource"
+	errGetTeam              = "failed to get team"
+	errListTeams            = "failed to list organization teams"
+	errListMembers          = "failed to list team members"
+	errListRepositories     = "failed to list team repositories"
+	errListTeamMemberships  = "failed to list team memberships"
+	errListTeamRepositories = "failed to list team repositories resources"
+	errCreateTeam           = "failed to create team"
+	errUpdateTeam           = "failed to update team"
+	errAddMember            = "failed to add team member"
+	errRemoveMember         = "failed to remove team member"
+	errAddRepository        = "failed to add team repository"
+	errRemoveRepository     = "failed to remove team repository"
+	errDeleteTeam           = "failed to delete team"
+	errGetProviderConfig    = "failed to get provider config"
+)
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient by:
+// 1. Getting the provider config
+// 2. Creating a Gitea API client
+// 3. Returning an external client wrapping the API client
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v2.Team)
+	if !ok {
+		return nil, errors.New(errNotTeam)
+	}
+
+	pcRef := cr.Spec.ProviderConfigReference
+	if pcRef == nil {
+		return nil, errors.New("providerConfigRef is required")
+	}
+
+	var pc v1beta1.ProviderConfig
+	if err := c.kube.Get(ctx, client.ObjectKey{
+		Namespace: cr.GetNamespace(),
+		Name:      pcRef.Name,
+	}, &pc); err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
+
+	conn, err := clients.NewClient(ctx, &pc, c.kube)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalClient{client: conn, kube: c.kube}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it matches the managed resource's desired state.
+type externalClient struct {
+	client clients.Client
+	kube   client.Client
+}
+
+// state is the membership of a team in Gitea, alongside the members and
+// repositories the resources in the team's namespace declare for it. All
+// names are lower case.
+type state struct {
+	team         *clients.Team
+	members      []string
+	repositories []string
+
+	declaredMembers      map[string]bool
+	declaredRepositories map[string]bool
+}
+
+func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	_, span := tracing.StartSpan(ctx, "team.observe",
+		tracing.SpanAttrs("team", tracing.ResourceName(mg), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.Team)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTeam)
+	}
+
+	p := cr.Spec.ForProvider
+	var t *clients.Team
+	adopted := false
+
+	// Gitea identifies teams by ID. Until the resource has one, an existing
+	// team with the same name is adopted rather than duplicated.
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		list, err := e.client.ListOrganizationTeams(ctx, p.Organization)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errListTeams)
+		}
+		for _, candidate := range list {
+			if strings.EqualFold(candidate.Name, p.Name) {
+				t = candidate
+				break
+			}
+		}
+		if t == nil {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		meta.SetExternalName(cr, strconv.FormatInt(t.ID, 10))
+		adopted = true
+	} else {
+		t, err = e.client.GetTeam(ctx, id)
+		if err != nil {
+			if strings.Contains(err.Error(), "404") {
+				return managed.ExternalObservation{ResourceExists: false}, nil
+			}
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetTeam)
+		}
+	}
+
+	s, err := e.state(ctx, cr, t)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.Status.AtProvider = v2.TeamObservation{
+		ID:             &t.ID,
+		OrganizationID: &t.Organization.ID,
+		Members:        s.members,
+		Repositories:   s.repositories,
+		UnitsMap:       t.UnitsMap,
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	drift := diff(p, s)
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        len(drift) == 0,
+		ResourceLateInitialized: adopted,
+		Diff:                    strings.Join(drift, ", "),
+	}, nil
+}
+
+func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	_, span := tracing.StartSpan(ctx, "team.create",
+		tracing.SpanAttrs("team", tracing.ResourceName(mg), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.Team)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTeam)
+	}
+
+	p := cr.Spec.ForProvider
+	req := &clients.CreateTeamRequest{
+		Name:     p.Name,
+		Units:    p.Units,
+		UnitsMap: p.UnitsMap,
+	}
+	if p.Description != nil {
+		req.Description = *p.Description
+	}
+	if p.Permission != nil {
+		req.Permission = *p.Permission
+	}
+	if p.CanCreateOrgRepo != nil {
+		req.CanCreateOrgRepo = *p.CanCreateOrgRepo
+	}
+	if p.IncludesAllRepositories != nil {
+		req.IncludesAllRepositories = *p.IncludesAllRepositories
+	}
+
+	t, err := e.client.CreateTeam(ctx, p.Organization, req)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateTeam)
+	}
+
+	meta.SetExternalName(cr, strconv.FormatInt(t.ID, 10))
+
+	// Members and repositories are added by the update that follows the
+	// next observation.
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	_, span := tracing.StartSpan(ctx, "team.update",
+		tracing.SpanAttrs("team", tracing.ResourceName(mg), "update")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.Team)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTeam)
+	}
+
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateTeam)
+	}
+
+	p := cr.Spec.ForProvider
+	t, err := e.client.UpdateTeam(ctx, id, &clients.UpdateTeamRequest{
+		Name:                    &p.Name,
+		Description:             p.Description,
+		Permission:              p.Permission,
+		CanCreateOrgRepo:        p.CanCreateOrgRepo,
+		IncludesAllRepositories: p.IncludesAllRepositories,
+		Units:                   p.Units,
+		UnitsMap:                p.UnitsMap,
+	})
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateTeam)
+	}
+
+	s, err := e.state(ctx, cr, t)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	org := t.Organization.Username
+	if org == "" {
+		org = p.Organization
+	}
+
+	for _, m := range missing(p.Members, s.members) {
+		if err := e.client.AddTeamMember(ctx, id, m); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrapf(err, "%s %s", errAddMember, m)
+		}
+	}
+	for _, r := range missing(p.Repositories, s.repositories) {
+		if err := e.client.AddTeamRepository(ctx, id, org, r); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrapf(err, "%s %s", errAddRepository, r)
+		}
+	}
+
+	if !isTrue(p.Exclusive) {
+		return managed.ExternalUpdate{}, nil
+	}
+	for _, m := range extra(s.members, s.declaredMembers) {
+		err := e.client.RemoveTeamMember(ctx, id, m)
+		if err != nil && !strings.Contains(err.Error(), "404") {
+			return managed.ExternalUpdate{}, errors.Wrapf(err, "%s %s", errRemoveMember, m)
+		}
+	}
+	if isTrue(p.IncludesAllRepositories) {
+		return managed.ExternalUpdate{}, nil
+	}
+	for _, r := range extra(s.repositories, s.declaredRepositories) {
+		err := e.client.RemoveTeamRepository(ctx, id, org, r)
+		if err != nil && !strings.Contains(err.Error(), "404") {
+			return managed.ExternalUpdate{}, errors.Wrapf(err, "%s %s", errRemoveRepository, r)
+		}
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	_, span := tracing.StartSpan(ctx, "team.delete",
+		tracing.SpanAttrs("team", tracing.ResourceName(mg), "delete")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.Team)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotTeam)
+	}
+
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalDelete{}, nil
+	}
+
+	err = e.client.DeleteTeam(ctx, id)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteTeam)
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *externalClient) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// state reads the team's members and repositories from Gitea and collects
+// the ones declared for it by its spec and by the TeamMembership and
+// TeamRepository resources in its namespace.
+func (e *externalClient) state(ctx context.Context, cr *v2.Team, t *clients.Team) (*state, error) {
+	s := &state{
+		team:                 t,
+		declaredMembers:      map[string]bool{},
+		declaredRepositories: map[string]bool{},
+	}
+
+	members, err := e.client.ListTeamMembers(ctx, t.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, errListMembers)
+	}
+	for _, m := range members {
+		s.members = append(s.members, strings.ToLower(m.Username))
+	}
+	repos, err := e.client.ListTeamRepositories(ctx, t.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, errListRepositories)
+	}
+	for _, r := range repos {
+		s.repositories = append(s.repositories, strings.ToLower(r.Name))
+	}
+	sort.Strings(s.members)
+	sort.Strings(s.repositories)
+
+	p := cr.Spec.ForProvider
+	for _, m := range p.Members {
+		s.declaredMembers[strings.ToLower(m)] = true
+	}
+	for _, r := range p.Repositories {
+		s.declaredRepositories[strings.ToLower(r)] = true
+	}
+
+	// The other resources only matter when undeclared entries are pruned.
+	if !isTrue(p.Exclusive) || e.kube == nil {
+		return s, nil
+	}
+
+	memberships := &teammembershipv2.TeamMembershipList{}
+	if err := e.kube.List(ctx, memberships, client.InNamespace(cr.GetNamespace())); err != nil {
+		return nil, errors.Wrap(err, errListTeamMemberships)
+	}
+	for _, m := range memberships.Items {
+		mp := m.Spec.ForProvider
+		if m.GetDeletionTimestamp() == nil && teams.Targets(cr, mp.TeamRef, mp.Organization, mp.Team) {
+			s.declaredMembers[strings.ToLower(mp.Username)] = true
+		}
+	}
+
+	repositories := &teamrepositoryv2.TeamRepositoryList{}
+	if err := e.kube.List(ctx, repositories, client.InNamespace(cr.GetNamespace())); err != nil {
+		return nil, errors.Wrap(err, errListTeamRepositories)
+	}
+	for _, r := range repositories.Items {
+		rp := r.Spec.ForProvider
+		if r.GetDeletionTimestamp() != nil || !teams.Targets(cr, rp.TeamRef, rp.Organization, rp.Team) {
+			continue
+		}
+		_, name, err := teams.Repository(ctx, e.kube, cr.GetNamespace(), rp.RepositoryRef, rp.Repository, p.Organization)
+		if err != nil {
+			if teams.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		s.declaredRepositories[strings.ToLower(name)] = true
+	}
+
+	return s, nil
+}
+
+// diff returns the spec fields whose value differs from the team in Gitea.
+// Optional settings are only compared when set.
+func diff(p v2.TeamParameters, s *state) []string {
+	t := s.team
+	var d []string
+	if !strings.EqualFold(p.Name, t.Name) {
+		d = append(d, "name")
+	}
+	if p.Description != nil && *p.Description != t.Description {
+		d = append(d, "description")
+	}
+	// Gitea derives the permission and units from a units map.
+	if len(p.UnitsMap) == 0 && p.Permission != nil && *p.Permission != t.Permission {
+		d = append(d, "permission")
+	}
+	if p.CanCreateOrgRepo != nil && *p.CanCreateOrgRepo != t.CanCreateOrgRepo {
+		d = append(d, "canCreateOrgRepo")
+	}
+	if p.IncludesAllRepositories != nil && *p.IncludesAllRepositories != t.IncludesAllRepositories {
+		d = append(d, "includesAllRepositories")
+	}
+	if len(p.UnitsMap) == 0 && len(p.Units) > 0 && !lists.SameSet(p.Units, t.Units) {
+		d = append(d, "units")
+	}
+	d = append(d, unitsDiff(p.UnitsMap, t.UnitsMap)...)
+
+	exclusive := isTrue(p.Exclusive)
+	if len(missing(p.Members, s.members)) > 0 || exclusive && len(extra(s.members, s.declaredMembers)) > 0 {
+		d = append(d, "members")
+	}
+	pruneRepositories := exclusive && !isTrue(p.IncludesAllRepositories)
+	if len(missing(p.Repositories, s.repositories)) > 0 || pruneRepositories && len(extra(s.repositories, s.declaredRepositories)) > 0 {
+		d = append(d, "repositories")
+	}
+	return d
+}
+
+// unitsDiff returns the units whose access level differs from Gitea, where a
+// unit Gitea does not report has no access.
+func unitsDiff(want, have map[string]string) []string {
+	units := make([]string, 0, len(want))
+	for u := range want {
+		units = append(units, u)
+	}
+	sort.Strings(units)
+
+	var d []string
+	for _, u := range units {
+		access, ok := have[u]
+		if !ok {
+			access = "none"
+		}
+		if want[u] != access {
+			d = append(d, "unitsMap["+u+"]")
+		}
+	}
+	return d
+}
+
+// missing returns the names in want that are not in have, which is lower case.
+func missing(want, have []string) []string {
+	present := map[string]bool{}
+	for _, h := range have {
+		present[h] = true
+	}
+	var m []string
+	for _, w := range want {
+		if !present[strings.ToLower(w)] {
+			m = append(m, w)
+		}
+	}
+	return m
+}
+
+// extra returns the names in have that are not declared.
+func extra(have []string, declared map[string]bool) []string {
+	var x []string
+	for _, h := range have {
+		if !declared[h] {
+			x = append(x, h)
+		}
+	}
+	return x
+}
+
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
+
+// Setup adds a controller that reconciles Team managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v2.TeamKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v2.TeamGroupVersionKind),
+		managed.WithExternalConnector(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v2.Team{}).
+		Complete(r)
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package team
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-gitea/apis/team/v2"
+	teammembershipv2 "github.com/rossigee/provider-gitea/apis/teammembership/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type mockTeamClient struct {
+	testutil.NoopClient
+	team         *clients.Team
+	members      []string
+	repositories []string
+
+	added   []string
+	removed []string
+	updated *clients.UpdateTeamRequest
+}
+
+func (m *mockTeamClient) ListOrganizationTeams(ctx context.Context, org string) ([]*clients.Team, error) {
+	if m.team == nil {
+		return nil, nil
+	}
+	return []*clients.Team{m.team}, nil
+}
+
+func (m *mockTeamClient) GetTeam(ctx context.Context, teamID int64) (*clients.Team, error) {
+	if m.team == nil || m.team.ID != teamID {
+		return nil, fmt.Errorf("API request failed with status 404: not found")
+	}
+	return m.team, nil
+}
+
+func (m *mockTeamClient) UpdateTeam(ctx context.Context, teamID int64, req *clients.UpdateTeamRequest) (*clients.Team, error) {
+	m.updated = req
+	return m.team, nil
+}
+
+func (m *mockTeamClient) ListTeamMembers(ctx context.Context, teamID int64) ([]*clients.User, error) {
+	var users []*clients.User
+	for _, name := range m.members {
+		users = append(users, &clients.User{Username: name})
+	}
+	return users, nil
+}
+
+func (m *mockTeamClient) ListTeamRepositories(ctx context.Context, teamID int64) ([]*clients.Repository, error) {
+	var repos []*clients.Repository
+	for _, name := range m.repositories {
+		repos = append(repos, &clients.Repository{Name: name})
+	}
+	return repos, nil
+}
+
+func (m *mockTeamClient) AddTeamMember(ctx context.Context, teamID int64, username string) error {
+	m.added = append(m.added, "member:"+username)
+	return nil
+}
+
+func (m *mockTeamClient) RemoveTeamMember(ctx context.Context, teamID int64, username string) error {
+	m.removed = append(m.removed, "member:"+username)
+	return nil
+}
+
+func (m *mockTeamClient) AddTeamRepository(ctx context.Context, teamID int64, org, repo string) error {
+	m.added = append(m.added, "repo:"+org+"/"+repo)
+	return nil
+}
+
+func (m *mockTeamClient) RemoveTeamRepository(ctx context.Context, teamID int64, org, repo string) error {
+	m.removed = append(m.removed, "repo:"+org+"/"+repo)
+	return nil
+}
+
+func newTeam(externalName string, members ...string) *v2.Team {
+	cr := &v2.Team{
+		ObjectMeta: metav1.ObjectMeta{Name: "developers", Namespace: "default"},
+		Spec: v2.TeamSpec{
+			ForProvider: v2.TeamParameters{
+				Name:         "Developers",
+				Organization: "testorg",
+				Members:      members,
+			},
+		},
+	}
+	meta.SetExternalName(cr, externalName)
+	return cr
+}
+
+func giteaTeam() *clients.Team {
+	t := &clients.Team{ID: 5, Name: "developers"}
+	t.Organization.ID = 1
+	t.Organization.Username = "testorg"
+	return t
+}
+
+func TestObserve(t *testing.T) {
+	t.Run("no team with the name does not exist", func(t *testing.T) {
+		e := &externalClient{client: &mockTeamClient{}}
+		obs, err := e.Observe(context.Background(), newTeam("developers"))
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+
+	t.Run("adopts a team with the same name", func(t *testing.T) {
+		cr := newTeam("developers")
+		e := &externalClient{client: &mockTeamClient{team: giteaTeam()}}
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.True(t, obs.ResourceLateInitialized)
+		assert.Equal(t, "5", meta.GetExternalName(cr))
+		assert.Equal(t, xpv1.ReasonAvailable, cr.GetCondition(xpv1.TypeReady).Reason)
+	})
+
+	t.Run("missing member is drift", func(t *testing.T) {
+		cr := newTeam("5", "Alice", "bob")
+		e := &externalClient{client: &mockTeamClient{team: giteaTeam(), members: []string{"alice"}}}
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate)
+		assert.Equal(t, "members", obs.Diff)
+		assert.Equal(t, []string{"alice"}, cr.Status.AtProvider.Members)
+	})
+
+	t.Run("units map is compared unit by unit", func(t *testing.T) {
+		gt := giteaTeam()
+		gt.Permission = "write"
+		gt.UnitsMap = map[string]string{"repo.code": "write", "repo.issues": "write"}
+		permission := "read"
+		cr := newTeam("5")
+		cr.Spec.ForProvider.Permission = &permission
+		cr.Spec.ForProvider.UnitsMap = map[string]string{"repo.code": "write", "repo.issues": "read", "repo.wiki": "none"}
+
+		e := &externalClient{client: &mockTeamClient{team: gt}}
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate)
+		assert.Equal(t, "unitsMap[repo.issues]", obs.Diff)
+
+		cr.Spec.ForProvider.UnitsMap["repo.issues"] = "write"
+		obs, err = e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+	})
+
+	t.Run("extra member is only drift when exclusive", func(t *testing.T) {
+		m := &mockTeamClient{team: giteaTeam(), members: []string{"alice", "mallory"}}
+		cr := newTeam("5", "alice")
+		e := &externalClient{client: m}
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+
+		exclusive := true
+		cr.Spec.ForProvider.Exclusive = &exclusive
+		e.kube = fake.NewClientBuilder().WithScheme(testutil.Scheme(t)).Build()
+		obs, err = e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate)
+		assert.Equal(t, "members", obs.Diff)
+	})
+}
+
+func TestUpdateExclusive(t *testing.T) {
+	exclusive, all := true, true
+	cr := newTeam("5", "alice")
+	cr.Spec.ForProvider.Exclusive = &exclusive
+	cr.Spec.ForProvider.IncludesAllRepositories = &all
+	cr.Spec.ForProvider.Repositories = []string{"app"}
+
+	team := "developers"
+	org := "testorg"
+	membership := &teammembershipv2.TeamMembership{
+		ObjectMeta: metav1.ObjectMeta{Name: "carol", Namespace: "default"},
+		Spec: teammembershipv2.TeamMembershipSpec{
+			ForProvider: teammembershipv2.TeamMembershipParameters{
+				Organization: &org,
+				Team:         &team,
+				Username:     "Carol",
+			},
+		},
+	}
+
+	m := &mockTeamClient{
+		team:         giteaTeam(),
+		members:      []string{"carol", "mallory"},
+		repositories: []string{"legacy"},
+	}
+	e := &externalClient{
+		client: m,
+		kube:   fake.NewClientBuilder().WithScheme(testutil.Scheme(t)).WithObjects(membership).Build(),
+	}
+
+	_, err := e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	require.NotNil(t, m.updated)
+	assert.Equal(t, []string{"member:alice", "repo:testorg/app"}, m.added)
+	// carol is declared by a TeamMembership and the team includes all
+	// repositories, so only mallory is removed.
+	assert.Equal(t, []string{"member:mallory"}, m.removed)
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teammembership
+
+import (
+	"context"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-gitea/apis/teammembership/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/teams"
+	"github.com/rossigee/provider-gitea/internal/tracing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/rossigee/provider-gitea/apis/v1beta1"
+)
+
+const (
+	errNotTeamMembership = "managed resource is not a TeamMembership custom resource"
+	errResolveTeam       = "failed to resolve team"
+	errGetMember         = "failed to get team member"
+	errAddMember         = "failed to add team member"
+	errRemoveMember      = "failed to remove team member"
+	errGetProviderConfig = "failed to get provider config"
+)
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient by:
+// 1. Getting the provider config
+// 2. Creating a Gitea API client
+// 3. Returning an external client wrapping the API client
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v2.TeamMembership)
+	if !ok {
+		return nil, errors.New(errNotTeamMembership)
+	}
+
+	pcRef := cr.Spec.ProviderConfigReference
+	if pcRef == nil {
+		return nil, errors.New("providerConfigRef is required")
+	}
+
+	var pc v1beta1.ProviderConfig
+	if err := c.kube.Get(ctx, client.ObjectKey{
+		Namespace: cr.GetNamespace(),
+		Name:      pcRef.Name,
+	}, &pc); err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
+
+	conn, err := clients.NewClient(ctx, &pc, c.kube)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalClient{client: conn, kube: c.kube}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it matches the managed resource's desired state.
+type externalClient struct {
+	client clients.Client
+	kube   client.Client
+}
+
+func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	_, span := tracing.StartSpan(ctx, "teammembership.observe",
+		tracing.SpanAttrs("teammembership", tracing.ResourceName(mg), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.TeamMembership)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTeamMembership)
+	}
+
+	t, err := e.team(ctx, cr)
+	if err != nil {
+		if teams.IsNotFound(err) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errResolveTeam)
+	}
+
+	u, err := e.client.GetTeamMember(ctx, t.ID, cr.Spec.ForProvider.Username)
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetMember)
+	}
+
+	cr.Status.AtProvider = v2.TeamMembershipObservation{
+		TeamID:       &t.ID,
+		Organization: &t.Organization.Username,
+		Team:         &t.Name,
+		FullName:     &u.FullName,
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	// Membership is either present or not; there is nothing to update.
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	_, span := tracing.StartSpan(ctx, "teammembership.create",
+		tracing.SpanAttrs("teammembership", tracing.ResourceName(mg), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.TeamMembership)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTeamMembership)
+	}
+
+	t, err := e.team(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errResolveTeam)
+	}
+
+	err = e.client.AddTeamMember(ctx, t.ID, cr.Spec.ForProvider.Username)
+	return managed.ExternalCreation{}, errors.Wrap(err, errAddMember)
+}
+
+func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	_, span := tracing.StartSpan(ctx, "teammembership.delete",
+		tracing.SpanAttrs("teammembership", tracing.ResourceName(mg), "delete")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.TeamMembership)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotTeamMembership)
+	}
+
+	t, err := e.team(ctx, cr)
+	if err != nil {
+		if teams.IsNotFound(err) {
+			return managed.ExternalDelete{}, nil
+		}
+		return managed.ExternalDelete{}, errors.Wrap(err, errResolveTeam)
+	}
+
+	err = e.client.RemoveTeamMember(ctx, t.ID, cr.Spec.ForProvider.Username)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return managed.ExternalDelete{}, errors.Wrap(err, errRemoveMember)
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *externalClient) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+func (e *externalClient) team(ctx context.Context, cr *v2.TeamMembership) (*clients.Team, error) {
+	p := cr.Spec.ForProvider
+	return teams.Resolve(ctx, e.kube, e.client, cr.GetNamespace(), p.TeamRef, p.Organization, p.Team)
+}
+
+// Setup adds a controller that reconciles TeamMembership managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v2.TeamMembershipKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v2.TeamMembershipGroupVersionKind),
+		managed.WithExternalConnector(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v2.TeamMembership{}).
+		Complete(r)
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teammembership
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	teamv2 "github.com/rossigee/provider-gitea/apis/team/v2"
+	"github.com/rossigee/provider-gitea/apis/teammembership/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type mockTeamMembershipClient struct {
+	testutil.NoopClient
+	members map[string]bool
+	added   string
+	removed string
+}
+
+func (m *mockTeamMembershipClient) ListOrganizationTeams(ctx context.Context, org string) ([]*clients.Team, error) {
+	return []*clients.Team{team()}, nil
+}
+
+func (m *mockTeamMembershipClient) GetTeam(ctx context.Context, teamID int64) (*clients.Team, error) {
+	return team(), nil
+}
+
+func (m *mockTeamMembershipClient) GetTeamMember(ctx context.Context, teamID int64, username string) (*clients.User, error) {
+	if !m.members[username] {
+		return nil, fmt.Errorf("API request failed with status 404: not found")
+	}
+	return &clients.User{Username: username, FullName: "Alice Example"}, nil
+}
+
+func (m *mockTeamMembershipClient) AddTeamMember(ctx context.Context, teamID int64, username string) error {
+	m.added = username
+	return nil
+}
+
+func (m *mockTeamMembershipClient) RemoveTeamMember(ctx context.Context, teamID int64, username string) error {
+	m.removed = username
+	return nil
+}
+
+func team() *clients.Team {
+	t := &clients.Team{ID: 5, Name: "developers"}
+	t.Organization.Username = "testorg"
+	return t
+}
+
+func newMembership(teamName string) *v2.TeamMembership {
+	org := "testorg"
+	return &v2.TeamMembership{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice", Namespace: "default"},
+		Spec: v2.TeamMembershipSpec{
+			ForProvider: v2.TeamMembershipParameters{
+				Organization: &org,
+				Team:         &teamName,
+				Username:     "alice",
+			},
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	t.Run("missing team does not exist", func(t *testing.T) {
+		e := &externalClient{client: &mockTeamMembershipClient{}}
+		obs, err := e.Observe(context.Background(), newMembership("unknown"))
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+
+	t.Run("user not in team does not exist", func(t *testing.T) {
+		e := &externalClient{client: &mockTeamMembershipClient{}}
+		obs, err := e.Observe(context.Background(), newMembership("Developers"))
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+
+	t.Run("member exists", func(t *testing.T) {
+		cr := newMembership("Developers")
+		e := &externalClient{client: &mockTeamMembershipClient{members: map[string]bool{"alice": true}}}
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.Equal(t, int64(5), *cr.Status.AtProvider.TeamID)
+		assert.Equal(t, "Alice Example", *cr.Status.AtProvider.FullName)
+		assert.Equal(t, xpv1.ReasonAvailable, cr.GetCondition(xpv1.TypeReady).Reason)
+	})
+}
+
+func TestCreateWithTeamRef(t *testing.T) {
+
+	ref := &teamv2.Team{ObjectMeta: metav1.ObjectMeta{Name: "developers", Namespace: "default"}}
+	cr := &v2.TeamMembership{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice", Namespace: "default"},
+		Spec: v2.TeamMembershipSpec{
+			ForProvider: v2.TeamMembershipParameters{
+				TeamRef:  &xpv1.Reference{Name: "developers"},
+				Username: "alice",
+			},
+		},
+	}
+
+	m := &mockTeamMembershipClient{}
+	kube := fake.NewClientBuilder().WithScheme(testutil.Scheme(t)).WithObjects(ref).Build()
+	e := &externalClient{client: m, kube: kube}
+
+	// The referenced team has not been created in Gitea yet.
+	_, err := e.Create(context.Background(), cr)
+	require.Error(t, err)
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceExists)
+
+	require.NoError(t, kube.Get(context.Background(), client.ObjectKeyFromObject(ref), ref))
+	meta.SetExternalName(ref, "5")
+	require.NoError(t, kube.Update(context.Background(), ref))
+
+	_, err = e.Create(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", m.added)
+}
+
+func TestDelete(t *testing.T) {
+	m := &mockTeamMembershipClient{}
+	e := &externalClient{client: m}
+
+	_, err := e.Delete(context.Background(), newMembership("unknown"))
+	require.NoError(t, err)
+	assert.Empty(t, m.removed)
+
+	_, err = e.Delete(context.Background(), newMembership("Developers"))
+	require.NoError(t, err)
+	assert.Equal(t, "alice", m.removed)
+}
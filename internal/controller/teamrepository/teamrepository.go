@@ -0,0 +1,213 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teamrepository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-gitea/apis/teamrepository/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/teams"
+	"github.com/rossigee/provider-gitea/internal/tracing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/rossigee/provider-gitea/apis/v1beta1"
+)
+
+const (
+	errNotTeamRepository = "managed resource is not a TeamRepository custom resource"
+	errResolveTeam       = "failed to resolve team"
+	errResolveRepository = "failed to resolve repository"
+	errGetRepository     = "failed to get team repository"
+	errAddRepository     = "failed to add team repository"
+	errRemoveRepository  = "failed to remove team repository"
+	errGetProviderConfig = "failed to get provider config"
+)
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient by:
+// 1. Getting the provider config
+// 2. Creating a Gitea API client
+// 3. Returning an external client wrapping the API client
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v2.TeamRepository)
+	if !ok {
+		return nil, errors.New(errNotTeamRepository)
+	}
+
+	pcRef := cr.Spec.ProviderConfigReference
+	if pcRef == nil {
+		return nil, errors.New("providerConfigRef is required")
+	}
+
+	var pc v1beta1.ProviderConfig
+	if err := c.kube.Get(ctx, client.ObjectKey{
+		Namespace: cr.GetNamespace(),
+		Name:      pcRef.Name,
+	}, &pc); err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
+
+	conn, err := clients.NewClient(ctx, &pc, c.kube)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalClient{client: conn, kube: c.kube}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it matches the managed resource's desired state.
+type externalClient struct {
+	client clients.Client
+	kube   client.Client
+}
+
+func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	_, span := tracing.StartSpan(ctx, "teamrepository.observe",
+		tracing.SpanAttrs("teamrepository", tracing.ResourceName(mg), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.TeamRepository)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTeamRepository)
+	}
+
+	t, owner, name, err := e.resolve(ctx, cr)
+	if err != nil {
+		if teams.IsNotFound(err) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, err
+	}
+
+	r, err := e.client.GetTeamRepository(ctx, t.ID, owner, name)
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetRepository)
+	}
+
+	cr.Status.AtProvider = v2.TeamRepositoryObservation{
+		TeamID:       &t.ID,
+		Organization: &t.Organization.Username,
+		Team:         &t.Name,
+		Repository:   &r.FullName,
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	// Access is either granted or not; the team's permission applies to all
+	// of its repositories, so there is nothing to update.
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	_, span := tracing.StartSpan(ctx, "teamrepository.create",
+		tracing.SpanAttrs("teamrepository", tracing.ResourceName(mg), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.TeamRepository)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTeamRepository)
+	}
+
+	t, owner, name, err := e.resolve(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	err = e.client.AddTeamRepository(ctx, t.ID, owner, name)
+	return managed.ExternalCreation{}, errors.Wrap(err, errAddRepository)
+}
+
+func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	_, span := tracing.StartSpan(ctx, "teamrepository.delete",
+		tracing.SpanAttrs("teamrepository", tracing.ResourceName(mg), "delete")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.TeamRepository)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotTeamRepository)
+	}
+
+	t, owner, name, err := e.resolve(ctx, cr)
+	if err != nil {
+		if teams.IsNotFound(err) {
+			return managed.ExternalDelete{}, nil
+		}
+		return managed.ExternalDelete{}, err
+	}
+
+	err = e.client.RemoveTeamRepository(ctx, t.ID, owner, name)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return managed.ExternalDelete{}, errors.Wrap(err, errRemoveRepository)
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *externalClient) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// resolve returns the team and the owner and name of the repository. A
+// repository given by name belongs to the team's organization.
+func (e *externalClient) resolve(ctx context.Context, cr *v2.TeamRepository) (*clients.Team, string, string, error) {
+	p := cr.Spec.ForProvider
+	t, err := teams.Resolve(ctx, e.kube, e.client, cr.GetNamespace(), p.TeamRef, p.Organization, p.Team)
+	if err != nil {
+		return nil, "", "", errors.Wrap(err, errResolveTeam)
+	}
+	owner, name, err := teams.Repository(ctx, e.kube, cr.GetNamespace(), p.RepositoryRef, p.Repository, t.Organization.Username)
+	if err != nil {
+		return nil, "", "", errors.Wrap(err, errResolveRepository)
+	}
+	return t, owner, name, nil
+}
+
+// Setup adds a controller that reconciles TeamRepository managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v2.TeamRepositoryKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v2.TeamRepositoryGroupVersionKind),
+		managed.WithExternalConnector(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v2.TeamRepository{}).
+		Complete(r)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teamrepository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	repositoryv2 "github.com/rossigee/provider-gitea/apis/repository/v2"
+	"github.com/rossigee/provider-gitea/apis/teamrepository/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type mockTeamRepositoryClient struct {
+	testutil.NoopClient
+	repositories map[string]bool
+	added        string
+	removed      string
+}
+
+func (m *mockTeamRepositoryClient) ListOrganizationTeams(ctx context.Context, org string) ([]*clients.Team, error) {
+	t := &clients.Team{ID: 5, Name: "developers"}
+	t.Organization.Username = "testorg"
+	return []*clients.Team{t}, nil
+}
+
+func (m *mockTeamRepositoryClient) GetTeamRepository(ctx context.Context, teamID int64, org, repo string) (*clients.Repository, error) {
+	if !m.repositories[org+"/"+repo] {
+		return nil, fmt.Errorf("API request failed with status 404: not found")
+	}
+	return &clients.Repository{Name: repo, FullName: org + "/" + repo}, nil
+}
+
+func (m *mockTeamRepositoryClient) AddTeamRepository(ctx context.Context, teamID int64, org, repo string) error {
+	m.added = org + "/" + repo
+	return nil
+}
+
+func (m *mockTeamRepositoryClient) RemoveTeamRepository(ctx context.Context, teamID int64, org, repo string) error {
+	m.removed = org + "/" + repo
+	return nil
+}
+
+func newTeamRepository() *v2.TeamRepository {
+	org, team, repo := "testorg", "developers", "app"
+	return &v2.TeamRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "developers-app", Namespace: "default"},
+		Spec: v2.TeamRepositorySpec{
+			ForProvider: v2.TeamRepositoryParameters{
+				Organization: &org,
+				Team:         &team,
+				Repository:   &repo,
+			},
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	t.Run("repository not in team does not exist", func(t *testing.T) {
+		e := &externalClient{client: &mockTeamRepositoryClient{}}
+		obs, err := e.Observe(context.Background(), newTeamRepository())
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+
+	t.Run("repository in team exists", func(t *testing.T) {
+		cr := newTeamRepository()
+		e := &externalClient{client: &mockTeamRepositoryClient{repositories: map[string]bool{"testorg/app": true}}}
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.Equal(t, "testorg/app", *cr.Status.AtProvider.Repository)
+		assert.Equal(t, xpv1.ReasonAvailable, cr.GetCondition(xpv1.TypeReady).Reason)
+	})
+}
+
+func TestCreateWithRepositoryRef(t *testing.T) {
+
+	repo := &repositoryv2.Repository{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}}
+	meta.SetExternalName(repo, "testorg/app-renamed")
+
+	cr := newTeamRepository()
+	cr.Spec.ForProvider.Repository = nil
+	cr.Spec.ForProvider.RepositoryRef = &xpv1.Reference{Name: "app"}
+
+	m := &mockTeamRepositoryClient{}
+	e := &externalClient{client: m, kube: fake.NewClientBuilder().WithScheme(testutil.Scheme(t)).WithObjects(repo).Build()}
+
+	_, err := e.Create(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, "testorg/app-renamed", m.added)
+}
+
+func TestDelete(t *testing.T) {
+	m := &mockTeamRepositoryClient{}
+	e := &externalClient{client: m}
+
+	_, err := e.Delete(context.Background(), newTeamRepository())
+	require.NoError(t, err)
+	assert.Equal(t, "testorg/app", m.removed)
+}
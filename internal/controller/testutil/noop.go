@@ -21,6 +21,7 @@ package testutil
 
 import (
 	"context"
+	"io"
 
 	"github.com/rossigee/provider-gitea/internal/clients"
 )
@@ -159,14 +160,6 @@ func (NoopClient) ListRepositoryCollaborators(ctx context.Context, owner, repo s
 	return nil, nil
 }
 
-// Org settings
-func (NoopClient) GetOrganizationSettings(ctx context.Context, org string) (*clients.OrganizationSettings, error) {
-	return nil, nil
-}
-func (NoopClient) UpdateOrganizationSettings(ctx context.Context, org string, req *clients.UpdateOrganizationSettingsRequest) (*clients.OrganizationSettings, error) {
-	return nil, nil
-}
-
 // Git hooks
 func (NoopClient) GetGitHook(ctx context.Context, repository, hookType string) (*clients.GitHook, error) {
 	return nil, nil
@@ -180,16 +173,16 @@ func (NoopClient) UpdateGitHook(ctx context.Context, repository, hookType string
 func (NoopClient) DeleteGitHook(ctx context.Context, repository, hookType string) error { return nil }
 
 // Branch protection
-func (NoopClient) GetBranchProtection(ctx context.Context, repository, branch string) (*clients.BranchProtection, error) {
+func (NoopClient) GetBranchProtection(ctx context.Context, repository, ruleName string) (*clients.BranchProtection, error) {
 	return nil, nil
 }
-func (NoopClient) CreateBranchProtection(ctx context.Context, repository, branch string, req *clients.CreateBranchProtectionRequest) (*clients.BranchProtection, error) {
+func (NoopClient) CreateBranchProtection(ctx context.Context, repository string, req *clients.CreateBranchProtectionRequest) (*clients.BranchProtection, error) {
 	return nil, nil
 }
-func (NoopClient) UpdateBranchProtection(ctx context.Context, repository, branch string, req *clients.UpdateBranchProtectionRequest) (*clients.BranchProtection, error) {
+func (NoopClient) UpdateBranchProtection(ctx context.Context, repository, ruleName string, req *clients.UpdateBranchProtectionRequest) (*clients.BranchProtection, error) {
 	return nil, nil
 }
-func (NoopClient) DeleteBranchProtection(ctx context.Context, repository, branch string) error { return nil }
+func (NoopClient) DeleteBranchProtection(ctx context.Context, repository, ruleName string) error { return nil }
 
 // Repo keys
 func (NoopClient) GetRepositoryKey(ctx context.Context, repository string, keyID int64) (*clients.RepositoryKey, error) {
@@ -278,7 +271,7 @@ func (NoopClient) UpdateRelease(ctx context.Context, owner, repo string, id int6
 	return nil, nil
 }
 func (NoopClient) DeleteRelease(ctx context.Context, owner, repo string, id int64) error { return nil }
-func (NoopClient) CreateReleaseAttachment(ctx context.Context, owner, repo string, releaseID int64, filename, contentType string, content []byte) (*clients.ReleaseAttachment, error) {
+func (NoopClient) CreateReleaseAttachment(ctx context.Context, owner, repo string, releaseID int64, filename, contentType string, content io.Reader) (*clients.ReleaseAttachment, error) {
 	return nil, nil
 }
 func (NoopClient) DeleteReleaseAttachment(ctx context.Context, owner, repo string, releaseID, attachmentID int64) error {
@@ -286,19 +279,19 @@ func (NoopClient) DeleteReleaseAttachment(ctx context.Context, owner, repo strin
 }
 
 // Org members
-func (NoopClient) GetOrganizationMember(ctx context.Context, org, username string) (*clients.OrganizationMember, error) {
-	return nil, nil
+func (NoopClient) IsOrganizationMember(ctx context.Context, org, username string) (bool, error) {
+	return false, nil
 }
-func (NoopClient) AddOrganizationMember(ctx context.Context, org, username string, req *clients.AddOrganizationMemberRequest) (*clients.OrganizationMember, error) {
-	return nil, nil
+func (NoopClient) IsPublicOrganizationMember(ctx context.Context, org, username string) (bool, error) {
+	return false, nil
 }
-func (NoopClient) UpdateOrganizationMember(ctx context.Context, org, username string, req *clients.UpdateOrganizationMemberRequest) (*clients.OrganizationMember, error) {
-	return nil, nil
+func (NoopClient) SetOrganizationMemberVisibility(ctx context.Context, org, username string, public bool) error {
+	return nil
 }
 func (NoopClient) RemoveOrganizationMember(ctx context.Context, org, username string) error { return nil }
 
 // Actions
-func (NoopClient) GetAction(ctx context.Context, repository, workflowName string) (*clients.Action, error) {
+func (NoopClient) GetAction(ctx context.Context, repository, workflowName, branch string) (*clients.Action, error) {
 	return nil, nil
 }
 func (NoopClient) CreateAction(ctx context.Context, repository string, req *clients.CreateActionRequest) (*clients.Action, error) {
@@ -307,21 +300,32 @@ func (NoopClient) CreateAction(ctx context.Context, repository string, req *clie
 func (NoopClient) UpdateAction(ctx context.Context, repository, workflowName string, req *clients.UpdateActionRequest) (*clients.Action, error) {
 	return nil, nil
 }
-func (NoopClient) DeleteAction(ctx context.Context, repository, workflowName string) error { return nil }
+func (NoopClient) DeleteAction(ctx context.Context, repository, workflowName string, req *clients.DeleteActionRequest) error {
+	return nil
+}
+func (NoopClient) GetActionWorkflow(ctx context.Context, repository, workflowName string) (*clients.ActionWorkflow, error) {
+	return nil, nil
+}
 func (NoopClient) EnableAction(ctx context.Context, repository, workflowName string) error { return nil }
 func (NoopClient) DisableAction(ctx context.Context, repository, workflowName string) error { return nil }
+func (NoopClient) GetActionLastRun(ctx context.Context, repository, workflowName string) (*clients.ActionRun, error) {
+	return nil, nil
+}
 
 // Runners
 func (NoopClient) GetRunner(ctx context.Context, scope, scopeValue string, runnerID int64) (*clients.Runner, error) {
 	return nil, nil
 }
-func (NoopClient) CreateRunner(ctx context.Context, scope, scopeValue string, req *clients.CreateRunnerRequest) (*clients.Runner, error) {
+func (NoopClient) ListRunners(ctx context.Context, scope, scopeValue string) ([]clients.Runner, error) {
 	return nil, nil
 }
-func (NoopClient) UpdateRunner(ctx context.Context, scope, scopeValue string, runnerID int64, req *clients.UpdateRunnerRequest) (*clients.Runner, error) {
+func (NoopClient) DeleteRunner(ctx context.Context, scope, scopeValue string, runnerID int64) error { return nil }
+func (NoopClient) GetRunnerRegistrationToken(ctx context.Context, scope, scopeValue string) (*clients.RunnerRegistrationToken, error) {
+	return nil, nil
+}
+func (NoopClient) CreateRunnerRegistrationToken(ctx context.Context, scope, scopeValue string) (*clients.RunnerRegistrationToken, error) {
 	return nil, nil
 }
-func (NoopClient) DeleteRunner(ctx context.Context, scope, scopeValue string, runnerID int64) error { return nil }
 
 // Admin users
 func (NoopClient) GetAdminUser(ctx context.Context, username string) (*clients.AdminUser, error) {
@@ -335,5 +339,181 @@ func (NoopClient) UpdateAdminUser(ctx context.Context, username string, req *cli
 }
 func (NoopClient) DeleteAdminUser(ctx context.Context, username string) error { return nil }
 
+// Repository File operations
+func (NoopClient) GetRepositoryFile(ctx context.Context, owner, repo, filepath, ref string) (*clients.ContentsResponse, error) {
+	return nil, nil
+}
+func (NoopClient) CreateRepositoryFile(ctx context.Context, owner, repo, filepath string, req *clients.CreateFileOptions) (*clients.FileResponse, error) {
+	return nil, nil
+}
+func (NoopClient) UpdateRepositoryFile(ctx context.Context, owner, repo, filepath string, req *clients.UpdateFileOptions) (*clients.FileResponse, error) {
+	return nil, nil
+}
+func (NoopClient) DeleteRepositoryFile(ctx context.Context, owner, repo, filepath string, req *clients.DeleteFileOptions) error { return nil }
+func (NoopClient) GetRepositoryBranch(ctx context.Context, owner, repo, branch string) (*clients.RepositoryBranch, error) {
+	return nil, nil
+}
+func (NoopClient) GetPullRequestByBranches(ctx context.Context, owner, repo, base, head string) (*clients.PullRequest, error) {
+	return nil, nil
+}
+
+// Workflow run operations
+func (NoopClient) ListActionRuns(ctx context.Context, repository, workflowName, event string) ([]clients.ActionRun, error) {
+	return nil, nil
+}
+func (NoopClient) GetActionRun(ctx context.Context, repository string, runID int64) (*clients.ActionRun, error) {
+	return nil, nil
+}
+func (NoopClient) ListActionRunJobs(ctx context.Context, repository string, runID int64) ([]clients.ActionJob, error) {
+	return nil, nil
+}
+func (NoopClient) DispatchWorkflow(ctx context.Context, repository, workflowName string, req *clients.DispatchWorkflowRequest) error { return nil }
+
+// Tag protection operations
+func (NoopClient) ListTagProtections(ctx context.Context, repository string) ([]clients.TagProtection, error) {
+	return nil, nil
+}
+func (NoopClient) GetTagProtection(ctx context.Context, repository string, id int64) (*clients.TagProtection, error) {
+	return nil, nil
+}
+func (NoopClient) CreateTagProtection(ctx context.Context, repository string, req *clients.CreateTagProtectionRequest) (*clients.TagProtection, error) {
+	return nil, nil
+}
+func (NoopClient) UpdateTagProtection(ctx context.Context, repository string, id int64, req *clients.UpdateTagProtectionRequest) (*clients.TagProtection, error) {
+	return nil, nil
+}
+func (NoopClient) DeleteTagProtection(ctx context.Context, repository string, id int64) error { return nil }
+
+// Branch and tag operations
+func (NoopClient) CreateRepositoryBranch(ctx context.Context, owner, repo string, req *clients.CreateBranchRequest) (*clients.RepositoryBranch, error) {
+	return nil, nil
+}
+func (NoopClient) DeleteRepositoryBranch(ctx context.Context, owner, repo, branch string) error { return nil }
+func (NoopClient) GetRepositoryTag(ctx context.Context, owner, repo, tag string) (*clients.RepositoryTag, error) {
+	return nil, nil
+}
+func (NoopClient) CreateRepositoryTag(ctx context.Context, owner, repo string, req *clients.CreateTagRequest) (*clients.RepositoryTag, error) {
+	return nil, nil
+}
+func (NoopClient) DeleteRepositoryTag(ctx context.Context, owner, repo, tag string) error { return nil }
+
+// Team membership operations
+func (NoopClient) ListTeamMembers(ctx context.Context, teamID int64) ([]*clients.User, error) {
+	return nil, nil
+}
+func (NoopClient) GetTeamMember(ctx context.Context, teamID int64, username string) (*clients.User, error) {
+	return nil, nil
+}
+func (NoopClient) AddTeamMember(ctx context.Context, teamID int64, username string) error { return nil }
+func (NoopClient) RemoveTeamMember(ctx context.Context, teamID int64, username string) error { return nil }
+func (NoopClient) ListTeamRepositories(ctx context.Context, teamID int64) ([]*clients.Repository, error) {
+	return nil, nil
+}
+func (NoopClient) GetTeamRepository(ctx context.Context, teamID int64, org, repo string) (*clients.Repository, error) {
+	return nil, nil
+}
+func (NoopClient) AddTeamRepository(ctx context.Context, teamID int64, org, repo string) error { return nil }
+func (NoopClient) RemoveTeamRepository(ctx context.Context, teamID int64, org, repo string) error { return nil }
+
+// Organization avatar, label and block operations
+func (NoopClient) UpdateOrganizationAvatar(ctx context.Context, org string, image []byte) error { return nil }
+func (NoopClient) DeleteOrganizationAvatar(ctx context.Context, org string) error { return nil }
+func (NoopClient) ListOrganizationLabels(ctx context.Context, org string) ([]*clients.Label, error) {
+	return nil, nil
+}
+func (NoopClient) CreateOrganizationLabel(ctx context.Context, org string, req *clients.CreateLabelRequest) (*clients.Label, error) {
+	return nil, nil
+}
+func (NoopClient) UpdateOrganizationLabel(ctx context.Context, org string, labelID int64, req *clients.UpdateLabelRequest) (*clients.Label, error) {
+	return nil, nil
+}
+func (NoopClient) DeleteOrganizationLabel(ctx context.Context, org string, labelID int64) error { return nil }
+func (NoopClient) ListOrganizationBlocks(ctx context.Context, org string) ([]*clients.User, error) {
+	return nil, nil
+}
+func (NoopClient) BlockOrganizationUser(ctx context.Context, org, username string) error { return nil }
+func (NoopClient) UnblockOrganizationUser(ctx context.Context, org, username string) error { return nil }
+
+// Release notes operations
+func (NoopClient) ListReleases(ctx context.Context, owner, repo string) ([]*clients.Release, error) {
+	return nil, nil
+}
+func (NoopClient) CompareCommits(ctx context.Context, owner, repo, base, head string) (*clients.Compare, error) {
+	return nil, nil
+}
+
+// Repository transfer operations
+func (NoopClient) TransferRepository(ctx context.Context, owner, name string, req *clients.TransferRepositoryRequest) (*clients.Repository, error) {
+	return nil, nil
+}
+
+// User offboarding operations
+func (NoopClient) PurgeUser(ctx context.Context, username string) error { return nil }
+func (NoopClient) ListUserRepositories(ctx context.Context, username string) ([]*clients.Repository, error) {
+	return nil, nil
+}
+func (NoopClient) ListUserOrganizations(ctx context.Context, username string) ([]*clients.Organization, error) {
+	return nil, nil
+}
+
+// User account operations
+func (NoopClient) ListUserEmails(ctx context.Context, username string) ([]*clients.Email, error) {
+	return nil, nil
+}
+func (NoopClient) AddUserEmails(ctx context.Context, username string, emails []string) ([]*clients.Email, error) {
+	return nil, nil
+}
+func (NoopClient) DeleteUserEmails(ctx context.Context, username string, emails []string) error { return nil }
+func (NoopClient) ListUserGPGKeys(ctx context.Context, username string) ([]*clients.GPGKey, error) {
+	return nil, nil
+}
+func (NoopClient) GetUserGPGKey(ctx context.Context, username string, id int64) (*clients.GPGKey, error) {
+	return nil, nil
+}
+func (NoopClient) CreateUserGPGKey(ctx context.Context, username string, req *clients.CreateGPGKeyRequest) (*clients.GPGKey, error) {
+	return nil, nil
+}
+func (NoopClient) DeleteUserGPGKey(ctx context.Context, username string, id int64) error { return nil }
+func (NoopClient) GetUserSettings(ctx context.Context, username string) (*clients.UserSettings, error) {
+	return nil, nil
+}
+func (NoopClient) UpdateUserSettings(ctx context.Context, username string, req *clients.UpdateUserSettingsRequest) (*clients.UserSettings, error) {
+	return nil, nil
+}
+
+// OAuth2 application operations
+func (NoopClient) GetOAuth2Application(ctx context.Context, username string, id int64) (*clients.OAuth2Application, error) {
+	return nil, nil
+}
+func (NoopClient) CreateOAuth2Application(ctx context.Context, username string, req *clients.OAuth2ApplicationRequest) (*clients.OAuth2Application, error) {
+	return nil, nil
+}
+func (NoopClient) UpdateOAuth2Application(ctx context.Context, username string, id int64, req *clients.OAuth2ApplicationRequest) (*clients.OAuth2Application, error) {
+	return nil, nil
+}
+func (NoopClient) DeleteOAuth2Application(ctx context.Context, username string, id int64) error { return nil }
+
+func (NoopClient) GetAdminWebhook(ctx context.Context, id int64) (*clients.Webhook, error) {
+	return nil, nil
+}
+func (NoopClient) CreateAdminWebhook(ctx context.Context, req *clients.CreateWebhookRequest) (*clients.Webhook, error) {
+	return nil, nil
+}
+func (NoopClient) UpdateAdminWebhook(ctx context.Context, id int64, req *clients.UpdateWebhookRequest) (*clients.Webhook, error) {
+	return nil, nil
+}
+func (NoopClient) DeleteAdminWebhook(ctx context.Context, id int64) error { return nil }
+
+func (NoopClient) ListRepositoryWebhooks(ctx context.Context, owner, repo string) ([]*clients.Webhook, error) {
+	return nil, nil
+}
+func (NoopClient) ListDeployKeys(ctx context.Context, owner, repo string) ([]*clients.DeployKey, error) {
+	return nil, nil
+}
+
+func (NoopClient) ListOrganizationRepositories(ctx context.Context, org string) ([]*clients.Repository, error) {
+	return nil, nil
+}
+
 // Disconnect
 func (NoopClient) Disconnect(ctx context.Context) error { return nil }
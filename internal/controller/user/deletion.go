@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package user
+
+import (
+	"context"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-gitea/apis/user/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+)
+
+const (
+	errDeactivateUser        = "failed to deactivate user"
+	errPurgeUser             = "failed to purge user"
+	errListUserRepositories  = "failed to list user repositories"
+	errTransferRepository    = "failed to transfer user repository"
+	errTransferPending       = "repository transfers are awaiting acceptance"
+	errListUserOrganizations = "failed to list user organizations"
+	errLeaveOrganization     = "failed to remove user from organization"
+
+	deletionModeDeactivate = "Deactivate"
+	deletionModePurge      = "Purge"
+	deletionModeTransferTo = "TransferTo"
+
+	stepCompleted = "Completed"
+	stepPending   = "Pending"
+	stepFailed    = "Failed"
+)
+
+func deletionMode(cr *v2.User) string {
+	if cr.Spec.ForProvider.DeletionMode == nil {
+		return ""
+	}
+	return *cr.Spec.ForProvider.DeletionMode
+}
+
+// deactivated reports whether a user being deleted with the Deactivate mode
+// has reached its final state, so that the resource can be released.
+func deactivated(cr *v2.User, user *clients.User) bool {
+	return meta.WasDeleted(cr) && deletionMode(cr) == deletionModeDeactivate &&
+		!user.Active && user.ProhibitLogin
+}
+
+// setStep records the state of a deletion step in the status.
+func setStep(cr *v2.User, name, state, message string) {
+	step := v2.UserDeletionStep{Name: name, State: state, Message: message}
+	for i := range cr.Status.AtProvider.DeletionSteps {
+		if cr.Status.AtProvider.DeletionSteps[i].Name == name {
+			cr.Status.AtProvider.DeletionSteps[i] = step
+			return
+		}
+	}
+	cr.Status.AtProvider.DeletionSteps = append(cr.Status.AtProvider.DeletionSteps, step)
+}
+
+// recordStep records a deletion step as completed, or as failed with err.
+func recordStep(cr *v2.User, name string, err error) {
+	if err != nil {
+		setStep(cr, name, stepFailed, err.Error())
+		return
+	}
+	setStep(cr, name, stepCompleted, "")
+}
+
+// deactivate sets the user inactive and prohibits it from logging in.
+func (e *externalClient) deactivate(ctx context.Context, cr *v2.User, user *clients.User) error {
+	if !user.Active && user.ProhibitLogin {
+		setStep(cr, "deactivate", stepCompleted, "")
+		return nil
+	}
+
+	inactive, prohibit := false, true
+	_, err := e.client.UpdateUser(ctx, user.Username, &clients.UpdateUserRequest{
+		LoginName:     cr.Spec.ForProvider.LoginName,
+		SourceID:      cr.Spec.ForProvider.SourceID,
+		Active:        &inactive,
+		ProhibitLogin: &prohibit,
+	})
+	recordStep(cr, "deactivate", err)
+	return errors.Wrap(err, errDeactivateUser)
+}
+
+// offboard transfers the repositories of a user to the TransferTo
+// organization and removes the user from its organizations, which is what
+// Gitea requires before the user can be deleted.
+func (e *externalClient) offboard(ctx context.Context, cr *v2.User, username string) error {
+	repos, err := e.client.ListUserRepositories(ctx, username)
+	if err != nil {
+		return errors.Wrap(err, errListUserRepositories)
+	}
+
+	org := *cr.Spec.ForProvider.TransferTo
+	pending := false
+	for _, repo := range repos {
+		if repo.Owner == nil || !strings.EqualFold(repo.Owner.Username, username) {
+			continue
+		}
+
+		step := "transfer " + repo.FullName
+		if repo.RepoTransfer == nil {
+			moved, err := e.client.TransferRepository(ctx, username, repo.Name, &clients.TransferRepositoryRequest{NewOwner: org})
+			if err != nil {
+				recordStep(cr, step, err)
+				return errors.Wrap(err, errTransferRepository)
+			}
+			if moved == nil || moved.RepoTransfer == nil {
+				setStep(cr, step, stepCompleted, "")
+				continue
+			}
+		}
+		setStep(cr, step, stepPending, "awaiting acceptance by "+org)
+		pending = true
+	}
+	if pending {
+		return errors.New(errTransferPending)
+	}
+
+	orgs, err := e.client.ListUserOrganizations(ctx, username)
+	if err != nil {
+		return errors.Wrap(err, errListUserOrganizations)
+	}
+	for _, o := range orgs {
+		name := o.Username
+		if name == "" {
+			name = o.Name
+		}
+		err := e.client.RemoveOrganizationMember(ctx, name, username)
+		recordStep(cr, "leave "+name, err)
+		if err != nil {
+			return errors.Wrap(err, errLeaveOrganization)
+		}
+	}
+	return nil
+}
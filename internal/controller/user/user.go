@@ -43,6 +43,8 @@ const (
 	errDeleteUser         = "failed to delete user"
 	errGetProviderConfig  = "failed to get provider config"
 	errInvalidExternalName = "invalid external-name, expected username"
+	errGetUserSettings     = "failed to get user settings"
+	errUpdateUserSettings  = "failed to update user settings"
 )
 
 type connector struct {
@@ -105,22 +107,55 @@ func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (mana
 
 	isAdmin := user.IsAdmin
 	cr.Status.AtProvider = v2.UserObservation{
-		ID:        &user.ID,
-		AvatarURL: &user.AvatarURL,
-		IsAdmin:   &isAdmin,
-		LastLogin: &user.LastLogin,
-		Created:   &user.Created,
-		Language:  &user.Language,
+		ID:            &user.ID,
+		AvatarURL:     &user.AvatarURL,
+		IsAdmin:       &isAdmin,
+		LastLogin:     &user.LastLogin,
+		Created:       &user.Created,
+		Language:      &user.Language,
+		DeletionSteps: cr.Status.AtProvider.DeletionSteps,
+	}
+
+	// A deactivated user is left in place, so it no longer counts as
+	// existing once the resource is being deleted.
+	if deactivated(cr, user) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
 
 	cr.SetConditions(xpv1.Available())
 
+	upToDate := isUserUpToDate(cr, user)
+	if upToDate && cr.Spec.ForProvider.Settings != nil && !meta.WasDeleted(cr) {
+		settings, err := e.client.GetUserSettings(ctx, externalName)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetUserSettings)
+		}
+		upToDate = areSettingsUpToDate(cr.Spec.ForProvider.Settings, settings)
+	}
+
 	return managed.ExternalObservation{
 		ResourceExists:   true,
-		ResourceUpToDate: isUserUpToDate(cr, user),
+		ResourceUpToDate: upToDate,
 	}, nil
 }
 
+// areSettingsUpToDate compares the settings that are set in the spec.
+func areSettingsUpToDate(want *v2.UserSettings, got *clients.UserSettings) bool {
+	switch {
+	case want.Theme != nil && *want.Theme != got.Theme:
+		return false
+	case want.DiffViewStyle != nil && *want.DiffViewStyle != got.DiffViewStyle:
+		return false
+	case want.HideEmail != nil && *want.HideEmail != got.HideEmail:
+		return false
+	case want.HideActivity != nil && *want.HideActivity != got.HideActivity:
+		return false
+	case want.Language != nil && *want.Language != got.Language:
+		return false
+	}
+	return true
+}
+
 func isUserUpToDate(cr *v2.User, user *clients.User) bool {
 	if cr.Spec.ForProvider.Email != "" && cr.Spec.ForProvider.Email != user.Email {
 		return false
@@ -240,6 +275,20 @@ func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (manag
 		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateUser)
 	}
 
+	// Settings can only be changed by the user, so they are applied through
+	// the Sudo header after the admin update.
+	if settings := cr.Spec.ForProvider.Settings; settings != nil {
+		if _, err := e.client.UpdateUserSettings(ctx, externalName, &clients.UpdateUserSettingsRequest{
+			Theme:         settings.Theme,
+			DiffViewStyle: settings.DiffViewStyle,
+			HideEmail:     settings.HideEmail,
+			HideActivity:  settings.HideActivity,
+			Language:      settings.Language,
+		}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateUserSettings)
+		}
+	}
+
 	isAdmin := user.IsAdmin
 	cr.Status.AtProvider = v2.UserObservation{
 		ID:        &user.ID,
@@ -266,15 +315,30 @@ func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (manag
 	}
 
 	// Gracefully handle already-deleted external resource.
-	if _, err := e.client.GetUser(ctx, externalName); err == nil {
-		if err := e.client.DeleteUser(ctx, externalName); err != nil {
-			return managed.ExternalDelete{}, errors.Wrap(err, errDeleteUser)
+	user, err := e.client.GetUser(ctx, externalName)
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return managed.ExternalDelete{}, nil
 		}
-		return managed.ExternalDelete{}, nil
-	} else if !strings.Contains(err.Error(), "404") {
 		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteUser)
 	}
-	return managed.ExternalDelete{}, nil
+
+	switch deletionMode(cr) {
+	case deletionModeDeactivate:
+		return managed.ExternalDelete{}, e.deactivate(ctx, cr, user)
+	case deletionModePurge:
+		err := e.client.PurgeUser(ctx, externalName)
+		recordStep(cr, "purge", err)
+		return managed.ExternalDelete{}, errors.Wrap(err, errPurgeUser)
+	case deletionModeTransferTo:
+		if err := e.offboard(ctx, cr, externalName); err != nil {
+			return managed.ExternalDelete{}, err
+		}
+	}
+
+	err = e.client.DeleteUser(ctx, externalName)
+	recordStep(cr, "delete", err)
+	return managed.ExternalDelete{}, errors.Wrap(err, errDeleteUser)
 }
 
 func (e *externalClient) Disconnect(ctx context.Context) error {
@@ -36,6 +36,14 @@ type mockUserClient struct {
 	createUserFn func(ctx context.Context, req *clients.CreateUserRequest) (*clients.User, error)
 	updateUserFn func(ctx context.Context, name string, req *clients.UpdateUserRequest) (*clients.User, error)
 	deleteUserFn func(ctx context.Context, name string) error
+	purgeUserFn  func(ctx context.Context, name string) error
+	listReposFn  func(ctx context.Context, name string) ([]*clients.Repository, error)
+	listOrgsFn   func(ctx context.Context, name string) ([]*clients.Organization, error)
+	transferFn   func(ctx context.Context, owner, name string, req *clients.TransferRepositoryRequest) (*clients.Repository, error)
+	leaveOrgFn   func(ctx context.Context, org, name string) error
+
+	getSettingsFn    func(ctx context.Context, name string) (*clients.UserSettings, error)
+	updateSettingsFn func(ctx context.Context, name string, req *clients.UpdateUserSettingsRequest) (*clients.UserSettings, error)
 }
 
 func (m *mockUserClient) GetUser(ctx context.Context, name string) (*clients.User, error) {
@@ -62,6 +70,48 @@ func (m *mockUserClient) DeleteUser(ctx context.Context, name string) error {
 	}
 	return nil
 }
+func (m *mockUserClient) PurgeUser(ctx context.Context, name string) error {
+	if m.purgeUserFn != nil {
+		return m.purgeUserFn(ctx, name)
+	}
+	return nil
+}
+func (m *mockUserClient) ListUserRepositories(ctx context.Context, name string) ([]*clients.Repository, error) {
+	if m.listReposFn != nil {
+		return m.listReposFn(ctx, name)
+	}
+	return nil, nil
+}
+func (m *mockUserClient) ListUserOrganizations(ctx context.Context, name string) ([]*clients.Organization, error) {
+	if m.listOrgsFn != nil {
+		return m.listOrgsFn(ctx, name)
+	}
+	return nil, nil
+}
+func (m *mockUserClient) TransferRepository(ctx context.Context, owner, name string, req *clients.TransferRepositoryRequest) (*clients.Repository, error) {
+	if m.transferFn != nil {
+		return m.transferFn(ctx, owner, name, req)
+	}
+	return nil, nil
+}
+func (m *mockUserClient) RemoveOrganizationMember(ctx context.Context, org, name string) error {
+	if m.leaveOrgFn != nil {
+		return m.leaveOrgFn(ctx, org, name)
+	}
+	return nil
+}
+func (m *mockUserClient) GetUserSettings(ctx context.Context, name string) (*clients.UserSettings, error) {
+	if m.getSettingsFn != nil {
+		return m.getSettingsFn(ctx, name)
+	}
+	return &clients.UserSettings{}, nil
+}
+func (m *mockUserClient) UpdateUserSettings(ctx context.Context, name string, req *clients.UpdateUserSettingsRequest) (*clients.UserSettings, error) {
+	if m.updateSettingsFn != nil {
+		return m.updateSettingsFn(ctx, name, req)
+	}
+	return &clients.UserSettings{}, nil
+}
 
 func strPtr(s string) *string { return &s }
 
@@ -403,3 +453,214 @@ func TestDelete(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestDeletionModes(t *testing.T) {
+	newUser := func(mode string) *v2.User {
+		now := metav1.Now()
+		cr := &v2.User{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "jane", DeletionTimestamp: &now},
+			Spec: v2.UserSpec{ForProvider: v2.UserParameters{
+				Username:     "jane",
+				DeletionMode: strPtr(mode),
+				TransferTo:   strPtr("archive"),
+			}},
+		}
+		meta.SetExternalName(cr, "jane")
+		return cr
+	}
+	active := func(ctx context.Context, name string) (*clients.User, error) {
+		return &clients.User{Username: name, Active: true}, nil
+	}
+
+	t.Run("Deactivate disables the account instead of deleting it", func(t *testing.T) {
+		var req *clients.UpdateUserRequest
+		ec := &externalClient{client: &mockUserClient{
+			getUserFn: active,
+			updateUserFn: func(ctx context.Context, name string, r *clients.UpdateUserRequest) (*clients.User, error) {
+				req = r
+				return &clients.User{Username: name}, nil
+			},
+			deleteUserFn: func(ctx context.Context, name string) error {
+				t.Fatal("user must not be deleted")
+				return nil
+			},
+		}}
+
+		cr := newUser("Deactivate")
+		_, err := ec.Delete(context.Background(), cr)
+		require.NoError(t, err)
+		require.NotNil(t, req)
+		assert.False(t, *req.Active)
+		assert.True(t, *req.ProhibitLogin)
+		assert.Equal(t, []v2.UserDeletionStep{{Name: "deactivate", State: "Completed"}}, cr.Status.AtProvider.DeletionSteps)
+
+		ec.client = &mockUserClient{getUserFn: func(ctx context.Context, name string) (*clients.User, error) {
+			return &clients.User{Username: name, ProhibitLogin: true}, nil
+		}}
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists, "a deactivated user releases the resource")
+		assert.Len(t, cr.Status.AtProvider.DeletionSteps, 1)
+	})
+
+	t.Run("Purge deletes the user with its content", func(t *testing.T) {
+		purged := false
+		ec := &externalClient{client: &mockUserClient{
+			getUserFn: active,
+			purgeUserFn: func(ctx context.Context, name string) error {
+				purged = true
+				return nil
+			},
+		}}
+
+		cr := newUser("Purge")
+		_, err := ec.Delete(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, purged)
+		assert.Equal(t, "purge", cr.Status.AtProvider.DeletionSteps[0].Name)
+	})
+
+	t.Run("TransferTo moves repositories and leaves organizations first", func(t *testing.T) {
+		var calls []string
+		ec := &externalClient{client: &mockUserClient{
+			getUserFn: active,
+			listReposFn: func(ctx context.Context, name string) ([]*clients.Repository, error) {
+				return []*clients.Repository{
+					{Name: "notes", FullName: "jane/notes", Owner: &clients.User{Username: "jane"}},
+					{Name: "shared", FullName: "acme/shared", Owner: &clients.User{Username: "acme"}},
+				}, nil
+			},
+			transferFn: func(ctx context.Context, owner, name string, req *clients.TransferRepositoryRequest) (*clients.Repository, error) {
+				calls = append(calls, "transfer "+owner+"/"+name+" to "+req.NewOwner)
+				return &clients.Repository{Name: name}, nil
+			},
+			listOrgsFn: func(ctx context.Context, name string) ([]*clients.Organization, error) {
+				return []*clients.Organization{{Username: "acme"}}, nil
+			},
+			leaveOrgFn: func(ctx context.Context, org, name string) error {
+				calls = append(calls, "leave "+org)
+				return nil
+			},
+			deleteUserFn: func(ctx context.Context, name string) error {
+				calls = append(calls, "delete "+name)
+				return nil
+			},
+		}}
+
+		cr := newUser("TransferTo")
+		_, err := ec.Delete(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"transfer jane/notes to archive", "leave acme", "delete jane"}, calls)
+		assert.Equal(t, []v2.UserDeletionStep{
+			{Name: "transfer jane/notes", State: "Completed"},
+			{Name: "leave acme", State: "Completed"},
+			{Name: "delete", State: "Completed"},
+		}, cr.Status.AtProvider.DeletionSteps)
+	})
+
+	t.Run("TransferTo waits for pending transfers", func(t *testing.T) {
+		ec := &externalClient{client: &mockUserClient{
+			getUserFn: active,
+			listReposFn: func(ctx context.Context, name string) ([]*clients.Repository, error) {
+				return []*clients.Repository{{
+					Name: "notes", FullName: "jane/notes", Owner: &clients.User{Username: "jane"},
+					RepoTransfer: &clients.RepoTransfer{Recipient: &clients.User{Username: "archive"}},
+				}}, nil
+			},
+			deleteUserFn: func(ctx context.Context, name string) error {
+				t.Fatal("user must not be deleted while transfers are pending")
+				return nil
+			},
+		}}
+
+		cr := newUser("TransferTo")
+		_, err := ec.Delete(context.Background(), cr)
+		require.Error(t, err)
+		assert.Equal(t, "Pending", cr.Status.AtProvider.DeletionSteps[0].State)
+	})
+
+	t.Run("failed step is reported", func(t *testing.T) {
+		ec := &externalClient{client: &mockUserClient{
+			getUserFn: active,
+			deleteUserFn: func(ctx context.Context, name string) error {
+				return fmt.Errorf("API request failed with status 422: user still owns repositories")
+			},
+		}}
+
+		cr := newUser("Delete")
+		_, err := ec.Delete(context.Background(), cr)
+		require.Error(t, err)
+		assert.Equal(t, "Failed", cr.Status.AtProvider.DeletionSteps[0].State)
+		assert.Contains(t, cr.Status.AtProvider.DeletionSteps[0].Message, "owns repositories")
+	})
+}
+
+func TestSettings(t *testing.T) {
+	hide := true
+	newUser := func() *v2.User {
+		cr := &v2.User{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "jane"},
+			Spec: v2.UserSpec{ForProvider: v2.UserParameters{
+				Username: "jane",
+				Email:    "jane@example.com",
+				Settings: &v2.UserSettings{
+					Theme:         strPtr("gitea-dark"),
+					DiffViewStyle: strPtr("split"),
+					HideEmail:     &hide,
+				},
+			}},
+		}
+		meta.SetExternalName(cr, "jane")
+		return cr
+	}
+	getUser := func(ctx context.Context, name string) (*clients.User, error) {
+		return &clients.User{Username: name, Email: "jane@example.com"}, nil
+	}
+
+	t.Run("settings drift makes the user out of date", func(t *testing.T) {
+		ec := &externalClient{client: &mockUserClient{
+			getUserFn: getUser,
+			getSettingsFn: func(ctx context.Context, name string) (*clients.UserSettings, error) {
+				return &clients.UserSettings{Theme: "gitea-dark", DiffViewStyle: "unified", HideEmail: true, Language: "de-DE"}, nil
+			},
+		}}
+
+		obs, err := ec.Observe(context.Background(), newUser())
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate)
+	})
+
+	t.Run("unset settings are ignored", func(t *testing.T) {
+		ec := &externalClient{client: &mockUserClient{
+			getUserFn: getUser,
+			getSettingsFn: func(ctx context.Context, name string) (*clients.UserSettings, error) {
+				return &clients.UserSettings{Theme: "gitea-dark", DiffViewStyle: "split", HideEmail: true, Language: "de-DE"}, nil
+			},
+		}}
+
+		obs, err := ec.Observe(context.Background(), newUser())
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+	})
+
+	t.Run("update applies settings as the user", func(t *testing.T) {
+		var got *clients.UpdateUserSettingsRequest
+		ec := &externalClient{client: &mockUserClient{
+			updateUserFn: func(ctx context.Context, name string, req *clients.UpdateUserRequest) (*clients.User, error) {
+				return &clients.User{Username: name}, nil
+			},
+			updateSettingsFn: func(ctx context.Context, name string, req *clients.UpdateUserSettingsRequest) (*clients.UserSettings, error) {
+				assert.Equal(t, "jane", name)
+				got = req
+				return &clients.UserSettings{}, nil
+			},
+		}}
+
+		_, err := ec.Update(context.Background(), newUser())
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, "split", *got.DiffViewStyle)
+		assert.True(t, *got.HideEmail)
+		assert.Nil(t, got.Language)
+	})
+}
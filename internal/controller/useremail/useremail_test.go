@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package useremail
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-gitea/apis/useremail/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockEmailClient struct {
+	testutil.NoopClient
+	emails  []*clients.Email
+	added   []string
+	deleted []string
+	sudo    string
+}
+
+func (m *mockEmailClient) ListUserEmails(ctx context.Context, username string) ([]*clients.Email, error) {
+	m.sudo = username
+	return m.emails, nil
+}
+
+func (m *mockEmailClient) AddUserEmails(ctx context.Context, username string, emails []string) ([]*clients.Email, error) {
+	m.sudo = username
+	m.added = emails
+	return nil, nil
+}
+
+func (m *mockEmailClient) DeleteUserEmails(ctx context.Context, username string, emails []string) error {
+	m.deleted = emails
+	return fmt.Errorf("API request failed with status 404: not found")
+}
+
+func newEmail() *v2.UserEmail {
+	return &v2.UserEmail{Spec: v2.UserEmailSpec{ForProvider: v2.UserEmailParameters{
+		Username: "jane",
+		Email:    "jane.doe@example.com",
+	}}}
+}
+
+func TestObserve(t *testing.T) {
+	t.Run("missing email does not exist", func(t *testing.T) {
+		m := &mockEmailClient{emails: []*clients.Email{{Email: "jane@example.com", Primary: true}}}
+		ec := &externalClient{client: m}
+
+		obs, err := ec.Observe(context.Background(), newEmail())
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+		assert.Equal(t, "jane", m.sudo)
+	})
+
+	t.Run("email matches case-insensitively", func(t *testing.T) {
+		ec := &externalClient{client: &mockEmailClient{emails: []*clients.Email{
+			{Email: "Jane.Doe@example.com", Verified: true},
+		}}}
+		cr := newEmail()
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.True(t, *cr.Status.AtProvider.Verified)
+		assert.Equal(t, xpv1.ReasonAvailable, cr.GetCondition(xpv1.TypeReady).Reason)
+	})
+}
+
+func TestCreateAndDelete(t *testing.T) {
+	m := &mockEmailClient{}
+	ec := &externalClient{client: m}
+
+	_, err := ec.Create(context.Background(), newEmail())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jane.doe@example.com"}, m.added)
+
+	_, err = ec.Delete(context.Background(), newEmail())
+	require.NoError(t, err, "an already removed email is not an error")
+	assert.Equal(t, []string{"jane.doe@example.com"}, m.deleted)
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usergpgkey
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-gitea/apis/usergpgkey/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/tracing"
+	"golang.org/x/crypto/openpgp" //nolint:staticcheck // Only used to read key IDs, which the package still does correctly.
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/rossigee/provider-gitea/apis/v1beta1"
+)
+
+const (
+	errNotUserGPGKey     = "managed resource is not a UserGPGKey custom resource"
+	errGetGPGKey         = "failed to get user GPG key"
+	errListGPGKeys       = "failed to list user GPG keys"
+	errCreateGPGKey      = "failed to create user GPG key"
+	errDeleteGPGKey      = "failed to delete user GPG key"
+	errGetProviderConfig = "failed to get provider config"
+	errNoPublicKey       = "armored key holds no public key"
+)
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient by:
+// 1. Getting the provider config
+// 2. Creating a Gitea API client
+// 3. Returning an external client wrapping the API client
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v2.UserGPGKey)
+	if !ok {
+		return nil, errors.New(errNotUserGPGKey)
+	}
+
+	pcRef := cr.Spec.ProviderConfigReference
+	if pcRef == nil {
+		return nil, errors.New("providerConfigRef is required")
+	}
+
+	var pc v1beta1.ProviderConfig
+	if err := c.kube.Get(ctx, client.ObjectKey{
+		Namespace: cr.GetNamespace(),
+		Name:      pcRef.Name,
+	}, &pc); err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
+
+	conn, err := clients.NewClient(ctx, &pc, c.kube)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalClient{client: conn}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it matches the managed resource's desired state.
+type externalClient struct {
+	client clients.Client
+}
+
+func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	_, span := tracing.StartSpan(ctx, "usergpgkey.observe",
+		tracing.SpanAttrs("usergpgkey", tracing.ResourceName(mg), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.UserGPGKey)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotUserGPGKey)
+	}
+
+	var key *clients.GPGKey
+	adopted := false
+
+	// Gitea identifies GPG keys by ID. Until the resource has one, a key the
+	// user already has is adopted by its key ID rather than added again,
+	// which Gitea would reject.
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		key, err = e.find(ctx, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errListGPGKeys)
+		}
+		if key == nil {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		meta.SetExternalName(cr, strconv.FormatInt(key.ID, 10))
+		adopted = true
+	} else {
+		key, err = e.client.GetUserGPGKey(ctx, cr.Spec.ForProvider.Username, id)
+		if err != nil {
+			if strings.Contains(err.Error(), "404") {
+				return managed.ExternalObservation{ResourceExists: false}, nil
+			}
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetGPGKey)
+		}
+	}
+
+	cr.Status.AtProvider = observation(key)
+	cr.SetConditions(xpv1.Available())
+
+	// Gitea cannot edit GPG keys, and the key is immutable in the spec, so
+	// an existing key is always up to date.
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        true,
+		ResourceLateInitialized: adopted,
+	}, nil
+}
+
+func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	_, span := tracing.StartSpan(ctx, "usergpgkey.create",
+		tracing.SpanAttrs("usergpgkey", tracing.ResourceName(mg), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.UserGPGKey)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotUserGPGKey)
+	}
+
+	req := &clients.CreateGPGKeyRequest{ArmoredKey: cr.Spec.ForProvider.ArmoredPublicKey}
+	if cr.Spec.ForProvider.ArmoredSignature != nil {
+		req.ArmoredSignature = *cr.Spec.ForProvider.ArmoredSignature
+	}
+
+	key, err := e.client.CreateUserGPGKey(ctx, cr.Spec.ForProvider.Username, req)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateGPGKey)
+	}
+
+	meta.SetExternalName(cr, strconv.FormatInt(key.ID, 10))
+	cr.Status.AtProvider = observation(key)
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	_, span := tracing.StartSpan(ctx, "usergpgkey.delete",
+		tracing.SpanAttrs("usergpgkey", tracing.ResourceName(mg), "delete")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.UserGPGKey)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotUserGPGKey)
+	}
+
+	keyID, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalDelete{}, nil
+	}
+
+	err = e.client.DeleteUserGPGKey(ctx, cr.Spec.ForProvider.Username, keyID)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteGPGKey)
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *externalClient) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// find returns the key of the user whose key ID matches the armored public
+// key of cr, or nil if there is none. A key that cannot be parsed is never
+// found, leaving Gitea to report what is wrong with it on creation.
+func (e *externalClient) find(ctx context.Context, cr *v2.UserGPGKey) (*clients.GPGKey, error) {
+	want, err := armoredKeyID(cr.Spec.ForProvider.ArmoredPublicKey)
+	if err != nil {
+		return nil, nil
+	}
+
+	keys, err := e.client.ListUserGPGKeys(ctx, cr.Spec.ForProvider.Username)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		if strings.EqualFold(k.KeyID, want) {
+			return k, nil
+		}
+	}
+	return nil, nil
+}
+
+// armoredKeyID returns the ID of the primary key of an ASCII-armored public
+// key, in the form Gitea reports it.
+func armoredKeyID(armored string) (string, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armored))
+	if err != nil {
+		return "", err
+	}
+	if len(entities) == 0 || entities[0].PrimaryKey == nil {
+		return "", errors.New(errNoPublicKey)
+	}
+	return entities[0].PrimaryKey.KeyIdString(), nil
+}
+
+// observation reports a GPG key in the status.
+func observation(key *clients.GPGKey) v2.UserGPGKeyObservation {
+	obs := v2.UserGPGKeyObservation{
+		ID:           &key.ID,
+		KeyID:        &key.KeyID,
+		PrimaryKeyID: &key.PrimaryKeyID,
+		Verified:     &key.Verified,
+		CanSign:      &key.CanSign,
+		CreatedAt:    &key.CreatedAt,
+		ExpiresAt:    &key.ExpiresAt,
+	}
+	for _, email := range key.Emails {
+		obs.Emails = append(obs.Emails, email.Email)
+	}
+	return obs
+}
+
+// Setup adds a controller that reconciles UserGPGKey managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v2.UserGPGKeyKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v2.UserGPGKeyGroupVersionKind),
+		managed.WithExternalConnector(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v2.UserGPGKey{}).
+		Complete(r)
+}
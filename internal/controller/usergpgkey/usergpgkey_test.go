@@ -0,0 +1,168 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usergpgkey
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-gitea/apis/usergpgkey/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"       //nolint:staticcheck // Generates test keys only.
+	"golang.org/x/crypto/openpgp/armor" //nolint:staticcheck // Generates test keys only.
+)
+
+const armored = "-----BEGIN PGP PUBLIC KEY BLOCK-----\n\nmQENBGb...\n-----END PGP PUBLIC KEY BLOCK-----\n"
+
+type mockGPGKeyClient struct {
+	testutil.NoopClient
+	key     *clients.GPGKey
+	created *clients.CreateGPGKeyRequest
+	deleted int64
+}
+
+func (m *mockGPGKeyClient) ListUserGPGKeys(ctx context.Context, username string) ([]*clients.GPGKey, error) {
+	if m.key == nil {
+		return nil, nil
+	}
+	return []*clients.GPGKey{{ID: 3, KeyID: "0123456789ABCDEF"}, m.key}, nil
+}
+
+func (m *mockGPGKeyClient) GetUserGPGKey(ctx context.Context, username string, id int64) (*clients.GPGKey, error) {
+	if m.key == nil || m.key.ID != id {
+		return nil, fmt.Errorf("API request failed with status 404: not found")
+	}
+	return m.key, nil
+}
+
+func (m *mockGPGKeyClient) CreateUserGPGKey(ctx context.Context, username string, req *clients.CreateGPGKeyRequest) (*clients.GPGKey, error) {
+	m.created = req
+	return &clients.GPGKey{ID: 12, KeyID: "3AA5C34371567BD2"}, nil
+}
+
+func (m *mockGPGKeyClient) DeleteUserGPGKey(ctx context.Context, username string, id int64) error {
+	m.deleted = id
+	return nil
+}
+
+func newKey() *v2.UserGPGKey {
+	return &v2.UserGPGKey{Spec: v2.UserGPGKeySpec{ForProvider: v2.UserGPGKeyParameters{
+		Username:         "jane",
+		ArmoredPublicKey: armored,
+	}}}
+}
+
+func TestObserve(t *testing.T) {
+	t.Run("key not created yet does not exist", func(t *testing.T) {
+		ec := &externalClient{client: &mockGPGKeyClient{}}
+		cr := newKey()
+		meta.SetExternalName(cr, "jane-signing-key")
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+
+	t.Run("existing key is available", func(t *testing.T) {
+		ec := &externalClient{client: &mockGPGKeyClient{key: &clients.GPGKey{
+			ID:       12,
+			KeyID:    "3AA5C34371567BD2",
+			CanSign:  true,
+			Verified: true,
+			Emails:   []*clients.GPGKeyEmail{{Email: "jane@example.com", Verified: true}},
+		}}}
+		cr := newKey()
+		meta.SetExternalName(cr, "12")
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.Equal(t, "3AA5C34371567BD2", *cr.Status.AtProvider.KeyID)
+		assert.Equal(t, []string{"jane@example.com"}, cr.Status.AtProvider.Emails)
+		assert.Equal(t, xpv1.ReasonAvailable, cr.GetCondition(xpv1.TypeReady).Reason)
+	})
+}
+
+// armoredTestKey returns a freshly generated ASCII-armored public key and
+// its key ID.
+func armoredTestKey(t *testing.T) (string, string) {
+	t.Helper()
+	e, err := openpgp.NewEntity("Jane", "", "jane@example.com", nil)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, e.Serialize(w))
+	require.NoError(t, w.Close())
+	return buf.String(), e.PrimaryKey.KeyIdString()
+}
+
+func TestObserveAdopts(t *testing.T) {
+	key, keyID := armoredTestKey(t)
+
+	t.Run("matching key is adopted", func(t *testing.T) {
+		ec := &externalClient{client: &mockGPGKeyClient{key: &clients.GPGKey{ID: 12, KeyID: keyID}}}
+		cr := newKey()
+		cr.Spec.ForProvider.ArmoredPublicKey = key
+		meta.SetExternalName(cr, "jane-signing-key")
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.True(t, obs.ResourceLateInitialized)
+		assert.Equal(t, "12", meta.GetExternalName(cr))
+		assert.Equal(t, keyID, *cr.Status.AtProvider.KeyID)
+	})
+
+	t.Run("other keys are not adopted", func(t *testing.T) {
+		ec := &externalClient{client: &mockGPGKeyClient{key: &clients.GPGKey{ID: 12, KeyID: "FEDCBA9876543210"}}}
+		cr := newKey()
+		cr.Spec.ForProvider.ArmoredPublicKey = key
+		meta.SetExternalName(cr, "jane-signing-key")
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+		assert.Equal(t, "jane-signing-key", meta.GetExternalName(cr))
+	})
+}
+
+func TestCreateAndDelete(t *testing.T) {
+	m := &mockGPGKeyClient{}
+	ec := &externalClient{client: m}
+	cr := newKey()
+	signature := "-----BEGIN PGP SIGNATURE-----"
+	cr.Spec.ForProvider.ArmoredSignature = &signature
+
+	_, err := ec.Create(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, armored, m.created.ArmoredKey)
+	assert.Equal(t, signature, m.created.ArmoredSignature)
+	assert.Equal(t, "12", meta.GetExternalName(cr))
+
+	_, err = ec.Delete(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), m.deleted)
+}
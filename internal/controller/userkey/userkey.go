@@ -0,0 +1,310 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userkey
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-gitea/apis/userkey/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/sshkey"
+	"github.com/rossigee/provider-gitea/internal/tracing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/rossigee/provider-gitea/apis/v1beta1"
+)
+
+const (
+	errNotUserKey        = "managed resource is not a UserKey custom resource"
+	errGetUserKey        = "failed to get user key"
+	errCreateUserKey     = "failed to create user key"
+	errDeleteUserKey     = "failed to delete user key"
+	errGetProviderConfig = "failed to get provider config"
+	errGenerateKey       = "failed to generate user key"
+	errReplaceKey        = "failed to replace user key"
+	errDeleteRetiredKey  = "failed to delete retired user key"
+)
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient by:
+// 1. Getting the provider config
+// 2. Creating a Gitea API client
+// 3. Returning an external client wrapping the API client
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v2.UserKey)
+	if !ok {
+		return nil, errors.New(errNotUserKey)
+	}
+
+	pcRef := cr.Spec.ProviderConfigReference
+	if pcRef == nil {
+		return nil, errors.New("providerConfigRef is required")
+	}
+
+	var pc v1beta1.ProviderConfig
+	if err := c.kube.Get(ctx, client.ObjectKey{
+		Namespace: cr.GetNamespace(),
+		Name:      pcRef.Name,
+	}, &pc); err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
+
+	conn, err := clients.NewClient(ctx, &pc, c.kube)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalClient{client: conn, kube: c.kube, baseURL: pc.Spec.BaseURL}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it matches the managed resource's desired state.
+type externalClient struct {
+	client  clients.Client
+	kube    client.Client
+	baseURL string
+}
+
+func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	_, span := tracing.StartSpan(ctx, "userkey.observe",
+		tracing.SpanAttrs("userkey", tracing.ResourceName(mg), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.UserKey)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotUserKey)
+	}
+
+	externalID := meta.GetExternalName(cr)
+	if externalID == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	keyID, err := strconv.ParseInt(externalID, 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	key, err := e.client.GetUserKey(ctx, cr.Spec.ForProvider.Username, keyID)
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetUserKey)
+	}
+
+	cr.Status.AtProvider = v2.UserKeyObservation{
+		ID:          &key.ID,
+		Title:       &key.Title,
+		Fingerprint: &key.Fingerprint,
+		PublicKey:   &key.Key,
+		CreatedAt:   &key.CreatedAt,
+		URL:         &key.URL,
+		Username:    &cr.Spec.ForProvider.Username,
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	// Gitea does not allow user keys to be edited, so an existing key is
+	// only out of date when a generated key has been asked to regenerate or
+	// the key it replaced has yet to be deleted.
+	_, retired := sshkey.Retired(cr)
+	upToDate := !retired && (cr.Spec.ForProvider.GenerateKey == nil || !sshkey.Regeneration.Requested(cr))
+
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: upToDate}, nil
+}
+
+func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	_, span := tracing.StartSpan(ctx, "userkey.create",
+		tracing.SpanAttrs("userkey", tracing.ResourceName(mg), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.UserKey)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotUserKey)
+	}
+
+	if cr.Spec.ForProvider.GenerateKey != nil {
+		id, cd, err := e.generate(ctx, cr, cr.Spec.ForProvider.Title)
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errCreateUserKey)
+		}
+		meta.SetExternalName(cr, strconv.FormatInt(id, 10))
+		sshkey.Regeneration.Mark(cr)
+		return managed.ExternalCreation{ConnectionDetails: cd}, nil
+	}
+
+	key, err := e.client.CreateUserKey(ctx, cr.Spec.ForProvider.Username, &clients.CreateUserKeyRequest{
+		Title: cr.Spec.ForProvider.Title,
+		Key:   cr.Spec.ForProvider.Key,
+	})
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateUserKey)
+	}
+
+	meta.SetExternalName(cr, strconv.FormatInt(key.ID, 10))
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	_, span := tracing.StartSpan(ctx, "userkey.update",
+		tracing.SpanAttrs("userkey", tracing.ResourceName(mg), "update")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.UserKey)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotUserKey)
+	}
+
+	// A replaced key is only deleted once the reconciler has published the
+	// connection details of its replacement, so consumers are never left
+	// without a working key.
+	if err := e.deleteRetired(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// User keys are immutable in Gitea, so the only other update is
+	// replacing a generated key whose regeneration was requested.
+	if cr.Spec.ForProvider.GenerateKey == nil || !sshkey.Regeneration.Requested(cr) {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	newID, cd, err := e.generate(ctx, cr, sshkey.Title(cr.Spec.ForProvider.Title, cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errReplaceKey)
+	}
+
+	if err := sshkey.RecordRegeneration(ctx, e.kube, cr, strconv.FormatInt(newID, 10)); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errReplaceKey)
+	}
+
+	return managed.ExternalUpdate{ConnectionDetails: cd}, nil
+}
+
+func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	_, span := tracing.StartSpan(ctx, "userkey.delete",
+		tracing.SpanAttrs("userkey", tracing.ResourceName(mg), "delete")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.UserKey)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotUserKey)
+	}
+
+	if err := e.deleteRetired(ctx, cr); err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
+	keyID, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalDelete{}, nil
+	}
+
+	err = e.client.DeleteUserKey(ctx, cr.Spec.ForProvider.Username, keyID)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteUserKey)
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *externalClient) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// deleteRetired deletes the key superseded by the current one, if any.
+func (e *externalClient) deleteRetired(ctx context.Context, cr *v2.UserKey) error {
+	id, ok := sshkey.Retired(cr)
+	if !ok {
+		return nil
+	}
+	err := e.client.DeleteUserKey(ctx, cr.Spec.ForProvider.Username, id)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return errors.Wrap(err, errDeleteRetiredKey)
+	}
+	return errors.Wrap(sshkey.ForgetRetired(ctx, e.kube, cr), errDeleteRetiredKey)
+}
+
+// generate registers a freshly generated keypair under title and returns the
+// new key ID along with connection details carrying the private key.
+func (e *externalClient) generate(ctx context.Context, cr *v2.UserKey, title string) (int64, managed.ConnectionDetails, error) {
+	gen := cr.Spec.ForProvider.GenerateKey
+	bits := 0
+	if gen.Bits != nil {
+		bits = *gen.Bits
+	}
+
+	kp, err := sshkey.Generate(gen.Algorithm, bits, cr.Spec.ForProvider.Title)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, errGenerateKey)
+	}
+
+	key, err := e.client.CreateUserKey(ctx, cr.Spec.ForProvider.Username, &clients.CreateUserKeyRequest{
+		Title: title,
+		Key:   kp.PublicKey,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	cd := managed.ConnectionDetails{
+		"privateKey": []byte(kp.PrivateKey),
+		"publicKey":  []byte(kp.PublicKey),
+	}
+
+	// User keys are not tied to a repository, so the host key is scanned on
+	// the standard SSH port of the Gitea host. known_hosts is a convenience
+	// for consumers, so an unreachable SSH server is not an error.
+	if u, err := url.Parse(e.baseURL); err == nil && u.Hostname() != "" {
+		if kh, err := sshkey.KnownHosts(ctx, net.JoinHostPort(u.Hostname(), "22")); err == nil {
+			cd["knownHosts"] = []byte(kh)
+		}
+	}
+
+	return key.ID, cd, nil
+}
+
+// Setup adds a controller that reconciles UserKey managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v2.UserKeyKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v2.UserKeyGroupVersionKind),
+		managed.WithExternalConnector(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v2.UserKey{}).
+		Complete(r)
+}
@@ -44,9 +44,33 @@ const (
 	errUpdateWebhook      = "failed to update webhook"
 	errDeleteWebhook      = "failed to delete webhook"
 	errGetProviderConfig  = "failed to get provider config"
-	errInvalidExternalID  = "invalid external-id format, expected owner/repo/id or org/id"
+	errInvalidExternalID  = "invalid external-id format, expected owner/repo/id, org/id or admin:id"
+
+	// adminIDPrefix starts the external ID of instance-wide webhooks. Gitea
+	// user and organization names cannot contain a colon, so admin:id cannot
+	// be mistaken for the org/id and owner/repo/id forms.
+	adminIDPrefix = "admin:"
+
+	adminScopeSystem = "system"
+)
+
+// hookScope is where a webhook is registered.
+type hookScope int
+
+const (
+	scopeRepository hookScope = iota
+	scopeOrganization
+	scopeAdmin
 )
 
+// hookRef identifies a webhook within its scope.
+type hookRef struct {
+	scope hookScope
+	owner string
+	repo  string
+	id    int64
+}
+
 // A connector is expected to produce an ExternalClient when its Connect method is called.
 type connector struct {
 	kube client.Client
@@ -104,17 +128,12 @@ func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (mana
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
 
-	owner, repo, webhookID, isOrg, err := parseExternalID(externalID)
+	ref, err := parseExternalID(externalID)
 	if err != nil {
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
 
-	var webhook *clients.Webhook
-	if isOrg {
-		webhook, err = e.client.GetOrganizationWebhook(ctx, owner, webhookID)
-	} else {
-		webhook, err = e.client.GetRepositoryWebhook(ctx, owner, repo, webhookID)
-	}
+	webhook, err := e.get(ctx, ref)
 	if err != nil {
 		if strings.Contains(err.Error(), "404") {
 			return managed.ExternalObservation{ResourceExists: false}, nil
@@ -156,8 +175,8 @@ func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (manag
 		repo = *cr.Spec.ForProvider.Repository
 	}
 
-	if owner == "" {
-		return managed.ExternalCreation{}, errors.New("either organization, owner, or repository is required")
+	if owner == "" && cr.Spec.ForProvider.AdminScope == nil {
+		return managed.ExternalCreation{}, errors.New("either organization, owner, repository or adminScope is required")
 	}
 
 	webhookType := "gitea"
@@ -196,10 +215,17 @@ func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (manag
 
 	var webhook *clients.Webhook
 	var err error
-
-	if repo != "" {
+	ref := hookRef{owner: owner, repo: repo}
+
+	switch {
+	case cr.Spec.ForProvider.AdminScope != nil:
+		ref = hookRef{scope: scopeAdmin}
+		createReq.Config["is_system_webhook"] = strconv.FormatBool(*cr.Spec.ForProvider.AdminScope == adminScopeSystem)
+		webhook, err = e.client.CreateAdminWebhook(ctx, createReq)
+	case repo != "":
 		webhook, err = e.client.CreateRepositoryWebhook(ctx, owner, repo, createReq)
-	} else {
+	default:
+		ref.scope = scopeOrganization
 		webhook, err = e.client.CreateOrganizationWebhook(ctx, owner, createReq)
 	}
 
@@ -207,8 +233,8 @@ func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (manag
 		return managed.ExternalCreation{}, errors.Wrap(err, errCreateWebhook)
 	}
 
-	externalID := buildExternalID(owner, repo, webhook.ID, repo == "")
-	meta.SetExternalName(cr, externalID)
+	ref.id = webhook.ID
+	meta.SetExternalName(cr, buildExternalID(ref))
 
 	cr.Status.AtProvider = v2.WebhookObservation{
 		ID:        &webhook.ID,
@@ -230,7 +256,7 @@ func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (manag
 	}
 
 	externalID := meta.GetExternalName(cr)
-	owner, repo, webhookID, isOrg, err := parseExternalID(externalID)
+	ref, err := parseExternalID(externalID)
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, errInvalidExternalID)
 	}
@@ -246,10 +272,13 @@ func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (manag
 		updateReq.Events = &events
 	}
 
-	if isOrg {
-		_, err = e.client.UpdateOrganizationWebhook(ctx, owner, webhookID, updateReq)
-	} else {
-		_, err = e.client.UpdateRepositoryWebhook(ctx, owner, repo, webhookID, updateReq)
+	switch ref.scope {
+	case scopeAdmin:
+		_, err = e.client.UpdateAdminWebhook(ctx, ref.id, updateReq)
+	case scopeOrganization:
+		_, err = e.client.UpdateOrganizationWebhook(ctx, ref.owner, ref.id, updateReq)
+	default:
+		_, err = e.client.UpdateRepositoryWebhook(ctx, ref.owner, ref.repo, ref.id, updateReq)
 	}
 
 	if err != nil {
@@ -270,16 +299,19 @@ func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (manag
 	}
 
 	externalID := meta.GetExternalName(cr)
-	owner, repo, webhookID, isOrg, err := parseExternalID(externalID)
+	ref, err := parseExternalID(externalID)
 	if err != nil {
 		return managed.ExternalDelete{}, errors.Wrap(err, errInvalidExternalID)
 	}
 
 	var errDel error
-	if isOrg {
-		errDel = e.client.DeleteOrganizationWebhook(ctx, owner, webhookID)
-	} else {
-		errDel = e.client.DeleteRepositoryWebhook(ctx, owner, repo, webhookID)
+	switch ref.scope {
+	case scopeAdmin:
+		errDel = e.client.DeleteAdminWebhook(ctx, ref.id)
+	case scopeOrganization:
+		errDel = e.client.DeleteOrganizationWebhook(ctx, ref.owner, ref.id)
+	default:
+		errDel = e.client.DeleteRepositoryWebhook(ctx, ref.owner, ref.repo, ref.id)
 	}
 
 	return managed.ExternalDelete{}, errors.Wrap(errDel, errDeleteWebhook)
@@ -289,39 +321,56 @@ func (e *externalClient) Disconnect(ctx context.Context) error {
 	return nil
 }
 
-func parseExternalID(externalID string) (owner, repo string, id int64, isOrg bool, err error) {
-	parts := strings.Split(externalID, "/")
-	if len(parts) < 2 {
-		return "", "", 0, false, errors.New(errInvalidExternalID)
+func (e *externalClient) get(ctx context.Context, ref hookRef) (*clients.Webhook, error) {
+	switch ref.scope {
+	case scopeAdmin:
+		return e.client.GetAdminWebhook(ctx, ref.id)
+	case scopeOrganization:
+		return e.client.GetOrganizationWebhook(ctx, ref.owner, ref.id)
+	default:
+		return e.client.GetRepositoryWebhook(ctx, ref.owner, ref.repo, ref.id)
 	}
+}
 
-	if len(parts) == 3 {
-		owner = parts[0]
-		repo = parts[1]
-		id, err = strconv.ParseInt(parts[2], 10, 64)
+// parseExternalID parses the owner/repo/id, org/id and admin:id external
+// ID forms of repository, organization and instance-wide webhooks.
+func parseExternalID(externalID string) (hookRef, error) {
+	if strings.HasPrefix(externalID, adminIDPrefix) {
+		id, err := strconv.ParseInt(strings.TrimPrefix(externalID, adminIDPrefix), 10, 64)
 		if err != nil {
-			return "", "", 0, false, errors.New(errInvalidExternalID)
+			return hookRef{}, errors.New(errInvalidExternalID)
 		}
-		return owner, repo, id, false, nil
+		return hookRef{scope: scopeAdmin, id: id}, nil
 	}
 
-	if len(parts) == 2 {
-		owner = parts[0]
-		id, err = strconv.ParseInt(parts[1], 10, 64)
+	parts := strings.Split(externalID, "/")
+	switch len(parts) {
+	case 3:
+		id, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil {
+			return hookRef{}, errors.New(errInvalidExternalID)
+		}
+		return hookRef{scope: scopeRepository, owner: parts[0], repo: parts[1], id: id}, nil
+	case 2:
+		id, err := strconv.ParseInt(parts[1], 10, 64)
 		if err != nil {
-			return "", "", 0, false, errors.New(errInvalidExternalID)
+			return hookRef{}, errors.New(errInvalidExternalID)
 		}
-		return owner, "", id, true, nil
+		return hookRef{scope: scopeOrganization, owner: parts[0], id: id}, nil
 	}
 
-	return "", "", 0, false, errors.New(errInvalidExternalID)
+	return hookRef{}, errors.New(errInvalidExternalID)
 }
 
-func buildExternalID(owner, repo string, id int64, isOrg bool) string {
-	if isOrg {
-		return fmt.Sprintf("%s/%d", owner, id)
+func buildExternalID(ref hookRef) string {
+	switch ref.scope {
+	case scopeAdmin:
+		return fmt.Sprintf("%s%d", adminIDPrefix, ref.id)
+	case scopeOrganization:
+		return fmt.Sprintf("%s/%d", ref.owner, ref.id)
+	default:
+		return fmt.Sprintf("%s/%s/%d", ref.owner, ref.repo, ref.id)
 	}
-	return fmt.Sprintf("%s/%s/%d", owner, repo, id)
 }
 
 func isWebhookUpToDate(cr *v2.Webhook, webhook *clients.Webhook) bool {
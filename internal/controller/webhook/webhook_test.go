@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/rossigee/provider-gitea/apis/webhook/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAdminHookClient struct {
+	testutil.NoopClient
+	created *clients.CreateWebhookRequest
+	hook    *clients.Webhook
+	updated int64
+	deleted int64
+}
+
+func (m *mockAdminHookClient) CreateAdminWebhook(ctx context.Context, req *clients.CreateWebhookRequest) (*clients.Webhook, error) {
+	m.created = req
+	return &clients.Webhook{ID: 5}, nil
+}
+
+func (m *mockAdminHookClient) GetAdminWebhook(ctx context.Context, id int64) (*clients.Webhook, error) {
+	return m.hook, nil
+}
+
+func (m *mockAdminHookClient) UpdateAdminWebhook(ctx context.Context, id int64, req *clients.UpdateWebhookRequest) (*clients.Webhook, error) {
+	m.updated = id
+	return m.hook, nil
+}
+
+func (m *mockAdminHookClient) DeleteAdminWebhook(ctx context.Context, id int64) error {
+	m.deleted = id
+	return nil
+}
+
+func TestParseExternalID(t *testing.T) {
+	cases := map[string]struct {
+		id   string
+		want hookRef
+	}{
+		"repository":   {id: "acme/app/3", want: hookRef{scope: scopeRepository, owner: "acme", repo: "app", id: 3}},
+		"organization": {id: "acme/4", want: hookRef{scope: scopeOrganization, owner: "acme", id: 4}},
+		"admin":        {id: "admin:5", want: hookRef{scope: scopeAdmin, id: 5}},
+		"repository named like the admin form": {
+			id:   "admin/system/6",
+			want: hookRef{scope: scopeRepository, owner: "admin", repo: "system", id: 6},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseExternalID(tc.id)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.id, buildExternalID(got))
+		})
+	}
+
+	for _, id := range []string{"admin:", "admin:x", "acme", "acme/app/x"} {
+		_, err := parseExternalID(id)
+		assert.Error(t, err, id)
+	}
+}
+
+func TestAdminWebhook(t *testing.T) {
+	newHook := func(scope string) *v2.Webhook {
+		return &v2.Webhook{Spec: v2.WebhookSpec{ForProvider: v2.WebhookParameters{
+			AdminScope: &scope,
+			URL:        "https://audit.example.com/gitea",
+		}}}
+	}
+
+	t.Run("system hook", func(t *testing.T) {
+		m := &mockAdminHookClient{}
+		ec := &externalClient{client: m}
+		cr := newHook("system")
+		secret := "s3cret"
+		cr.Spec.ForProvider.Secret = &secret
+
+		_, err := ec.Create(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, "admin:5", meta.GetExternalName(cr))
+		assert.Equal(t, "true", m.created.Config["is_system_webhook"])
+		assert.Equal(t, "s3cret", m.created.Config["secret"])
+	})
+
+	t.Run("default hook", func(t *testing.T) {
+		m := &mockAdminHookClient{}
+		ec := &externalClient{client: m}
+
+		_, err := ec.Create(context.Background(), newHook("default"))
+		require.NoError(t, err)
+		assert.Equal(t, "false", m.created.Config["is_system_webhook"])
+	})
+
+	t.Run("observe, update and delete use the admin API", func(t *testing.T) {
+		m := &mockAdminHookClient{hook: &clients.Webhook{
+			ID:     5,
+			Active: true,
+			Config: map[string]string{"url": "https://old.example.com/gitea"},
+		}}
+		ec := &externalClient{client: m}
+		cr := newHook("system")
+		meta.SetExternalName(cr, "admin:5")
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.False(t, obs.ResourceUpToDate)
+
+		_, err = ec.Update(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), m.updated)
+
+		_, err = ec.Delete(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), m.deleted)
+	})
+}
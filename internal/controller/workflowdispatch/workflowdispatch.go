@@ -0,0 +1,310 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflowdispatch
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-gitea/apis/workflowdispatch/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/tracing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/rossigee/provider-gitea/apis/v1beta1"
+)
+
+const (
+	errNotWorkflowDispatch = "managed resource is not a WorkflowDispatch custom resource"
+	errListRuns            = "failed to list workflow runs"
+	errGetRun              = "failed to get workflow run"
+	errListJobs            = "failed to list workflow run jobs"
+	errDispatch            = "failed to dispatch workflow"
+	errRecordDispatch      = "failed to record workflow dispatch"
+	errGetProviderConfig   = "failed to get provider config"
+
+	// AnnotationDispatchedGeneration records the spec generation the
+	// workflow was last dispatched for.
+	AnnotationDispatchedGeneration = "gitea.m.crossplane.io/dispatched-generation"
+
+	// AnnotationDispatchedAfterRun records the newest workflow_dispatch run
+	// of the workflow before the last dispatch. The dispatched run is the
+	// first one after it.
+	AnnotationDispatchedAfterRun = "gitea.m.crossplane.io/dispatched-after-run"
+
+	// AnnotationRunPending marks a dispatch whose run has not been
+	// identified yet. Once it is, the run ID becomes the external name.
+	AnnotationRunPending = "gitea.m.crossplane.io/run-pending"
+
+	eventWorkflowDispatch = "workflow_dispatch"
+	statusCompleted       = "completed"
+	conclusionSuccess     = "success"
+)
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient by:
+// 1. Getting the provider config
+// 2. Creating a Gitea API client
+// 3. Returning an external client wrapping the API client
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v2.WorkflowDispatch)
+	if !ok {
+		return nil, errors.New(errNotWorkflowDispatch)
+	}
+
+	pcRef := cr.Spec.ProviderConfigReference
+	if pcRef == nil {
+		return nil, errors.New("providerConfigRef is required")
+	}
+
+	var pc v1beta1.ProviderConfig
+	if err := c.kube.Get(ctx, client.ObjectKey{
+		Namespace: cr.GetNamespace(),
+		Name:      pcRef.Name,
+	}, &pc); err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
+
+	conn, err := clients.NewClient(ctx, &pc, c.kube)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalClient{client: conn, kube: c.kube}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it matches the managed resource's desired state.
+type externalClient struct {
+	client clients.Client
+	kube   client.Client
+}
+
+func (e *externalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	_, span := tracing.StartSpan(ctx, "workflowdispatch.observe",
+		tracing.SpanAttrs("workflowdispatch", tracing.ResourceName(mg), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.WorkflowDispatch)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotWorkflowDispatch)
+	}
+
+	// Runs are kept as workflow history, so there is nothing left to wait
+	// for once the resource is deleted.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	generation, dispatched := cr.GetAnnotations()[AnnotationDispatchedGeneration]
+	if !dispatched {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if generation != strconv.FormatInt(cr.GetGeneration(), 10) {
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, nil
+	}
+
+	p := cr.Spec.ForProvider
+	lateInitialized := false
+	_, pending := cr.GetAnnotations()[AnnotationRunPending]
+	runID, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if pending || err != nil {
+		run, err := e.dispatchedRun(ctx, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if run == nil {
+			cr.SetConditions(xpv1.Creating().WithMessage("waiting for the dispatched workflow run"))
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+		}
+		runID = run.ID
+		meta.SetExternalName(cr, strconv.FormatInt(runID, 10))
+		meta.RemoveAnnotations(cr, AnnotationRunPending)
+		lateInitialized = true
+	}
+
+	run, err := e.client.GetActionRun(ctx, p.Repository, runID)
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			cr.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf("workflow run %d no longer exists", runID)))
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: lateInitialized}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetRun)
+	}
+
+	jobs, err := e.client.ListActionRunJobs(ctx, p.Repository, runID)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return managed.ExternalObservation{}, errors.Wrap(err, errListJobs)
+	}
+
+	cr.Status.AtProvider = v2.WorkflowDispatchObservation{
+		RunID:       &run.ID,
+		RunNumber:   &run.RunNumber,
+		Status:      &run.Status,
+		Conclusion:  &run.Conclusion,
+		RunURL:      &run.HTMLURL,
+		StartedAt:   &run.StartedAt,
+		CompletedAt: &run.CompletedAt,
+	}
+	for _, j := range jobs {
+		cr.Status.AtProvider.Jobs = append(cr.Status.AtProvider.Jobs, v2.WorkflowJob{
+			Name:        j.Name,
+			Status:      j.Status,
+			Conclusion:  j.Conclusion,
+			StartedAt:   j.StartedAt,
+			CompletedAt: j.CompletedAt,
+		})
+	}
+
+	switch {
+	case run.Conclusion == conclusionSuccess:
+		cr.SetConditions(xpv1.Available())
+	case run.Status == statusCompleted:
+		cr.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf("workflow run %d concluded with %s", run.RunNumber, run.Conclusion)))
+	default:
+		cr.SetConditions(xpv1.Creating().WithMessage(fmt.Sprintf("workflow run %d is %s", run.RunNumber, run.Status)))
+	}
+
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: lateInitialized}, nil
+}
+
+func (e *externalClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	_, span := tracing.StartSpan(ctx, "workflowdispatch.create",
+		tracing.SpanAttrs("workflowdispatch", tracing.ResourceName(mg), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.WorkflowDispatch)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotWorkflowDispatch)
+	}
+
+	return managed.ExternalCreation{}, e.dispatch(ctx, cr)
+}
+
+func (e *externalClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	_, span := tracing.StartSpan(ctx, "workflowdispatch.update",
+		tracing.SpanAttrs("workflowdispatch", tracing.ResourceName(mg), "update")...)
+	defer span.End()
+
+	cr, ok := mg.(*v2.WorkflowDispatch)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotWorkflowDispatch)
+	}
+
+	orig := cr.DeepCopy()
+	if err := e.dispatch(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	cr.Status.AtProvider = v2.WorkflowDispatchObservation{}
+
+	// The managed reconciler does not persist annotations set during
+	// Update, so the dispatch is recorded explicitly.
+	return managed.ExternalUpdate{}, errors.Wrap(e.kube.Patch(ctx, cr, client.MergeFrom(orig)), errRecordDispatch)
+}
+
+// Delete is a no-op. Completed runs are kept as workflow history.
+func (e *externalClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	if _, ok := mg.(*v2.WorkflowDispatch); !ok {
+		return managed.ExternalDelete{}, errors.New(errNotWorkflowDispatch)
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *externalClient) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// dispatch triggers the workflow and records the dispatch in the
+// annotations of cr.
+func (e *externalClient) dispatch(ctx context.Context, cr *v2.WorkflowDispatch) error {
+	p := cr.Spec.ForProvider
+
+	runs, err := e.client.ListActionRuns(ctx, p.Repository, p.WorkflowName, eventWorkflowDispatch)
+	if err != nil {
+		return errors.Wrap(err, errListRuns)
+	}
+	var after int64
+	for _, r := range runs {
+		after = max(after, r.ID)
+	}
+
+	if err := e.client.DispatchWorkflow(ctx, p.Repository, p.WorkflowName, &clients.DispatchWorkflowRequest{
+		Ref:    p.Ref,
+		Inputs: p.Inputs,
+	}); err != nil {
+		return errors.Wrap(err, errDispatch)
+	}
+
+	// The run is identified by the next Observe.
+	meta.SetExternalName(cr, cr.GetName())
+	meta.AddAnnotations(cr, map[string]string{
+		AnnotationDispatchedGeneration: strconv.FormatInt(cr.GetGeneration(), 10),
+		AnnotationDispatchedAfterRun:   strconv.FormatInt(after, 10),
+		AnnotationRunPending:           "true",
+	})
+	return nil
+}
+
+// dispatchedRun returns the first workflow_dispatch run of the workflow
+// after the last dispatch, or nil if it has not appeared yet.
+func (e *externalClient) dispatchedRun(ctx context.Context, cr *v2.WorkflowDispatch) (*clients.ActionRun, error) {
+	p := cr.Spec.ForProvider
+	after, _ := strconv.ParseInt(cr.GetAnnotations()[AnnotationDispatchedAfterRun], 10, 64)
+
+	runs, err := e.client.ListActionRuns(ctx, p.Repository, p.WorkflowName, eventWorkflowDispatch)
+	if err != nil {
+		return nil, errors.Wrap(err, errListRuns)
+	}
+
+	var run *clients.ActionRun
+	for i := range runs {
+		if runs[i].ID > after && (run == nil || runs[i].ID < run.ID) {
+			run = &runs[i]
+		}
+	}
+	return run, nil
+}
+
+// Setup adds a controller that reconciles WorkflowDispatch managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v2.WorkflowDispatchKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v2.WorkflowDispatchGroupVersionKind),
+		managed.WithExternalConnector(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v2.WorkflowDispatch{}).
+		Complete(r)
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflowdispatch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-gitea/apis/workflowdispatch/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type mockDispatchClient struct {
+	testutil.NoopClient
+	runs       []clients.ActionRun
+	jobs       []clients.ActionJob
+	dispatched []*clients.DispatchWorkflowRequest
+}
+
+func (m *mockDispatchClient) ListActionRuns(ctx context.Context, repository, workflowName, event string) ([]clients.ActionRun, error) {
+	return m.runs, nil
+}
+
+func (m *mockDispatchClient) GetActionRun(ctx context.Context, repository string, runID int64) (*clients.ActionRun, error) {
+	for i := range m.runs {
+		if m.runs[i].ID == runID {
+			return &m.runs[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockDispatchClient) ListActionRunJobs(ctx context.Context, repository string, runID int64) ([]clients.ActionJob, error) {
+	return m.jobs, nil
+}
+
+func (m *mockDispatchClient) DispatchWorkflow(ctx context.Context, repository, workflowName string, req *clients.DispatchWorkflowRequest) error {
+	m.dispatched = append(m.dispatched, req)
+	return nil
+}
+
+func newDispatch(generation int64, annotations map[string]string) *v2.WorkflowDispatch {
+	cr := &v2.WorkflowDispatch{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "migrate", Generation: generation},
+		Spec: v2.WorkflowDispatchSpec{
+			ForProvider: v2.WorkflowDispatchParameters{
+				Repository:   "testorg/testrepo",
+				WorkflowName: "migrate.yml",
+				Ref:          "main",
+				Inputs:       map[string]string{"version": "v42"},
+			},
+		},
+	}
+	meta.SetExternalName(cr, "migrate")
+	meta.AddAnnotations(cr, annotations)
+	return cr
+}
+
+func TestObserve(t *testing.T) {
+	t.Run("never dispatched does not exist", func(t *testing.T) {
+		ec := &externalClient{client: &mockDispatchClient{}}
+
+		obs, err := ec.Observe(context.Background(), newDispatch(1, nil))
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+
+	t.Run("new generation needs dispatch", func(t *testing.T) {
+		ec := &externalClient{client: &mockDispatchClient{}}
+
+		obs, err := ec.Observe(context.Background(), newDispatch(2, map[string]string{AnnotationDispatchedGeneration: "1"}))
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.False(t, obs.ResourceUpToDate)
+	})
+
+	t.Run("adopts first run after dispatch", func(t *testing.T) {
+		ec := &externalClient{client: &mockDispatchClient{
+			runs: []clients.ActionRun{
+				{ID: 9, RunNumber: 3, Status: "in_progress"},
+				{ID: 8, RunNumber: 2, Status: "in_progress"},
+				{ID: 7, RunNumber: 1, Status: "completed", Conclusion: "success"},
+			},
+			jobs: []clients.ActionJob{{Name: "migrate", Status: "in_progress"}},
+		}}
+		cr := newDispatch(1, map[string]string{AnnotationDispatchedGeneration: "1", AnnotationDispatchedAfterRun: "7"})
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.True(t, obs.ResourceLateInitialized)
+		assert.Equal(t, "8", meta.GetExternalName(cr))
+		assert.NotContains(t, cr.GetAnnotations(), AnnotationRunPending)
+		assert.Equal(t, xpv1.ReasonCreating, cr.GetCondition(xpv1.TypeReady).Reason)
+		require.Len(t, cr.Status.AtProvider.Jobs, 1)
+	})
+
+	t.Run("numeric name is not mistaken for a run", func(t *testing.T) {
+		ec := &externalClient{client: &mockDispatchClient{
+			runs: []clients.ActionRun{{ID: 42, RunNumber: 1, Status: "completed", Conclusion: "success"}},
+		}}
+		cr := newDispatch(1, map[string]string{
+			AnnotationDispatchedGeneration: "1",
+			AnnotationDispatchedAfterRun:   "42",
+			AnnotationRunPending:           "true",
+		})
+		cr.SetName("42")
+		meta.SetExternalName(cr, "42")
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceLateInitialized)
+		assert.Equal(t, xpv1.ReasonCreating, cr.GetCondition(xpv1.TypeReady).Reason)
+		assert.Nil(t, cr.Status.AtProvider.RunID)
+	})
+
+	t.Run("deleted dispatch no longer exists", func(t *testing.T) {
+		ec := &externalClient{client: &mockDispatchClient{
+			runs: []clients.ActionRun{{ID: 8, RunNumber: 2, Status: "completed", Conclusion: "success"}},
+		}}
+		cr := newDispatch(1, map[string]string{AnnotationDispatchedGeneration: "1"})
+		meta.SetExternalName(cr, "8")
+		now := metav1.Now()
+		cr.SetDeletionTimestamp(&now)
+
+		obs, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+
+	t.Run("successful run is ready", func(t *testing.T) {
+		ec := &externalClient{client: &mockDispatchClient{
+			runs: []clients.ActionRun{{ID: 8, RunNumber: 2, Status: "completed", Conclusion: "success"}},
+		}}
+		cr := newDispatch(1, map[string]string{AnnotationDispatchedGeneration: "1"})
+		meta.SetExternalName(cr, "8")
+
+		_, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, xpv1.ReasonAvailable, cr.GetCondition(xpv1.TypeReady).Reason)
+		assert.Equal(t, "success", *cr.Status.AtProvider.Conclusion)
+	})
+
+	t.Run("failed run is unavailable", func(t *testing.T) {
+		ec := &externalClient{client: &mockDispatchClient{
+			runs: []clients.ActionRun{{ID: 8, RunNumber: 2, Status: "completed", Conclusion: "failure"}},
+		}}
+		cr := newDispatch(1, map[string]string{AnnotationDispatchedGeneration: "1"})
+		meta.SetExternalName(cr, "8")
+
+		_, err := ec.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, xpv1.ReasonUnavailable, cr.GetCondition(xpv1.TypeReady).Reason)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	m := &mockDispatchClient{runs: []clients.ActionRun{{ID: 4}, {ID: 6}}}
+	ec := &externalClient{client: m}
+	cr := newDispatch(1, nil)
+
+	_, err := ec.Create(context.Background(), cr)
+	require.NoError(t, err)
+	require.Len(t, m.dispatched, 1)
+	assert.Equal(t, "main", m.dispatched[0].Ref)
+	assert.Equal(t, "1", cr.GetAnnotations()[AnnotationDispatchedGeneration])
+	assert.Equal(t, "6", cr.GetAnnotations()[AnnotationDispatchedAfterRun])
+	assert.Equal(t, "true", cr.GetAnnotations()[AnnotationRunPending])
+}
+
+func TestUpdateRedispatches(t *testing.T) {
+	ctx := context.Background()
+
+	cr := newDispatch(2, map[string]string{AnnotationDispatchedGeneration: "1"})
+	meta.SetExternalName(cr, "8")
+	kube := fake.NewClientBuilder().WithScheme(testutil.Scheme(t)).WithObjects(cr.DeepCopy()).Build()
+	m := &mockDispatchClient{runs: []clients.ActionRun{{ID: 8}}}
+	ec := &externalClient{client: m, kube: kube}
+
+	_, err := ec.Update(ctx, cr)
+	require.NoError(t, err)
+	require.Len(t, m.dispatched, 1)
+
+	got := &v2.WorkflowDispatch{}
+	require.NoError(t, kube.Get(ctx, client.ObjectKeyFromObject(cr), got))
+	assert.Equal(t, "migrate", meta.GetExternalName(got))
+	assert.Equal(t, "8", got.GetAnnotations()[AnnotationDispatchedAfterRun])
+	assert.Equal(t, "true", got.GetAnnotations()[AnnotationRunPending])
+}
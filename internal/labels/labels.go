@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package labels reads the label catalogs of label sets and compares declared
+// labels with the labels in Gitea.
+package labels
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	labelsetv2 "github.com/rossigee/provider-gitea/apis/labelset/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+)
+
+const (
+	errGetConfigMap        = "failed to get label catalog ConfigMap"
+	errMissingConfigMapKey = "label catalog ConfigMap has no key %q"
+	errParseCatalog        = "failed to parse label catalog"
+	errInvalidCatalog      = "invalid label catalog"
+)
+
+// Catalog returns the labels of the set, read from the spec or from the
+// ConfigMap it references.
+func Catalog(ctx context.Context, kube client.Client, cr *labelsetv2.LabelSet) ([]labelsetv2.LabelDefinition, error) {
+	p := cr.Spec.ForProvider
+	if p.LabelsFrom == nil {
+		return p.Labels, nil
+	}
+
+	ref := p.LabelsFrom.ConfigMapKeyRef
+	cm := &corev1.ConfigMap{}
+	if err := kube.Get(ctx, client.ObjectKey{Namespace: cr.GetNamespace(), Name: ref.Name}, cm); err != nil {
+		return nil, errors.Wrap(err, errGetConfigMap)
+	}
+	data, ok := cm.Data[ref.Key]
+	if !ok {
+		return nil, errors.Errorf(errMissingConfigMapKey, ref.Key)
+	}
+
+	var catalog []labelsetv2.LabelDefinition
+	if err := yaml.UnmarshalStrict([]byte(data), &catalog); err != nil {
+		return nil, errors.Wrap(err, errParseCatalog)
+	}
+	seen := map[string]bool{}
+	for _, l := range catalog {
+		switch {
+		case l.Name == "" || l.Color == "":
+			return nil, errors.Errorf("%s: every label needs a name and a color", errInvalidCatalog)
+		case seen[l.Name]:
+			return nil, errors.Errorf("%s: label %s is listed twice", errInvalidCatalog, l.Name)
+		}
+		seen[l.Name] = true
+	}
+	return catalog, nil
+}
+
+// Targets reports whether the label set last reported syncing the
+// repository, given in owner/name format.
+func Targets(cr *labelsetv2.LabelSet, repo string) bool {
+	for _, s := range cr.Status.AtProvider.Repositories {
+		if strings.EqualFold(s.Repository, repo) {
+			return true
+		}
+	}
+	return false
+}
+
+// Definition is a declared label. The label types of the Organization and
+// LabelSet APIs convert to it.
+type Definition struct {
+	Name        string
+	Color       string
+	Description *string
+	Exclusive   *bool
+}
+
+// Change is a declared label that is missing from Gitea, when Existing is
+// nil, or differs from it.
+type Change struct {
+	Want     Definition
+	Existing *clients.Label
+}
+
+// Changes returns the declared labels that need to be created or updated.
+func Changes(want []Definition, have []*clients.Label) []Change {
+	var changes []Change
+	for _, w := range want {
+		var existing *clients.Label
+		for _, h := range have {
+			if h.Name == w.Name {
+				existing = h
+				break
+			}
+		}
+		if existing == nil || !Matches(w, existing) {
+			changes = append(changes, Change{Want: w, Existing: existing})
+		}
+	}
+	return changes
+}
+
+// Matches compares colors without the leading # and ignoring case, and the
+// description and exclusive flag only when set.
+func Matches(w Definition, h *clients.Label) bool {
+	if !strings.EqualFold(strings.TrimPrefix(w.Color, "#"), strings.TrimPrefix(h.Color, "#")) {
+		return false
+	}
+	if w.Description != nil && *w.Description != h.Description {
+		return false
+	}
+	if w.Exclusive != nil && *w.Exclusive != h.Exclusive {
+		return false
+	}
+	return true
+}
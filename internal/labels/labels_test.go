@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package labels
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rossigee/provider-gitea/internal/clients"
+)
+
+func TestChanges(t *testing.T) {
+	desc, exclusive := "Needs triage", true
+	want := []Definition{
+		{Name: "bug", Color: "#E11D21"},
+		{Name: "triage", Color: "fbca04", Description: &desc},
+		{Name: "priority/high", Color: "b60205", Exclusive: &exclusive},
+		{Name: "new", Color: "0e8a16"},
+	}
+	have := []*clients.Label{
+		{ID: 1, Name: "bug", Color: "e11d21", Description: "unchanged"},
+		{ID: 2, Name: "triage", Color: "#fbca04", Description: "old"},
+		{ID: 3, Name: "priority/high", Color: "b60205"},
+		{ID: 4, Name: "unmanaged", Color: "000000"},
+	}
+
+	changes := Changes(want, have)
+	require.Len(t, changes, 3)
+	assert.Equal(t, "triage", changes[0].Want.Name)
+	assert.Equal(t, int64(2), changes[0].Existing.ID)
+	assert.Equal(t, "priority/high", changes[1].Want.Name)
+	assert.Equal(t, int64(3), changes[1].Existing.ID)
+	assert.Equal(t, "new", changes[2].Want.Name)
+	assert.Nil(t, changes[2].Existing)
+}
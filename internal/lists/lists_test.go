@@ -0,0 +1,35 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lists
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSameSet(t *testing.T) {
+	assert.True(t, SameSet([]string{"b", "a"}, []string{"a", "b"}))
+	assert.True(t, SameSet(nil, []string{}))
+	assert.False(t, SameSet([]string{"a"}, []string{"a", "a"}))
+	assert.False(t, SameSet([]string{"a", "b"}, []string{"a", "c"}))
+}
+
+func TestOrEmpty(t *testing.T) {
+	assert.Equal(t, []string{}, OrEmpty(nil))
+	assert.Equal(t, []string{"a"}, OrEmpty([]string{"a"}))
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package regeneration lets users request a new provider-issued credential,
+// such as a keypair or a client secret, by changing an annotation.
+package regeneration
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const errCopyObject = "cannot copy object"
+
+// Annotations names the pair of annotations that track regeneration of a
+// credential.
+type Annotations struct {
+	// Request is changed by users whenever they want a new credential.
+	Request string
+
+	// Issued records the Request value the current credential was issued
+	// for.
+	Issued string
+}
+
+// Requested reports whether the request annotation of o has changed since
+// its credential was last issued.
+func (a Annotations) Requested(o client.Object) bool {
+	an := o.GetAnnotations()
+	return an[a.Request] != an[a.Issued]
+}
+
+// Mark records that the credential of o was issued for the current value of
+// its request annotation.
+func (a Annotations) Mark(o client.Object) {
+	if v, ok := o.GetAnnotations()[a.Request]; ok {
+		meta.AddAnnotations(o, map[string]string{a.Issued: v})
+	}
+}
+
+// Record marks o and persists the mark. The managed reconciler only persists
+// annotations set during Create, so a credential reissued during Update must
+// be recorded explicitly.
+func (a Annotations) Record(ctx context.Context, kube client.Client, o client.Object) error {
+	orig, ok := o.DeepCopyObject().(client.Object)
+	if !ok {
+		return errors.New(errCopyObject)
+	}
+	a.Mark(o)
+	return kube.Patch(ctx, o, client.MergeFrom(orig))
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regeneration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	repositorykeyv2 "github.com/rossigee/provider-gitea/apis/repositorykey/v2"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+)
+
+var annotations = Annotations{Request: "example.org/regenerate", Issued: "example.org/regenerated"}
+
+func TestRequestedAndMark(t *testing.T) {
+	cr := &repositorykeyv2.RepositoryKey{}
+	assert.False(t, annotations.Requested(cr), "no annotation means no regeneration")
+
+	meta.AddAnnotations(cr, map[string]string{annotations.Request: "1"})
+	assert.True(t, annotations.Requested(cr))
+
+	annotations.Mark(cr)
+	assert.False(t, annotations.Requested(cr))
+	assert.Equal(t, "1", cr.GetAnnotations()[annotations.Issued])
+}
+
+func TestRecord(t *testing.T) {
+	ctx := context.Background()
+	cr := &repositorykeyv2.RepositoryKey{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "key"}}
+	kube := fake.NewClientBuilder().WithScheme(testutil.Scheme(t)).WithObjects(cr.DeepCopy()).Build()
+
+	meta.AddAnnotations(cr, map[string]string{annotations.Request: "2"})
+	require.NoError(t, annotations.Record(ctx, kube, cr))
+
+	got := &repositorykeyv2.RepositoryKey{}
+	require.NoError(t, kube.Get(ctx, client.ObjectKeyFromObject(cr), got))
+	assert.Equal(t, "2", got.GetAnnotations()[annotations.Issued])
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sshkey generates SSH keypairs on behalf of key resources and tracks
+// requests to regenerate them.
+package sshkey
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/rossigee/provider-gitea/internal/regeneration"
+)
+
+// Supported key algorithms.
+const (
+	AlgorithmED25519 = "ed25519"
+	AlgorithmRSA     = "rsa"
+)
+
+// DefaultRSABits is the RSA key size used when none is requested.
+const DefaultRSABits = 4096
+
+const (
+	// AnnotationRegenerate requests a new keypair whenever its value changes.
+	AnnotationRegenerate = "gitea.m.crossplane.io/regenerate-key"
+
+	// AnnotationRegenerated records the AnnotationRegenerate value the
+	// current keypair was generated for.
+	AnnotationRegenerated = "gitea.m.crossplane.io/regenerated-key"
+
+	// AnnotationRetired records the ID of a key superseded by a regenerated
+	// one. It is deleted once the replacement has been published.
+	AnnotationRetired = "gitea.m.crossplane.io/retired-key"
+)
+
+// Regeneration tracks keypair regeneration requests.
+var Regeneration = regeneration.Annotations{Request: AnnotationRegenerate, Issued: AnnotationRegenerated}
+
+const (
+	errGenerateKey     = "failed to generate key"
+	errMarshalKey      = "failed to marshal private key"
+	errUnknownAlg      = "unknown key algorithm"
+	errParseSSHURL     = "failed to parse SSH URL"
+	errScanHostKey     = "failed to scan SSH host key"
+	errRecordKey       = "failed to record key"
+	defaultSSHPort     = "22"
+	hostKeyScanTimeout = 10 * time.Second
+)
+
+// KeyPair is an OpenSSH formatted keypair.
+type KeyPair struct {
+	// PrivateKey in OpenSSH PEM format.
+	PrivateKey string
+
+	// PublicKey in authorized_keys format.
+	PublicKey string
+}
+
+// Generate creates a keypair of the supplied algorithm. bits is only used for
+// RSA keys; zero selects DefaultRSABits. comment is appended to the public key.
+func Generate(algorithm string, bits int, comment string) (*KeyPair, error) {
+	var (
+		pub  ssh.PublicKey
+		priv *pem.Block
+		err  error
+	)
+
+	switch algorithm {
+	case AlgorithmED25519, "":
+		pk, sk, gerr := ed25519.GenerateKey(rand.Reader)
+		if gerr != nil {
+			return nil, errors.Wrap(gerr, errGenerateKey)
+		}
+		if pub, err = ssh.NewPublicKey(pk); err != nil {
+			return nil, errors.Wrap(err, errGenerateKey)
+		}
+		priv, err = ssh.MarshalPrivateKey(sk, comment)
+	case AlgorithmRSA:
+		if bits == 0 {
+			bits = DefaultRSABits
+		}
+		sk, gerr := rsa.GenerateKey(rand.Reader, bits)
+		if gerr != nil {
+			return nil, errors.Wrap(gerr, errGenerateKey)
+		}
+		if pub, err = ssh.NewPublicKey(&sk.PublicKey); err != nil {
+			return nil, errors.Wrap(err, errGenerateKey)
+		}
+		priv, err = ssh.MarshalPrivateKey(sk, comment)
+	default:
+		return nil, errors.Errorf("%s: %q", errUnknownAlg, algorithm)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errMarshalKey)
+	}
+
+	authorized := strings.TrimSuffix(string(ssh.MarshalAuthorizedKey(pub)), "\n")
+	if comment != "" {
+		authorized += " " + comment
+	}
+
+	return &KeyPair{
+		PrivateKey: string(pem.EncodeToMemory(priv)),
+		PublicKey:  authorized,
+	}, nil
+}
+
+// Address returns the host:port of an SSH clone URL. Both ssh:// URLs and
+// the scp-like user@host:path form are accepted.
+func Address(sshURL string) (string, error) {
+	if strings.Contains(sshURL, "://") {
+		u, err := url.Parse(sshURL)
+		if err != nil {
+			return "", errors.Wrap(err, errParseSSHURL)
+		}
+		if u.Port() == "" {
+			return net.JoinHostPort(u.Hostname(), defaultSSHPort), nil
+		}
+		return u.Host, nil
+	}
+
+	host, _, ok := strings.Cut(sshURL, ":")
+	if !ok {
+		return "", errors.Errorf("%s: %q", errParseSSHURL, sshURL)
+	}
+	if i := strings.LastIndex(host, "@"); i >= 0 {
+		host = host[i+1:]
+	}
+	return net.JoinHostPort(host, defaultSSHPort), nil
+}
+
+// KnownHosts connects to the SSH server at address and returns a known_hosts
+// line for the host key it presents. No authentication is attempted.
+func KnownHosts(ctx context.Context, address string) (string, error) {
+	var hostKey ssh.PublicKey
+	errCaptured := errors.New("host key captured")
+
+	d := net.Dialer{Timeout: hostKeyScanTimeout}
+	conn, err := d.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return "", errors.Wrap(err, errScanHostKey)
+	}
+	defer conn.Close() //nolint:errcheck // Nothing useful to do on close failure.
+
+	_ = conn.SetDeadline(time.Now().Add(hostKeyScanTimeout))
+	_, _, _, err = ssh.NewClientConn(conn, address, &ssh.ClientConfig{
+		User: "git",
+		HostKeyCallback: func(_ string, _ net.Addr, key ssh.PublicKey) error {
+			hostKey = key
+			return errCaptured
+		},
+	})
+	if hostKey == nil {
+		return "", errors.Wrap(err, errScanHostKey)
+	}
+
+	return knownhosts.Line([]string{knownhosts.Normalize(address)}, hostKey), nil
+}
+
+// Title returns the title a replacement for the key of o is registered
+// under. Gitea rejects a second key with the title of an existing one, so
+// the replacement, which is registered before the key it supersedes is
+// deleted, is told apart by the regeneration it satisfies.
+func Title(title string, o client.Object) string {
+	return title + "-" + o.GetAnnotations()[AnnotationRegenerate]
+}
+
+// RecordRegeneration persists the external name of a replacement key along
+// with the regenerate annotation it satisfies, and records the key it
+// supersedes as retired. The managed reconciler only persists annotations
+// set during Create, so a key replaced during Update must be recorded
+// explicitly.
+func RecordRegeneration(ctx context.Context, kube client.Client, o client.Object, externalName string) error {
+	orig, ok := o.DeepCopyObject().(client.Object)
+	if !ok {
+		return errors.New(errRecordKey)
+	}
+	if old := meta.GetExternalName(o); old != "" && old != externalName {
+		meta.AddAnnotations(o, map[string]string{AnnotationRetired: old})
+	}
+	meta.SetExternalName(o, externalName)
+	Regeneration.Mark(o)
+	return errors.Wrap(kube.Patch(ctx, o, client.MergeFrom(orig)), errRecordKey)
+}
+
+// Retired returns the ID of the key superseded by the current key of o, if
+// it has yet to be deleted.
+func Retired(o client.Object) (int64, bool) {
+	id, err := strconv.ParseInt(o.GetAnnotations()[AnnotationRetired], 10, 64)
+	return id, err == nil
+}
+
+// ForgetRetired persists that the key superseded by the current key of o
+// has been deleted.
+func ForgetRetired(ctx context.Context, kube client.Client, o client.Object) error {
+	orig, ok := o.DeepCopyObject().(client.Object)
+	if !ok {
+		return errors.New(errRecordKey)
+	}
+	meta.RemoveAnnotations(o, AnnotationRetired)
+	return errors.Wrap(kube.Patch(ctx, o, client.MergeFrom(orig)), errRecordKey)
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshkey
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	repositorykeyv2 "github.com/rossigee/provider-gitea/apis/repositorykey/v2"
+	"github.com/rossigee/provider-gitea/internal/controller/testutil"
+)
+
+func TestGenerate(t *testing.T) {
+	cases := map[string]struct {
+		algorithm string
+		bits      int
+		keyType   string
+	}{
+		"Default": {algorithm: "", keyType: ssh.KeyAlgoED25519},
+		"ED25519": {algorithm: AlgorithmED25519, keyType: ssh.KeyAlgoED25519},
+		"RSA2048": {algorithm: AlgorithmRSA, bits: 2048, keyType: ssh.KeyAlgoRSA},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kp, err := Generate(tc.algorithm, tc.bits, "ci@example.com")
+			require.NoError(t, err)
+
+			signer, err := ssh.ParsePrivateKey([]byte(kp.PrivateKey))
+			require.NoError(t, err)
+			assert.Equal(t, tc.keyType, signer.PublicKey().Type())
+
+			pub, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(kp.PublicKey))
+			require.NoError(t, err)
+			assert.Equal(t, "ci@example.com", comment)
+			assert.Equal(t, signer.PublicKey().Marshal(), pub.Marshal())
+		})
+	}
+
+	t.Run("UnknownAlgorithm", func(t *testing.T) {
+		_, err := Generate("dsa", 0, "")
+		assert.Error(t, err)
+	})
+}
+
+func TestAddress(t *testing.T) {
+	cases := map[string]string{
+		"git@gitea.example.com:org/repo.git":            "gitea.example.com:22",
+		"ssh://git@gitea.example.com:2222/org/repo.git": "gitea.example.com:2222",
+		"ssh://git@gitea.example.com/org/repo.git":      "gitea.example.com:22",
+	}
+
+	for in, want := range cases {
+		got, err := Address(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	_, err := Address("gitea.example.com")
+	assert.Error(t, err)
+}
+
+func TestKnownHosts(t *testing.T) {
+	_, sk, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	hostKey, err := ssh.NewSignerFromKey(sk)
+	require.NoError(t, err)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close() //nolint:errcheck // Test cleanup.
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		cfg := &ssh.ServerConfig{NoClientAuth: true}
+		cfg.AddHostKey(hostKey)
+		_, _, _, _ = ssh.NewServerConn(conn, cfg)
+		_ = conn.Close()
+	}()
+
+	line, err := KnownHosts(context.Background(), l.Addr().String())
+	require.NoError(t, err)
+
+	fields := strings.Fields(line)
+	require.Len(t, fields, 3)
+	assert.Equal(t, ssh.KeyAlgoED25519, fields[1])
+	assert.Contains(t, line, strings.TrimSpace(string(ssh.MarshalAuthorizedKey(hostKey.PublicKey()))))
+}
+
+func TestRegeneration(t *testing.T) {
+	ctx := context.Background()
+
+	cr := &repositorykeyv2.RepositoryKey{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "key"}}
+	meta.SetExternalName(cr, "7")
+	meta.AddAnnotations(cr, map[string]string{AnnotationRegenerate: "2"})
+	kube := fake.NewClientBuilder().WithScheme(testutil.Scheme(t)).WithObjects(cr.DeepCopy()).Build()
+
+	assert.Equal(t, "deploy-2", Title("deploy", cr))
+	_, retired := Retired(cr)
+	assert.False(t, retired)
+
+	require.NoError(t, RecordRegeneration(ctx, kube, cr, "42"))
+
+	got := &repositorykeyv2.RepositoryKey{}
+	require.NoError(t, kube.Get(ctx, client.ObjectKeyFromObject(cr), got))
+	assert.Equal(t, "42", meta.GetExternalName(got))
+	assert.False(t, Regeneration.Requested(got))
+	id, retired := Retired(got)
+	require.True(t, retired)
+	assert.Equal(t, int64(7), id)
+
+	require.NoError(t, ForgetRetired(ctx, kube, cr))
+	require.NoError(t, kube.Get(ctx, client.ObjectKeyFromObject(cr), got))
+	_, retired = Retired(got)
+	assert.False(t, retired)
+}
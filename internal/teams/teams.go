@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package teams resolves the Gitea teams and repositories referred to by team
+// membership and team repository resources.
+package teams
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	repositoryv2 "github.com/rossigee/provider-gitea/apis/repository/v2"
+	teamv2 "github.com/rossigee/provider-gitea/apis/team/v2"
+	"github.com/rossigee/provider-gitea/internal/clients"
+)
+
+const (
+	errGetTeamResource       = "failed to get referenced Team"
+	errGetRepositoryResource = "failed to get referenced Repository"
+	errListTeams             = "failed to list organization teams"
+	errNoTeam                = "either teamRef or organization and team is required"
+	errNoRepository          = "either repository or repositoryRef is required"
+)
+
+// errNotReady is returned when a referenced resource has not been created in
+// Gitea yet.
+var errNotReady = errors.New("referenced resource has not been created yet")
+
+// IsNotFound reports whether err means the team or repository does not exist,
+// either in Gitea or as a referenced resource.
+func IsNotFound(err error) bool {
+	return clients.IsNotFound(err) || kerrors.IsNotFound(errors.Cause(err)) || errors.Is(err, errNotReady)
+}
+
+// Resolve returns the team referred to by ref, a Team in namespace, or
+// otherwise by organization and team name.
+func Resolve(ctx context.Context, kube client.Client, gitea clients.Client, namespace string, ref *xpv1.Reference, org, name *string) (*clients.Team, error) {
+	if ref != nil {
+		cr := &teamv2.Team{}
+		if err := kube.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, cr); err != nil {
+			return nil, errors.Wrap(err, errGetTeamResource)
+		}
+		id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(errNotReady, "team %s", ref.Name)
+		}
+		return gitea.GetTeam(ctx, id)
+	}
+
+	if org == nil || name == nil {
+		return nil, errors.New(errNoTeam)
+	}
+	teams, err := gitea.ListOrganizationTeams(ctx, *org)
+	if err != nil {
+		return nil, errors.Wrap(err, errListTeams)
+	}
+	for _, t := range teams {
+		if strings.EqualFold(t.Name, *name) {
+			return t, nil
+		}
+	}
+	return nil, clients.NewNotFoundError("team", *org+"/"+*name)
+}
+
+// Targets reports whether a team selector refers to the Team cr. Selectors
+// are only compared with Teams in their own namespace.
+func Targets(cr *teamv2.Team, ref *xpv1.Reference, org, name *string) bool {
+	if ref != nil {
+		return ref.Name == cr.GetName()
+	}
+	return org != nil && name != nil &&
+		strings.EqualFold(*org, cr.Spec.ForProvider.Organization) &&
+		strings.EqualFold(*name, cr.Spec.ForProvider.Name)
+}
+
+// Repository returns the owner and name of the repository referred to by
+// ref, a Repository in namespace, or otherwise by a repository name in org.
+func Repository(ctx context.Context, kube client.Client, namespace string, ref *xpv1.Reference, name *string, org string) (string, string, error) {
+	if ref != nil {
+		cr := &repositoryv2.Repository{}
+		if err := kube.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, cr); err != nil {
+			return "", "", errors.Wrap(err, errGetRepositoryResource)
+		}
+		parts := strings.Split(meta.GetExternalName(cr), "/")
+		if len(parts) != 2 {
+			return "", "", errors.Wrapf(errNotReady, "repository %s", ref.Name)
+		}
+		return parts[0], parts[1], nil
+	}
+
+	if name == nil {
+		return "", "", errors.New(errNoRepository)
+	}
+	return org, *name, nil
+}
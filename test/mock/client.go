@@ -19,6 +19,8 @@ package mock
 
 import (
 	"context"
+	"io"
+
 	"github.com/rossigee/provider-gitea/internal/clients"
 	"github.com/stretchr/testify/mock"
 )
@@ -342,23 +344,6 @@ func (m *Client) ListRepositoryCollaborators(ctx context.Context, owner, repo st
 	return args.Get(0).([]*clients.RepositoryCollaborator), args.Error(1)
 }
 
-// Organization Settings operations
-func (m *Client) GetOrganizationSettings(ctx context.Context, org string) (*clients.OrganizationSettings, error) {
-	args := m.Called(ctx, org)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*clients.OrganizationSettings), args.Error(1)
-}
-
-func (m *Client) UpdateOrganizationSettings(ctx context.Context, org string, req *clients.UpdateOrganizationSettingsRequest) (*clients.OrganizationSettings, error) {
-	args := m.Called(ctx, org, req)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*clients.OrganizationSettings), args.Error(1)
-}
-
 // Git Hooks operations
 func (m *Client) GetGitHook(ctx context.Context, repository, hookType string) (*clients.GitHook, error) {
 	args := m.Called(ctx, repository, hookType)
@@ -390,32 +375,32 @@ func (m *Client) DeleteGitHook(ctx context.Context, repository, hookType string)
 }
 
 // Branch Protection operations
-func (m *Client) GetBranchProtection(ctx context.Context, repository, branch string) (*clients.BranchProtection, error) {
-	args := m.Called(ctx, repository, branch)
+func (m *Client) GetBranchProtection(ctx context.Context, repository, ruleName string) (*clients.BranchProtection, error) {
+	args := m.Called(ctx, repository, ruleName)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*clients.BranchProtection), args.Error(1)
 }
 
-func (m *Client) CreateBranchProtection(ctx context.Context, repository, branch string, req *clients.CreateBranchProtectionRequest) (*clients.BranchProtection, error) {
-	args := m.Called(ctx, repository, branch, req)
+func (m *Client) CreateBranchProtection(ctx context.Context, repository string, req *clients.CreateBranchProtectionRequest) (*clients.BranchProtection, error) {
+	args := m.Called(ctx, repository, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*clients.BranchProtection), args.Error(1)
 }
 
-func (m *Client) UpdateBranchProtection(ctx context.Context, repository, branch string, req *clients.UpdateBranchProtectionRequest) (*clients.BranchProtection, error) {
-	args := m.Called(ctx, repository, branch, req)
+func (m *Client) UpdateBranchProtection(ctx context.Context, repository, ruleName string, req *clients.UpdateBranchProtectionRequest) (*clients.BranchProtection, error) {
+	args := m.Called(ctx, repository, ruleName, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*clients.BranchProtection), args.Error(1)
 }
 
-func (m *Client) DeleteBranchProtection(ctx context.Context, repository, branch string) error {
-	args := m.Called(ctx, repository, branch)
+func (m *Client) DeleteBranchProtection(ctx context.Context, repository, ruleName string) error {
+	args := m.Called(ctx, repository, ruleName)
 	return args.Error(0)
 }
 
@@ -639,7 +624,7 @@ func (m *Client) DeleteRelease(ctx context.Context, owner, repo string, id int64
 	return args.Error(0)
 }
 
-func (m *Client) CreateReleaseAttachment(ctx context.Context, owner, repo string, releaseID int64, filename, contentType string, content []byte) (*clients.ReleaseAttachment, error) {
+func (m *Client) CreateReleaseAttachment(ctx context.Context, owner, repo string, releaseID int64, filename, contentType string, content io.Reader) (*clients.ReleaseAttachment, error) {
 	args := m.Called(ctx, owner, repo, releaseID, filename, contentType, content)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -653,28 +638,19 @@ func (m *Client) DeleteReleaseAttachment(ctx context.Context, owner, repo string
 }
 
 // Organization Member operations
-func (m *Client) GetOrganizationMember(ctx context.Context, org, username string) (*clients.OrganizationMember, error) {
+func (m *Client) IsOrganizationMember(ctx context.Context, org, username string) (bool, error) {
 	args := m.Called(ctx, org, username)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*clients.OrganizationMember), args.Error(1)
+	return args.Bool(0), args.Error(1)
 }
 
-func (m *Client) AddOrganizationMember(ctx context.Context, org, username string, req *clients.AddOrganizationMemberRequest) (*clients.OrganizationMember, error) {
-	args := m.Called(ctx, org, username, req)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*clients.OrganizationMember), args.Error(1)
+func (m *Client) IsPublicOrganizationMember(ctx context.Context, org, username string) (bool, error) {
+	args := m.Called(ctx, org, username)
+	return args.Bool(0), args.Error(1)
 }
 
-func (m *Client) UpdateOrganizationMember(ctx context.Context, org, username string, req *clients.UpdateOrganizationMemberRequest) (*clients.OrganizationMember, error) {
-	args := m.Called(ctx, org, username, req)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*clients.OrganizationMember), args.Error(1)
+func (m *Client) SetOrganizationMemberVisibility(ctx context.Context, org, username string, public bool) error {
+	args := m.Called(ctx, org, username, public)
+	return args.Error(0)
 }
 
 func (m *Client) RemoveOrganizationMember(ctx context.Context, org, username string) error {
@@ -683,8 +659,8 @@ func (m *Client) RemoveOrganizationMember(ctx context.Context, org, username str
 }
 
 // Action operations
-func (m *Client) GetAction(ctx context.Context, repository, workflowName string) (*clients.Action, error) {
-	args := m.Called(ctx, repository, workflowName)
+func (m *Client) GetAction(ctx context.Context, repository, workflowName, branch string) (*clients.Action, error) {
+	args := m.Called(ctx, repository, workflowName, branch)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -707,11 +683,19 @@ func (m *Client) UpdateAction(ctx context.Context, repository, workflowName stri
 	return args.Get(0).(*clients.Action), args.Error(1)
 }
 
-func (m *Client) DeleteAction(ctx context.Context, repository, workflowName string) error {
-	args := m.Called(ctx, repository, workflowName)
+func (m *Client) DeleteAction(ctx context.Context, repository, workflowName string, req *clients.DeleteActionRequest) error {
+	args := m.Called(ctx, repository, workflowName, req)
 	return args.Error(0)
 }
 
+func (m *Client) GetActionWorkflow(ctx context.Context, repository, workflowName string) (*clients.ActionWorkflow, error) {
+	args := m.Called(ctx, repository, workflowName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.ActionWorkflow), args.Error(1)
+}
+
 func (m *Client) EnableAction(ctx context.Context, repository, workflowName string) error {
 	args := m.Called(ctx, repository, workflowName)
 	return args.Error(0)
@@ -722,29 +706,29 @@ func (m *Client) DisableAction(ctx context.Context, repository, workflowName str
 	return args.Error(0)
 }
 
-// Runner operations
-func (m *Client) GetRunner(ctx context.Context, scope, scopeValue string, runnerID int64) (*clients.Runner, error) {
-	args := m.Called(ctx, scope, scopeValue, runnerID)
+func (m *Client) GetActionLastRun(ctx context.Context, repository, workflowName string) (*clients.ActionRun, error) {
+	args := m.Called(ctx, repository, workflowName)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*clients.Runner), args.Error(1)
+	return args.Get(0).(*clients.ActionRun), args.Error(1)
 }
 
-func (m *Client) CreateRunner(ctx context.Context, scope, scopeValue string, req *clients.CreateRunnerRequest) (*clients.Runner, error) {
-	args := m.Called(ctx, scope, scopeValue, req)
+// Runner operations
+func (m *Client) GetRunner(ctx context.Context, scope, scopeValue string, runnerID int64) (*clients.Runner, error) {
+	args := m.Called(ctx, scope, scopeValue, runnerID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*clients.Runner), args.Error(1)
 }
 
-func (m *Client) UpdateRunner(ctx context.Context, scope, scopeValue string, runnerID int64, req *clients.UpdateRunnerRequest) (*clients.Runner, error) {
-	args := m.Called(ctx, scope, scopeValue, runnerID, req)
+func (m *Client) ListRunners(ctx context.Context, scope, scopeValue string) ([]clients.Runner, error) {
+	args := m.Called(ctx, scope, scopeValue)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*clients.Runner), args.Error(1)
+	return args.Get(0).([]clients.Runner), args.Error(1)
 }
 
 func (m *Client) DeleteRunner(ctx context.Context, scope, scopeValue string, runnerID int64) error {
@@ -752,6 +736,22 @@ func (m *Client) DeleteRunner(ctx context.Context, scope, scopeValue string, run
 	return args.Error(0)
 }
 
+func (m *Client) GetRunnerRegistrationToken(ctx context.Context, scope, scopeValue string) (*clients.RunnerRegistrationToken, error) {
+	args := m.Called(ctx, scope, scopeValue)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.RunnerRegistrationToken), args.Error(1)
+}
+
+func (m *Client) CreateRunnerRegistrationToken(ctx context.Context, scope, scopeValue string) (*clients.RunnerRegistrationToken, error) {
+	args := m.Called(ctx, scope, scopeValue)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.RunnerRegistrationToken), args.Error(1)
+}
+
 // Admin User operations
 func (m *Client) GetAdminUser(ctx context.Context, username string) (*clients.AdminUser, error) {
 	args := m.Called(ctx, username)
@@ -781,3 +781,461 @@ func (m *Client) DeleteAdminUser(ctx context.Context, username string) error {
 	args := m.Called(ctx, username)
 	return args.Error(0)
 }
+
+// Repository File operations
+func (m *Client) GetRepositoryFile(ctx context.Context, owner, repo, filepath, ref string) (*clients.ContentsResponse, error) {
+	args := m.Called(ctx, owner, repo, filepath, ref)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.ContentsResponse), args.Error(1)
+}
+
+func (m *Client) CreateRepositoryFile(ctx context.Context, owner, repo, filepath string, req *clients.CreateFileOptions) (*clients.FileResponse, error) {
+	args := m.Called(ctx, owner, repo, filepath, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.FileResponse), args.Error(1)
+}
+
+func (m *Client) UpdateRepositoryFile(ctx context.Context, owner, repo, filepath string, req *clients.UpdateFileOptions) (*clients.FileResponse, error) {
+	args := m.Called(ctx, owner, repo, filepath, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.FileResponse), args.Error(1)
+}
+
+func (m *Client) DeleteRepositoryFile(ctx context.Context, owner, repo, filepath string, req *clients.DeleteFileOptions) error {
+	args := m.Called(ctx, owner, repo, filepath, req)
+	return args.Error(0)
+}
+
+func (m *Client) GetRepositoryBranch(ctx context.Context, owner, repo, branch string) (*clients.RepositoryBranch, error) {
+	args := m.Called(ctx, owner, repo, branch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.RepositoryBranch), args.Error(1)
+}
+
+func (m *Client) GetPullRequestByBranches(ctx context.Context, owner, repo, base, head string) (*clients.PullRequest, error) {
+	args := m.Called(ctx, owner, repo, base, head)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.PullRequest), args.Error(1)
+}
+
+// Workflow run operations
+func (m *Client) ListActionRuns(ctx context.Context, repository, workflowName, event string) ([]clients.ActionRun, error) {
+	args := m.Called(ctx, repository, workflowName, event)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]clients.ActionRun), args.Error(1)
+}
+
+func (m *Client) GetActionRun(ctx context.Context, repository string, runID int64) (*clients.ActionRun, error) {
+	args := m.Called(ctx, repository, runID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.ActionRun), args.Error(1)
+}
+
+func (m *Client) ListActionRunJobs(ctx context.Context, repository string, runID int64) ([]clients.ActionJob, error) {
+	args := m.Called(ctx, repository, runID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]clients.ActionJob), args.Error(1)
+}
+
+func (m *Client) DispatchWorkflow(ctx context.Context, repository, workflowName string, req *clients.DispatchWorkflowRequest) error {
+	args := m.Called(ctx, repository, workflowName, req)
+	return args.Error(0)
+}
+
+// Tag protection operations
+func (m *Client) ListTagProtections(ctx context.Context, repository string) ([]clients.TagProtection, error) {
+	args := m.Called(ctx, repository)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]clients.TagProtection), args.Error(1)
+}
+
+func (m *Client) GetTagProtection(ctx context.Context, repository string, id int64) (*clients.TagProtection, error) {
+	args := m.Called(ctx, repository, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.TagProtection), args.Error(1)
+}
+
+func (m *Client) CreateTagProtection(ctx context.Context, repository string, req *clients.CreateTagProtectionRequest) (*clients.TagProtection, error) {
+	args := m.Called(ctx, repository, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.TagProtection), args.Error(1)
+}
+
+func (m *Client) UpdateTagProtection(ctx context.Context, repository string, id int64, req *clients.UpdateTagProtectionRequest) (*clients.TagProtection, error) {
+	args := m.Called(ctx, repository, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.TagProtection), args.Error(1)
+}
+
+func (m *Client) DeleteTagProtection(ctx context.Context, repository string, id int64) error {
+	args := m.Called(ctx, repository, id)
+	return args.Error(0)
+}
+
+// Branch and tag operations
+func (m *Client) CreateRepositoryBranch(ctx context.Context, owner, repo string, req *clients.CreateBranchRequest) (*clients.RepositoryBranch, error) {
+	args := m.Called(ctx, owner, repo, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.RepositoryBranch), args.Error(1)
+}
+
+func (m *Client) DeleteRepositoryBranch(ctx context.Context, owner, repo, branch string) error {
+	args := m.Called(ctx, owner, repo, branch)
+	return args.Error(0)
+}
+
+func (m *Client) GetRepositoryTag(ctx context.Context, owner, repo, tag string) (*clients.RepositoryTag, error) {
+	args := m.Called(ctx, owner, repo, tag)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.RepositoryTag), args.Error(1)
+}
+
+func (m *Client) CreateRepositoryTag(ctx context.Context, owner, repo string, req *clients.CreateTagRequest) (*clients.RepositoryTag, error) {
+	args := m.Called(ctx, owner, repo, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.RepositoryTag), args.Error(1)
+}
+
+func (m *Client) DeleteRepositoryTag(ctx context.Context, owner, repo, tag string) error {
+	args := m.Called(ctx, owner, repo, tag)
+	return args.Error(0)
+}
+
+// Team membership operations
+func (m *Client) ListTeamMembers(ctx context.Context, teamID int64) ([]*clients.User, error) {
+	args := m.Called(ctx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*clients.User), args.Error(1)
+}
+
+func (m *Client) GetTeamMember(ctx context.Context, teamID int64, username string) (*clients.User, error) {
+	args := m.Called(ctx, teamID, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.User), args.Error(1)
+}
+
+func (m *Client) AddTeamMember(ctx context.Context, teamID int64, username string) error {
+	args := m.Called(ctx, teamID, username)
+	return args.Error(0)
+}
+
+func (m *Client) RemoveTeamMember(ctx context.Context, teamID int64, username string) error {
+	args := m.Called(ctx, teamID, username)
+	return args.Error(0)
+}
+
+func (m *Client) ListTeamRepositories(ctx context.Context, teamID int64) ([]*clients.Repository, error) {
+	args := m.Called(ctx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*clients.Repository), args.Error(1)
+}
+
+func (m *Client) GetTeamRepository(ctx context.Context, teamID int64, org, repo string) (*clients.Repository, error) {
+	args := m.Called(ctx, teamID, org, repo)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.Repository), args.Error(1)
+}
+
+func (m *Client) AddTeamRepository(ctx context.Context, teamID int64, org, repo string) error {
+	args := m.Called(ctx, teamID, org, repo)
+	return args.Error(0)
+}
+
+func (m *Client) RemoveTeamRepository(ctx context.Context, teamID int64, org, repo string) error {
+	args := m.Called(ctx, teamID, org, repo)
+	return args.Error(0)
+}
+
+// Organization avatar, label and block operations
+func (m *Client) UpdateOrganizationAvatar(ctx context.Context, org string, image []byte) error {
+	args := m.Called(ctx, org, image)
+	return args.Error(0)
+}
+
+func (m *Client) DeleteOrganizationAvatar(ctx context.Context, org string) error {
+	args := m.Called(ctx, org)
+	return args.Error(0)
+}
+
+func (m *Client) ListOrganizationLabels(ctx context.Context, org string) ([]*clients.Label, error) {
+	args := m.Called(ctx, org)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*clients.Label), args.Error(1)
+}
+
+func (m *Client) CreateOrganizationLabel(ctx context.Context, org string, req *clients.CreateLabelRequest) (*clients.Label, error) {
+	args := m.Called(ctx, org, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.Label), args.Error(1)
+}
+
+func (m *Client) UpdateOrganizationLabel(ctx context.Context, org string, labelID int64, req *clients.UpdateLabelRequest) (*clients.Label, error) {
+	args := m.Called(ctx, org, labelID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.Label), args.Error(1)
+}
+
+func (m *Client) DeleteOrganizationLabel(ctx context.Context, org string, labelID int64) error {
+	args := m.Called(ctx, org, labelID)
+	return args.Error(0)
+}
+
+func (m *Client) ListOrganizationBlocks(ctx context.Context, org string) ([]*clients.User, error) {
+	args := m.Called(ctx, org)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*clients.User), args.Error(1)
+}
+
+func (m *Client) BlockOrganizationUser(ctx context.Context, org, username string) error {
+	args := m.Called(ctx, org, username)
+	return args.Error(0)
+}
+
+func (m *Client) UnblockOrganizationUser(ctx context.Context, org, username string) error {
+	args := m.Called(ctx, org, username)
+	return args.Error(0)
+}
+
+// Release notes operations
+func (m *Client) ListReleases(ctx context.Context, owner, repo string) ([]*clients.Release, error) {
+	args := m.Called(ctx, owner, repo)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*clients.Release), args.Error(1)
+}
+
+func (m *Client) CompareCommits(ctx context.Context, owner, repo, base, head string) (*clients.Compare, error) {
+	args := m.Called(ctx, owner, repo, base, head)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.Compare), args.Error(1)
+}
+
+// Repository transfer operations
+func (m *Client) TransferRepository(ctx context.Context, owner, name string, req *clients.TransferRepositoryRequest) (*clients.Repository, error) {
+	args := m.Called(ctx, owner, name, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.Repository), args.Error(1)
+}
+
+// User offboarding operations
+func (m *Client) PurgeUser(ctx context.Context, username string) error {
+	args := m.Called(ctx, username)
+	return args.Error(0)
+}
+
+func (m *Client) ListUserRepositories(ctx context.Context, username string) ([]*clients.Repository, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*clients.Repository), args.Error(1)
+}
+
+func (m *Client) ListUserOrganizations(ctx context.Context, username string) ([]*clients.Organization, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*clients.Organization), args.Error(1)
+}
+
+// User account operations
+func (m *Client) ListUserEmails(ctx context.Context, username string) ([]*clients.Email, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*clients.Email), args.Error(1)
+}
+
+func (m *Client) AddUserEmails(ctx context.Context, username string, emails []string) ([]*clients.Email, error) {
+	args := m.Called(ctx, username, emails)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*clients.Email), args.Error(1)
+}
+
+func (m *Client) DeleteUserEmails(ctx context.Context, username string, emails []string) error {
+	args := m.Called(ctx, username, emails)
+	return args.Error(0)
+}
+
+func (m *Client) ListUserGPGKeys(ctx context.Context, username string) ([]*clients.GPGKey, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*clients.GPGKey), args.Error(1)
+}
+
+func (m *Client) GetUserGPGKey(ctx context.Context, username string, id int64) (*clients.GPGKey, error) {
+	args := m.Called(ctx, username, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.GPGKey), args.Error(1)
+}
+
+func (m *Client) CreateUserGPGKey(ctx context.Context, username string, req *clients.CreateGPGKeyRequest) (*clients.GPGKey, error) {
+	args := m.Called(ctx, username, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.GPGKey), args.Error(1)
+}
+
+func (m *Client) DeleteUserGPGKey(ctx context.Context, username string, id int64) error {
+	args := m.Called(ctx, username, id)
+	return args.Error(0)
+}
+
+func (m *Client) GetUserSettings(ctx context.Context, username string) (*clients.UserSettings, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.UserSettings), args.Error(1)
+}
+
+func (m *Client) UpdateUserSettings(ctx context.Context, username string, req *clients.UpdateUserSettingsRequest) (*clients.UserSettings, error) {
+	args := m.Called(ctx, username, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.UserSettings), args.Error(1)
+}
+
+// OAuth2 application operations
+func (m *Client) GetOAuth2Application(ctx context.Context, username string, id int64) (*clients.OAuth2Application, error) {
+	args := m.Called(ctx, username, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.OAuth2Application), args.Error(1)
+}
+
+func (m *Client) CreateOAuth2Application(ctx context.Context, username string, req *clients.OAuth2ApplicationRequest) (*clients.OAuth2Application, error) {
+	args := m.Called(ctx, username, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.OAuth2Application), args.Error(1)
+}
+
+func (m *Client) UpdateOAuth2Application(ctx context.Context, username string, id int64, req *clients.OAuth2ApplicationRequest) (*clients.OAuth2Application, error) {
+	args := m.Called(ctx, username, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.OAuth2Application), args.Error(1)
+}
+
+func (m *Client) DeleteOAuth2Application(ctx context.Context, username string, id int64) error {
+	args := m.Called(ctx, username, id)
+	return args.Error(0)
+}
+
+func (m *Client) GetAdminWebhook(ctx context.Context, id int64) (*clients.Webhook, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.Webhook), args.Error(1)
+}
+
+func (m *Client) CreateAdminWebhook(ctx context.Context, req *clients.CreateWebhookRequest) (*clients.Webhook, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.Webhook), args.Error(1)
+}
+
+func (m *Client) UpdateAdminWebhook(ctx context.Context, id int64, req *clients.UpdateWebhookRequest) (*clients.Webhook, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*clients.Webhook), args.Error(1)
+}
+
+func (m *Client) DeleteAdminWebhook(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *Client) ListRepositoryWebhooks(ctx context.Context, owner, repo string) ([]*clients.Webhook, error) {
+	args := m.Called(ctx, owner, repo)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*clients.Webhook), args.Error(1)
+}
+
+func (m *Client) ListDeployKeys(ctx context.Context, owner, repo string) ([]*clients.DeployKey, error) {
+	args := m.Called(ctx, owner, repo)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*clients.DeployKey), args.Error(1)
+}
+
+func (m *Client) ListOrganizationRepositories(ctx context.Context, org string) ([]*clients.Repository, error) {
+	args := m.Called(ctx, org)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*clients.Repository), args.Error(1)
+}